@@ -0,0 +1,13 @@
+package communication
+
+import log "github.com/Sirupsen/logrus"
+
+//DevPushService is a fake push service that just logs the notifications that should be send
+type DevPushService struct {
+}
+
+//Send sends a push notification
+func (s *DevPushService) Send(pushtoken string, message string, data map[string]string) (err error) {
+	log.Infof("In production a push notification would be sent to %s with the following content:\n%s\n%v", pushtoken, message, data)
+	return
+}
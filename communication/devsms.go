@@ -11,3 +11,9 @@ func (s *DevSMSService) Send(phonenumber string, message string) (err error) {
 	log.Infof("In production an sms would be sent to %s with the following content:\n%s", phonenumber, message)
 	return
 }
+
+//SendSMS logs the sms that should be send
+func (s *DevSMSService) SendSMS(sms *OutgoingSMS) (messageID string, err error) {
+	err = s.Send(sms.Phonenumber, sms.Message)
+	return
+}
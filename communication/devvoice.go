@@ -0,0 +1,13 @@
+package communication
+
+import log "github.com/Sirupsen/logrus"
+
+//DevVoiceService is a fake voice service that just logs the calls that should be made
+type DevVoiceService struct {
+}
+
+//Call logs the message that should be read out
+func (s *DevVoiceService) Call(phonenumber string, message string, language string) (err error) {
+	log.Infof("In production %s would be called with the following message (%s):\n%s", phonenumber, language, message)
+	return
+}
@@ -0,0 +1,142 @@
+package communication
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//dkimSignedHeaders are the headers that are signed if the message has them
+var dkimSignedHeaders = []string{"From", "Reply-To", "Subject", "Date", "To", "Cc", "Message-ID", "Mime-Version", "Content-Type", "Content-Transfer-Encoding"}
+
+//ErrInvalidDKIMKey is returned when the DKIM key is not a pem encoded RSA private key
+var ErrInvalidDKIMKey = errors.New("The DKIM key is not a pem encoded RSA private key")
+
+//DKIMSigner adds a DKIM-Signature (RFC 6376) to messages, using rsa-sha256 and relaxed canonicalization.
+// The public key must be published in the TXT record <Selector>._domainkey.<Domain>.
+type DKIMSigner struct {
+	Domain   string
+	Selector string
+	key      *rsa.PrivateKey
+}
+
+//NewDKIMSigner creates a DKIMSigner with a PKCS#1 or PKCS#8 pem encoded RSA private key
+func NewDKIMSigner(domain string, selector string, pemKey []byte) (signer *DKIMSigner, err error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		err = ErrInvalidDKIMKey
+		return
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		rsaKey, isRSA := parsed.(*rsa.PrivateKey)
+		if pkcs8Err != nil || !isRSA {
+			err = ErrInvalidDKIMKey
+			return
+		}
+		key, err = rsaKey, nil
+	}
+	signer = &DKIMSigner{Domain: domain, Selector: selector, key: key}
+	return
+}
+
+//Sign returns the message with a DKIM-Signature header in front of it
+func (s *DKIMSigner) Sign(message []byte) (signed []byte, err error) {
+	message = toCRLF(message)
+	end := bytes.Index(message, []byte("\r\n\r\n"))
+	if end < 0 {
+		err = errors.New("The message has no body")
+		return
+	}
+	headers := parseHeaders(message[:end+2])
+	bodyHash := sha256.Sum256(relaxedBody(message[end+4:]))
+
+	var names []string
+	var canonical bytes.Buffer
+	for _, name := range dkimSignedHeaders {
+		if header, found := headers[strings.ToLower(name)]; found {
+			names = append(names, name)
+			canonical.WriteString(relaxedHeader(header))
+		}
+	}
+	signature := fmt.Sprintf("DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s;\r\n\tt=%d; h=%s;\r\n\tbh=%s;\r\n\tb=",
+		s.Domain, s.Selector, time.Now().Unix(), strings.Join(names, ":"), base64.StdEncoding.EncodeToString(bodyHash[:]))
+	canonical.WriteString(strings.TrimSuffix(relaxedHeader(signature), "\r\n"))
+	hash := sha256.Sum256(canonical.Bytes())
+	b, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return
+	}
+	signed = make([]byte, 0, len(signature)+len(message)+512)
+	signed = append(signed, signature...)
+	signed = append(signed, base64.StdEncoding.EncodeToString(b)...)
+	signed = append(signed, "\r\n"...)
+	signed = append(signed, message...)
+	return
+}
+
+//toCRLF replaces the bare line feeds of a message by CRLF
+func toCRLF(message []byte) []byte {
+	if bytes.Count(message, []byte("\n")) == bytes.Count(message, []byte("\r\n")) {
+		return message
+	}
+	return bytes.Replace(bytes.Replace(message, []byte("\r\n"), []byte("\n"), -1), []byte("\n"), []byte("\r\n"), -1)
+}
+
+//parseHeaders returns the headers, including their folded lines, by lowercase name.
+// If a header occurs more than once, the last one is signed.
+func parseHeaders(header []byte) map[string]string {
+	headers := map[string]string{}
+	var current string
+	for _, line := range strings.SplitAfter(string(header), "\r\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && current != "" {
+			headers[current] += line
+			continue
+		}
+		current = ""
+		if i := strings.Index(line, ":"); i > 0 {
+			current = strings.ToLower(strings.TrimSpace(line[:i]))
+			headers[current] = line
+		}
+	}
+	return headers
+}
+
+//relaxedHeader canonicalizes a header with the relaxed algorithm of RFC 6376 section 3.4.2
+func relaxedHeader(header string) string {
+	i := strings.Index(header, ":")
+	value := strings.Replace(strings.Replace(header[i+1:], "\r\n", "", -1), "\t", " ", -1)
+	return strings.ToLower(strings.TrimSpace(header[:i])) + ":" + strings.Join(strings.Fields(value), " ") + "\r\n"
+}
+
+//relaxedBody canonicalizes a body with the relaxed algorithm of RFC 6376 section 3.4.4
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		line = strings.TrimRight(strings.Replace(line, "\t", " ", -1), " ")
+		for strings.Contains(line, "  ") {
+			line = strings.Replace(line, "  ", " ", -1)
+		}
+		lines[i] = line
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
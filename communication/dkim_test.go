@@ -0,0 +1,75 @@
+package communication
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDKIMCanonicalization(t *testing.T) {
+	//Examples of RFC 6376 section 3.4.5
+	assert.Equal(t, "a:X\r\n", relaxedHeader("A: X\r\n"))
+	assert.Equal(t, "b:Y Z\r\n", relaxedHeader("B : Y\t\r\n\tZ  \r\n"))
+	assert.Equal(t, " C\r\nD E\r\n", string(relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))))
+	assert.Empty(t, relaxedBody([]byte("\r\n\r\n")))
+
+	headers := parseHeaders([]byte("Subject: first\r\nFrom: a@example.com\r\nSubject: second\r\n folded\r\n"))
+	assert.Equal(t, "Subject: second\r\n folded\r\n", headers["subject"])
+	assert.Equal(t, "From: a@example.com\r\n", headers["from"])
+
+	assert.Equal(t, "a\r\nb\r\n", string(toCRLF([]byte("a\nb\r\n"))))
+}
+
+func TestDKIMSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	signer, err := NewDKIMSigner("example.com", "iyo", pemKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = NewDKIMSigner("example.com", "iyo", []byte("not a key"))
+	assert.Equal(t, ErrInvalidDKIMKey, err)
+
+	message := "Mime-Version: 1.0\r\nFrom: \"Acme\" <noreply@example.com>\r\nTo: bob@example.org\r\nSubject: Hello  there\r\n\tfolded\r\nX-Other: not signed\r\n\r\nHello bob \t\r\n\r\n\r\n"
+	signed, err := signer.Sign([]byte(message))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasSuffix(string(signed), message))
+	dkimHeader := string(signed[:len(signed)-len(message)])
+	assert.Contains(t, dkimHeader, "d=example.com; s=iyo;")
+	assert.Contains(t, dkimHeader, "h=From:Subject:To:Mime-Version;")
+
+	bodyHash := sha256.Sum256([]byte("Hello bob\r\n"))
+	assert.Contains(t, dkimHeader, "bh="+base64.StdEncoding.EncodeToString(bodyHash[:])+";")
+
+	b := regexp.MustCompile(`b=([A-Za-z0-9+/=]+)\r\n$`).FindStringSubmatch(dkimHeader)
+	if !assert.Len(t, b, 2) {
+		return
+	}
+	signature, _ := base64.StdEncoding.DecodeString(b[1])
+	unsigned := strings.Replace(strings.TrimSuffix(dkimHeader, b[1]+"\r\n"), "\r\n\t", " ", -1)
+	canonical := "from:\"Acme\" <noreply@example.com>\r\nsubject:Hello there folded\r\nto:bob@example.org\r\nmime-version:1.0\r\n" +
+		"dkim-signature:" + strings.TrimPrefix(unsigned, "DKIM-Signature: ")
+	hash := sha256.Sum256([]byte(canonical))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+}
+
+func TestIsPermanentEmailError(t *testing.T) {
+	assert.True(t, IsPermanentEmailError(&textproto.Error{Code: 550, Msg: "No such user"}))
+	assert.False(t, IsPermanentEmailError(&textproto.Error{Code: 451, Msg: "Try again later"}))
+	assert.False(t, IsPermanentEmailError(assert.AnError))
+}
@@ -1,6 +1,14 @@
 package communication
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
 	log "github.com/Sirupsen/logrus"
 	"github.com/go-gomail/gomail"
 )
@@ -8,6 +16,17 @@ import (
 //EmailService defines an email communication channel
 type EmailService interface {
 	Send(recipients []string, subject string, message string) (err error)
+	SendMessage(message *EmailMessage) (err error)
+}
+
+//EmailMessage is an email with an html body and a plain text alternative.
+// If SenderName is set, it replaces the display name of the configured sender.
+type EmailMessage struct {
+	Recipients []string
+	SenderName string
+	Subject    string
+	Text       string
+	HTML       string
 }
 
 //DevEmailService is the implementation of an EmailService suitable for use in local development environments
@@ -19,15 +38,24 @@ func (s *DevEmailService) Send(recipients []string, subject string, message stri
 	return
 }
 
+//SendMessage sends an EmailMessage
+func (s *DevEmailService) SendMessage(message *EmailMessage) (err error) {
+	log.Infof("In production an email would be sent to %s with subject %q and the following content:\n%s", message.Recipients, message.Subject, message.Text)
+	return
+}
+
 //SMTPEmailService implements an email service using plain old SMTP
 type SMTPEmailService struct {
 	dialer *gomail.Dialer
+	sender mail.Address
+	dkim   *DKIMSigner
 }
 
-//NewSMTPEmailService creates a nes SMTPEmailService
-func NewSMTPEmailService(host string, port int, user string, password string) (service *SMTPEmailService) {
+//NewSMTPEmailService creates a nes SMTPEmailService, the emails are sent from the sender address.
+// If dkim is not nil, the emails are signed with it.
+func NewSMTPEmailService(host string, port int, user string, password string, sender mail.Address, dkim *DKIMSigner) (service *SMTPEmailService) {
 	dialer := gomail.NewDialer(host, port, user, password)
-	service = &SMTPEmailService{dialer: dialer}
+	service = &SMTPEmailService{dialer: dialer, sender: sender, dkim: dkim}
 	return
 }
 
@@ -35,12 +63,70 @@ func NewSMTPEmailService(host string, port int, user string, password string) (s
 func (s *SMTPEmailService) Send(recipients []string, subject string, message string) (err error) {
 	gomsg := gomail.NewMessage()
 	gomsg.SetHeader("Subject", subject)
-	gomsg.SetHeader("From", "noreply@itsyou.online")
+	gomsg.SetAddressHeader("From", s.sender.Address, s.sender.Name)
 	gomsg.SetHeader("To", recipients...)
 	gomsg.SetBody("text/html", message)
-	err = s.dialer.DialAndSend(gomsg)
+	return s.send(recipients, gomsg)
+}
+
+//SendMessage sends an EmailMessage as a multipart email
+func (s *SMTPEmailService) SendMessage(message *EmailMessage) (err error) {
+	senderName := s.sender.Name
+	if message.SenderName != "" {
+		senderName = message.SenderName
+	}
+	gomsg := gomail.NewMessage()
+	gomsg.SetHeader("Subject", message.Subject)
+	gomsg.SetAddressHeader("From", s.sender.Address, senderName)
+	gomsg.SetHeader("To", message.Recipients...)
+	gomsg.SetBody("text/plain", message.Text)
+	gomsg.AddAlternative("text/html", message.HTML)
+	return s.send(message.Recipients, gomsg)
+}
+
+//send signs a message if a DKIM key is configured and hands it to the SMTP server
+func (s *SMTPEmailService) send(recipients []string, gomsg *gomail.Message) (err error) {
+	messageID, err := newMessageID(s.sender.Address)
 	if err != nil {
+		return
+	}
+	gomsg.SetHeader("Message-ID", messageID)
+	raw := &bytes.Buffer{}
+	if _, err = gomsg.WriteTo(raw); err != nil {
+		return
+	}
+	content := raw.Bytes()
+	if s.dkim != nil {
+		if content, err = s.dkim.Sign(content); err != nil {
+			log.Error("Failed to sign email ", err)
+			return
+		}
+	}
+	sender, err := s.dialer.Dial()
+	if err != nil {
+		log.Error("Failed to connect to the smtp server ", err)
+		return
+	}
+	defer sender.Close()
+	if err = sender.Send(s.sender.Address, recipients, bytes.NewReader(content)); err != nil {
 		log.Error("Failed to send email ", err)
 	}
 	return
 }
+
+//newMessageID creates a random Message-ID in the domain of the sender address
+func newMessageID(sender string) (messageID string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	messageID = fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), sender[strings.LastIndex(sender, "@")+1:])
+	return
+}
+
+//IsPermanentEmailError checks if the SMTP server refused a message with a permanent (5xx) error,
+// sending it again will fail as well
+func IsPermanentEmailError(err error) bool {
+	smtpErr, ok := err.(*textproto.Error)
+	return ok && smtpErr.Code >= 500
+}
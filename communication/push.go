@@ -0,0 +1,46 @@
+package communication
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+//PushService defines a push notification channel to paired devices
+type PushService interface {
+	Send(pushtoken string, message string, data map[string]string) (err error)
+}
+
+//HTTPPushService is a push notification channel that hands the notifications to a push gateway
+type HTTPPushService struct {
+	GatewayURL string
+}
+
+//Send sends a push notification
+func (s *HTTPPushService) Send(pushtoken string, message string, data map[string]string) (err error) {
+	notification := struct {
+		To      string            `json:"to"`
+		Message string            `json:"message"`
+		Data    map[string]string `json:"data"`
+	}{To: pushtoken, Message: message, Data: data}
+	body, err := json.Marshal(&notification)
+	if err != nil {
+		return
+	}
+	resp, err := http.Post(s.GatewayURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Error("Error sending push notification: ", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		response, _ := ioutil.ReadAll(resp.Body)
+		log.Error("Problem when sending push notification: ", resp.StatusCode, "\n", string(response))
+		err = errors.New("Error sending push notification")
+	}
+	return
+}
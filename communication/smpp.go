@@ -0,0 +1,229 @@
+package communication
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	smppBindTransmitter     = 0x00000002
+	smppBindTransmitterResp = 0x80000002
+	smppSubmitSM            = 0x00000004
+	smppSubmitSMResp        = 0x80000004
+	smppUnbind              = 0x00000006
+	smppUnbindResp          = 0x80000006
+	smppEnquireLink         = 0x00000015
+	smppEnquireLinkResp     = 0x80000015
+	smppGenericNack         = 0x80000000
+
+	//smppMessagePayload is the optional parameter used for messages that do not fit in the short_message field
+	smppMessagePayload  = 0x0424
+	smppMaxShortMessage = 254
+	smppMaxPDULength    = 64 * 1024
+)
+
+//SMPPSMSService is an SMS communication channel that submits the messages to an SMSC with SMPP 3.4.
+// Every message is sent over a new transmitter session.
+// The SourceAddress is the sender shown on the phone, a phone number or an alphanumeric name.
+type SMPPSMSService struct {
+	Address       string
+	TLS           bool
+	SystemID      string
+	Password      string
+	SourceAddress string
+}
+
+//SMPPError is returned when the SMSC answers with a non zero command status
+type SMPPError struct {
+	Command uint32
+	Status  uint32
+}
+
+func (e *SMPPError) Error() string {
+	return fmt.Sprintf("SMPP command 0x%08x failed with status 0x%08x", e.Command, e.Status)
+}
+
+//Send sends an SMS
+func (s *SMPPSMSService) Send(phonenumber string, message string) (err error) {
+	_, err = s.SendSMS(&OutgoingSMS{Phonenumber: phonenumber, Message: message})
+	return
+}
+
+//SendSMS submits an SMS and returns the message_id the SMSC gave it.
+// Delivery receipts are not requested since they can only be received on a receiver session.
+func (s *SMPPSMSService) SendSMS(sms *OutgoingSMS) (messageID string, err error) {
+	var conn net.Conn
+	dialer := &net.Dialer{Timeout: smsRequestTimeout}
+	if s.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.Address, nil)
+	} else {
+		conn, err = dialer.Dial("tcp", s.Address)
+	}
+	if err != nil {
+		log.Error("Error connecting to the SMSC: ", err)
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(smsRequestTimeout))
+
+	session := &smppSession{conn: conn, reader: bufio.NewReader(conn)}
+	bind := &bytes.Buffer{}
+	writeCString(bind, s.SystemID)
+	writeCString(bind, s.Password)
+	writeCString(bind, "")
+	bind.Write([]byte{0x34, 0, 0})
+	writeCString(bind, "")
+	if _, err = session.call(smppBindTransmitter, smppBindTransmitterResp, bind.Bytes()); err != nil {
+		log.Error("Error binding to the SMSC: ", err)
+		return
+	}
+	response, err := session.call(smppSubmitSM, smppSubmitSMResp, s.submitSM(sms))
+	if err != nil {
+		log.Error("Error submitting sms to the SMSC: ", err)
+		return
+	}
+	messageID = readCString(response)
+	//The message is accepted, a failing unbind does not matter anymore
+	session.call(smppUnbind, smppUnbindResp, nil)
+	return
+}
+
+//submitSM builds the body of a submit_sm PDU
+func (s *SMPPSMSService) submitSM(sms *OutgoingSMS) []byte {
+	body := &bytes.Buffer{}
+	writeCString(body, "")
+	sourceTON, sourceNPI := byte(5), byte(0)
+	if isDigits(strings.TrimPrefix(s.SourceAddress, "+")) {
+		sourceTON, sourceNPI = 1, 1
+	}
+	body.Write([]byte{sourceTON, sourceNPI})
+	writeCString(body, strings.TrimPrefix(s.SourceAddress, "+"))
+	body.Write([]byte{1, 1})
+	writeCString(body, strings.TrimPrefix(sms.Phonenumber, "+"))
+	//esm_class, protocol_id and priority_flag
+	body.Write([]byte{0, 0, 0})
+	writeCString(body, "")
+	writeCString(body, "")
+	dataCoding, message := encodeSMSText(sms.Message)
+	//registered_delivery, replace_if_present_flag, data_coding and sm_default_msg_id
+	body.Write([]byte{0, 0, dataCoding, 0})
+	if len(message) <= smppMaxShortMessage {
+		body.WriteByte(byte(len(message)))
+		body.Write(message)
+		return body.Bytes()
+	}
+	body.WriteByte(0)
+	binary.Write(body, binary.BigEndian, uint16(smppMessagePayload))
+	binary.Write(body, binary.BigEndian, uint16(len(message)))
+	body.Write(message)
+	return body.Bytes()
+}
+
+//encodeSMSText encodes ascii messages as IA5 and all others as UCS2
+func encodeSMSText(message string) (dataCoding byte, encoded []byte) {
+	ascii := true
+	for _, r := range message {
+		if r >= 0x80 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return 0x01, []byte(message)
+	}
+	for _, unit := range utf16.Encode([]rune(message)) {
+		encoded = append(encoded, byte(unit>>8), byte(unit))
+	}
+	return 0x08, encoded
+}
+
+//smppSession exchanges PDU's with an SMSC
+type smppSession struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	sequence uint32
+}
+
+//call sends a request and waits for its response, enquire_link requests of the SMSC in between are answered
+func (session *smppSession) call(command uint32, responseCommand uint32, body []byte) (response []byte, err error) {
+	session.sequence++
+	if err = session.write(command, 0, session.sequence, body); err != nil {
+		return
+	}
+	for {
+		var id, status, sequence uint32
+		id, status, sequence, response, err = session.read()
+		if err != nil {
+			return
+		}
+		if id == smppEnquireLink {
+			if err = session.write(smppEnquireLinkResp, 0, sequence, nil); err != nil {
+				return
+			}
+			continue
+		}
+		if sequence != session.sequence || (id != responseCommand && id != smppGenericNack) {
+			continue
+		}
+		if status != 0 || id == smppGenericNack {
+			err = &SMPPError{Command: command, Status: status}
+		}
+		return
+	}
+}
+
+func (session *smppSession) write(command uint32, status uint32, sequence uint32, body []byte) (err error) {
+	pdu := &bytes.Buffer{}
+	binary.Write(pdu, binary.BigEndian, []uint32{uint32(16 + len(body)), command, status, sequence})
+	pdu.Write(body)
+	_, err = session.conn.Write(pdu.Bytes())
+	return
+}
+
+func (session *smppSession) read() (command uint32, status uint32, sequence uint32, body []byte, err error) {
+	header := make([]uint32, 4)
+	if err = binary.Read(session.reader, binary.BigEndian, header); err != nil {
+		return
+	}
+	if header[0] < 16 || header[0] > smppMaxPDULength {
+		err = fmt.Errorf("Invalid SMPP PDU length %d", header[0])
+		return
+	}
+	body = make([]byte, header[0]-16)
+	if _, err = io.ReadFull(session.reader, body); err != nil {
+		return
+	}
+	command, status, sequence = header[1], header[2], header[3]
+	return
+}
+
+func writeCString(buffer *bytes.Buffer, value string) {
+	buffer.WriteString(value)
+	buffer.WriteByte(0)
+}
+
+func readCString(body []byte) string {
+	if end := bytes.IndexByte(body, 0); end >= 0 {
+		return string(body[:end])
+	}
+	return string(body)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
@@ -1,20 +1,40 @@
 package communication
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
 
+//smsRequestTimeout limits how long an sms gateway gets to accept a message
+const smsRequestTimeout = 15 * time.Second
+
 //SMSService defines an sms communication channel
 type SMSService interface {
 	Send(phonenumber string, message string) (err error)
 }
 
+//OutgoingSMS is an sms handed to an SMSProvider.
+// If StatusCallback is set, the provider is asked to post delivery reports to it.
+type OutgoingSMS struct {
+	Phonenumber    string
+	Message        string
+	StatusCallback string
+}
+
+//SMSProvider is an sms gateway that can be used in an SMSProviderRegistry,
+// the returned messageID is the identifier the provider gave to the sms if it has one
+type SMSProvider interface {
+	SendSMS(sms *OutgoingSMS) (messageID string, err error)
+}
+
 //TwilioSMSService is an SMS communication channel using Twilio
 type TwilioSMSService struct {
 	AccountSID          string
@@ -24,12 +44,21 @@ type TwilioSMSService struct {
 
 //Send sends an SMS
 func (s *TwilioSMSService) Send(phonenumber string, message string) (err error) {
-	client := &http.Client{}
+	_, err = s.SendSMS(&OutgoingSMS{Phonenumber: phonenumber, Message: message})
+	return
+}
+
+//SendSMS sends an SMS and returns the sid Twilio gave it
+func (s *TwilioSMSService) SendSMS(sms *OutgoingSMS) (messageID string, err error) {
+	client := &http.Client{Timeout: smsRequestTimeout}
 
 	data := url.Values{
 		"MessagingServiceSid": {s.MessagingServiceSID},
-		"To":   {phonenumber},
-		"Body": {message},
+		"To":   {sms.Phonenumber},
+		"Body": {sms.Message},
+	}
+	if sms.StatusCallback != "" {
+		data.Set("StatusCallback", sms.StatusCallback)
 	}
 
 	req, err := http.NewRequest("POST", "https://api.twilio.com/2010-04-01/Accounts/"+s.AccountSID+"/Messages.json", strings.NewReader(data.Encode()))
@@ -49,6 +78,65 @@ func (s *TwilioSMSService) Send(phonenumber string, message string) (err error)
 	if resp.StatusCode != http.StatusCreated {
 		log.Error("Problem when sending sms via Twilio: ", resp.StatusCode, "\n", string(body))
 		err = errors.New("Error sending sms")
+		return
+	}
+	message := struct {
+		Sid string `json:"sid"`
+	}{}
+	json.Unmarshal(body, &message)
+	messageID = message.Sid
+	return
+}
+
+//HTTPSMSService is an SMS communication channel that hands the messages to a generic http gateway.
+// The gateway gets a json POST with the "to", "message" and optional "statuscallback" fields and can return the "id" it gave to the message.
+type HTTPSMSService struct {
+	GatewayURL string
+	Token      string
+}
+
+//Send sends an SMS
+func (s *HTTPSMSService) Send(phonenumber string, message string) (err error) {
+	_, err = s.SendSMS(&OutgoingSMS{Phonenumber: phonenumber, Message: message})
+	return
+}
+
+//SendSMS sends an SMS and returns the id the gateway gave it
+func (s *HTTPSMSService) SendSMS(sms *OutgoingSMS) (messageID string, err error) {
+	body, err := json.Marshal(&struct {
+		To             string `json:"to"`
+		Message        string `json:"message"`
+		StatusCallback string `json:"statuscallback,omitempty"`
+	}{To: sms.Phonenumber, Message: sms.Message, StatusCallback: sms.StatusCallback})
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest("POST", s.GatewayURL, bytes.NewReader(body))
+	if err != nil {
+		log.Error("Error creating sms request: ", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	client := &http.Client{Timeout: smsRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Error("Error sending sms via the gateway: ", err)
+		return
+	}
+	defer resp.Body.Close()
+	response, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Error("Problem when sending sms via the gateway: ", resp.StatusCode, "\n", string(response))
+		err = errors.New("Error sending sms")
+		return
 	}
+	message := struct {
+		ID string `json:"id"`
+	}{}
+	json.Unmarshal(response, &message)
+	messageID = message.ID
 	return
 }
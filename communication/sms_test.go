@@ -0,0 +1,169 @@
+package communication
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//testSMSProvider records the messages it is asked to send and fails if err is set
+type testSMSProvider struct {
+	id   string
+	err  error
+	sent []string
+}
+
+func (p *testSMSProvider) SendSMS(sms *OutgoingSMS) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	p.sent = append(p.sent, sms.Phonenumber)
+	return p.id, nil
+}
+
+func TestSMSProviderRegistry(t *testing.T) {
+	twilio := &testSMSProvider{id: "SM1", err: errors.New("outage")}
+	smpp := &testSMSProvider{id: "smpp1"}
+	gateway := &testSMSProvider{id: "gw1"}
+	registry := NewSMSProviderRegistry()
+	registry.Register("twilio", twilio)
+	registry.Register("smpp", smpp)
+	registry.Register("gateway", gateway)
+
+	assert.NoError(t, registry.AddRoute("+32", "gateway"))
+	assert.NoError(t, registry.AddRoute("+324", "smpp", "gateway"))
+	assert.Error(t, registry.AddRoute("+33", "unknown"))
+	assert.Error(t, registry.AddRoute("32", "smpp"))
+	assert.Error(t, registry.AddRoute("+1"))
+
+	assert.Equal(t, []string{"twilio", "smpp", "gateway"}, registry.ProvidersFor("+15551234"))
+	assert.Equal(t, []string{"gateway"}, registry.ProvidersFor("+3212345678"))
+	assert.Equal(t, []string{"smpp", "gateway"}, registry.ProvidersFor("+32475123456"))
+
+	provider, id, failures := registry.Deliver(&OutgoingSMS{Phonenumber: "+15551234", Message: "code"})
+	assert.Equal(t, "smpp", provider)
+	assert.Equal(t, "smpp1", id)
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "twilio", failures[0].Provider)
+	}
+
+	provider, _, _ = registry.Deliver(&OutgoingSMS{Phonenumber: "+3212345678", Message: "code"})
+	assert.Equal(t, "gateway", provider)
+	assert.Equal(t, []string{"+15551234"}, smpp.sent)
+
+	smpp.err = errors.New("bind failed")
+	gateway.err = errors.New("503")
+	err := registry.Send("+15551234", "code")
+	if assert.IsType(t, &SMSDeliveryError{}, err) {
+		assert.Len(t, err.(*SMSDeliveryError).Failures, 3)
+		assert.Equal(t, "Error sending sms: twilio: outage, smpp: bind failed, gateway: 503", err.Error())
+	}
+}
+
+func TestHTTPSMSService(t *testing.T) {
+	var received map[string]string
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"id": "msg-1"}`))
+	}))
+	defer server.Close()
+
+	s := &HTTPSMSService{GatewayURL: server.URL, Token: "secret"}
+	id, err := s.SendSMS(&OutgoingSMS{Phonenumber: "+32475123456", Message: "code 1234", StatusCallback: "https://itsyou.online/sms/status/abc"})
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, map[string]string{"to": "+32475123456", "message": "code 1234", "statuscallback": "https://itsyou.online/sms/status/abc"}, received)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	s.GatewayURL = failing.URL
+	assert.Error(t, s.Send("+32475123456", "code 1234"))
+}
+
+//runTestSMSC accepts a single SMPP session, answers an enquire_link before the submit_sm response
+// and sends the submitted short message on the returned channel
+func runTestSMSC(listener net.Listener, submitStatus uint32) <-chan []byte {
+	submitted := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		session := &smppSession{conn: conn, reader: bufio.NewReader(conn)}
+		for {
+			command, _, sequence, body, err := session.read()
+			if err != nil {
+				return
+			}
+			switch command {
+			case smppBindTransmitter:
+				status := uint32(0)
+				if readCString(body) != "iyo" {
+					status = 0x0e
+				}
+				session.write(smppBindTransmitterResp, status, sequence, []byte("smsc\x00"))
+			case smppSubmitSM:
+				submitted <- body
+				session.write(smppEnquireLink, 0, 99, nil)
+				session.write(smppSubmitSMResp, submitStatus, sequence, []byte("msg-42\x00"))
+			case smppEnquireLinkResp:
+			case smppUnbind:
+				session.write(smppUnbindResp, 0, sequence, nil)
+				return
+			}
+		}
+	}()
+	return submitted
+}
+
+func TestSMPPSMSService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer listener.Close()
+
+	submitted := runTestSMSC(listener, 0)
+	s := &SMPPSMSService{Address: listener.Addr().String(), SystemID: "iyo", Password: "secret", SourceAddress: "itsyou"}
+	id, err := s.SendSMS(&OutgoingSMS{Phonenumber: "+32475123456", Message: "code 1234"})
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-42", id)
+	body := <-submitted
+	assert.Equal(t, []byte("\x00\x05\x00itsyou\x00\x01\x0132475123456\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x09code 1234"), body)
+
+	runTestSMSC(listener, 0x45)
+	_, err = s.SendSMS(&OutgoingSMS{Phonenumber: "+32475123456", Message: "code 1234"})
+	assert.Equal(t, &SMPPError{Command: smppSubmitSM, Status: 0x45}, err)
+
+	runTestSMSC(listener, 0)
+	s.SystemID = "other"
+	err = s.Send("+32475123456", "code 1234")
+	assert.Equal(t, &SMPPError{Command: smppBindTransmitter, Status: 0x0e}, err)
+}
+
+func TestSubmitSMLongAndUnicodeMessages(t *testing.T) {
+	s := &SMPPSMSService{SourceAddress: "+3212345678"}
+	body := s.submitSM(&OutgoingSMS{Phonenumber: "+32475123456", Message: "Tsjüs"})
+	assert.Equal(t, []byte("\x00\x01\x013212345678\x00"), body[:14])
+	assert.Equal(t, []byte{0x08, 0x00, 10, 0, 'T', 0, 's', 0, 'j', 0, 0xfc, 0, 's'}, body[len(body)-13:])
+
+	long := make([]byte, 300)
+	for i := range long {
+		long[i] = 'a'
+	}
+	body = s.submitSM(&OutgoingSMS{Phonenumber: "+32475123456", Message: string(long)})
+	//sm_length 0 followed by the message_payload parameter
+	assert.Equal(t, []byte{0, 0x04, 0x24, 0x01, 0x2c}, body[len(body)-305:len(body)-300])
+}
@@ -0,0 +1,121 @@
+package communication
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//ErrUnknownSMSProvider is returned when a route refers to a provider that is not registered
+var ErrUnknownSMSProvider = errors.New("Unknown sms provider")
+
+//SMSProviderError is the error of a single provider when sending an sms
+type SMSProviderError struct {
+	Provider string
+	Err      error
+}
+
+func (e *SMSProviderError) Error() string {
+	return e.Provider + ": " + e.Err.Error()
+}
+
+//SMSDeliveryError is returned when none of the providers could send an sms
+type SMSDeliveryError struct {
+	Failures []SMSProviderError
+}
+
+func (e *SMSDeliveryError) Error() string {
+	if len(e.Failures) == 0 {
+		return "No sms provider available"
+	}
+	failures := make([]string, len(e.Failures))
+	for i := range e.Failures {
+		failures[i] = e.Failures[i].Error()
+	}
+	return "Error sending sms: " + strings.Join(failures, ", ")
+}
+
+//smsRoute limits the providers used for the phone numbers starting with a prefix
+type smsRoute struct {
+	prefix    string
+	providers []string
+}
+
+//SMSProviderRegistry sends sms through the registered providers.
+// The providers are tried in the order they are registered until one accepts the message,
+// routes can select other providers for the phone numbers of a country.
+type SMSProviderRegistry struct {
+	providers map[string]SMSProvider
+	order     []string
+	routes    []smsRoute
+}
+
+//NewSMSProviderRegistry creates an empty SMSProviderRegistry
+func NewSMSProviderRegistry() *SMSProviderRegistry {
+	return &SMSProviderRegistry{providers: map[string]SMSProvider{}}
+}
+
+//Register adds a provider as the last one to fall back to
+func (r *SMSProviderRegistry) Register(name string, provider SMSProvider) {
+	if _, exists := r.providers[name]; !exists {
+		r.order = append(r.order, name)
+	}
+	r.providers[name] = provider
+}
+
+//Providers returns the names of the registered providers in the order they are tried
+func (r *SMSProviderRegistry) Providers() []string {
+	return append([]string{}, r.order...)
+}
+
+//AddRoute sends the sms to the phone numbers starting with prefix, like "+32", only through the given providers in that order.
+// If multiple routes match, the one with the longest prefix is used.
+func (r *SMSProviderRegistry) AddRoute(prefix string, providers ...string) error {
+	if !strings.HasPrefix(prefix, "+") || !isDigits(prefix[1:]) || len(providers) == 0 {
+		return fmt.Errorf("Invalid sms route for %q", prefix)
+	}
+	for _, name := range providers {
+		if _, exists := r.providers[name]; !exists {
+			return fmt.Errorf("%v: %s", ErrUnknownSMSProvider, name)
+		}
+	}
+	r.routes = append(r.routes, smsRoute{prefix: prefix, providers: providers})
+	return nil
+}
+
+//ProvidersFor returns the names of the providers an sms to a phone number is sent with, in the order they are tried
+func (r *SMSProviderRegistry) ProvidersFor(phonenumber string) []string {
+	var route *smsRoute
+	for i := range r.routes {
+		if strings.HasPrefix(phonenumber, r.routes[i].prefix) && (route == nil || len(r.routes[i].prefix) > len(route.prefix)) {
+			route = &r.routes[i]
+		}
+	}
+	if route != nil {
+		return route.providers
+	}
+	return r.order
+}
+
+//Deliver tries the providers for the phone number until one accepts the sms.
+// The name of that provider and the id it gave to the message are returned together with the errors of the providers tried before it,
+// provider is empty if none of them accepted it.
+func (r *SMSProviderRegistry) Deliver(sms *OutgoingSMS) (provider string, messageID string, failures []SMSProviderError) {
+	for _, name := range r.ProvidersFor(sms.Phonenumber) {
+		id, err := r.providers[name].SendSMS(sms)
+		if err == nil {
+			return name, id, failures
+		}
+		failures = append(failures, SMSProviderError{Provider: name, Err: err})
+	}
+	return
+}
+
+//Send sends an SMS, an SMSDeliveryError is returned if none of the providers accepted it
+func (r *SMSProviderRegistry) Send(phonenumber string, message string) (err error) {
+	provider, _, failures := r.Deliver(&OutgoingSMS{Phonenumber: phonenumber, Message: message})
+	if provider == "" {
+		err = &SMSDeliveryError{Failures: failures}
+	}
+	return
+}
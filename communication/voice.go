@@ -0,0 +1,72 @@
+package communication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+//voiceRequestTimeout limits how long the voice provider gets to accept a call
+const voiceRequestTimeout = 15 * time.Second
+
+//VoiceService defines a channel that calls a phonenumber and reads out a message.
+// The language is a BCP 47 tag like en-US the message is spoken in.
+type VoiceService interface {
+	Call(phonenumber string, message string, language string) (err error)
+}
+
+//TwilioVoiceService is a voice communication channel using Twilio, the message is read out with text-to-speech
+type TwilioVoiceService struct {
+	AccountSID string
+	AuthToken  string
+	//From is the Twilio phonenumber the call is made from
+	From string
+}
+
+//Call calls a phonenumber and reads out the message twice
+func (s *TwilioVoiceService) Call(phonenumber string, message string, language string) (err error) {
+	client := &http.Client{Timeout: voiceRequestTimeout}
+
+	data := url.Values{
+		"To":    {phonenumber},
+		"From":  {s.From},
+		"Twiml": {twiml(message, language)},
+	}
+	req, err := http.NewRequest("POST", "https://api.twilio.com/2010-04-01/Accounts/"+s.AccountSID+"/Calls.json", strings.NewReader(data.Encode()))
+	if err != nil {
+		log.Error("Error creating call request: ", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.AccountSID, s.AuthToken)
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Error("Error calling via Twilio: ", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := ioutil.ReadAll(resp.Body)
+		log.Error("Problem when calling via Twilio: ", resp.StatusCode, "\n", string(body))
+		err = errors.New("Error making call")
+	}
+	return
+}
+
+//twiml returns the instructions for Twilio to read out a message
+func twiml(message string, language string) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<Response><Say loop="2" language="`)
+	xml.EscapeText(buf, []byte(language))
+	buf.WriteString(`">`)
+	xml.EscapeText(buf, []byte(message))
+	buf.WriteString(`</Say></Response>`)
+	return buf.String()
+}
@@ -0,0 +1,12 @@
+package communication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwiml(t *testing.T) {
+	assert.Equal(t, `<Response><Say loop="2" language="nl-NL">Je code is 1, 2, 3 &lt;&amp;&gt;</Say></Response>`, twiml("Je code is 1, 2, 3 <&>", "nl-NL"))
+	assert.Equal(t, `<Response><Say loop="2" language="&#34;&gt;">code</Say></Response>`, twiml("code", `">`))
+}
@@ -99,3 +99,17 @@ func (cm *CompanyManager) Delete(company *Company) error {
 	//TODO: implement delete company
 	return errors.New("Not implemented")
 }
+
+//RenameOrganization replaces the globalid of an organization in the organizations of the companies
+func (cm *CompanyManager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = cm.collection.UpdateAll(
+		bson.M{"organizations": oldGlobalID},
+		bson.M{"$set": bson.M{"organizations.$": newGlobalID}})
+	return
+}
+
+//RemoveOrganization removes an organization from the organizations of the companies
+func (cm *CompanyManager) RemoveOrganization(globalID string) (err error) {
+	_, err = cm.collection.UpdateAll(bson.M{"organizations": globalID}, bson.M{"$pull": bson.M{"organizations": globalID}})
+	return
+}
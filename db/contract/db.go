@@ -105,3 +105,24 @@ func (m *Manager) GetByIncludedParty(party *Party, start int, max int, includeEx
 	}
 	return
 }
+
+//RenameOrganization changes the name of an organization party to its new globalid
+func (m *Manager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.collection.UpdateAll(
+		bson.M{"parties": bson.M{"$elemMatch": bson.M{"type": "org", "name": oldGlobalID}}},
+		bson.M{"$set": bson.M{"parties.$.name": newGlobalID}})
+	return
+}
+
+//RemoveOrganization removes an organization from the parties of the contracts,
+// the contracts that have no parties left are removed
+func (m *Manager) RemoveOrganization(globalID string) (err error) {
+	_, err = m.collection.UpdateAll(
+		bson.M{"parties": bson.M{"$elemMatch": bson.M{"type": "org", "name": globalID}}},
+		bson.M{"$pull": bson.M{"parties": bson.M{"type": "org", "name": globalID}}})
+	if err != nil {
+		return
+	}
+	_, err = m.collection.RemoveAll(bson.M{"parties": bson.M{"$size": 0}})
+	return
+}
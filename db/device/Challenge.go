@@ -0,0 +1,36 @@
+package device
+
+import (
+	"fmt"
+	"time"
+)
+
+//ChallengeStatus is the state of a push login challenge
+type ChallengeStatus string
+
+const (
+	//ChallengePending is a challenge the device did not answer yet
+	ChallengePending ChallengeStatus = "pending"
+	//ChallengeApproved is a challenge the device approved
+	ChallengeApproved ChallengeStatus = "approved"
+	//ChallengeDenied is a challenge the device denied
+	ChallengeDenied ChallengeStatus = "denied"
+)
+
+//Challenge is a login waiting for approval on a paired device
+type Challenge struct {
+	Key       string
+	Username  string
+	DeviceID  string
+	Status    ChallengeStatus
+	CreatedAt time.Time
+}
+
+//SignedMessage is the message a device needs to sign to approve or deny the challenge
+func (c *Challenge) SignedMessage(approve bool) string {
+	decision := "deny"
+	if approve {
+		decision = "approve"
+	}
+	return fmt.Sprintf("%s:%s", c.Key, decision)
+}
@@ -0,0 +1,76 @@
+package device
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"math/big"
+
+	"github.com/itsyouonline/identityserver/db"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/validator.v2"
+)
+
+//ErrInvalidPublicKey is returned when a public key is not a base64 encoded ECDSA key in PKIX format
+var ErrInvalidPublicKey = errors.New("Invalid public key")
+
+//Device is a paired companion app that can approve or deny logins
+type Device struct {
+	ID        bson.ObjectId `json:"id" bson:"_id,omitempty"`
+	Username  string        `json:"username"`
+	Label     string        `json:"label" validate:"min=2,max=50"`
+	PublicKey string        `json:"publickey" validate:"nonzero"`
+	PushToken string        `json:"pushtoken,omitempty" validate:"max=1024"`
+	CreatedAt db.DateTime   `json:"createdat"`
+}
+
+//Validate checks if the Device is valid
+func (d *Device) Validate() error {
+	if err := validator.Validate(d); err != nil {
+		return err
+	}
+	_, err := ParsePublicKey(d.PublicKey)
+	return err
+}
+
+//ParsePublicKey decodes a base64 encoded ECDSA public key in PKIX (DER) format
+func ParsePublicKey(encoded string) (key *ecdsa.PublicKey, err error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		err = ErrInvalidPublicKey
+		return
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		err = ErrInvalidPublicKey
+		return
+	}
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		err = ErrInvalidPublicKey
+	}
+	return
+}
+
+//VerifySignature checks a base64 encoded ASN.1 ECDSA signature of the SHA-256 hash of message
+func (d *Device) VerifySignature(message string, signature string) bool {
+	key, err := ParsePublicKey(d.PublicKey)
+	if err != nil {
+		return false
+	}
+	der, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	sig := struct {
+		R, S *big.Int
+	}{}
+	if _, err = asn1.Unmarshal(der, &sig); err != nil {
+		return false
+	}
+	hash := sha256.Sum256([]byte(message))
+	return ecdsa.Verify(key, hash[:], sig.R, sig.S)
+}
@@ -0,0 +1,76 @@
+package device
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKey(t *testing.T) (key *ecdsa.PrivateKey, encodedPublicKey string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	encodedPublicKey = base64.StdEncoding.EncodeToString(der)
+	return
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, message string) string {
+	hash := sha256.Sum256([]byte(message))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	assert.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func TestValidate(t *testing.T) {
+	_, publicKey := newTestKey(t)
+	type testcase struct {
+		device Device
+		valid  bool
+	}
+	testcases := []testcase{
+		{device: Device{Label: "phone", PublicKey: publicKey}, valid: true},
+		{device: Device{Label: "p", PublicKey: publicKey}, valid: false},
+		{device: Device{Label: "phone"}, valid: false},
+		{device: Device{Label: "phone", PublicKey: "notbase64!"}, valid: false},
+		{device: Device{Label: "phone", PublicKey: base64.StdEncoding.EncodeToString([]byte("notakey"))}, valid: false},
+	}
+	for _, test := range testcases {
+		err := test.device.Validate()
+		assert.Equal(t, test.valid, err == nil, test.device.Label)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, publicKey := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+	device := &Device{PublicKey: publicKey}
+	challenge := &Challenge{Key: "challengekey"}
+
+	type testcase struct {
+		message   string
+		signature string
+		valid     bool
+	}
+	testcases := []testcase{
+		{message: challenge.SignedMessage(true), signature: sign(t, key, challenge.SignedMessage(true)), valid: true},
+		{message: challenge.SignedMessage(false), signature: sign(t, key, challenge.SignedMessage(false)), valid: true},
+		//A denial can not be replayed as an approval
+		{message: challenge.SignedMessage(true), signature: sign(t, key, challenge.SignedMessage(false)), valid: false},
+		{message: challenge.SignedMessage(true), signature: sign(t, otherKey, challenge.SignedMessage(true)), valid: false},
+		{message: challenge.SignedMessage(true), signature: "invalid", valid: false},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.valid, device.VerifySignature(test.message, test.signature), test.message)
+	}
+}
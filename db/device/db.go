@@ -0,0 +1,154 @@
+package device
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	mongoDeviceCollectionName    = "devices"
+	mongoChallengeCollectionName = "devicechallenges"
+	//challengeValidity matches the lifetime of the login session
+	challengeValidity = time.Minute * 5
+)
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	index := mgo.Index{
+		Key:    []string{"username", "label"},
+		Unique: true,
+	}
+	db.EnsureIndex(mongoDeviceCollectionName, index)
+
+	index = mgo.Index{
+		Key:    []string{"key"},
+		Unique: true,
+	}
+	db.EnsureIndex(mongoChallengeCollectionName, index)
+
+	automaticExpiration := mgo.Index{
+		Key:         []string{"createdat"},
+		ExpireAfter: challengeValidity,
+		Background:  true,
+	}
+	db.EnsureIndex(mongoChallengeCollectionName, automaticExpiration)
+}
+
+//Manager is used to store paired devices and their login challenges
+type Manager struct {
+	session *mgo.Session
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session: session,
+	}
+}
+
+func (m *Manager) getDeviceCollection() *mgo.Collection {
+	return db.GetCollection(m.session, mongoDeviceCollectionName)
+}
+
+func (m *Manager) getChallengeCollection() *mgo.Collection {
+	return db.GetCollection(m.session, mongoChallengeCollectionName)
+}
+
+//Create pairs a new device
+func (m *Manager) Create(device *Device) (err error) {
+	device.ID = bson.NewObjectId()
+	device.CreatedAt = db.DateTime(time.Now())
+	err = m.getDeviceCollection().Insert(device)
+	if mgo.IsDup(err) {
+		err = db.ErrDuplicate
+	}
+	return
+}
+
+//GetByUser gets all devices paired by a user
+func (m *Manager) GetByUser(username string) (devices []Device, err error) {
+	devices = []Device{}
+	err = m.getDeviceCollection().Find(bson.M{"username": username}).All(&devices)
+	return
+}
+
+//Get gets a device of a user, mgo.ErrNotFound is returned if the user has no such device
+func (m *Manager) Get(username string, id string) (device *Device, err error) {
+	if !bson.IsObjectIdHex(id) {
+		err = mgo.ErrNotFound
+		return
+	}
+	device = &Device{}
+	err = m.getDeviceCollection().Find(bson.M{"_id": bson.ObjectIdHex(id), "username": username}).One(device)
+	if err != nil {
+		device = nil
+	}
+	return
+}
+
+//Delete unpairs a device, mgo.ErrNotFound is returned if the user has no such device
+func (m *Manager) Delete(username string, id string) (err error) {
+	if !bson.IsObjectIdHex(id) {
+		err = mgo.ErrNotFound
+		return
+	}
+	err = m.getDeviceCollection().Remove(bson.M{"_id": bson.ObjectIdHex(id), "username": username})
+	if err == nil {
+		_, err = m.getChallengeCollection().RemoveAll(bson.M{"deviceid": id})
+	}
+	return
+}
+
+//NewChallenge creates and stores a pending challenge for a device
+func (m *Manager) NewChallenge(username string, deviceID string) (challenge *Challenge, err error) {
+	randombytes := make([]byte, 30) //Multiple of 3 to make sure no padding is added
+	if _, err = rand.Read(randombytes); err != nil {
+		return
+	}
+	challenge = &Challenge{
+		Key:       base64.URLEncoding.EncodeToString(randombytes),
+		Username:  username,
+		DeviceID:  deviceID,
+		Status:    ChallengePending,
+		CreatedAt: time.Now(),
+	}
+	err = m.getChallengeCollection().Insert(challenge)
+	return
+}
+
+//GetChallenge gets a challenge by its key, nil is returned if it does not exist or is expired
+func (m *Manager) GetChallenge(key string) (challenge *Challenge, err error) {
+	challenge = &Challenge{}
+	err = m.getChallengeCollection().Find(bson.M{"key": key}).One(challenge)
+	if err == mgo.ErrNotFound {
+		challenge, err = nil, nil
+		return
+	}
+	if err == nil && challenge.CreatedAt.Add(challengeValidity).Before(time.Now()) {
+		challenge = nil
+	}
+	return
+}
+
+//AnswerChallenge sets the status of a pending challenge for a device,
+// mgo.ErrNotFound is returned if there is no such pending challenge
+func (m *Manager) AnswerChallenge(key string, deviceID string, status ChallengeStatus) (err error) {
+	err = m.getChallengeCollection().Update(
+		bson.M{"key": key, "deviceid": deviceID, "status": ChallengePending},
+		bson.M{"$set": bson.M{"status": status}})
+	return
+}
+
+//RemoveChallenge removes a challenge so it can not be used again
+func (m *Manager) RemoveChallenge(key string) (err error) {
+	_, err = m.getChallengeCollection().RemoveAll(bson.M{"key": key})
+	return
+}
@@ -0,0 +1,47 @@
+package email
+
+import (
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	//StatusQueued is the status of a message that is waiting for a worker to send it
+	StatusQueued = "queued"
+	//StatusSent is the status of a message the smtp server accepted
+	StatusSent = "sent"
+	//StatusDead is the status of a message that is not retried anymore, it can be queued again by an admin
+	StatusDead = "dead"
+)
+
+const (
+	//ReasonBounce marks an email address that does not exist or permanently refuses mail
+	ReasonBounce = "bounce"
+	//ReasonComplaint marks an email address whose owner reported our mail as spam
+	ReasonComplaint = "complaint"
+)
+
+//Message is an email in the queue.
+// The content is removed once the message is sent since it usually contains a login or validation link,
+// dead messages keep it so they can be queued again.
+type Message struct {
+	ID          string      `json:"id" bson:"_id"`
+	Recipients  []string    `json:"recipients"`
+	SenderName  string      `json:"-"`
+	Subject     string      `json:"subject"`
+	Text        string      `json:"-"`
+	HTML        string      `json:"-"`
+	Status      string      `json:"status"`
+	Attempts    int         `json:"attempts"`
+	LastError   string      `json:"lasterror,omitempty"`
+	Created     db.DateTime `json:"created"`
+	Updated     db.DateTime `json:"updated"`
+	NextAttempt db.DateTime `json:"-"`
+}
+
+//Undeliverable is an email address no more mail is sent to because it bounced or its owner complained
+type Undeliverable struct {
+	EmailAddress string      `json:"emailaddress" bson:"_id"`
+	Reason       string      `json:"reason"`
+	Detail       string      `json:"detail,omitempty"`
+	Created      db.DateTime `json:"created"`
+}
@@ -0,0 +1,148 @@
+package email
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	mongoCollectionName              = "mailqueue"
+	undeliverableMongoCollectionName = "undeliverableemail"
+	//maxListed limits the number of dead messages and undeliverable addresses that are listed
+	maxListed = 500
+)
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	index := mgo.Index{
+		Key: []string{"status", "nextattempt"},
+	}
+	db.EnsureIndex(mongoCollectionName, index)
+
+	//Keep the messages a week to look into delivery problems
+	automaticExpiration := mgo.Index{
+		Key:         []string{"created"},
+		ExpireAfter: time.Second * 3600 * 24 * 7,
+		Background:  true,
+	}
+	db.EnsureIndex(mongoCollectionName, automaticExpiration)
+}
+
+//Manager is used to store the mail queue and the undeliverable email addresses
+type Manager struct {
+	session       *mgo.Session
+	collection    *mgo.Collection
+	undeliverable *mgo.Collection
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session:       session,
+		collection:    db.GetCollection(session, mongoCollectionName),
+		undeliverable: db.GetCollection(session, undeliverableMongoCollectionName),
+	}
+}
+
+//Save stores a new message
+func (m *Manager) Save(message *Message) error {
+	return m.collection.Insert(message)
+}
+
+//Claim takes the queued message that is due the longest and postpones its next attempt until lease,
+// so other workers do not send it at the same time. nil is returned if no message is due.
+func (m *Manager) Claim(now time.Time, lease time.Time) (message *Message, err error) {
+	message = &Message{}
+	change := mgo.Change{
+		Update:    bson.M{"$set": bson.M{"nextattempt": lease}},
+		ReturnNew: true,
+	}
+	_, err = m.collection.Find(bson.M{"status": StatusQueued, "nextattempt": bson.M{"$lte": now}}).Sort("nextattempt").Apply(change, message)
+	if err == mgo.ErrNotFound {
+		err = nil
+		message = nil
+	}
+	return
+}
+
+//MarkSent records that the smtp server accepted a message
+func (m *Manager) MarkSent(id string) error {
+	return m.recordAttempt(id, bson.M{
+		"status":    StatusSent,
+		"lasterror": "",
+		"text":      "",
+		"html":      "",
+	})
+}
+
+//MarkRetry records a failed attempt after which the message is tried again at nextAttempt
+func (m *Manager) MarkRetry(id string, lastError string, nextAttempt time.Time) error {
+	return m.recordAttempt(id, bson.M{"lasterror": lastError, "nextattempt": nextAttempt})
+}
+
+//MarkDead records that a message will not be retried anymore
+func (m *Manager) MarkDead(id string, lastError string) error {
+	return m.recordAttempt(id, bson.M{"status": StatusDead, "lasterror": lastError})
+}
+
+func (m *Manager) recordAttempt(id string, set bson.M) error {
+	set["updated"] = db.DateTime(time.Now())
+	return m.collection.UpdateId(id, bson.M{"$set": set, "$inc": bson.M{"attempts": 1}})
+}
+
+//Requeue queues a dead message again, mgo.ErrNotFound is returned if there is no dead message with this id
+func (m *Manager) Requeue(id string) error {
+	now := db.DateTime(time.Now())
+	return m.collection.Update(
+		bson.M{"_id": id, "status": StatusDead},
+		bson.M{"$set": bson.M{"status": StatusQueued, "attempts": 0, "nextattempt": now, "updated": now}})
+}
+
+//GetDead lists the dead messages, the newest first
+func (m *Manager) GetDead() (messages []Message, err error) {
+	messages = []Message{}
+	err = m.collection.Find(bson.M{"status": StatusDead}).Sort("-updated").Limit(maxListed).All(&messages)
+	return
+}
+
+//MarkUndeliverable stops sending mail to an email address
+func (m *Manager) MarkUndeliverable(undeliverable *Undeliverable) (err error) {
+	undeliverable.EmailAddress = strings.ToLower(undeliverable.EmailAddress)
+	_, err = m.undeliverable.UpsertId(undeliverable.EmailAddress, undeliverable)
+	return
+}
+
+//GetUndeliverable lists the undeliverable email addresses, the most recently marked first
+func (m *Manager) GetUndeliverable() (addresses []Undeliverable, err error) {
+	addresses = []Undeliverable{}
+	err = m.undeliverable.Find(nil).Sort("-created").Limit(maxListed).All(&addresses)
+	return
+}
+
+//FilterUndeliverable returns the email addresses that are marked undeliverable
+func (m *Manager) FilterUndeliverable(emailaddresses []string) (undeliverable []string, err error) {
+	lowercase := make([]string, len(emailaddresses))
+	for i, emailaddress := range emailaddresses {
+		lowercase[i] = strings.ToLower(emailaddress)
+	}
+	var marked []Undeliverable
+	if err = m.undeliverable.Find(bson.M{"_id": bson.M{"$in": lowercase}}).All(&marked); err != nil {
+		return
+	}
+	for _, u := range marked {
+		undeliverable = append(undeliverable, u.EmailAddress)
+	}
+	return
+}
+
+//RemoveUndeliverable sends mail to an email address again, mgo.ErrNotFound is returned if it was not marked undeliverable
+func (m *Manager) RemoveUndeliverable(emailaddress string) error {
+	return m.undeliverable.RemoveId(strings.ToLower(emailaddress))
+}
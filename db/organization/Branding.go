@@ -0,0 +1,37 @@
+package organization
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var brandingColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+//Branding overrides how the emails and sms sent for an organization and its suborganizations look.
+// The Name replaces the globalid in the texts, the SenderName is the display name of the email sender.
+type Branding struct {
+	Globalid   string `json:"-"`
+	Name       string `json:"name,omitempty"`
+	LogoURL    string `json:"logourl,omitempty"`
+	Color      string `json:"color,omitempty"`
+	SenderName string `json:"sendername,omitempty"`
+	Footer     string `json:"footer,omitempty"`
+}
+
+//IsValid checks if the logo is an https url, the color a hex rgb value and the texts not too long
+func (b *Branding) IsValid() bool {
+	if b.LogoURL != "" {
+		logo, err := url.Parse(b.LogoURL)
+		if err != nil || logo.Scheme != "https" || logo.Host == "" {
+			return false
+		}
+	}
+	if b.Color != "" && !brandingColorRegex.MatchString(b.Color) {
+		return false
+	}
+	if strings.ContainsAny(b.Name+b.SenderName, "\r\n<>\"") {
+		return false
+	}
+	return len(b.Name) <= 60 && len(b.SenderName) <= 60 && len(b.LogoURL) <= 500 && len(b.Footer) <= 500
+}
@@ -0,0 +1,88 @@
+package organization
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	//VerificationMethodDNS proves control of a domain with a TXT record
+	VerificationMethodDNS = "dns"
+	//VerificationMethodHTTP proves control of a domain with a file on its https well-known url
+	VerificationMethodHTTP = "http"
+)
+
+const (
+	//DomainMembershipNone does nothing for users with an email address at a verified domain, it is the default
+	DomainMembershipNone = "none"
+	//DomainMembershipInvite invites the users that validate an email address at a verified domain
+	DomainMembershipInvite = "invite"
+	//DomainMembershipMember makes the users that validate an email address at a verified domain members
+	DomainMembershipMember = "member"
+)
+
+//DomainVerification is the proof an organization controls one of its dns names.
+// The Token has to be published in a TXT record or on the well-known url of the domain.
+type DomainVerification struct {
+	Name     string `json:"name"`
+	Token    string `json:"token"`
+	Verified bool   `json:"verified"`
+	//Method is how the domain was verified the last time
+	Method      string      `json:"method,omitempty" bson:"method,omitempty"`
+	VerifiedAt  db.DateTime `json:"verifiedat,omitempty" bson:"verifiedat,omitempty"`
+	LastChecked db.DateTime `json:"lastchecked,omitempty" bson:"lastchecked,omitempty"`
+	//Started is set once an owner asked to verify the domain, only then the domain can get verified in the background
+	Started bool `json:"-" bson:"started,omitempty"`
+}
+
+//NewDomainVerification creates an unverified DomainVerification with a new random token
+func NewDomainVerification(name string) (verification *DomainVerification, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	verification = &DomainVerification{Name: strings.ToLower(name), Token: hex.EncodeToString(b)}
+	return
+}
+
+//IsValidDomainMembership checks if a domain membership rule is one of the known ones, empty means DomainMembershipNone
+func IsValidDomainMembership(rule string) bool {
+	return rule == "" || rule == DomainMembershipNone || rule == DomainMembershipInvite || rule == DomainMembershipMember
+}
+
+//GetDomainVerification returns the verification of a dns name of the organization or nil if there is none yet
+func (c *Organization) GetDomainVerification(name string) *DomainVerification {
+	for i := range c.DomainVerifications {
+		if strings.EqualFold(c.DomainVerifications[i].Name, name) {
+			return &c.DomainVerifications[i]
+		}
+	}
+	return nil
+}
+
+//VerifiedDomains returns the dns names of the organization it proved to control
+func (c *Organization) VerifiedDomains() (domains []string) {
+	domains = []string{}
+	for _, name := range c.DNS {
+		if v := c.GetDomainVerification(name); v != nil && v.Verified {
+			domains = append(domains, name)
+		}
+	}
+	return
+}
+
+//ParentDomains returns the domain of an email address and the domains it is a subdomain of, the top level domain excluded
+func ParentDomains(emailaddress string) (domains []string) {
+	i := strings.LastIndex(emailaddress, "@")
+	if i < 0 {
+		return
+	}
+	labels := strings.Split(strings.ToLower(emailaddress[i+1:]), ".")
+	for j := 0; j < len(labels)-1; j++ {
+		domains = append(domains, strings.Join(labels[j:], "."))
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package organization
+
+import "strings"
+
+const (
+	//InheritOwners makes the owners of the parent organizations owners of the organization, this is the default
+	InheritOwners = "owners"
+	//InheritAll makes the owners and the members of the parent organizations owners and members of the organization
+	InheritAll = "all"
+	//InheritNone makes only the users in the owners and members lists of the organization itself owners and members
+	InheritNone = "none"
+)
+
+//IsValidInheritance checks if the inheritance is one of the known settings, an empty one means InheritOwners
+func IsValidInheritance(inheritance string) bool {
+	return inheritance == "" || inheritance == InheritOwners || inheritance == InheritAll || inheritance == InheritNone
+}
+
+//Lineage returns the globalid of an organization followed by the ones of its parents, up to the root organization
+func Lineage(globalID string) (globalIDs []string) {
+	for parts := strings.Split(globalID, "."); len(parts) > 0; parts = parts[:len(parts)-1] {
+		globalIDs = append(globalIDs, strings.Join(parts, "."))
+	}
+	return
+}
+
+//EffectiveMembership determines if a user is an owner or a member of the first organization of a lineage,
+// the organization followed by its parents as returned by Lineage.
+// The parents are only taken into account as far as the organizations below them inherit from them.
+func EffectiveMembership(lineage []Organization, username string) (owner bool, member bool) {
+	inheritMembers := true
+	for _, org := range lineage {
+		owner = owner || contains(org.Owners, username)
+		member = member || inheritMembers && contains(org.Members, username)
+		if org.Inheritance == InheritNone {
+			break
+		}
+		inheritMembers = inheritMembers && org.Inheritance == InheritAll
+	}
+	return
+}
+
+//EffectiveMembers returns the owners and the members of the first organization of a lineage,
+// including the ones inherited from its parents. A user is only listed once, as owner if it is both.
+func EffectiveMembers(lineage []Organization) (owners []string, members []string) {
+	owners, members = []string{}, []string{}
+	inheritMembers := true
+	for _, org := range lineage {
+		for _, username := range org.Owners {
+			if !contains(owners, username) {
+				owners = append(owners, username)
+			}
+		}
+		if inheritMembers {
+			members = append(members, org.Members...)
+		}
+		if org.Inheritance == InheritNone {
+			break
+		}
+		inheritMembers = inheritMembers && org.Inheritance == InheritAll
+	}
+	members = withoutDuplicates(members, owners)
+	return
+}
+
+//EffectiveOrgMembers returns the organizations that are members of the first organization of a lineage,
+// including the ones inherited from its parents like EffectiveMembers does for users.
+func EffectiveOrgMembers(lineage []Organization) (orgMembers []string) {
+	orgMembers = []string{}
+	for _, org := range lineage {
+		orgMembers = append(orgMembers, org.OrgMembers...)
+		if org.Inheritance != InheritAll {
+			break
+		}
+	}
+	return withoutDuplicates(orgMembers, nil)
+}
+
+//withoutDuplicates returns the values in order without the duplicates and without the ones in exclude
+func withoutDuplicates(values []string, exclude []string) (unique []string) {
+	unique = make([]string, 0, len(values))
+	for _, value := range values {
+		if !contains(exclude, value) && !contains(unique, value) {
+			unique = append(unique, value)
+		}
+	}
+	return
+}
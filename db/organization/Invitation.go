@@ -3,7 +3,12 @@ package organization
 import "github.com/itsyouonline/identityserver/db"
 
 type Invitation struct {
-	Created db.DateTime `json:"created"`
-	Role    string      `json:"role"`
-	User    string      `json:"user"`
+	Created      db.DateTime `json:"created"`
+	Expires      db.DateTime `json:"expires"`
+	Role         string      `json:"role"`
+	User         string      `json:"user"`
+	EmailAddress string      `json:"emailaddress,omitempty"`
+	PhoneNumber  string      `json:"phonenumber,omitempty"`
+	Status       string      `json:"status"`
+	Inviter      string      `json:"inviter,omitempty"`
 }
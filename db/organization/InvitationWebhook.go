@@ -0,0 +1,26 @@
+package organization
+
+import (
+	"net/url"
+
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+//InvitationWebhook is the url an organization is notified on when one of its invitations is accepted or rejected.
+// If the Secret is set, the notifications are signed with it.
+type InvitationWebhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+//IsValid checks if the url is an absolute https url that does not point to the server's own network and the secret not too long
+func (w *InvitationWebhook) IsValid() bool {
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return false
+	}
+	if tools.IsInternalHost(u.Hostname()) {
+		return false
+	}
+	return len(w.URL) <= 500 && len(w.Secret) <= 200
+}
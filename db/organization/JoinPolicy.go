@@ -0,0 +1,46 @@
+package organization
+
+import "strings"
+
+const (
+	//JoinRequestsOpen lets every user request to join the organization, it is the default
+	JoinRequestsOpen = "open"
+	//JoinRequestsDomain only lets users with a validated email address in one of the verified dns names of the organization request to join
+	JoinRequestsDomain = "domain"
+	//JoinRequestsClosed refuses all requests to join, users can only be invited
+	JoinRequestsClosed = "closed"
+)
+
+//IsValidJoinPolicy checks if a join policy is one of the known ones, empty means JoinRequestsOpen
+func IsValidJoinPolicy(policy string) bool {
+	return policy == "" || policy == JoinRequestsOpen || policy == JoinRequestsDomain || policy == JoinRequestsClosed
+}
+
+//AcceptsJoinRequest checks if the join policy of the organization allows a user with these validated email addresses to request to join
+func (c *Organization) AcceptsJoinRequest(emailaddresses []string) bool {
+	switch c.JoinPolicy {
+	case JoinRequestsClosed:
+		return false
+	case JoinRequestsDomain:
+		for _, emailaddress := range emailaddresses {
+			for _, dns := range c.VerifiedDomains() {
+				if MatchesDomain(emailaddress, dns) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+//MatchesDomain checks if an email address is in a domain or one of its subdomains
+func MatchesDomain(emailaddress string, domain string) bool {
+	i := strings.LastIndex(emailaddress, "@")
+	if i < 0 || domain == "" {
+		return false
+	}
+	host := strings.ToLower(emailaddress[i+1:])
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
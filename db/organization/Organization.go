@@ -1,21 +1,57 @@
 package organization
 
-import "strings"
+import (
+	"strings"
+	"time"
+
+	"github.com/itsyouonline/identityserver/db"
+)
 
 type Organization struct {
 	DNS        		 	[]string `json:"dns"`
 	Globalid   		 	string   `json:"globalid"`
 	Members    		 	[]string `json:"members"`
 	Owners     		 	[]string `json:"owners"`
+	OrgMembers		 	[]string `json:"orgmembers"`
 	PublicKeys 		 	[]string `json:"publicKeys"`
 	SecondsValidity	int			 `json:"secondsvalidity"`
+	Roles						[]Role			 `json:"roles,omitempty"`
+	RoleAssignments	[]RoleAssignment `json:"roleassignments,omitempty"`
+	Inheritance			string			 `json:"inheritance,omitempty"`
+	InvitationWebhook	*InvitationWebhook `json:"-"`
+	JoinPolicy			string			 `json:"joinpolicy,omitempty"`
+	DomainVerifications	[]DomainVerification `json:"domainverifications,omitempty"`
+	DomainMembership	string			 `json:"domainmembership,omitempty"`
+	Deleted				*db.DateTime	 `json:"deleted,omitempty" bson:"deleted,omitempty"`
+	Purge				*db.DateTime	 `json:"purge,omitempty" bson:"purge,omitempty"`
 }
 
 // IsValid performs basic validation on the content of an organizations fields
-//TODO: globalid should not contain ':,.'
+// A globalid can not contain a colon, it separates the globalid from the role in user:memberof scopes
+//TODO: globalid should not contain ',.'
 func (c *Organization) IsValid() (valid bool) {
 	valid = true
 	globalIDLength := len(c.Globalid)
 	valid = valid && (globalIDLength >= 3) && (globalIDLength <= 150) && c.Globalid == strings.ToLower(c.Globalid)
+	valid = valid && !strings.Contains(c.Globalid, ":")
+	valid = valid && IsValidInheritance(c.Inheritance)
+	valid = valid && IsValidJoinPolicy(c.JoinPolicy)
+	valid = valid && IsValidDomainMembership(c.DomainMembership)
 	return
 }
+
+//IsDeleted checks if the organization is deleted and waiting to be purged
+func (c *Organization) IsDeleted() bool {
+	return c.Deleted != nil
+}
+
+//CanBeRestoredAt checks if the organization is deleted and its data is not due to be purged yet at a specific time
+func (c *Organization) CanBeRestoredAt(t time.Time) bool {
+	return c.IsDeleted() && c.Purge != nil && t.Before(time.Time(*c.Purge))
+}
+
+//DeletedWith checks if the organization was deleted together with another one, an organization that was
+// deleted on its own before stays deleted when the other one is restored
+func (c *Organization) DeletedWith(other *Organization) bool {
+	return c.IsDeleted() && other.IsDeleted() && time.Time(*c.Deleted).Equal(time.Time(*other.Deleted))
+}
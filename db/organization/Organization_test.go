@@ -1,9 +1,12 @@
 package organization
 
 import (
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/itsyouonline/identityserver/db"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,8 +22,274 @@ func TestOrganizationValidation(t *testing.T) {
 		testcase{org: &Organization{Globalid: "abc"}, valid: true},
 		testcase{org: &Organization{Globalid: strings.Repeat("1", 150)}, valid: true},
 		testcase{org: &Organization{Globalid: strings.Repeat("1", 151)}, valid: false},
+		testcase{org: &Organization{Globalid: "abc:billing"}, valid: false},
+		testcase{org: &Organization{Globalid: "abc", Inheritance: InheritAll}, valid: true},
+		testcase{org: &Organization{Globalid: "abc", Inheritance: "parents"}, valid: false},
+		testcase{org: &Organization{Globalid: "abc", JoinPolicy: JoinRequestsDomain}, valid: true},
+		testcase{org: &Organization{Globalid: "abc", JoinPolicy: "invite"}, valid: false},
 	}
 	for _, test := range testcases {
 		assert.Equal(t, test.valid, test.org.IsValid(), test.org.Globalid)
 	}
 }
+
+func TestBrandingValidation(t *testing.T) {
+	type testcase struct {
+		branding *Branding
+		valid    bool
+	}
+	testcases := []testcase{
+		testcase{branding: &Branding{}, valid: true},
+		testcase{branding: &Branding{Name: "Acme", LogoURL: "https://acme.example.com/logo.png", Color: "#336699", SenderName: "Acme", Footer: "Acme Corp"}, valid: true},
+		testcase{branding: &Branding{LogoURL: "http://acme.example.com/logo.png"}, valid: false},
+		testcase{branding: &Branding{LogoURL: "javascript:alert(1)"}, valid: false},
+		testcase{branding: &Branding{Color: "red"}, valid: false},
+		testcase{branding: &Branding{Color: "#33669"}, valid: false},
+		testcase{branding: &Branding{SenderName: "Acme\r\nBcc: someone@example.com"}, valid: false},
+		testcase{branding: &Branding{Name: "\"Acme\""}, valid: false},
+		testcase{branding: &Branding{Name: strings.Repeat("a", 61)}, valid: false},
+		testcase{branding: &Branding{Footer: strings.Repeat("a", 501)}, valid: false},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.valid, test.branding.IsValid(), "%+v", test.branding)
+	}
+}
+
+func TestRoleValidation(t *testing.T) {
+	type testcase struct {
+		role  *Role
+		valid bool
+	}
+	testcases := []testcase{
+		testcase{role: &Role{Name: "billing", Permissions: []string{}}, valid: true},
+		testcase{role: &Role{Name: "dev-ops_2", Permissions: []string{PermissionManageAPIKeys, PermissionManageRegistry}}, valid: true},
+		testcase{role: &Role{Name: "b"}, valid: false},
+		testcase{role: &Role{Name: "Billing"}, valid: false},
+		testcase{role: &Role{Name: "bill:ing"}, valid: false},
+		testcase{role: &Role{Name: RoleOwner}, valid: false},
+		testcase{role: &Role{Name: RoleMember}, valid: false},
+		testcase{role: &Role{Name: "auditor", Permissions: []string{"organization:owner"}}, valid: false},
+		testcase{role: &Role{Name: "auditor", Permissions: []string{PermissionReadContracts, PermissionReadContracts}}, valid: false},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.valid, test.role.IsValid(), "%+v", test.role)
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	org := &Organization{
+		Globalid: "acme",
+		Owners:   []string{"alice"},
+		Members:  []string{"bob", "carol"},
+		Roles: []Role{
+			Role{Name: "billing", Permissions: []string{PermissionReadContracts}},
+			Role{Name: "developer", Permissions: []string{PermissionManageAPIKeys, PermissionReadContracts}},
+		},
+		RoleAssignments: []RoleAssignment{
+			RoleAssignment{Username: "bob", Role: "billing"},
+			RoleAssignment{Username: "bob", Role: "developer"},
+			RoleAssignment{Username: "dave", Role: "developer"},
+			RoleAssignment{Username: "carol", Role: "removed"},
+		},
+	}
+	assert.Equal(t, Permissions, org.GetPermissions("alice"))
+	assert.Equal(t, []string{PermissionReadContracts, PermissionManageAPIKeys}, org.GetPermissions("bob"))
+	assert.Empty(t, org.GetPermissions("carol"))
+	assert.Empty(t, org.GetPermissions("dave"), "users that left the organization keep no permissions")
+
+	assert.True(t, org.HasRole("alice", RoleOwner))
+	assert.False(t, org.HasRole("alice", RoleMember))
+	assert.True(t, org.HasRole("bob", RoleMember))
+	assert.True(t, org.HasRole("bob", "developer"))
+	assert.False(t, org.HasRole("carol", "billing"))
+	assert.False(t, org.HasRole("carol", "removed"))
+	assert.False(t, org.HasRole("dave", "developer"))
+}
+
+func TestSplitMembership(t *testing.T) {
+	globalid, role := SplitMembership("acme.engineering")
+	assert.Equal(t, "acme.engineering", globalid)
+	assert.Equal(t, "", role)
+	globalid, role = SplitMembership("acme.engineering:developer")
+	assert.Equal(t, "acme.engineering", globalid)
+	assert.Equal(t, "developer", role)
+}
+
+func TestLineage(t *testing.T) {
+	assert.Equal(t, []string{"acme.engineering.backend", "acme.engineering", "acme"}, Lineage("acme.engineering.backend"))
+	assert.Equal(t, []string{"acme"}, Lineage("acme"))
+}
+
+func TestEffectiveMembership(t *testing.T) {
+	acme := Organization{Globalid: "acme", Owners: []string{"alice"}, Members: []string{"bob"}}
+	engineering := Organization{Globalid: "acme.engineering", Owners: []string{"carol"}, Members: []string{"dave"}, Inheritance: InheritAll}
+	backend := Organization{Globalid: "acme.engineering.backend", Members: []string{"erin"}}
+	type testcase struct {
+		lineage  []Organization
+		username string
+		owner    bool
+		member   bool
+	}
+	testcases := []testcase{
+		testcase{lineage: []Organization{engineering, acme}, username: "alice", owner: true, member: false},
+		testcase{lineage: []Organization{engineering, acme}, username: "bob", owner: false, member: true},
+		testcase{lineage: []Organization{backend, engineering, acme}, username: "alice", owner: true, member: false},
+		testcase{lineage: []Organization{backend, engineering, acme}, username: "carol", owner: true, member: false},
+		testcase{lineage: []Organization{backend, engineering, acme}, username: "dave", owner: false, member: false},
+		testcase{lineage: []Organization{backend, engineering, acme}, username: "bob", owner: false, member: false},
+		testcase{lineage: []Organization{backend, engineering, acme}, username: "erin", owner: false, member: true},
+	}
+	for _, test := range testcases {
+		owner, member := EffectiveMembership(test.lineage, test.username)
+		assert.Equal(t, test.owner, owner, "%s in %s", test.username, test.lineage[0].Globalid)
+		assert.Equal(t, test.member, member, "%s in %s", test.username, test.lineage[0].Globalid)
+	}
+
+	backend.Inheritance = InheritNone
+	owner, _ := EffectiveMembership([]Organization{backend, engineering, acme}, "alice")
+	assert.False(t, owner)
+
+	owners, members := EffectiveMembers([]Organization{engineering, acme})
+	assert.Equal(t, []string{"carol", "alice"}, owners)
+	assert.Equal(t, []string{"dave", "bob"}, members)
+}
+
+func TestEffectiveOrgMembers(t *testing.T) {
+	acme := Organization{Globalid: "acme", OrgMembers: []string{"partnerco"}}
+	helpdesk := Organization{Globalid: "acme.helpdesk", OrgMembers: []string{"partnerco.support", "partnerco"}, Inheritance: InheritAll}
+	backend := Organization{Globalid: "acme.backend", OrgMembers: []string{"partnerco.dev"}}
+
+	assert.Equal(t, []string{"partnerco.support", "partnerco"}, EffectiveOrgMembers([]Organization{helpdesk, acme}))
+	assert.Equal(t, []string{"partnerco.dev"}, EffectiveOrgMembers([]Organization{backend, acme}))
+	assert.Empty(t, EffectiveOrgMembers(nil))
+}
+
+func TestAcceptsJoinRequest(t *testing.T) {
+	emails := []string{"bob@gmail.com", "bob@Mail.Example.com"}
+	assert.True(t, (&Organization{}).AcceptsJoinRequest(nil))
+	assert.True(t, (&Organization{JoinPolicy: JoinRequestsOpen}).AcceptsJoinRequest(nil))
+	assert.False(t, (&Organization{JoinPolicy: JoinRequestsClosed}).AcceptsJoinRequest(emails))
+	verified := []DomainVerification{{Name: "example.com", Verified: true}, {Name: "example.org"}}
+	assert.True(t, (&Organization{JoinPolicy: JoinRequestsDomain, DNS: []string{"example.com"}, DomainVerifications: verified}).AcceptsJoinRequest(emails))
+	assert.False(t, (&Organization{JoinPolicy: JoinRequestsDomain, DNS: []string{"example.com"}}).AcceptsJoinRequest(emails), "the domain is not verified")
+	assert.False(t, (&Organization{JoinPolicy: JoinRequestsDomain, DNS: []string{"example.org"}, DomainVerifications: verified}).AcceptsJoinRequest(emails))
+	assert.False(t, (&Organization{JoinPolicy: JoinRequestsDomain}).AcceptsJoinRequest(emails))
+
+	assert.True(t, MatchesDomain("alice@example.com", "example.com."))
+	assert.False(t, MatchesDomain("alice@notexample.com", "example.com"))
+	assert.False(t, MatchesDomain("example.com", "example.com"))
+}
+
+func TestVerifiedDomains(t *testing.T) {
+	org := &Organization{
+		DNS: []string{"Acme.com", "acme.org", "acme.net"},
+		DomainVerifications: []DomainVerification{
+			{Name: "acme.com", Verified: true},
+			{Name: "acme.org"},
+			{Name: "removed.com", Verified: true},
+		},
+	}
+	assert.Equal(t, []string{"Acme.com"}, org.VerifiedDomains())
+	assert.NotNil(t, org.GetDomainVerification("ACME.COM"))
+	assert.Nil(t, org.GetDomainVerification("acme.net"))
+
+	assert.Equal(t, []string{"mail.acme.co.uk", "acme.co.uk", "co.uk"}, ParentDomains("bob@Mail.Acme.co.uk"))
+	assert.Empty(t, ParentDomains("bob"))
+
+	verification, err := NewDomainVerification("Acme.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "acme.com", verification.Name)
+	assert.Len(t, verification.Token, 32)
+	assert.False(t, verification.Verified)
+}
+
+func TestRenames(t *testing.T) {
+	renames := Renames("acme.dev", "globex.engineering", []string{"acme.dev.backend", "acme.dev.backend.db", "acme.development"})
+	assert.Equal(t, []Rename{
+		{Old: "acme.dev", New: "globex.engineering"},
+		{Old: "acme.dev.backend", New: "globex.engineering.backend"},
+		{Old: "acme.dev.backend.db", New: "globex.engineering.backend.db"},
+	}, renames)
+	assert.Equal(t, "acme", Parent("acme.dev"))
+	assert.Equal(t, "", Parent("acme"))
+	assert.True(t, IsDescendant("acme.dev.backend", "acme"))
+	assert.False(t, IsDescendant("acme", "acme"))
+	assert.False(t, IsDescendant("acmes.dev", "acme"))
+}
+
+func TestRenameReference(t *testing.T) {
+	renamed, changed := RenameReference("acme.dev", "acme.dev", "globex")
+	assert.True(t, changed)
+	assert.Equal(t, "globex", renamed)
+	renamed, changed = RenameReference("acme.dev:admin", "acme.dev", "globex")
+	assert.True(t, changed)
+	assert.Equal(t, "globex:admin", renamed)
+	renamed, changed = RenameReference("acme.dev.backend", "acme.dev", "globex")
+	assert.False(t, changed)
+	assert.Equal(t, "acme.dev.backend", renamed)
+}
+
+func TestCanBeRestoredAt(t *testing.T) {
+	now := time.Now()
+	deleted := db.DateTime(now.Add(-time.Hour))
+	purge := db.DateTime(now.Add(time.Hour))
+	org := &Organization{Globalid: "acme"}
+	assert.False(t, org.IsDeleted())
+	assert.False(t, org.CanBeRestoredAt(now))
+	org.Deleted, org.Purge = &deleted, &purge
+	assert.True(t, org.IsDeleted())
+	assert.True(t, org.CanBeRestoredAt(now))
+	assert.False(t, org.CanBeRestoredAt(now.Add(2*time.Hour)))
+}
+
+func TestDeletedWith(t *testing.T) {
+	now := time.Now()
+	childDeleted := db.DateTime(now.Add(-2 * time.Hour))
+	parentDeleted := db.DateTime(now.Add(-time.Hour))
+	purge := db.DateTime(now.Add(time.Hour))
+	parent := &Organization{Globalid: "acme"}
+	child := &Organization{Globalid: "acme.sales"}
+	assert.False(t, child.DeletedWith(parent))
+
+	//The whole tree deleted at once is restored together
+	parent.Deleted, parent.Purge = &parentDeleted, &purge
+	child.Deleted, child.Purge = &parentDeleted, &purge
+	assert.True(t, child.DeletedWith(parent))
+
+	//A suborganization deleted before its parent keeps its own deletion time and stays deleted when the parent is restored
+	child.Deleted = &childDeleted
+	assert.False(t, child.DeletedWith(parent))
+	assert.True(t, child.CanBeRestoredAt(now))
+}
+
+func TestInvitationWebhookValidation(t *testing.T) {
+	valid := []string{"https://example.com/hook", "https://93.184.216.34/hook", "https://example.com:8443/hook"}
+	for _, u := range valid {
+		assert.True(t, (&InvitationWebhook{URL: u}).IsValid(), u)
+	}
+	invalid := []string{
+		"http://example.com/hook",
+		"https:///hook",
+		"https://localhost/hook",
+		"https://api.localhost/hook",
+		"https://127.0.0.1/hook",
+		"https://10.0.0.1/hook",
+		"https://192.168.1.1:8443/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hook",
+		"https://[fe80::1]/hook",
+		"https://0.0.0.0/hook",
+	}
+	for _, u := range invalid {
+		assert.False(t, (&InvitationWebhook{URL: u}).IsValid(), u)
+	}
+	assert.False(t, (&InvitationWebhook{URL: "https://example.com/hook", Secret: strings.Repeat("s", 201)}).IsValid())
+}
+
+func TestSubOrganizationsRegEx(t *testing.T) {
+	pattern := regexp.MustCompile(subOrganizationsRegEx("acm.x").Pattern)
+	assert.True(t, pattern.MatchString("acm.x.dev"))
+	assert.False(t, pattern.MatchString("acm.x"))
+	assert.False(t, pattern.MatchString("acmex.dev"))
+	assert.False(t, pattern.MatchString("acm.xy"))
+}
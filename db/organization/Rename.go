@@ -0,0 +1,48 @@
+package organization
+
+import "strings"
+
+//Rename is the change of the globalid of a single organization when an organization is renamed or moved
+type Rename struct {
+	Old string
+	New string
+}
+
+//Renames returns the renames of an organization and its suborganizations when the organization gets newGlobalID.
+// The suborganizations keep their place below it, the organization itself is the first rename.
+func Renames(globalID string, newGlobalID string, subOrganizationIDs []string) (renames []Rename) {
+	renames = []Rename{{Old: globalID, New: newGlobalID}}
+	for _, id := range subOrganizationIDs {
+		if IsDescendant(id, globalID) {
+			renames = append(renames, Rename{Old: id, New: newGlobalID + strings.TrimPrefix(id, globalID)})
+		}
+	}
+	return
+}
+
+//IsDescendant checks if an organization is a suborganization, at any depth, of another one
+func IsDescendant(globalID string, ancestor string) bool {
+	return strings.HasPrefix(globalID, ancestor+".")
+}
+
+//Parent returns the globalid of the parent organization, an empty string for a root organization
+func Parent(globalID string) string {
+	if i := strings.LastIndex(globalID, "."); i >= 0 {
+		return globalID[:i]
+	}
+	return ""
+}
+
+//RenameReference renames a reference to an organization as stored in authorizations and user:memberof scopes,
+// `globalid` or `globalid:role`. References to other organizations are returned unchanged.
+func RenameReference(reference string, oldGlobalID string, newGlobalID string) (renamed string, changed bool) {
+	globalid, role := SplitMembership(reference)
+	if globalid != oldGlobalID {
+		return reference, false
+	}
+	renamed = newGlobalID
+	if strings.Contains(reference, ":") {
+		renamed += ":" + role
+	}
+	return renamed, true
+}
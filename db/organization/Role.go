@@ -0,0 +1,145 @@
+package organization
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	//PermissionManageAPIKeys allows to create, update and remove the api keys of an organization
+	PermissionManageAPIKeys = "apikeys:manage"
+	//PermissionManageRegistry allows to list, add and remove the registry entries of an organization
+	PermissionManageRegistry = "registry:manage"
+	//PermissionInviteMembers allows to invite members and to list and cancel the pending invitations
+	PermissionInviteMembers = "members:invite"
+	//PermissionReadContracts allows to read the contracts of an organization
+	PermissionReadContracts = "contracts:read"
+)
+
+const (
+	//RoleOwner is the built-in role of the users in the owners list of an organization
+	RoleOwner = "owner"
+	//RoleMember is the built-in role of the users in the members list of an organization
+	RoleMember = "member"
+)
+
+//Permissions are all the permissions a role can grant, owners have all of them
+var Permissions = []string{PermissionManageAPIKeys, PermissionManageRegistry, PermissionInviteMembers, PermissionReadContracts}
+
+var roleNameRegex = regexp.MustCompile(`^[a-z0-9_-]{2,30}$`)
+
+//Role is a named set of permissions the owners of an organization can assign to its members and owners
+type Role struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+//RoleAssignment grants a role of an organization to one of its members or owners
+type RoleAssignment struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+//IsValid checks the name of the role and that it only grants known permissions.
+// The names of the built-in owner and member roles can not be used.
+func (role *Role) IsValid() bool {
+	if !roleNameRegex.MatchString(role.Name) || role.Name == RoleOwner || role.Name == RoleMember {
+		return false
+	}
+	for i, permission := range role.Permissions {
+		if !IsValidPermission(permission) {
+			return false
+		}
+		for _, other := range role.Permissions[:i] {
+			if other == permission {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//IsValidPermission checks if a permission is one a role can grant
+func IsValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+//GetRole returns the role with a specific name or nil if the organization does not define it
+func (c *Organization) GetRole(name string) *Role {
+	for i := range c.Roles {
+		if c.Roles[i].Name == name {
+			return &c.Roles[i]
+		}
+	}
+	return nil
+}
+
+//HasRole checks if a user has a role in the organization, the built-in owner and member roles included
+func (c *Organization) HasRole(username, role string) bool {
+	switch role {
+	case RoleOwner:
+		return contains(c.Owners, username)
+	case RoleMember:
+		return contains(c.Members, username)
+	}
+	if c.GetRole(role) == nil || !contains(c.Members, username) && !contains(c.Owners, username) {
+		return false
+	}
+	for _, assignment := range c.RoleAssignments {
+		if assignment.Username == username && assignment.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+//GetPermissions returns the permissions a user has in the organization.
+// Owners have all permissions, members only the ones granted by their roles.
+func (c *Organization) GetPermissions(username string) (permissions []string) {
+	permissions = []string{}
+	if contains(c.Owners, username) {
+		return append(permissions, Permissions...)
+	}
+	if !contains(c.Members, username) {
+		return
+	}
+	for _, assignment := range c.RoleAssignments {
+		if assignment.Username != username {
+			continue
+		}
+		role := c.GetRole(assignment.Role)
+		if role == nil {
+			continue
+		}
+		for _, permission := range role.Permissions {
+			if !contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+//SplitMembership splits the value of a user:memberof scope, `globalid` or `globalid:role`, in the globalid and the role.
+// Globalids can not contain a colon so the role is everything after the first one.
+func SplitMembership(value string) (globalid string, role string) {
+	globalid = value
+	if i := strings.Index(value, ":"); i >= 0 {
+		globalid, role = value[:i], value[i+1:]
+	}
+	return
+}
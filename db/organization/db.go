@@ -3,6 +3,8 @@ package organization
 import (
 	"errors"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"time"
 
@@ -16,6 +18,7 @@ const (
 	mongoCollectionName = "organizations"
 	logoCollectionName = "organizationLogos"
 	last2FACollectionName = "last2falogin"
+	brandingCollectionName = "organizationbranding"
 )
 
 //InitModels initialize models in mongo, if required.
@@ -50,6 +53,12 @@ func InitModels() {
 		Background:  true,
 	}
 	db.EnsureIndex(last2FACollectionName, automatic2FAExpiration)
+
+	index = mgo.Index{
+		Key:    []string{"globalid"},
+		Unique: true,
+	}
+	db.EnsureIndex(brandingCollectionName, index)
 }
 
 //Manager is used to store organizations
@@ -70,6 +79,12 @@ type Last2FAManager struct {
 	collection *mgo.Collection
 }
 
+//BrandingManager is used to save how the messages sent for an organization look
+type BrandingManager struct {
+	session    *mgo.Session
+	collection *mgo.Collection
+}
+
 func getCollection(session *mgo.Session) *mgo.Collection {
 	return db.GetCollection(session, mongoCollectionName)
 }
@@ -111,6 +126,15 @@ func NewLast2FAManager(r *http.Request) *Last2FAManager {
 	}
 }
 
+//NewBrandingManager creates and initializes a new BrandingManager
+func NewBrandingManager(r *http.Request) *BrandingManager {
+	session := db.GetDBSession(r)
+	return &BrandingManager{
+		session:    session,
+		collection: db.GetCollection(session, brandingCollectionName),
+	}
+}
+
 // GetOrganizations gets a list of organizations.
 func (m *Manager) GetOrganizations(organizationIDs []string) ([]Organization, error) {
 	var organizations []Organization
@@ -121,11 +145,10 @@ func (m *Manager) GetOrganizations(organizationIDs []string) ([]Organization, er
 }
 
 // GetSubOrganizations returns all organizations which have {globalID} as parent (including the organization with {globalID} as globalid)
-//TODO: validate globalID since it is appended in the query
 //TODO: put an index on the globalid field
 func (m *Manager) GetSubOrganizations(globalID string) ([]Organization, error) {
 	var organizations = make([]Organization, 0, 0)
-	var qry = bson.M{"globalid": bson.M{"$regex": bson.RegEx{"^" + globalID + `\.`, ""}}}
+	var qry = bson.M{"globalid": subOrganizationsRegEx(globalID)}
 	if err := m.collection.Find(qry).All(&organizations); err != nil {
 		return nil, err
 	}
@@ -133,20 +156,160 @@ func (m *Manager) GetSubOrganizations(globalID string) ([]Organization, error) {
 	return organizations, nil
 }
 
-//IsOwner checks if a specific user is in the owners list of an organization
+//subOrganizationsRegEx matches the globalids below an organization, the globalid is quoted so its dots only match dots
+func subOrganizationsRegEx(globalID string) bson.RegEx {
+	return bson.RegEx{Pattern: "^" + regexp.QuoteMeta(globalID+"."), Options: ""}
+}
+
+//IsOwner checks if a specific user is in the owners list of an organization or inherits ownership from a parent organization
 func (m *Manager) IsOwner(globalID, username string) (isowner bool, err error) {
-	matches, err := m.collection.Find(bson.M{"globalid": globalID, "owners": username}).Count()
-	isowner = (matches > 0)
+	lineage, err := m.GetLineage(globalID)
+	if err != nil {
+		return
+	}
+	isowner, _ = EffectiveMembership(lineage, username)
 	return
 }
 
-//IsMember checks if a specific user is in the members list of an organization
+//IsMember checks if a specific user is in the members list of an organization or inherits membership from a parent organization.
+// Members and owners of the organizations that are members of it, directly or through other member organizations, are members as well.
 func (m *Manager) IsMember(globalID, username string) (ismember bool, err error) {
-	matches, err := m.collection.Find(bson.M{"globalid": globalID, "members": username}).Count()
-	ismember = (matches > 0)
+	err = m.walkOrgMembers(globalID, func(current string, lineage []Organization) bool {
+		isowner, isdirectmember := EffectiveMembership(lineage, username)
+		ismember = isdirectmember || current != globalID && isowner
+		return ismember
+	})
+	return
+}
+
+//HasOrgMember checks if an organization is a member of another one, directly or through other member organizations
+func (m *Manager) HasOrgMember(globalID, orgMember string) (hasorgmember bool, err error) {
+	err = m.walkOrgMembers(globalID, func(current string, lineage []Organization) bool {
+		hasorgmember = current != globalID && current == orgMember
+		return hasorgmember
+	})
 	return
 }
 
+//walkOrgMembers calls visit with the lineage of an organization and then, breadth first, of the organizations that are members of it.
+// Every organization is visited once so a cycle of organizations that are members of each other ends the walk.
+// The walk stops as soon as visit returns true.
+func (m *Manager) walkOrgMembers(globalID string, visit func(current string, lineage []Organization) bool) error {
+	visited := make(map[string]bool)
+	queue := []string{globalID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		lineage, err := m.GetLineage(current)
+		if err != nil {
+			return err
+		}
+		if visit(current, lineage) {
+			return nil
+		}
+		queue = append(queue, EffectiveOrgMembers(lineage)...)
+	}
+	return nil
+}
+
+//GetLineage gets an organization and the parent organizations it inherits from in a single query,
+// ordered from the organization itself up to the root organization. Only the members, owners, orgmembers and the inheritance are loaded.
+// The lineage stops at the first missing or deleted organization, it is empty if the organization itself does not exist or is deleted.
+func (m *Manager) GetLineage(globalID string) (lineage []Organization, err error) {
+	globalIDs := Lineage(globalID)
+	var organizations []Organization
+	qry := bson.M{"globalid": bson.M{"$in": globalIDs}, "deleted": bson.M{"$exists": false}}
+	fields := bson.M{"globalid": 1, "owners": 1, "members": 1, "orgmembers": 1, "inheritance": 1}
+	if err = m.collection.Find(qry).Select(fields).All(&organizations); err != nil {
+		return
+	}
+	lineage = make([]Organization, 0, len(organizations))
+	for _, id := range globalIDs {
+		found := false
+		for _, org := range organizations {
+			if org.Globalid == id {
+				lineage = append(lineage, org)
+				found = true
+				break
+			}
+		}
+		if !found || lineage[len(lineage)-1].Inheritance == InheritNone {
+			break
+		}
+	}
+	return
+}
+
+//GetEffectiveMembers gets the owners and members of an organization, including the ones inherited from its parents
+// and the owners and members of the organizations that are members of it
+func (m *Manager) GetEffectiveMembers(globalID string) (owners []string, members []string, err error) {
+	err = m.walkOrgMembers(globalID, func(current string, lineage []Organization) bool {
+		orgOwners, orgMembers := EffectiveMembers(lineage)
+		if current == globalID {
+			owners, members = orgOwners, orgMembers
+		} else {
+			members = append(append(members, orgOwners...), orgMembers...)
+		}
+		return false
+	})
+	members = withoutDuplicates(members, owners)
+	return
+}
+
+//AddOrgMember makes an organization a member of another one
+func (m *Manager) AddOrgMember(globalID string, orgMember string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$addToSet": bson.M{"orgmembers": orgMember}})
+}
+
+//RemoveOrgMember removes an organization from the members of another one
+func (m *Manager) RemoveOrgMember(globalID string, orgMember string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$pull": bson.M{"orgmembers": orgMember}})
+}
+
+//RemoveOrgMemberFromAll removes an organization from the members of all other organizations
+func (m *Manager) RemoveOrgMemberFromAll(orgMember string) (err error) {
+	_, err = m.collection.UpdateAll(
+		bson.M{"orgmembers": orgMember},
+		bson.M{"$pull": bson.M{"orgmembers": orgMember}})
+	return
+}
+
+//SetInheritance sets what an organization inherits from its parent organizations
+func (m *Manager) SetInheritance(globalID string, inheritance string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$set": bson.M{"inheritance": inheritance}})
+}
+
+//SetInvitationWebhook sets the url the organization is notified on when an invitation is accepted or rejected
+func (m *Manager) SetInvitationWebhook(globalID string, webhook *InvitationWebhook) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$set": bson.M{"invitationwebhook": webhook}})
+}
+
+//RemoveInvitationWebhook removes the invitation webhook of an organization, its owners are notified by email again
+func (m *Manager) RemoveInvitationWebhook(globalID string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$unset": bson.M{"invitationwebhook": ""}})
+}
+
+//SetJoinPolicy sets who can request to join an organization
+func (m *Manager) SetJoinPolicy(globalID string, policy string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$set": bson.M{"joinpolicy": policy}})
+}
+
 // AllByUser get organizations for certain user.
 func (m *Manager) AllByUser(username string) ([]Organization, error) {
 	var organizations []Organization
@@ -254,9 +417,13 @@ func (m *Manager) SaveMember(organization *Organization, username string) error
 
 // RemoveMember remove member
 func (m *Manager) RemoveMember(organization *Organization, username string) error {
-	return m.collection.Update(
+	err := m.collection.Update(
 		bson.M{"globalid": organization.Globalid},
 		bson.M{"$pull": bson.M{"members": username}})
+	if err != nil {
+		return err
+	}
+	return m.removeRoleAssignments(organization.Globalid, username)
 }
 
 // SaveOwner save or update owners
@@ -268,9 +435,13 @@ func (m *Manager) SaveOwner(organization *Organization, owner string) error {
 
 // RemoveOwner remove owner
 func (m *Manager) RemoveOwner(organization *Organization, owner string) error {
-	return m.collection.Update(
+	err := m.collection.Update(
 		bson.M{"globalid": organization.Globalid},
 		bson.M{"$pull": bson.M{"owners": owner}})
+	if err != nil {
+		return err
+	}
+	return m.removeRoleAssignments(organization.Globalid, owner)
 }
 
 func (m *Manager) AddDNS(organization *Organization, dnsName string) error {
@@ -282,7 +453,7 @@ func (m *Manager) AddDNS(organization *Organization, dnsName string) error {
 func (m *Manager) UpdateDNS(organization *Organization, oldDNSName string, newDNSName string) error {
 	err := m.collection.Update(
 		bson.M{"globalid": organization.Globalid},
-		bson.M{"$pull": bson.M{"dns": oldDNSName}})
+		bson.M{"$pull": bson.M{"dns": oldDNSName, "domainverifications": bson.M{"name": strings.ToLower(oldDNSName)}}})
 	if err != nil {
 		return err
 	}
@@ -295,7 +466,93 @@ func (m *Manager) UpdateDNS(organization *Organization, oldDNSName string, newDN
 func (m *Manager) RemoveDNS(organization *Organization, dns string) error {
 	return m.collection.Update(
 		bson.M{"globalid": organization.Globalid},
-		bson.M{"$pull": bson.M{"dns": dns}})
+		bson.M{"$pull": bson.M{"dns": dns, "domainverifications": bson.M{"name": strings.ToLower(dns)}}})
+}
+
+//AddDomainVerification starts the verification of a dns name of an organization, db.ErrDuplicate is returned if it already has one
+func (m *Manager) AddDomainVerification(globalID string, verification *DomainVerification) error {
+	err := m.collection.Update(
+		bson.M{"globalid": globalID, "domainverifications.name": bson.M{"$ne": verification.Name}},
+		bson.M{"$push": bson.M{"domainverifications": verification}})
+	if err == mgo.ErrNotFound && m.Exists(globalID) {
+		return db.ErrDuplicate
+	}
+	return err
+}
+
+//SaveDomainVerification saves the result of checking a dns name of an organization
+func (m *Manager) SaveDomainVerification(globalID string, verification *DomainVerification) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID, "domainverifications.name": verification.Name},
+		bson.M{"$set": bson.M{"domainverifications.$": verification}})
+}
+
+//GetWithDomainVerifications gets the organizations that have dns names to verify
+func (m *Manager) GetWithDomainVerifications() (organizations []Organization, err error) {
+	err = m.collection.Find(bson.M{"domainverifications.0": bson.M{"$exists": true}}).Select(bson.M{"globalid": 1, "dns": 1, "domainverifications": 1}).All(&organizations)
+	return
+}
+
+//GetByVerifiedDomains gets the organizations with a domain membership rule that verified one of the domains
+func (m *Manager) GetByVerifiedDomains(domains []string) (organizations []Organization, err error) {
+	err = m.collection.Find(bson.M{
+		"domainverifications": bson.M{"$elemMatch": bson.M{"name": bson.M{"$in": domains}, "verified": true}},
+		"domainmembership":    bson.M{"$in": []string{DomainMembershipInvite, DomainMembershipMember}},
+	}).All(&organizations)
+	return
+}
+
+//SetDomainMembership sets what happens to users that validate an email address at a verified domain of the organization
+func (m *Manager) SetDomainMembership(globalID string, rule string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$set": bson.M{"domainmembership": rule}})
+}
+
+//Rename changes the globalid of an organization and of its memberships of other organizations.
+// db.ErrDuplicate is returned if an organization with the new globalid already exists.
+func (m *Manager) Rename(oldGlobalID string, newGlobalID string) error {
+	err := m.collection.Update(
+		bson.M{"globalid": oldGlobalID},
+		bson.M{"$set": bson.M{"globalid": newGlobalID}})
+	if mgo.IsDup(err) {
+		return db.ErrDuplicate
+	}
+	if err != nil {
+		return err
+	}
+	_, err = m.collection.UpdateAll(
+		bson.M{"orgmembers": oldGlobalID},
+		bson.M{"$set": bson.M{"orgmembers.$": newGlobalID}})
+	return err
+}
+
+//MarkDeleted disables organizations until they are purged or restored.
+// Deleted organizations give no ownership or membership but their globalids stay taken.
+// Organizations that are already deleted keep their own deletion time so restoring the others leaves them deleted.
+func (m *Manager) MarkDeleted(globalIDs []string, deleted db.DateTime, purge db.DateTime) (err error) {
+	_, err = m.collection.UpdateAll(
+		bson.M{"globalid": bson.M{"$in": globalIDs}, "deleted": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deleted": deleted, "purge": purge}})
+	return
+}
+
+//Restore enables the organizations again that were deleted at a specific time
+func (m *Manager) Restore(globalIDs []string, deleted db.DateTime) (err error) {
+	_, err = m.collection.UpdateAll(
+		bson.M{"globalid": bson.M{"$in": globalIDs}, "deleted": deleted},
+		bson.M{"$unset": bson.M{"deleted": "", "purge": ""}})
+	return
+}
+
+//GetPurgeable gets the globalids of the deleted organizations that are due to be purged
+func (m *Manager) GetPurgeable(now time.Time) (globalIDs []string, err error) {
+	var organizations []Organization
+	err = m.collection.Find(bson.M{"purge": bson.M{"$lte": now}}).Select(bson.M{"globalid": 1}).All(&organizations)
+	for _, org := range organizations {
+		globalIDs = append(globalIDs, org.Globalid)
+	}
+	return
 }
 
 // Remove removes the organization
@@ -303,17 +560,38 @@ func (m *Manager) Remove(globalid string) error {
 	return m.collection.Remove(bson.M{"globalid": globalid})
 }
 
+//Purge removes a deleted organization and its memberships of other organizations
+func (m *Manager) Purge(globalID string) error {
+	if err := m.RemoveOrgMemberFromAll(globalID); err != nil {
+		return err
+	}
+	_, err := m.collection.RemoveAll(bson.M{"globalid": globalID})
+	return err
+}
+
 // Remove the organization logo
 func (m *LogoManager) Remove(globalid string) error {
 	return m.collection.Remove(bson.M{"globalid": globalid})
 }
 
+//Rename moves the logo of an organization to its new globalid
+func (m *LogoManager) Rename(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.collection.UpdateAll(bson.M{"globalid": oldGlobalID}, bson.M{"$set": bson.M{"globalid": newGlobalID}})
+	return
+}
+
 // Remove the Last2FA entries for this organization
 func (m *Last2FAManager) RemoveByOrganization(globalid string) error {
 	_, err := m.collection.RemoveAll(bson.M{"globalid": globalid})
 	return err
 }
 
+//Rename moves the Last2FA entries of an organization to its new globalid
+func (m *Last2FAManager) Rename(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.collection.UpdateAll(bson.M{"globalid": oldGlobalID}, bson.M{"$set": bson.M{"globalid": newGlobalID}})
+	return
+}
+
 //Remove the Last2FA entries for this user
 func (m *Last2FAManager) RemoveByUser(username string) error {
 	_, err := m.collection.RemoveAll(bson.M{"username": username})
@@ -345,10 +623,104 @@ func (m *Manager) CountByUser(username string) (int, error) {
 // RemoveUser Removes a user from an organization
 func (m *Manager) RemoveUser(globalId string, username string) error {
 	qry := bson.M{"globalid": globalId}
-	update := bson.M{"$pull": bson.M{"owners": username, "members": username}}
+	update := bson.M{"$pull": bson.M{"owners": username, "members": username, "roleassignments": bson.M{"username": username}}}
 	return m.collection.Update(qry, update)
 }
 
+//CreateRole adds a role to an organization, db.ErrDuplicate is returned if the organization already defines a role with the same name
+func (m *Manager) CreateRole(globalID string, role *Role) error {
+	err := m.collection.Update(
+		bson.M{"globalid": globalID, "roles.name": bson.M{"$ne": role.Name}},
+		bson.M{"$push": bson.M{"roles": role}})
+	if err == mgo.ErrNotFound && m.Exists(globalID) {
+		return db.ErrDuplicate
+	}
+	return err
+}
+
+//UpdateRole replaces the permissions of a role, mgo.ErrNotFound is returned if the organization does not define it
+func (m *Manager) UpdateRole(globalID string, role *Role) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID, "roles.name": role.Name},
+		bson.M{"$set": bson.M{"roles.$.permissions": role.Permissions}})
+}
+
+//RemoveRole removes a role from an organization together with its assignments
+func (m *Manager) RemoveRole(globalID string, name string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID, "roles.name": name},
+		bson.M{"$pull": bson.M{"roles": bson.M{"name": name}, "roleassignments": bson.M{"role": name}}})
+}
+
+//AssignRole grants a role to a member or owner of an organization.
+// mgo.ErrNotFound is returned if the role does not exist or the user is not a member or an owner.
+func (m *Manager) AssignRole(globalID string, username string, role string) error {
+	qry := bson.M{
+		"globalid":   globalID,
+		"roles.name": role,
+		"$or":        []bson.M{{"members": username}, {"owners": username}},
+	}
+	return m.collection.Update(qry, bson.M{"$addToSet": bson.M{"roleassignments": RoleAssignment{Username: username, Role: role}}})
+}
+
+//UnassignRole takes a role away from a user
+func (m *Manager) UnassignRole(globalID string, username string, role string) error {
+	return m.collection.Update(
+		bson.M{"globalid": globalID},
+		bson.M{"$pull": bson.M{"roleassignments": bson.M{"username": username, "role": role}}})
+}
+
+//GetPermissions returns the permissions a user has in an organization.
+// Owners of the parent organizations it inherits from have all permissions, the roles only apply to the organization itself.
+func (m *Manager) GetPermissions(globalID string, username string) (permissions []string, err error) {
+	isOwner, err := m.IsOwner(globalID, username)
+	if err != nil {
+		return
+	}
+	if isOwner {
+		permissions = append([]string{}, Permissions...)
+		return
+	}
+	org, err := m.GetByName(globalID)
+	if err != nil {
+		return
+	}
+	permissions = org.GetPermissions(username)
+	return
+}
+
+//HasRole checks if a user has a role in an organization.
+// The built-in owner and member roles are inherited from the parent organizations, the other roles are not.
+func (m *Manager) HasRole(globalID string, username string, role string) (hasrole bool, err error) {
+	switch role {
+	case RoleOwner:
+		return m.IsOwner(globalID, username)
+	case RoleMember:
+		return m.IsMember(globalID, username)
+	}
+	org, err := m.GetByName(globalID)
+	if err == mgo.ErrNotFound {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	hasrole = org.HasRole(username, role)
+	return
+}
+
+//removeRoleAssignments removes the role assignments of a user that is no longer a member or an owner of the organization
+func (m *Manager) removeRoleAssignments(globalID string, username string) error {
+	err := m.collection.Update(
+		bson.M{"globalid": globalID, "members": bson.M{"$ne": username}, "owners": bson.M{"$ne": username}},
+		bson.M{"$pull": bson.M{"roleassignments": bson.M{"username": username}}})
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
 // GetValidity gets the 2FA validity duration in seconds
 func (m *Manager) GetValidity(globalId string) (int, error) {
 		var org *Organization
@@ -428,3 +800,46 @@ func (m *Last2FAManager) RemoveLast2FA(globalId string, username string) error {
 	}
 	return m.collection.Remove(bson.M{"$and": condition})
 }
+
+//GetBranding gets the branding of an organization, mgo.ErrNotFound is returned if it has none
+func (m *BrandingManager) GetBranding(globalID string) (branding *Branding, err error) {
+	branding = &Branding{}
+	err = m.collection.Find(bson.M{"globalid": globalID}).One(branding)
+	if err != nil {
+		branding = nil
+	}
+	return
+}
+
+//GetInheritedBranding gets the branding of an organization or, if it has none, of its closest parent organization.
+// nil is returned if none of them has a branding.
+func (m *BrandingManager) GetInheritedBranding(globalID string) (branding *Branding, err error) {
+	var brandings []Branding
+	if err = m.collection.Find(bson.M{"globalid": bson.M{"$in": Lineage(globalID)}}).All(&brandings); err != nil {
+		return
+	}
+	for i := range brandings {
+		if branding == nil || len(brandings[i].Globalid) > len(branding.Globalid) {
+			branding = &brandings[i]
+		}
+	}
+	return
+}
+
+//SaveBranding creates or replaces the branding of an organization
+func (m *BrandingManager) SaveBranding(branding *Branding) (err error) {
+	_, err = m.collection.Upsert(bson.M{"globalid": branding.Globalid}, branding)
+	return
+}
+
+//RemoveBranding removes the branding of an organization
+func (m *BrandingManager) RemoveBranding(globalID string) (err error) {
+	_, err = m.collection.RemoveAll(bson.M{"globalid": globalID})
+	return
+}
+
+//Rename moves the branding of an organization to its new globalid
+func (m *BrandingManager) Rename(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.collection.UpdateAll(bson.M{"globalid": oldGlobalID}, bson.M{"$set": bson.M{"globalid": newGlobalID}})
+	return
+}
@@ -160,3 +160,15 @@ func (m *Manager) GetRegistryEntry(username string, globalid string, key string)
 	return
 
 }
+
+//RenameOrganization moves the registry of an organization to its new globalid
+func (m *Manager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.getRegistryCollection().UpdateAll(bson.M{"globalid": oldGlobalID}, bson.M{"$set": bson.M{"globalid": newGlobalID}})
+	return
+}
+
+//RemoveOrganization removes the registry of an organization
+func (m *Manager) RemoveOrganization(globalID string) (err error) {
+	_, err = m.getRegistryCollection().RemoveAll(bson.M{"globalid": globalID})
+	return
+}
@@ -0,0 +1,18 @@
+package saml
+
+import (
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+//IdentityProvider is the SAML identity provider the members of an organization login with
+type IdentityProvider struct {
+	ID              bson.ObjectId `json:"-" bson:"_id,omitempty"`
+	Globalid        string        `json:"globalid"`
+	EntityID        string        `json:"entityid"`
+	SingleSignOnURL string        `json:"singlesignonurl"`
+	Certificates    []string      `json:"certificates"`
+	Metadata        string        `json:"metadata"`
+	Updated         db.DateTime   `json:"updated"`
+}
@@ -0,0 +1,20 @@
+package saml
+
+import (
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+//ServiceProvider is an application an organization registered to receive SAML assertions about its users
+type ServiceProvider struct {
+	ID                          bson.ObjectId `json:"-" bson:"_id,omitempty"`
+	Globalid                    string        `json:"globalid"`
+	Label                       string        `json:"label"`
+	EntityID                    string        `json:"entityid"`
+	AssertionConsumerServiceURL string        `json:"assertionconsumerserviceurl"`
+	//Scopes are the user:name, user:email and user:memberof scopes the attributes in the assertions are drawn from
+	Scopes   []string    `json:"scopes"`
+	Metadata string      `json:"metadata,omitempty"`
+	Updated  db.DateTime `json:"updated"`
+}
@@ -0,0 +1,142 @@
+package saml
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	identityProviderCollectionName = "samlidentityproviders"
+	serviceProviderCollectionName  = "samlserviceproviders"
+)
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	//An organization has a single identity provider
+	index := mgo.Index{
+		Key:    []string{"globalid"},
+		Unique: true,
+	}
+	db.EnsureIndex(identityProviderCollectionName, index)
+
+	index = mgo.Index{
+		Key:    []string{"globalid", "label"},
+		Unique: true,
+	}
+	db.EnsureIndex(serviceProviderCollectionName, index)
+	//Authentication requests identify the service provider by its entity id
+	index = mgo.Index{
+		Key:    []string{"globalid", "entityid"},
+		Unique: true,
+	}
+	db.EnsureIndex(serviceProviderCollectionName, index)
+}
+
+//Manager is used to store the SAML configuration of organizations
+type Manager struct {
+	session *mgo.Session
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session: session,
+	}
+}
+
+func (m *Manager) getIdentityProviderCollection() *mgo.Collection {
+	return db.GetCollection(m.session, identityProviderCollectionName)
+}
+
+//SaveIdentityProvider creates or replaces the identity provider of an organization
+func (m *Manager) SaveIdentityProvider(idp *IdentityProvider) (err error) {
+	idp.Updated = db.DateTime(time.Now())
+	_, err = m.getIdentityProviderCollection().Upsert(
+		bson.M{"globalid": idp.Globalid},
+		bson.M{"$set": bson.M{
+			"entityid":        idp.EntityID,
+			"singlesignonurl": idp.SingleSignOnURL,
+			"certificates":    idp.Certificates,
+			"metadata":        idp.Metadata,
+			"updated":         idp.Updated,
+		}})
+	return
+}
+
+//GetIdentityProvider gets the identity provider of an organization, mgo.ErrNotFound is returned if there is none
+func (m *Manager) GetIdentityProvider(globalid string) (idp *IdentityProvider, err error) {
+	idp = &IdentityProvider{}
+	err = m.getIdentityProviderCollection().Find(bson.M{"globalid": globalid}).One(idp)
+	if err != nil {
+		idp = nil
+	}
+	return
+}
+
+//RemoveIdentityProvider removes the identity provider of an organization
+func (m *Manager) RemoveIdentityProvider(globalid string) error {
+	return m.getIdentityProviderCollection().Remove(bson.M{"globalid": globalid})
+}
+
+func (m *Manager) getServiceProviderCollection() *mgo.Collection {
+	return db.GetCollection(m.session, serviceProviderCollectionName)
+}
+
+//CreateServiceProvider registers a service provider for an organization,
+// db.ErrDuplicate is returned if the label or the entity id is already taken
+func (m *Manager) CreateServiceProvider(sp *ServiceProvider) (err error) {
+	sp.Updated = db.DateTime(time.Now())
+	err = m.getServiceProviderCollection().Insert(sp)
+	if mgo.IsDup(err) {
+		err = db.ErrDuplicate
+	}
+	return
+}
+
+//GetServiceProviders lists the service providers of an organization
+func (m *Manager) GetServiceProviders(globalid string) (sps []ServiceProvider, err error) {
+	sps = []ServiceProvider{}
+	err = m.getServiceProviderCollection().Find(bson.M{"globalid": globalid}).All(&sps)
+	return
+}
+
+//GetServiceProvider gets a service provider by its label, mgo.ErrNotFound is returned if it does not exist
+func (m *Manager) GetServiceProvider(globalid string, label string) (sp *ServiceProvider, err error) {
+	sp = &ServiceProvider{}
+	err = m.getServiceProviderCollection().Find(bson.M{"globalid": globalid, "label": label}).One(sp)
+	if err != nil {
+		sp = nil
+	}
+	return
+}
+
+//RemoveServiceProvider removes a service provider of an organization
+func (m *Manager) RemoveServiceProvider(globalid string, label string) error {
+	return m.getServiceProviderCollection().Remove(bson.M{"globalid": globalid, "label": label})
+}
+
+//RenameOrganization moves the identity provider and the service providers of an organization to its new globalid
+func (m *Manager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	qry := bson.M{"globalid": oldGlobalID}
+	update := bson.M{"$set": bson.M{"globalid": newGlobalID}}
+	if _, err = m.getIdentityProviderCollection().UpdateAll(qry, update); err != nil {
+		return
+	}
+	_, err = m.getServiceProviderCollection().UpdateAll(qry, update)
+	return
+}
+
+//RemoveOrganization removes the identity provider and the service providers of an organization
+func (m *Manager) RemoveOrganization(globalID string) (err error) {
+	if _, err = m.getIdentityProviderCollection().RemoveAll(bson.M{"globalid": globalID}); err != nil {
+		return
+	}
+	_, err = m.getServiceProviderCollection().RemoveAll(bson.M{"globalid": globalID})
+	return
+}
@@ -0,0 +1,17 @@
+package scim
+
+import (
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+//ProvisionedUser records that an organization created a user through SCIM.
+// The ExternalID is the identifier of the user in the provisioning system of the organization.
+type ProvisionedUser struct {
+	ID         bson.ObjectId `json:"-" bson:"_id,omitempty"`
+	Globalid   string        `json:"globalid"`
+	Username   string        `json:"username"`
+	ExternalID string        `json:"externalid,omitempty"`
+	Created    db.DateTime   `json:"created"`
+}
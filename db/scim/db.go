@@ -0,0 +1,87 @@
+package scim
+
+import (
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const provisionedUserCollectionName = "scimprovisionedusers"
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	index := mgo.Index{
+		Key:    []string{"globalid", "username"},
+		Unique: true,
+	}
+	db.EnsureIndex(provisionedUserCollectionName, index)
+}
+
+//Manager is used to store the users organizations provisioned
+type Manager struct {
+	session *mgo.Session
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session: session,
+	}
+}
+
+func (m *Manager) getProvisionedUserCollection() *mgo.Collection {
+	return db.GetCollection(m.session, provisionedUserCollectionName)
+}
+
+//SaveProvisionedUser creates or updates the record of a provisioned user
+func (m *Manager) SaveProvisionedUser(user *ProvisionedUser) (err error) {
+	_, err = m.getProvisionedUserCollection().Upsert(
+		bson.M{"globalid": user.Globalid, "username": user.Username},
+		bson.M{
+			"$set":         bson.M{"externalid": user.ExternalID},
+			"$setOnInsert": bson.M{"created": user.Created},
+		})
+	return
+}
+
+//GetProvisionedUsers gets the users an organization provisioned
+func (m *Manager) GetProvisionedUsers(globalid string) (users []ProvisionedUser, err error) {
+	users = []ProvisionedUser{}
+	err = m.getProvisionedUserCollection().Find(bson.M{"globalid": globalid}).All(&users)
+	return
+}
+
+//GetProvisionedUser gets the record of a user an organization provisioned, mgo.ErrNotFound is returned if there is none
+func (m *Manager) GetProvisionedUser(globalid string, username string) (user *ProvisionedUser, err error) {
+	user = &ProvisionedUser{}
+	err = m.getProvisionedUserCollection().Find(bson.M{"globalid": globalid, "username": username}).One(user)
+	if err != nil {
+		user = nil
+	}
+	return
+}
+
+//RemoveProvisionedUser removes the record of a provisioned user
+func (m *Manager) RemoveProvisionedUser(globalid string, username string) (err error) {
+	err = m.getProvisionedUserCollection().Remove(bson.M{"globalid": globalid, "username": username})
+	if err == mgo.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+//RenameOrganization moves the records of the users an organization provisioned to its new globalid
+func (m *Manager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.getProvisionedUserCollection().UpdateAll(bson.M{"globalid": oldGlobalID}, bson.M{"$set": bson.M{"globalid": newGlobalID}})
+	return
+}
+
+//RemoveOrganization removes the records of the users an organization provisioned, the users themselves are kept
+func (m *Manager) RemoveOrganization(globalID string) (err error) {
+	_, err = m.getProvisionedUserCollection().RemoveAll(bson.M{"globalid": globalID})
+	return
+}
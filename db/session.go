@@ -35,3 +35,17 @@ func NewSession() *mgo.Session {
 
 	return dbSession.Copy()
 }
+
+//NewBackgroundRequest creates a request with its own db session for work that is not done while serving an http request,
+// so the managers can be used the same way. The returned function closes the session.
+func NewBackgroundRequest() (r *http.Request, done func()) {
+	r = &http.Request{}
+	session := SetDBSession(r)
+	done = func() {
+		if session != nil {
+			session.Close()
+		}
+		context.Clear(r)
+	}
+	return
+}
@@ -0,0 +1,41 @@
+package sms
+
+import (
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	//StatusPending is the status of a message that is not accepted by a provider yet
+	StatusPending = "pending"
+	//StatusSent is the status of a message a provider accepted
+	StatusSent = "sent"
+	//StatusDelivered is the status of a message the provider reported as delivered to the phone
+	StatusDelivered = "delivered"
+	//StatusUndelivered is the status of a message the provider accepted but could not deliver
+	StatusUndelivered = "undelivered"
+	//StatusFailed is the status of a message none of the providers accepted in time
+	StatusFailed = "failed"
+)
+
+//Message is an sms in the outbox.
+// The Text is only kept until the message is sent or has failed since it usually contains a login code.
+type Message struct {
+	ID                string      `json:"id" bson:"_id"`
+	Phonenumber       string      `json:"phonenumber"`
+	Text              string      `json:"-"`
+	Status            string      `json:"status"`
+	Attempts          int         `json:"attempts"`
+	Provider          string      `json:"provider,omitempty"`
+	ProviderMessageID string      `json:"providermessageid,omitempty"`
+	Failures          []Failure   `json:"failures"`
+	Created           db.DateTime `json:"created"`
+	Updated           db.DateTime `json:"updated"`
+	NextAttempt       db.DateTime `json:"-"`
+}
+
+//Failure is the error of a provider when it was asked to send a message
+type Failure struct {
+	Provider string      `json:"provider"`
+	Error    string      `json:"error"`
+	Time     db.DateTime `json:"time"`
+}
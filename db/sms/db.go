@@ -0,0 +1,135 @@
+package sms
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	mongoCollectionName = "smsoutbox"
+	//maxFailures limits the number of failed messages that are listed
+	maxFailures = 500
+)
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	index := mgo.Index{
+		Key: []string{"status", "nextattempt"},
+	}
+	db.EnsureIndex(mongoCollectionName, index)
+
+	//Keep the messages a week to look into delivery problems
+	automaticExpiration := mgo.Index{
+		Key:         []string{"created"},
+		ExpireAfter: time.Second * 3600 * 24 * 7,
+		Background:  true,
+	}
+	db.EnsureIndex(mongoCollectionName, automaticExpiration)
+}
+
+//Manager is used to store the sms outbox
+type Manager struct {
+	session    *mgo.Session
+	collection *mgo.Collection
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session:    session,
+		collection: db.GetCollection(session, mongoCollectionName),
+	}
+}
+
+//Save stores a new message
+func (m *Manager) Save(message *Message) error {
+	return m.collection.Insert(message)
+}
+
+//Get gets a message by its id
+func (m *Manager) Get(id string) (message *Message, err error) {
+	message = &Message{}
+	err = m.collection.FindId(id).One(message)
+	if err != nil {
+		message = nil
+	}
+	return
+}
+
+//Claim takes the pending message that is due the longest and postpones its next attempt until lease,
+// so other instances do not send it at the same time. nil is returned if no message is due.
+func (m *Manager) Claim(now time.Time, lease time.Time) (message *Message, err error) {
+	message = &Message{}
+	change := mgo.Change{
+		Update:    bson.M{"$set": bson.M{"nextattempt": lease}},
+		ReturnNew: true,
+	}
+	_, err = m.collection.Find(bson.M{"status": StatusPending, "nextattempt": bson.M{"$lte": now}}).Sort("nextattempt").Apply(change, message)
+	if err == mgo.ErrNotFound {
+		err = nil
+		message = nil
+	}
+	return
+}
+
+//MarkSent records that a provider accepted a message
+func (m *Manager) MarkSent(id string, provider string, providerMessageID string, failures []Failure) error {
+	return m.recordAttempt(id, failures, bson.M{
+		"status":            StatusSent,
+		"provider":          provider,
+		"providermessageid": providerMessageID,
+		"text":              "",
+	})
+}
+
+//MarkRetry records a failed attempt after which the message is tried again at nextAttempt
+func (m *Manager) MarkRetry(id string, failures []Failure, nextAttempt time.Time) error {
+	return m.recordAttempt(id, failures, bson.M{"nextattempt": nextAttempt})
+}
+
+//MarkFailed records that a message will not be sent anymore
+func (m *Manager) MarkFailed(id string, failures []Failure) error {
+	return m.recordAttempt(id, failures, bson.M{
+		"status": StatusFailed,
+		"text":   "",
+	})
+}
+
+func (m *Manager) recordAttempt(id string, failures []Failure, set bson.M) error {
+	set["updated"] = db.DateTime(time.Now())
+	if failures == nil {
+		failures = []Failure{}
+	}
+	return m.collection.UpdateId(id, bson.M{
+		"$set":  set,
+		"$inc":  bson.M{"attempts": 1},
+		"$push": bson.M{"failures": bson.M{"$each": failures}},
+	})
+}
+
+//SetDeliveryStatus stores the delivery report of a sent message, mgo.ErrNotFound is returned if there is no sent message with this id
+func (m *Manager) SetDeliveryStatus(id string, status string) error {
+	return m.collection.Update(
+		bson.M{"_id": id, "status": bson.M{"$in": []string{StatusSent, StatusDelivered, StatusUndelivered}}},
+		bson.M{"$set": bson.M{"status": status, "updated": db.DateTime(time.Now())}})
+}
+
+//GetFailures lists the messages created after since that failed, were not delivered or needed more than one attempt, the newest first
+func (m *Manager) GetFailures(since time.Time) (messages []Message, err error) {
+	messages = []Message{}
+	query := bson.M{
+		"created": bson.M{"$gte": since},
+		"$or": []bson.M{
+			{"status": bson.M{"$in": []string{StatusFailed, StatusUndelivered}}},
+			{"failures.0": bson.M{"$exists": true}},
+		},
+	}
+	err = m.collection.Find(query).Sort("-created").Limit(maxFailures).All(&messages)
+	return
+}
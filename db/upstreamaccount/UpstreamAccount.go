@@ -0,0 +1,26 @@
+package upstreamaccount
+
+import (
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+//UpstreamAccount is an account at an upstream identity provider linked to an itsyou.online user
+type UpstreamAccount struct {
+	ID       bson.ObjectId `json:"-" bson:"_id,omitempty"`
+	Provider string        `json:"provider"`
+	Subject  string        `json:"subject"`
+	Username string        `json:"username"`
+	Login    string        `json:"login"`
+	Name     string        `json:"name"`
+	Email    string        `json:"email"`
+	Picture  string        `json:"picture"`
+	Profile  string        `json:"profile"`
+	LinkedAt db.DateTime   `json:"linkedat"`
+}
+
+//SAMLProvider is the provider name under which the NameIDs of an organization's identity provider are linked to users
+func SAMLProvider(globalid string) string {
+	return "saml:" + globalid
+}
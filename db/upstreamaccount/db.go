@@ -0,0 +1,105 @@
+package upstreamaccount
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	mongoCollectionName = "upstreamaccounts"
+)
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	//An upstream account can only be linked to a single user
+	index := mgo.Index{
+		Key:    []string{"provider", "subject"},
+		Unique: true,
+	}
+	db.EnsureIndex(mongoCollectionName, index)
+
+	//A user can link a single account per provider
+	index = mgo.Index{
+		Key:    []string{"username", "provider"},
+		Unique: true,
+	}
+	db.EnsureIndex(mongoCollectionName, index)
+}
+
+//Manager is used to store the links between users and upstream accounts
+type Manager struct {
+	session *mgo.Session
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session: session,
+	}
+}
+
+func (m *Manager) getCollection() *mgo.Collection {
+	return db.GetCollection(m.session, mongoCollectionName)
+}
+
+//Link links an upstream account to a user, replacing a previously linked account of the same provider.
+// db.ErrDuplicate is returned if the upstream account is already linked to another user.
+func (m *Manager) Link(account *UpstreamAccount) (err error) {
+	account.LinkedAt = db.DateTime(time.Now())
+	_, err = m.getCollection().Upsert(
+		bson.M{"username": account.Username, "provider": account.Provider},
+		bson.M{"$set": bson.M{
+			"subject":  account.Subject,
+			"login":    account.Login,
+			"name":     account.Name,
+			"email":    account.Email,
+			"picture":  account.Picture,
+			"profile":  account.Profile,
+			"linkedat": account.LinkedAt,
+		}})
+	if mgo.IsDup(err) {
+		err = db.ErrDuplicate
+	}
+	return
+}
+
+//GetBySubject gets the account with the identifier the provider gave it, mgo.ErrNotFound is returned if it is not linked
+func (m *Manager) GetBySubject(provider string, subject string) (account *UpstreamAccount, err error) {
+	account = &UpstreamAccount{}
+	err = m.getCollection().Find(bson.M{"provider": provider, "subject": subject}).One(account)
+	if err != nil {
+		account = nil
+	}
+	return
+}
+
+//GetByUser gets all upstream accounts linked to a user
+func (m *Manager) GetByUser(username string) (accounts []UpstreamAccount, err error) {
+	accounts = []UpstreamAccount{}
+	err = m.getCollection().Find(bson.M{"username": username}).All(&accounts)
+	return
+}
+
+//Unlink removes the link with the account of a provider, mgo.ErrNotFound is returned if there is none
+func (m *Manager) Unlink(username string, provider string) (err error) {
+	err = m.getCollection().Remove(bson.M{"username": username, "provider": provider})
+	return
+}
+
+//RenameProvider moves the accounts linked for a provider to a new provider name
+func (m *Manager) RenameProvider(oldProvider string, newProvider string) (err error) {
+	_, err = m.getCollection().UpdateAll(bson.M{"provider": oldProvider}, bson.M{"$set": bson.M{"provider": newProvider}})
+	return
+}
+
+//RemoveProvider removes all accounts linked for a provider
+func (m *Manager) RemoveProvider(provider string) (err error) {
+	_, err = m.getCollection().RemoveAll(bson.M{"provider": provider})
+	return
+}
@@ -1,6 +1,10 @@
 package user
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+)
 
 // Authorization defines what userinformation is authorized to be seen by an organization
 // For an explanation about scopes and scopemapping, see https://github.com/itsyouonline/identityserver/blob/master/docs/oauth2/scopes.md
@@ -70,6 +74,81 @@ func (authorization Authorization) FilterAuthorizedScopes(requestedscopes []stri
 	return
 }
 
+//FilterUser returns the parts of a user the authorization gives access to, under the labels the organization requested.
+// The username is always included. Labelled properties that are not authorized are nil, authorized ones that no longer exist are left out.
+func (authorization *Authorization) FilterUser(u *User) (filtered *User) {
+	filtered = &User{Username: u.Username}
+	if authorization == nil {
+		return
+	}
+	if authorization.Name {
+		filtered.Firstname = u.Firstname
+		filtered.Lastname = u.Lastname
+	}
+	if authorization.Github {
+		filtered.Github = u.Github
+	}
+	if authorization.Facebook {
+		filtered.Facebook = u.Facebook
+	}
+	if authorization.Addresses != nil {
+		filtered.Addresses = make([]Address, 0)
+		for _, addressmap := range authorization.Addresses {
+			if address, err := u.GetAddressByLabel(addressmap.RealLabel); err == nil {
+				address.Label = addressmap.RequestedLabel
+				filtered.Addresses = append(filtered.Addresses, address)
+			}
+		}
+	}
+	if authorization.EmailAddresses != nil {
+		filtered.EmailAddresses = make([]EmailAddress, 0)
+		for _, emailmap := range authorization.EmailAddresses {
+			if email, err := u.GetEmailAddressByLabel(emailmap.RealLabel); err == nil {
+				filtered.EmailAddresses = append(filtered.EmailAddresses, EmailAddress{Label: emailmap.RequestedLabel, EmailAddress: email.EmailAddress})
+			}
+		}
+	}
+	if authorization.Phonenumbers != nil {
+		filtered.Phonenumbers = make([]Phonenumber, 0)
+		for _, phonemap := range authorization.Phonenumbers {
+			if phonenumber, err := u.GetPhonenumberByLabel(phonemap.RealLabel); err == nil {
+				filtered.Phonenumbers = append(filtered.Phonenumbers, Phonenumber{Label: phonemap.RequestedLabel, Phonenumber: phonenumber.Phonenumber})
+			}
+		}
+	}
+	if authorization.BankAccounts != nil {
+		filtered.BankAccounts = make([]BankAccount, 0)
+		for _, bankmap := range authorization.BankAccounts {
+			if bank, err := u.GetBankAccountByLabel(bankmap.RealLabel); err == nil {
+				bank.Label = bankmap.RequestedLabel
+				filtered.BankAccounts = append(filtered.BankAccounts, bank)
+			}
+		}
+	}
+	if authorization.DigitalWallet != nil {
+		filtered.DigitalWallet = make([]DigitalAssetAddress, 0)
+		for _, addressMap := range authorization.DigitalWallet {
+			if walletAddress, err := u.GetDigitalAssetAddressByLabel(addressMap.RealLabel); err == nil {
+				walletAddress.Label = addressMap.RequestedLabel
+				filtered.DigitalWallet = append(filtered.DigitalWallet, walletAddress)
+			}
+		}
+	}
+	return
+}
+
+//RenameOrganization replaces the globalid of an organization in the organizations the user shares membership of,
+// the role of a membership is kept. It returns if anything changed.
+func (authorization *Authorization) RenameOrganization(oldGlobalID string, newGlobalID string) (changed bool) {
+	for i, reference := range authorization.Organizations {
+		if renamed, ok := organization.RenameReference(reference, oldGlobalID, newGlobalID); ok {
+			authorization.Organizations[i] = renamed
+			changed = true
+		}
+	}
+	return
+}
+
 func (authorization Authorization) containsOrganization(globalid string) bool {
 	for _, orgid := range authorization.Organizations {
 		if orgid == globalid {
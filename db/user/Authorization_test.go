@@ -50,3 +50,39 @@ func TestScopesAreAuthorized(t *testing.T) {
 		assert.Equal(t, test.authorized, len(requestedScopes) == len(authorizedScopes), test.s)
 	}
 }
+
+func TestRenameOrganization(t *testing.T) {
+	authorization := Authorization{Organizations: []string{"acme.dev", "acme.dev:admin", "acme.dev.backend", "acme"}}
+	assert.True(t, authorization.RenameOrganization("acme.dev", "globex.dev"))
+	assert.Equal(t, []string{"globex.dev", "globex.dev:admin", "acme.dev.backend", "acme"}, authorization.Organizations)
+	assert.False(t, authorization.RenameOrganization("acme.dev", "globex.dev"))
+}
+
+func TestFilterUser(t *testing.T) {
+	u := &User{
+		Username:       "alice",
+		Firstname:      "Alice",
+		Lastname:       "Smith",
+		EmailAddresses: []EmailAddress{EmailAddress{Label: "work", EmailAddress: "alice@example.com"}, EmailAddress{Label: "home", EmailAddress: "alice@example.org"}},
+	}
+
+	filtered := (*Authorization)(nil).FilterUser(u)
+	assert.Equal(t, &User{Username: "alice"}, filtered)
+
+	filtered = (&Authorization{}).FilterUser(u)
+	assert.Equal(t, "", filtered.Firstname)
+	assert.Nil(t, filtered.EmailAddresses)
+
+	a := &Authorization{
+		Name:           true,
+		EmailAddresses: []AuthorizationMap{AuthorizationMap{RealLabel: "work", RequestedLabel: "main"}, AuthorizationMap{RealLabel: "removed", RequestedLabel: "other"}},
+		Phonenumbers:   []AuthorizationMap{},
+	}
+	filtered = a.FilterUser(u)
+	assert.Equal(t, "Alice", filtered.Firstname)
+	assert.Equal(t, "Smith", filtered.Lastname)
+	assert.Equal(t, []EmailAddress{EmailAddress{Label: "main", EmailAddress: "alice@example.com"}}, filtered.EmailAddresses)
+	assert.NotNil(t, filtered.Phonenumbers)
+	assert.Empty(t, filtered.Phonenumbers)
+	assert.Nil(t, filtered.Addresses)
+}
@@ -1,6 +1,10 @@
 package user
 
-import "regexp"
+import (
+	"regexp"
+
+	"github.com/itsyouonline/identityserver/phonenumbers"
+)
 
 //Phonenumber defines a phonenumber and has functions for validation
 type Phonenumber struct {
@@ -12,9 +16,27 @@ var (
 	phoneRegex = regexp.MustCompile(`^\+[0-9]+$`)
 )
 
-//IsValid checks if a phonenumber is in a valid format
+//IsValid checks if a phonenumber is in E.164 format and exists in the numbering plan of its country
 func (phonenumber Phonenumber) IsValid() (valid bool) {
-	valid = true
-	valid = valid && len(phonenumber.Phonenumber) < 51
-	return phoneRegex.Match([]byte(phonenumber.Phonenumber))
+	valid = len(phonenumber.Phonenumber) < 51 && phoneRegex.Match([]byte(phonenumber.Phonenumber))
+	if !valid {
+		return
+	}
+	normalized, err := phonenumbers.Normalize(phonenumber.Phonenumber, "")
+	return err == nil && normalized == phonenumber.Phonenumber
+}
+
+//Normalize converts the phonenumber to E.164 format, numbers without country calling code are
+// interpreted in defaultRegion. A number that can not be normalized is left as it is.
+func (phonenumber *Phonenumber) Normalize(defaultRegion string) {
+	if normalized, err := phonenumbers.Normalize(phonenumber.Phonenumber, defaultRegion); err == nil {
+		phonenumber.Phonenumber = normalized
+	}
+}
+
+//CanReceiveSMS checks if the phonenumber is not a landline or another kind of number that can not receive text messages.
+// Numbers that can not be parsed are not refused here, sending the sms will fail for them.
+func (phonenumber Phonenumber) CanReceiveSMS() bool {
+	n, err := phonenumbers.Parse(phonenumber.Phonenumber, "")
+	return err != nil || n.CanReceiveSMS()
 }
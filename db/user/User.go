@@ -32,6 +32,7 @@ type User struct {
 	Username       string                `json:"username"`
 	Firstname      string                `json:"firstname"`
 	Lastname       string                `json:"lastname"`
+	Language       string                `json:"language,omitempty"`
 }
 
 func (u *User) GetEmailAddressByLabel(label string) (email EmailAddress, err error) {
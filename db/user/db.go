@@ -3,6 +3,7 @@ package user
 import (
 	"errors"
 	"net/http"
+	"regexp"
 
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -92,6 +93,20 @@ func (m *Manager) GetByName(username string) (*User, error) {
 	return &user, err
 }
 
+//GetByNames gets the users with the given usernames, sorted by username
+func (m *Manager) GetByNames(usernames []string) (users []User, err error) {
+	users = []User{}
+	err = m.getUserCollection().Find(bson.M{"username": bson.M{"$in": usernames}}).Sort("username").All(&users)
+	return
+}
+
+//GetNames gets only the usernames, firstnames and lastnames of the users with the given usernames
+func (m *Manager) GetNames(usernames []string) (users []User, err error) {
+	users = []User{}
+	err = m.getUserCollection().Find(bson.M{"username": bson.M{"$in": usernames}}).Select(bson.M{"username": 1, "firstname": 1, "lastname": 1}).All(&users)
+	return
+}
+
 //Exists checks if a user with this username already exists.
 func (m *Manager) Exists(username string) (bool, error) {
 	count, err := m.getUserCollection().Find(bson.M{"username": username}).Count()
@@ -151,6 +166,28 @@ func (m *Manager) SavePhone(username string, phonenumber Phonenumber) error {
 		bson.M{"$push": bson.M{"phonenumbers": phonenumber}})
 }
 
+// ReplacePhonenumber changes the number of the phone with a label, if it still has the old number
+func (m *Manager) ReplacePhonenumber(username string, label string, old string, phonenumber string) error {
+	return m.getUserCollection().Update(
+		bson.M{"username": username, "phonenumbers": bson.M{"$elemMatch": bson.M{"label": label, "phonenumber": old}}},
+		bson.M{"$set": bson.M{"phonenumbers.$.phonenumber": phonenumber}})
+}
+
+// ForEachWithPhonenumbers calls handle for every user that has phonenumbers, only the username and phonenumbers are loaded.
+// It stops at the first error.
+func (m *Manager) ForEachWithPhonenumbers(handle func(u *User) error) (err error) {
+	iter := m.getUserCollection().Find(bson.M{"phonenumbers.0": bson.M{"$exists": true}}).Select(bson.M{"username": 1, "phonenumbers": 1}).Iter()
+	u := &User{}
+	for iter.Next(u) {
+		if err = handle(u); err != nil {
+			iter.Close()
+			return
+		}
+		u = &User{}
+	}
+	return iter.Close()
+}
+
 // RemovePhone remove phone associated with label
 func (m *Manager) RemovePhone(username string, label string) error {
 	return m.getUserCollection().Update(
@@ -250,6 +287,13 @@ func (m *Manager) GetAuthorization(username, organization string) (authorization
 	return
 }
 
+//GetAuthorizationsGrantedTo returns the authorizations the users with the given usernames gave an organization
+func (m *Manager) GetAuthorizationsGrantedTo(organization string, usernames []string) (authorizations []Authorization, err error) {
+	authorizations = []Authorization{}
+	err = m.getAuthorizationCollection().Find(bson.M{"grantedto": organization, "username": bson.M{"$in": usernames}}).All(&authorizations)
+	return
+}
+
 //UpdateAuthorization inserts or updates an authorization
 func (m *Manager) UpdateAuthorization(authorization *Authorization) (err error) {
 	_, err = m.getAuthorizationCollection().Upsert(bson.M{"username": authorization.Username, "grantedto": authorization.GrantedTo}, authorization)
@@ -268,6 +312,34 @@ func (m *Manager) DeleteAllAuthorizations(organization string) (err error) {
 	return err
 }
 
+//RenameOrganization moves the authorizations granted to an organization to its new globalid
+// and replaces it in the organizations the users share membership of
+func (m *Manager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	authorizations := m.getAuthorizationCollection()
+	_, err = authorizations.UpdateAll(bson.M{"grantedto": oldGlobalID}, bson.M{"$set": bson.M{"grantedto": newGlobalID}})
+	if err != nil {
+		return
+	}
+	var shared []Authorization
+	qry := bson.M{"organizations": bson.RegEx{Pattern: "^" + regexp.QuoteMeta(oldGlobalID) + "(:|$)"}}
+	if err = authorizations.Find(qry).Select(bson.M{"username": 1, "grantedto": 1, "organizations": 1}).All(&shared); err != nil {
+		return
+	}
+	for _, authorization := range shared {
+		if !authorization.RenameOrganization(oldGlobalID, newGlobalID) {
+			continue
+		}
+		err = authorizations.Update(
+			bson.M{"username": authorization.Username, "grantedto": authorization.GrantedTo},
+			bson.M{"$set": bson.M{"organizations": authorization.Organizations}})
+		if err != nil && err != mgo.ErrNotFound {
+			return
+		}
+	}
+	err = nil
+	return
+}
+
 func (u *User) getID() string {
 	return u.ID.Hex()
 }
@@ -281,6 +353,12 @@ func (m *Manager) UpdateName(username string, firstname string, lastname string)
 	return
 }
 
+//UpdateLanguage sets the locale the messages to a user are sent in, an empty language removes the preference
+func (m *Manager) UpdateLanguage(username string, language string) (err error) {
+	_, err = m.getUserCollection().UpdateAll(bson.M{"username": username}, bson.M{"$set": bson.M{"language": language}})
+	return
+}
+
 func (m *Manager) RemoveExpireDate(username string) (err error) {
 	qry := bson.M{"username": username}
 	values := bson.M{"expire": bson.M{}}
@@ -3,6 +3,7 @@ package validation
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 
 	"gopkg.in/mgo.v2"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/itsyouonline/identityserver/db"
 	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/phonenumbers"
 	"github.com/itsyouonline/identityserver/tools"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -177,6 +179,18 @@ func (manager *Manager) GetByKeyPhonenumberValidationInformation(key string) (in
 	return
 }
 
+//CountPhonenumberValidationCall increments the number of calls of a pending phonenumber validation and returns it,
+// info is nil if there is no validation with this key
+func (manager *Manager) CountPhonenumberValidationCall(key string) (info *PhonenumberValidationInformation, err error) {
+	mgoCollection := db.GetCollection(manager.session, mongoOngoingPhonenumberValidationCollectionName)
+	_, err = mgoCollection.Find(bson.M{"key": key}).Apply(mgo.Change{Update: bson.M{"$inc": bson.M{"calls": 1}}, ReturnNew: true}, &info)
+	if err == mgo.ErrNotFound {
+		info = nil
+		err = nil
+	}
+	return
+}
+
 func (manager *Manager) GetByKeyEmailAddressValidationInformation(key string) (info *EmailAddressValidationInformation, err error) {
 	mgoCollection := db.GetCollection(manager.session, mongoOngoingEmailAddressValidationCollectionName)
 	err = mgoCollection.Find(bson.M{"key": key}).One(&info)
@@ -222,6 +236,14 @@ func (manager *Manager) GetByEmailAddressValidatedEmailAddress(email string) (va
 	return
 }
 
+//GetByEmailAddressValidatedEmailAddressIgnoringCase gets a validated email address regardless of the case it was entered in
+func (manager *Manager) GetByEmailAddressValidatedEmailAddressIgnoringCase(email string) (validatedemail *ValidatedEmailAddress, err error) {
+	validatedemail = &ValidatedEmailAddress{}
+	mgoCollection := db.GetCollection(manager.session, mongoValidatedEmailAddresses)
+	err = mgoCollection.Find(bson.M{"emailaddress": bson.RegEx{Pattern: "^" + regexp.QuoteMeta(email) + "$", Options: "i"}}).One(validatedemail)
+	return
+}
+
 func (manager *Manager) GetByUsernameValidatedEmailAddress(username string) (validatedemails []ValidatedEmailAddress, err error) {
 	mgoCollection := db.GetCollection(manager.session, mongoValidatedEmailAddresses)
 	err = mgoCollection.Find(bson.M{"username": username}).All(&validatedemails)
@@ -260,7 +282,11 @@ func (manager *Manager) HasValidatedPhones(username string) (hasValidatedPhones
 	return hasValidatedPhones, err
 }
 
+//GetByPhoneNumber gets the validated phonenumber record, the search string is normalized to E.164 format if possible
 func (manager *Manager) GetByPhoneNumber(searchString string) (validatedPhonenumber ValidatedPhonenumber, err error) {
+	if normalized, normalizeErr := phonenumbers.Normalize(searchString, ""); normalizeErr == nil {
+		searchString = normalized
+	}
 	mgoCollection := db.GetCollection(manager.session, mongoValidatedPhonenumbers)
 	err = mgoCollection.Find(bson.M{"phonenumber": searchString}).One(&validatedPhonenumber)
 	return validatedPhonenumber, err
@@ -271,3 +297,29 @@ func (manager *Manager) GetByEmailAddress(searchString string) (validatedEmailad
 	err = mgoCollection.Find(bson.M{"emailaddress": searchString}).One(&validatedEmailaddress)
 	return validatedEmailaddress, err
 }
+
+//ForEachValidatedPhonenumber calls handle for every validated phonenumber, it stops at the first error
+func (manager *Manager) ForEachValidatedPhonenumber(handle func(validated *ValidatedPhonenumber) error) (err error) {
+	iter := db.GetCollection(manager.session, mongoValidatedPhonenumbers).Find(nil).Iter()
+	validated := &ValidatedPhonenumber{}
+	for iter.Next(validated) {
+		if err = handle(validated); err != nil {
+			iter.Close()
+			return
+		}
+		validated = &ValidatedPhonenumber{}
+	}
+	return iter.Close()
+}
+
+//ReplaceValidatedPhonenumber changes the phonenumber of a validated phonenumber record.
+// If the new phonenumber is already validated, the record is removed instead.
+func (manager *Manager) ReplaceValidatedPhonenumber(validated *ValidatedPhonenumber, phonenumber string) (err error) {
+	mgoCollection := db.GetCollection(manager.session, mongoValidatedPhonenumbers)
+	selector := bson.M{"username": validated.Username, "phonenumber": validated.Phonenumber}
+	err = mgoCollection.Update(selector, bson.M{"$set": bson.M{"phonenumber": phonenumber}})
+	if mgo.IsDup(err) {
+		err = mgoCollection.Remove(selector)
+	}
+	return
+}
@@ -16,6 +16,8 @@ type PhonenumberValidationInformation struct {
 	Phonenumber string
 	Confirmed   bool
 	CreatedAt   time.Time
+	//Calls is how many times the code was read out in a voice call
+	Calls int
 }
 
 type ValidatedEmailAddress struct {
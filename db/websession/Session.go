@@ -0,0 +1,20 @@
+package websession
+
+import (
+	"github.com/itsyouonline/identityserver/db"
+	"gopkg.in/mgo.v2/bson"
+)
+
+//Session is a server side record of an authenticated session on the itsyou.online website
+type Session struct {
+	ID           bson.ObjectId `json:"id" bson:"_id,omitempty"`
+	Key          string        `json:"-"`
+	Name         string        `json:"-"`
+	Username     string        `json:"username"`
+	Values       string        `json:"-"`
+	UserAgent    string        `json:"useragent"`
+	IP           string        `json:"ip"`
+	CreatedAt    db.DateTime   `json:"createdat"`
+	LastActivity db.DateTime   `json:"lastactivity"`
+	Current      bool          `json:"current" bson:"-"`
+}
@@ -0,0 +1,116 @@
+package websession
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	mongoCollectionName = "websessions"
+)
+
+//InitModels initialize models in mongo, if required.
+func InitModels() {
+	index := mgo.Index{
+		Key:      []string{"key"},
+		Unique:   true,
+		DropDups: true,
+	}
+	db.EnsureIndex(mongoCollectionName, index)
+
+	index = mgo.Index{
+		Key: []string{"username"},
+	}
+	db.EnsureIndex(mongoCollectionName, index)
+
+	//Sessions are refreshed on every request, idle ones are cleaned up by mongo
+	automaticExpiration := mgo.Index{
+		Key:         []string{"lastactivity"},
+		ExpireAfter: time.Second * 60 * 10,
+		Background:  true,
+	}
+	db.EnsureIndex(mongoCollectionName, automaticExpiration)
+}
+
+//Manager is used to store web sessions
+type Manager struct {
+	session    *mgo.Session
+	collection *mgo.Collection
+}
+
+//NewManager creates and initializes a new Manager
+func NewManager(r *http.Request) *Manager {
+	session := db.GetDBSession(r)
+	return &Manager{
+		session:    session,
+		collection: db.GetCollection(session, mongoCollectionName),
+	}
+}
+
+//GetByKey gets a session by its opaque key, nil is returned if no such session exists
+func (m *Manager) GetByKey(key string) (session *Session, err error) {
+	session = &Session{}
+	err = m.collection.Find(bson.M{"key": key}).One(session)
+	if err == mgo.ErrNotFound {
+		session = nil
+		err = nil
+	}
+	return
+}
+
+//GetByUser gets all active sessions of a user
+func (m *Manager) GetByUser(username string) (sessions []Session, err error) {
+	sessions = []Session{}
+	err = m.collection.Find(bson.M{"username": username}).Sort("-lastactivity").All(&sessions)
+	return
+}
+
+//Save creates or updates a session, the creation date of an existing session is preserved
+func (m *Manager) Save(session *Session) (err error) {
+	_, err = m.collection.Upsert(
+		bson.M{"key": session.Key},
+		bson.M{
+			"$set": bson.M{
+				"name":         session.Name,
+				"username":     session.Username,
+				"values":       session.Values,
+				"useragent":    session.UserAgent,
+				"ip":           session.IP,
+				"lastactivity": session.LastActivity,
+			},
+			"$setOnInsert": bson.M{"createdat": session.CreatedAt},
+		})
+	return
+}
+
+//DeleteByKey removes a session by its opaque key
+func (m *Manager) DeleteByKey(key string) (err error) {
+	_, err = m.collection.RemoveAll(bson.M{"key": key})
+	return
+}
+
+//Delete removes a session of a user, mgo.ErrNotFound is returned if the user has no such session
+func (m *Manager) Delete(username string, id string) (err error) {
+	if !bson.IsObjectIdHex(id) {
+		err = mgo.ErrNotFound
+		return
+	}
+	err = m.collection.Remove(bson.M{"_id": bson.ObjectIdHex(id), "username": username})
+	return
+}
+
+//DeleteByUser removes all sessions of a user except the one with the key given in exceptKey
+// Pass an empty exceptKey to remove all sessions
+func (m *Manager) DeleteByUser(username string, exceptKey string) (err error) {
+	selector := bson.M{"username": username}
+	if exceptKey != "" {
+		selector["key"] = bson.M{"$ne": exceptKey}
+	}
+	_, err = m.collection.RemoveAll(selector)
+	return
+}
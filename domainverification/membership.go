@@ -0,0 +1,64 @@
+package domainverification
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+)
+
+//JoinVerifiedDomains applies the domain membership rules of the organizations that verified the domain of an email address
+// a user just validated. Depending on the rule, the user becomes a member or gets an invitation.
+// Users that already are a member or owner, or that already got an invitation, are left alone.
+func JoinVerifiedDomains(r *http.Request, username string, emailaddress string) (err error) {
+	domains := organization.ParentDomains(emailaddress)
+	if len(domains) == 0 {
+		return
+	}
+	orgMgr := organization.NewManager(r)
+	orgs, err := orgMgr.GetByVerifiedDomains(domains)
+	if err != nil {
+		return
+	}
+	invitationMgr := invitations.NewInvitationManager(r)
+	for i := range orgs {
+		org := &orgs[i]
+		isMember, err := orgMgr.IsMember(org.Globalid, username)
+		if err != nil {
+			return err
+		}
+		isOwner, err := orgMgr.IsOwner(org.Globalid, username)
+		if err != nil {
+			return err
+		}
+		if isMember || isOwner {
+			continue
+		}
+		if org.DomainMembership == organization.DomainMembershipMember {
+			if err = orgMgr.SaveMember(org, username); err != nil {
+				return err
+			}
+			continue
+		}
+		hasInvite, err := invitationMgr.HasInvite(org.Globalid, username)
+		if err != nil {
+			return err
+		}
+		if hasInvite {
+			continue
+		}
+		invite := &invitations.JoinOrganizationInvitation{
+			Organization: org.Globalid,
+			Role:         invitations.RoleMember,
+			User:         username,
+			Status:       invitations.RequestPending,
+			Created:      db.DateTime(time.Now()),
+		}
+		if err = invitationMgr.Save(invite); err != nil {
+			return err
+		}
+	}
+	return
+}
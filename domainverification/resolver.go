@@ -0,0 +1,70 @@
+package domainverification
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+//wellKnownTimeout limits how long a domain gets to serve its well-known verification file
+const wellKnownTimeout = 10 * time.Second
+
+//maxWellKnownSize is the maximum size of the well-known verification file that is read
+const maxWellKnownSize = 1024
+
+//wellKnownTransport does not connect to internal addresses, the domain names are chosen by the organizations
+var wellKnownTransport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout: wellKnownTimeout,
+		Control: tools.RefuseInternalAddress,
+	}).DialContext,
+	TLSHandshakeTimeout: wellKnownTimeout,
+}
+
+//Resolver looks up the places a domain can publish its verification token, the tests use a stand-in
+type Resolver interface {
+	//LookupTXT returns the TXT records of a name, none if the name does not exist
+	LookupTXT(name string) ([]string, error)
+	//GetWellKnown returns the content of a well-known url, nil if it does not exist
+	GetWellKnown(url string) ([]byte, error)
+}
+
+//NetResolver looks up the TXT records in the DNS and fetches the well-known files over https
+type NetResolver struct{}
+
+//LookupTXT returns the TXT records of a name using the system resolver
+func (NetResolver) LookupTXT(name string) (records []string, err error) {
+	records, err = net.LookupTXT(name)
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return
+}
+
+//GetWellKnown fetches a well-known url, redirects are not followed so only the domain itself can serve it
+func (NetResolver) GetWellKnown(url string) (content []byte, err error) {
+	client := &http.Client{
+		Timeout:   wellKnownTimeout,
+		Transport: wellKnownTransport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode/100 == 3 {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("Unexpected response " + resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxWellKnownSize))
+}
@@ -0,0 +1,155 @@
+package domainverification
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+//txtRecordPrefix precedes the token in the TXT record
+const txtRecordPrefix = "itsyouonline-verification="
+
+//domainLabelRegex matches a single label of a dns name
+var domainLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?$`)
+
+//numericRegex matches a label of only digits, no top level domain is numeric so such names are ip addresses
+var numericRegex = regexp.MustCompile(`^[0-9]+$`)
+
+//ErrNotVerified denotes that the token of a domain was not found in its TXT records or well-known file
+var ErrNotVerified = errors.New("The verification token was not found")
+
+//TXTRecordName returns the name of the TXT record a domain publishes its verification token in
+func TXTRecordName(domain string) string {
+	return "_itsyouonline." + strings.ToLower(domain)
+}
+
+//TXTRecordValue returns the value of the TXT record with a verification token
+func TXTRecordValue(token string) string {
+	return txtRecordPrefix + token
+}
+
+//WellKnownURL returns the url a domain can serve its verification token on instead of publishing a TXT record
+func WellKnownURL(domain string) string {
+	return "https://" + strings.ToLower(domain) + "/.well-known/itsyouonline-verification"
+}
+
+//IsValidDomain checks if a name is a dns name that can be verified.
+// Ports, ip addresses and local names are refused, the well-known file of a domain is fetched from the server.
+func IsValidDomain(name string) bool {
+	if len(name) < 3 || len(name) >= 250 || net.ParseIP(name) != nil || tools.IsInternalHost(name) {
+		return false
+	}
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		if !domainLabelRegex.MatchString(label) {
+			return false
+		}
+	}
+	return !numericRegex.MatchString(labels[len(labels)-1])
+}
+
+//Check looks for the token of a domain in its TXT records and its well-known file and returns the method that proved control.
+// The well-known file is only fetched for valid domains, names stored before they were validated are only checked in the DNS.
+// ErrNotVerified is returned if the token was found in neither, other errors mean the lookups failed and the result is inconclusive.
+func Check(resolver Resolver, verification *organization.DomainVerification) (method string, err error) {
+	records, dnsErr := resolver.LookupTXT(TXTRecordName(verification.Name))
+	for _, record := range records {
+		if strings.TrimSpace(record) == TXTRecordValue(verification.Token) {
+			return organization.VerificationMethodDNS, nil
+		}
+	}
+	var content []byte
+	var httpErr error
+	if IsValidDomain(verification.Name) {
+		content, httpErr = resolver.GetWellKnown(WellKnownURL(verification.Name))
+	}
+	if strings.TrimSpace(string(content)) == verification.Token {
+		return organization.VerificationMethodHTTP, nil
+	}
+	if dnsErr != nil {
+		return "", dnsErr
+	}
+	if httpErr != nil {
+		return "", httpErr
+	}
+	return "", ErrNotVerified
+}
+
+//Verify checks a domain and updates its verification.
+// An inconclusive check only leaves a verified domain verified if keepOnError is set.
+func Verify(resolver Resolver, verification *organization.DomainVerification, now time.Time, keepOnError bool) (err error) {
+	method, err := Check(resolver, verification)
+	verification.LastChecked = db.DateTime(now)
+	if err == nil {
+		if !verification.Verified {
+			verification.VerifiedAt = db.DateTime(now)
+		}
+		verification.Verified = true
+		verification.Method = method
+		return
+	}
+	if err == ErrNotVerified || !keepOnError {
+		verification.Verified = false
+	}
+	return
+}
+
+//Run verifies the domains of all organizations again at every interval, domains that no longer publish their token lose their verified flag.
+// Unverified domains are only checked once an owner started their verification.
+func Run(resolver Resolver, interval time.Duration) {
+	for range time.Tick(interval) {
+		verifyAll(resolver)
+	}
+}
+
+func verifyAll(resolver Resolver) {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		log.Warn("Domain verification skipped, no database connection")
+		return
+	}
+	reverify(r, resolver, time.Now())
+}
+
+func reverify(r *http.Request, resolver Resolver, now time.Time) {
+	orgMgr := organization.NewManager(r)
+	orgs, err := orgMgr.GetWithDomainVerifications()
+	if err != nil {
+		log.Error("Failed to load the domains to verify: ", err)
+		return
+	}
+	for _, org := range orgs {
+		for i := range org.DomainVerifications {
+			verification := &org.DomainVerifications[i]
+			if !shouldReverify(verification) {
+				continue
+			}
+			wasVerified := verification.Verified
+			if err = Verify(resolver, verification, now, true); err != nil && err != ErrNotVerified {
+				log.Warnf("Verification of %s for %s is inconclusive: %v", verification.Name, org.Globalid, err)
+			}
+			if wasVerified && !verification.Verified {
+				log.Infof("Domain %s of %s is no longer verified", verification.Name, org.Globalid)
+			}
+			if err = orgMgr.SaveDomainVerification(org.Globalid, verification); err != nil {
+				log.Error("Failed to save the verification of ", verification.Name, ": ", err)
+			}
+		}
+	}
+}
+
+//shouldReverify checks if a domain is verified or an owner asked to verify it,
+// the token of a dns name nobody started verifying is not checked so adding a name does not verify it by itself
+func shouldReverify(verification *organization.DomainVerification) bool {
+	return verification.Verified || verification.Started
+}
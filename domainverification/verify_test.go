@@ -0,0 +1,114 @@
+package domainverification
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+)
+
+//testResolver is a stand-in for the DNS and the well-known files of the domains
+type testResolver struct {
+	records   map[string][]string
+	wellKnown map[string]string
+	err       error
+}
+
+func (t *testResolver) LookupTXT(name string) ([]string, error) {
+	return t.records[name], t.err
+}
+
+func (t *testResolver) GetWellKnown(url string) ([]byte, error) {
+	content, exists := t.wellKnown[url]
+	if !exists {
+		return nil, t.err
+	}
+	return []byte(content), nil
+}
+
+func TestCheck(t *testing.T) {
+	verification := &organization.DomainVerification{Name: "acme.com", Token: "abc123"}
+
+	resolver := &testResolver{records: map[string][]string{"_itsyouonline.acme.com": {"v=spf1 -all", "itsyouonline-verification=abc123"}}}
+	method, err := Check(resolver, verification)
+	assert.NoError(t, err)
+	assert.Equal(t, organization.VerificationMethodDNS, method)
+
+	resolver = &testResolver{wellKnown: map[string]string{"https://acme.com/.well-known/itsyouonline-verification": "abc123\n"}}
+	method, err = Check(resolver, verification)
+	assert.NoError(t, err)
+	assert.Equal(t, organization.VerificationMethodHTTP, method)
+
+	resolver = &testResolver{records: map[string][]string{"_itsyouonline.acme.com": {"itsyouonline-verification=other"}}}
+	_, err = Check(resolver, verification)
+	assert.Equal(t, ErrNotVerified, err)
+
+	resolver = &testResolver{err: errors.New("timeout")}
+	_, err = Check(resolver, verification)
+	assert.EqualError(t, err, "timeout")
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	verification := &organization.DomainVerification{Name: "acme.com", Token: "abc123"}
+	published := &testResolver{records: map[string][]string{"_itsyouonline.acme.com": {"itsyouonline-verification=abc123"}}}
+
+	assert.NoError(t, Verify(published, verification, now, false))
+	assert.True(t, verification.Verified)
+	assert.Equal(t, now, time.Time(verification.VerifiedAt))
+
+	later := now.Add(24 * time.Hour)
+	assert.NoError(t, Verify(published, verification, later, true))
+	assert.Equal(t, now, time.Time(verification.VerifiedAt), "the verification date is kept while the domain stays verified")
+	assert.Equal(t, later, time.Time(verification.LastChecked))
+
+	assert.Error(t, Verify(&testResolver{err: errors.New("timeout")}, verification, later, true))
+	assert.True(t, verification.Verified, "an inconclusive check keeps a verified domain verified")
+
+	assert.Equal(t, ErrNotVerified, Verify(&testResolver{}, verification, later, true))
+	assert.False(t, verification.Verified)
+}
+
+func TestShouldReverify(t *testing.T) {
+	assert.False(t, shouldReverify(&organization.DomainVerification{Name: "acme.com", Token: "abc123"}))
+	assert.True(t, shouldReverify(&organization.DomainVerification{Name: "acme.com", Token: "abc123", Started: true}))
+	assert.True(t, shouldReverify(&organization.DomainVerification{Name: "acme.com", Token: "abc123", Verified: true}))
+}
+
+func TestIsValidDomain(t *testing.T) {
+	type testcase struct {
+		name  string
+		valid bool
+	}
+	testcases := []testcase{
+		testcase{name: "acme.com", valid: true},
+		testcase{name: "mail.acme.com", valid: true},
+		testcase{name: "my-shop.example", valid: true},
+		testcase{name: "ab", valid: false},
+		testcase{name: "acme.com:8443", valid: false},
+		testcase{name: "acme.com/path", valid: false},
+		testcase{name: "user@acme.com", valid: false},
+		testcase{name: "acme..com", valid: false},
+		testcase{name: "-acme.com", valid: false},
+		testcase{name: "10.0.0.1", valid: false},
+		testcase{name: "8.8.8.8", valid: false},
+		testcase{name: "127.1", valid: false},
+		testcase{name: "[::1]", valid: false},
+		testcase{name: "::1", valid: false},
+		testcase{name: "localhost", valid: false},
+		testcase{name: "app.localhost", valid: false},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.valid, IsValidDomain(test.name), test.name)
+	}
+}
+
+func TestCheckSkipsWellKnownOfInvalidDomains(t *testing.T) {
+	verification := &organization.DomainVerification{Name: "127.0.0.1:8080", Token: "abc123"}
+	resolver := &testResolver{wellKnown: map[string]string{"https://127.0.0.1:8080/.well-known/itsyouonline-verification": "abc123"}}
+	_, err := Check(resolver, verification)
+	assert.Equal(t, ErrNotVerified, err)
+}
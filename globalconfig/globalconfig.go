@@ -1,6 +1,8 @@
 package globalconfig
 
 import (
+	"regexp"
+
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
@@ -69,6 +71,18 @@ func (m *Manager) Insert(c *GlobalConfig) error {
 	return err
 }
 
+// Save inserts or replaces a config key
+func (m *Manager) Save(c *GlobalConfig) error {
+	_, err := m.collection.Upsert(bson.M{"key": c.Key}, c)
+
+	return err
+}
+
+// Close closes the db session of the manager
+func (m *Manager) Close() {
+	m.session.Close()
+}
+
 // Delete a config key
 func (m *Manager) Delete(key string) error {
 	config, err := m.GetByKey(key)
@@ -79,3 +93,10 @@ func (m *Manager) Delete(key string) error {
 
 	return m.collection.Remove(config)
 }
+
+// ListByPrefix returns all config keys starting with prefix
+func (m *Manager) ListByPrefix(prefix string) (configs []GlobalConfig, err error) {
+	err = m.collection.Find(bson.M{"key": bson.RegEx{Pattern: "^" + regexp.QuoteMeta(prefix)}}).All(&configs)
+
+	return
+}
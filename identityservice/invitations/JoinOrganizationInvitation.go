@@ -1,6 +1,8 @@
 package invitations
 
 import (
+	"time"
+
 	"github.com/itsyouonline/identityserver/db"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -11,6 +13,7 @@ const (
 	RequestPending  InvitationStatus = "pending"
 	RequestAccepted InvitationStatus = "accepted"
 	RequestRejected InvitationStatus = "rejected"
+	RequestExpired  InvitationStatus = "expired"
 )
 
 const (
@@ -18,12 +21,100 @@ const (
 	RoleOwner  = "owner"
 )
 
+//MaxMessageLength is the maximum length of the note an inviter can add to an invitation
+const MaxMessageLength = 500
+
+//InvitationExpiration is how long an invitation can be accepted after it is sent or resent
+var InvitationExpiration = 14 * 24 * time.Hour
+
+//InvitationResendInterval is how long to wait before an invitation can be sent again
+const InvitationResendInterval = 10 * time.Minute
+
+//ContactInvitationWindow is the period over which the invitations sent by email or sms are limited
+const ContactInvitationWindow = time.Hour
+
+const (
+	//MaxContactInvitationsPerInviter is how many invitations a user can send by email or sms in a ContactInvitationWindow, over all organizations
+	MaxContactInvitationsPerInviter = 50
+	//MaxContactInvitationsPerOrganization is how many invitations an organization can send by email or sms in a ContactInvitationWindow
+	MaxContactInvitationsPerOrganization = 100
+)
+
 //JoinOrganizationInvitation defines an invitation to join an organization
+// The EmailAddress or PhoneNumber is set instead of the User when the person has no account with that validated email address or phone number yet.
+// Once the person validates it, the User is filled in.
 type JoinOrganizationInvitation struct {
 	ID           bson.ObjectId    `json:"-" bson:"_id,omitempty"`
 	Organization string           `json:"organization"`
 	Role         string           `json:"role"`
 	User         string           `json:"user"`
+	EmailAddress string           `json:"emailaddress,omitempty" bson:"emailaddress,omitempty"`
+	PhoneNumber  string           `json:"phonenumber,omitempty" bson:"phonenumber,omitempty"`
 	Status       InvitationStatus `json:"status"`
 	Created      db.DateTime      `json:"created"`
+	Expires      db.DateTime      `json:"expires"`
+	//Inviter is the username of the user that sent the invitation, empty if it was sent with an api key
+	Inviter string `json:"inviter,omitempty" bson:"inviter,omitempty"`
+	//Message is the note of the inviter
+	Message string `json:"message,omitempty" bson:"message,omitempty"`
+	//Sent is when the invitation was last resent, it was only sent when it was created if not set
+	Sent *db.DateTime `json:"-" bson:"sent,omitempty"`
+	//Answered is when the owners approved or rejected a request to join
+	Answered *db.DateTime `json:"-" bson:"answered,omitempty"`
+}
+
+//ExpirationTime returns when the invitation expires,
+// invitations stored before they had an expiration date expire InvitationExpiration after they were created
+func (invite *JoinOrganizationInvitation) ExpirationTime() time.Time {
+	if !time.Time(invite.Expires).IsZero() {
+		return time.Time(invite.Expires)
+	}
+	return time.Time(invite.Created).Add(InvitationExpiration)
+}
+
+//IsExpiredAt checks if the invitation is still pending but can no longer be accepted at a specific time
+func (invite *JoinOrganizationInvitation) IsExpiredAt(testtime time.Time) bool {
+	return invite.Status == RequestPending && testtime.After(invite.ExpirationTime())
+}
+
+//Renew makes a pending invitation valid for another InvitationExpiration, it is sent again at that time
+func (invite *JoinOrganizationInvitation) Renew(now time.Time) {
+	sent := db.DateTime(now)
+	invite.Status = RequestPending
+	invite.Expires = db.DateTime(now.Add(InvitationExpiration))
+	invite.Sent = &sent
+}
+
+//CanBeResentAt checks if the InvitationResendInterval passed since the invitation was last sent
+func (invite *JoinOrganizationInvitation) CanBeResentAt(testtime time.Time) bool {
+	sent := time.Time(invite.Created)
+	if invite.Sent != nil {
+		sent = time.Time(*invite.Sent)
+	}
+	return !testtime.Before(sent.Add(InvitationResendInterval))
+}
+
+//withinContactInvitationLimits checks if the number of invitations sent by email or sms in a ContactInvitationWindow,
+// including the ones about to be sent, stays within the limits. Invitations sent with an api key have no inviter and are only limited per organization.
+func withinContactInvitationLimits(byOrganization int, byInviter int) bool {
+	return byOrganization <= MaxContactInvitationsPerOrganization && byInviter <= MaxContactInvitationsPerInviter
+}
+
+//markExpired sets the status of the pending invitations that expired to RequestExpired
+func markExpired(invites []JoinOrganizationInvitation, now time.Time) {
+	for i := range invites {
+		if invites[i].IsExpiredAt(now) {
+			invites[i].Status = RequestExpired
+		}
+	}
+}
+
+//hasPendingInvitation checks if one of the invitations can still be accepted at a specific time
+func hasPendingInvitation(invites []JoinOrganizationInvitation, now time.Time) bool {
+	for i := range invites {
+		if invites[i].Status == RequestPending && !invites[i].IsExpiredAt(now) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,101 @@
+package invitations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestInvitationExpiration(t *testing.T) {
+	created := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	invite := &JoinOrganizationInvitation{Status: RequestPending, Created: db.DateTime(created)}
+	assert.Equal(t, created.Add(InvitationExpiration), invite.ExpirationTime())
+	assert.False(t, invite.IsExpiredAt(created.Add(InvitationExpiration)))
+	assert.True(t, invite.IsExpiredAt(created.Add(InvitationExpiration).Add(time.Second)))
+
+	invite.Status = RequestAccepted
+	assert.False(t, invite.IsExpiredAt(created.Add(2*InvitationExpiration)))
+
+	resent := created.Add(2 * InvitationExpiration)
+	invite.Status = RequestExpired
+	invite.Renew(resent)
+	assert.Equal(t, RequestPending, invite.Status)
+	assert.Equal(t, resent.Add(InvitationExpiration), invite.ExpirationTime())
+	assert.False(t, invite.IsExpiredAt(resent.Add(time.Hour)))
+}
+
+func TestMarkExpired(t *testing.T) {
+	now := time.Now()
+	invites := []JoinOrganizationInvitation{
+		{Status: RequestPending, Expires: db.DateTime(now.Add(-time.Minute))},
+		{Status: RequestPending, Expires: db.DateTime(now.Add(time.Minute))},
+		{Status: RequestRejected, Expires: db.DateTime(now.Add(-time.Minute))},
+	}
+	markExpired(invites, now)
+	assert.Equal(t, RequestExpired, invites[0].Status)
+	assert.Equal(t, RequestPending, invites[1].Status)
+	assert.Equal(t, RequestRejected, invites[2].Status)
+}
+
+func TestCanBeResentAt(t *testing.T) {
+	created := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	invite := &JoinOrganizationInvitation{Status: RequestPending, Created: db.DateTime(created)}
+	assert.False(t, invite.CanBeResentAt(created.Add(time.Minute)))
+	assert.True(t, invite.CanBeResentAt(created.Add(InvitationResendInterval)))
+
+	resent := created.Add(time.Hour)
+	invite.Renew(resent)
+	assert.False(t, invite.CanBeResentAt(resent.Add(InvitationResendInterval-time.Second)))
+	assert.True(t, invite.CanBeResentAt(resent.Add(InvitationResendInterval)))
+}
+
+func TestHasPendingInvitation(t *testing.T) {
+	now := time.Now()
+	assert.False(t, hasPendingInvitation(nil, now))
+	assert.False(t, hasPendingInvitation([]JoinOrganizationInvitation{
+		{Status: RequestPending, Expires: db.DateTime(now.Add(-time.Minute))},
+		{Status: RequestRejected, Expires: db.DateTime(now.Add(time.Minute))},
+		{Status: RequestAccepted, Expires: db.DateTime(now.Add(time.Minute))},
+	}, now))
+	assert.True(t, hasPendingInvitation([]JoinOrganizationInvitation{
+		{Status: RequestPending, Expires: db.DateTime(now.Add(-time.Minute))},
+		{Status: RequestPending, Expires: db.DateTime(now.Add(time.Minute))},
+	}, now))
+}
+
+func TestRejectedSince(t *testing.T) {
+	now := time.Now()
+	recent := db.DateTime(now.Add(-time.Hour))
+	old := db.DateTime(now.Add(-JoinRequestRetryInterval - time.Hour))
+	since := now.Add(-JoinRequestRetryInterval)
+	assert.False(t, rejectedSince(nil, since))
+	assert.False(t, rejectedSince([]JoinOrganizationInvitation{
+		{Status: RequestRejected, Answered: &old},
+		{Status: RequestRejected},
+		{Status: RequestAccepted, Answered: &recent},
+	}, since))
+	assert.True(t, rejectedSince([]JoinOrganizationInvitation{
+		{Status: RequestRejected, Answered: &old},
+		{Status: RequestRejected, Answered: &recent},
+	}, since))
+}
+
+func TestNormalizeEmailAddress(t *testing.T) {
+	assert.Equal(t, "john.doe@example.com", normalizeEmailAddress(" John.Doe@Example.COM"))
+	assert.Equal(t, normalizeEmailAddress("john.doe@example.com"), normalizeEmailAddress("JOHN.DOE@EXAMPLE.COM"))
+	assert.Equal(t, []bson.M{{"user": "John@Example.com"}, {"emailaddress": "john@example.com"}, {"phonenumber": "John@Example.com"}}, inviteeQuery("John@Example.com"))
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestWithinContactInvitationLimits(t *testing.T) {
+	assert.True(t, withinContactInvitationLimits(1, 0))
+	assert.True(t, withinContactInvitationLimits(MaxContactInvitationsPerOrganization, MaxContactInvitationsPerInviter))
+	assert.False(t, withinContactInvitationLimits(MaxContactInvitationsPerOrganization+1, 1))
+	assert.False(t, withinContactInvitationLimits(1, MaxContactInvitationsPerInviter+1))
+}
@@ -1,7 +1,10 @@
 package invitations
 
 import (
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -11,12 +14,34 @@ import (
 
 const (
 	mongoOrganizationRequestCollectionName = "join-organization-invitations"
+	mongoSentInvitationCollectionName      = "sent-invitations"
 )
 
+//ErrTooManyInvitationsSent denotes that sending more invitations by email or sms exceeds the limits of the ContactInvitationWindow
+var ErrTooManyInvitationsSent = errors.New("Too many invitations sent")
+
+//sentInvitation records an invitation sent by email or sms to limit how many are sent
+type sentInvitation struct {
+	Organization string
+	Inviter      string
+	Sent         time.Time
+}
+
+//InitModels initializes models in mongo, if required.
+func InitModels() {
+	automaticExpiration := mgo.Index{
+		Key:         []string{"sent"},
+		ExpireAfter: ContactInvitationWindow,
+		Background:  true,
+	}
+	db.EnsureIndex(mongoSentInvitationCollectionName, automaticExpiration)
+}
+
 //InvitationManager is used to store organizations
 type InvitationManager struct {
-	session    *mgo.Session
-	collection *mgo.Collection
+	session        *mgo.Session
+	collection     *mgo.Collection
+	sentCollection *mgo.Collection
 }
 
 func getOrganizationRequestCollection(session *mgo.Session) *mgo.Collection {
@@ -27,8 +52,9 @@ func getOrganizationRequestCollection(session *mgo.Session) *mgo.Collection {
 func NewInvitationManager(r *http.Request) *InvitationManager {
 	session := db.GetDBSession(r)
 	return &InvitationManager{
-		session:    session,
-		collection: getOrganizationRequestCollection(session),
+		session:        session,
+		collection:     getOrganizationRequestCollection(session),
+		sentCollection: db.GetCollection(session, mongoSentInvitationCollectionName),
 	}
 }
 
@@ -37,19 +63,58 @@ func (o *InvitationManager) GetByUser(username string) ([]JoinOrganizationInvita
 	orgRequests := []JoinOrganizationInvitation{}
 
 	err := o.collection.Find(bson.M{"user": username}).All(&orgRequests)
+	markExpired(orgRequests, time.Now())
 
 	return orgRequests, err
 }
 
 // GetPendingByOrganization gets all pending invitations for a user.
+// The ones that expired are included with the RequestExpired status so they can be resent.
 func (o *InvitationManager) GetPendingByOrganization(globalid string) ([]JoinOrganizationInvitation, error) {
 	orgRequests := []JoinOrganizationInvitation{}
 
 	err := o.collection.Find(bson.M{"organization": globalid, "status": RequestPending}).All(&orgRequests)
+	markExpired(orgRequests, time.Now())
 
 	return orgRequests, err
 }
 
+// GetPendingByInvitee gets the pending invitations of an organization for a username, email address or phone number,
+// expired ones included
+func (o *InvitationManager) GetPendingByInvitee(globalid string, invitee string) ([]JoinOrganizationInvitation, error) {
+	invites := []JoinOrganizationInvitation{}
+
+	err := o.collection.Find(bson.M{"organization": globalid, "status": RequestPending, "$or": inviteeQuery(invitee)}).All(&invites)
+	markExpired(invites, time.Now())
+
+	return invites, err
+}
+
+// RemovePendingByInvitee removes the pending invitations of an organization for a username, email address or phone number
+func (o *InvitationManager) RemovePendingByInvitee(globalid string, invitee string) (removed int, err error) {
+	info, err := o.collection.RemoveAll(bson.M{"organization": globalid, "status": RequestPending, "$or": inviteeQuery(invitee)})
+	if err != nil {
+		return
+	}
+	removed = info.Removed
+	return
+}
+
+func inviteeQuery(invitee string) []bson.M {
+	return []bson.M{{"user": invitee}, {"emailaddress": normalizeEmailAddress(invitee)}, {"phonenumber": invitee}}
+}
+
+//normalizeEmailAddress lowercases an email address, invitations are stored and looked up with the lowercase address
+// so the case the inviter typed it in does not have to match the one the invitee validates
+func normalizeEmailAddress(emailaddress string) string {
+	return strings.ToLower(strings.TrimSpace(emailaddress))
+}
+
+// UpdateExpiration saves the status, expiration date and send time of an invitation after it is renewed
+func (o *InvitationManager) UpdateExpiration(invite *JoinOrganizationInvitation) error {
+	return o.collection.UpdateId(invite.ID, bson.M{"$set": bson.M{"status": invite.Status, "expires": invite.Expires, "sent": invite.Sent}})
+}
+
 //Get get an invitation by it's content, not really this usefull, TODO: just make an exists method
 func (o *InvitationManager) Get(username string, organization string, role string, status InvitationStatus) (*JoinOrganizationInvitation, error) {
 	var orgRequest JoinOrganizationInvitation
@@ -66,9 +131,9 @@ func (o *InvitationManager) Get(username string, organization string, role strin
 	return &orgRequest, err
 }
 
-// Save save/update an invitation
+// Save save/update an invitation, the expiration date is set if it has none yet
 func (o *InvitationManager) Save(invite *JoinOrganizationInvitation) error {
-
+	invite.Expires = db.DateTime(invite.ExpirationTime())
 	_, err := o.collection.Upsert(
 		bson.M{
 			"user":         invite.User,
@@ -79,16 +144,85 @@ func (o *InvitationManager) Save(invite *JoinOrganizationInvitation) error {
 	return err
 }
 
+// SaveEmailInvitation save/update an invitation for an email address
+func (o *InvitationManager) SaveEmailInvitation(invite *JoinOrganizationInvitation) error {
+	invite.EmailAddress = normalizeEmailAddress(invite.EmailAddress)
+	invite.Expires = db.DateTime(invite.ExpirationTime())
+	_, err := o.collection.Upsert(
+		bson.M{
+			"emailaddress": invite.EmailAddress,
+			"organization": invite.Organization,
+			"role":         invite.Role,
+		}, invite)
+
+	return err
+}
+
+// SavePhonenumberInvitation save/update an invitation for a phone number
+func (o *InvitationManager) SavePhonenumberInvitation(invite *JoinOrganizationInvitation) error {
+	invite.Expires = db.DateTime(invite.ExpirationTime())
+	_, err := o.collection.Upsert(
+		bson.M{
+			"phonenumber":  invite.PhoneNumber,
+			"organization": invite.Organization,
+			"role":         invite.Role,
+		}, invite)
+
+	return err
+}
+
+// AttachEmailInvitations turns the pending invitations for an email address into invitations for the user that validated it
+func (o *InvitationManager) AttachEmailInvitations(emailaddress string, username string) error {
+	_, err := o.collection.UpdateAll(
+		bson.M{"emailaddress": normalizeEmailAddress(emailaddress), "status": RequestPending},
+		bson.M{"$set": bson.M{"user": username}, "$unset": bson.M{"emailaddress": ""}})
+	return err
+}
+
+// AttachPhonenumberInvitations turns the pending invitations for a phone number into invitations for the user that validated it
+func (o *InvitationManager) AttachPhonenumberInvitations(phonenumber string, username string) error {
+	_, err := o.collection.UpdateAll(
+		bson.M{"phonenumber": phonenumber, "status": RequestPending},
+		bson.M{"$set": bson.M{"user": username}, "$unset": bson.M{"phonenumber": ""}})
+	return err
+}
+
+// GetPendingEmailInvitations gets the pending invitations of an organization for email addresses
+func (o *InvitationManager) GetPendingEmailInvitations(globalid string) ([]JoinOrganizationInvitation, error) {
+	invites := []JoinOrganizationInvitation{}
+
+	err := o.collection.Find(bson.M{"organization": globalid, "status": RequestPending, "emailaddress": bson.M{"$exists": true}}).All(&invites)
+
+	return invites, err
+}
+
+// RemoveEmailInvitations removes the invitations of an organization for the email addresses
+func (o *InvitationManager) RemoveEmailInvitations(globalid string, emailaddresses []string) error {
+	normalized := make([]string, len(emailaddresses))
+	for i, emailaddress := range emailaddresses {
+		normalized[i] = normalizeEmailAddress(emailaddress)
+	}
+	_, err := o.collection.RemoveAll(bson.M{"organization": globalid, "emailaddress": bson.M{"$in": normalized}})
+	return err
+}
+
 // RemoveAll Removes all invitations linked to an organization
 func (o *InvitationManager) RemoveAll(globalid string) error {
 	_, err := o.collection.RemoveAll(bson.M{"organization": globalid})
 	return err
 }
 
-// HasInvite Checks if a user has an invite for an organization
+// RenameOrganization moves the invitations of an organization to its new globalid
+func (o *InvitationManager) RenameOrganization(oldGlobalID string, newGlobalID string) error {
+	_, err := o.collection.UpdateAll(bson.M{"organization": oldGlobalID}, bson.M{"$set": bson.M{"organization": newGlobalID}})
+	return err
+}
+
+// HasInvite Checks if a user has a pending invite for an organization that did not expire
 func (o *InvitationManager) HasInvite(globalid string, username string) (hasInvite bool, err error) {
-	count, err := o.collection.Find(bson.M{"organization": globalid, "user": username}).Count()
-	return count != 0, err
+	invites := []JoinOrganizationInvitation{}
+	err = o.collection.Find(bson.M{"organization": globalid, "user": username, "status": RequestPending}).All(&invites)
+	return hasPendingInvitation(invites, time.Now()), err
 }
 
 // CountByOrganization Counts the amount of invitations, filtered by an organization
@@ -96,3 +230,29 @@ func (o *InvitationManager) CountByOrganization(globalid string) (int, error) {
 	count, err := o.collection.Find(bson.M{"organization": globalid}).Count()
 	return count, err
 }
+
+//ReserveContactInvitations records that a number of invitations of an organization are about to be sent by email or sms.
+// ErrTooManyInvitationsSent is returned, and nothing is recorded, if that exceeds the limits for the organization or the inviter.
+func (o *InvitationManager) ReserveContactInvitations(globalid string, inviter string, count int, now time.Time) (err error) {
+	since := bson.M{"$gt": now.Add(-ContactInvitationWindow)}
+	byOrganization, err := o.sentCollection.Find(bson.M{"organization": globalid, "sent": since}).Count()
+	if err != nil {
+		return
+	}
+	byInviter := 0
+	if inviter != "" {
+		if byInviter, err = o.sentCollection.Find(bson.M{"inviter": inviter, "sent": since}).Count(); err != nil {
+			return
+		}
+		byInviter += count
+	}
+	if !withinContactInvitationLimits(byOrganization+count, byInviter) {
+		return ErrTooManyInvitationsSent
+	}
+	for i := 0; i < count; i++ {
+		if err = o.sentCollection.Insert(&sentInvitation{Organization: globalid, Inviter: inviter, Sent: now}); err != nil {
+			return
+		}
+	}
+	return
+}
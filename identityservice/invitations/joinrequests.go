@@ -0,0 +1,109 @@
+package invitations
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+)
+
+const (
+	mongoJoinRequestCollectionName = "join-organization-requests"
+)
+
+//JoinRequestRetryInterval is how long a user has to wait after a rejected request before asking to join the organization again
+const JoinRequestRetryInterval = 7 * 24 * time.Hour
+
+//JoinRequestManager stores the requests of users to join an organization.
+// A request is a JoinOrganizationInvitation in the other direction: the User asks and the owners of the Organization answer.
+type JoinRequestManager struct {
+	session    *mgo.Session
+	collection *mgo.Collection
+}
+
+//NewJoinRequestManager creates and initializes a new JoinRequestManager
+func NewJoinRequestManager(r *http.Request) *JoinRequestManager {
+	session := db.GetDBSession(r)
+	return &JoinRequestManager{
+		session:    session,
+		collection: db.GetCollection(session, mongoJoinRequestCollectionName),
+	}
+}
+
+// Get gets the request of a user to join an organization with a specific status
+func (o *JoinRequestManager) Get(username string, globalid string, status InvitationStatus) (*JoinOrganizationInvitation, error) {
+	var request JoinOrganizationInvitation
+
+	err := o.collection.Find(bson.M{"user": username, "organization": globalid, "status": status}).One(&request)
+
+	return &request, err
+}
+
+// GetPendingByOrganization gets the pending requests to join an organization
+func (o *JoinRequestManager) GetPendingByOrganization(globalid string) ([]JoinOrganizationInvitation, error) {
+	return o.GetPendingByOrganizations([]string{globalid})
+}
+
+// GetPendingByOrganizations gets the pending requests to join any of the organizations
+func (o *JoinRequestManager) GetPendingByOrganizations(globalids []string) ([]JoinOrganizationInvitation, error) {
+	requests := []JoinOrganizationInvitation{}
+
+	err := o.collection.Find(bson.M{"organization": bson.M{"$in": globalids}, "status": RequestPending}).All(&requests)
+
+	return requests, err
+}
+
+// Save save/update the request of a user to join an organization.
+// A new request replaces the pending one, answered requests are kept so a rejection is not overwritten.
+func (o *JoinRequestManager) Save(request *JoinOrganizationInvitation) error {
+	if request.ID != "" {
+		return o.collection.UpdateId(request.ID, request)
+	}
+	_, err := o.collection.Upsert(
+		bson.M{
+			"user":         request.User,
+			"organization": request.Organization,
+			"status":       RequestPending,
+		}, request)
+
+	return err
+}
+
+// RecentlyRejected checks if the owners rejected a request of the user to join the organization less than JoinRequestRetryInterval ago
+func (o *JoinRequestManager) RecentlyRejected(username string, globalid string) (bool, error) {
+	requests := []JoinOrganizationInvitation{}
+
+	err := o.collection.Find(bson.M{"user": username, "organization": globalid, "status": RequestRejected}).All(&requests)
+
+	return rejectedSince(requests, time.Now().Add(-JoinRequestRetryInterval)), err
+}
+
+//rejectedSince checks if one of the requests was rejected after a specific time
+func rejectedSince(requests []JoinOrganizationInvitation, since time.Time) bool {
+	for _, request := range requests {
+		if request.Status == RequestRejected && request.Answered != nil && time.Time(*request.Answered).After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemovePending removes the pending request of a user to join an organization
+func (o *JoinRequestManager) RemovePending(username string, globalid string) error {
+	return o.collection.Remove(bson.M{"user": username, "organization": globalid, "status": RequestPending})
+}
+
+// RemoveAll removes all requests to join an organization
+func (o *JoinRequestManager) RemoveAll(globalid string) error {
+	_, err := o.collection.RemoveAll(bson.M{"organization": globalid})
+	return err
+}
+
+// RenameOrganization moves the requests to join an organization to its new globalid
+func (o *JoinRequestManager) RenameOrganization(oldGlobalID string, newGlobalID string) error {
+	_, err := o.collection.UpdateAll(bson.M{"organization": oldGlobalID}, bson.M{"$set": bson.M{"organization": newGlobalID}})
+	return err
+}
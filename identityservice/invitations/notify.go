@@ -0,0 +1,118 @@
+package invitations
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/communication"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/messages"
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+//webhookTimeout limits how long the webhook of an organization gets to accept a notification
+const webhookTimeout = 10 * time.Second
+
+//webhookTransport only connects to public addresses, a webhook host that resolves to an internal one is refused
+var webhookTransport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout: webhookTimeout,
+		Control: tools.RefuseInternalAddress,
+	}).DialContext,
+	TLSHandshakeTimeout: webhookTimeout,
+}
+
+//SignatureHeader is the header of a webhook notification with the hex encoded HMAC-SHA256 of the body
+const SignatureHeader = "X-Iyo-Signature"
+
+//Notification is posted to the invitation webhook of an organization
+type Notification struct {
+	//Event is invitation.accepted or invitation.rejected
+	Event      string                      `json:"event"`
+	Invitation *JoinOrganizationInvitation `json:"invitation"`
+}
+
+//NotifyOrganization tells an organization that an invitation was accepted or rejected.
+// The notification is posted to the invitation webhook of the organization, if it has none its owners get an email.
+// Failures are logged, the invitee already answered.
+func NotifyOrganization(r *http.Request, emailService communication.EmailService, invite *JoinOrganizationInvitation) {
+	org, err := organization.NewManager(r).GetByName(invite.Organization)
+	if err != nil {
+		log.Error("Error getting organization ", invite.Organization, " to notify: ", err)
+		return
+	}
+	if org.InvitationWebhook != nil {
+		notification := &Notification{Event: "invitation." + string(invite.Status), Invitation: invite}
+		go func() {
+			if err := postNotification(org.InvitationWebhook, notification); err != nil {
+				log.Error("Error notifying organization ", invite.Organization, ": ", err)
+			}
+		}()
+		return
+	}
+
+	name := messages.InvitationAccepted
+	if invite.Status == RequestRejected {
+		name = messages.InvitationRejected
+	}
+	valMgr := validation.NewManager(r)
+	for _, owner := range org.Owners {
+		emails, err := valMgr.GetByUsernameValidatedEmailAddress(owner)
+		if err != nil || len(emails) == 0 {
+			continue
+		}
+		data := &messages.Data{Username: owner, Invitee: invite.User, URL: fmt.Sprintf("https://%s/#/organization/%s", r.Host, invite.Organization)}
+		message, err := messages.NewContext(r, owner, invite.Organization).RenderEmail(name, data)
+		if err != nil {
+			log.Error("Error rendering the notification for owner ", owner, " of ", invite.Organization, ": ", err)
+			continue
+		}
+		message.Recipients = []string{emails[0].EmailAddress}
+		if err = emailService.SendMessage(message); err != nil {
+			log.Error("Error notifying owner ", owner, " of ", invite.Organization, ": ", err)
+		}
+	}
+}
+
+func postNotification(webhook *organization.InvitationWebhook, notification *Notification) (err error) {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest("POST", webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+	client := &http.Client{Timeout: webhookTimeout, Transport: webhookTransport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err = errors.New("Webhook responded with " + resp.Status)
+	}
+	return
+}
+
+//Sign returns the hex encoded HMAC-SHA256 of a notification body with the secret of the webhook
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -3,4 +3,6 @@ package organization
 type OrganizationTreeItem struct {
 	Children []*OrganizationTreeItem `json:"children"`
 	GlobalID string                  `json:"globalid"`
+	//OrgMembers are the organizations that are members of this organization
+	OrgMembers []string `json:"orgmembers"`
 }
@@ -0,0 +1,77 @@
+package organization
+
+import (
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/oauthservice"
+)
+
+//DeletionGracePeriod is how long a soft deleted organization can be restored before its data is purged
+var DeletionGracePeriod = 30 * 24 * time.Hour
+
+//deletion describes the organizations a delete removes
+type deletion struct {
+	Organizations []string `json:"organizations"`
+	//Purge is when the data of soft deleted organizations is removed, until then they can be restored
+	Purge *db.DateTime `json:"purge,omitempty"`
+}
+
+//disableOrganizations revokes the access tokens of deleted organizations and disables their clients
+func disableOrganizations(r *http.Request, globalIDs []string, disabled bool) error {
+	oauthMgr := oauthservice.NewManager(r)
+	for _, globalID := range globalIDs {
+		if err := oauthMgr.DisableClients(globalID, disabled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//purgeOrganizations removes deleted organizations and everything that refers to them.
+// The references are removed in the opposite order they are listed in so the organizations themselves go last,
+// the organizations a purge failed for are still marked deleted and picked up again by RunPurge.
+func purgeOrganizations(r *http.Request, globalIDs []string) error {
+	references := globalIDReferences(r)
+	for i := len(references) - 1; i >= 0; i-- {
+		for _, globalID := range globalIDs {
+			if err := references[i].remove(globalID); err != nil {
+				log.Error("Error removing the ", references[i].name, " of organization ", globalID, ": ", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//RunPurge removes the deleted organizations whose grace period ended, every interval
+func RunPurge(interval time.Duration) {
+	for range time.Tick(interval) {
+		purgeDeleted()
+	}
+}
+
+func purgeDeleted() {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		log.Warn("Purging deleted organizations skipped, no database connection")
+		return
+	}
+	globalIDs, err := organization.NewManager(r).GetPurgeable(time.Now())
+	if err != nil {
+		log.Error("Failed to get the deleted organizations to purge: ", err)
+		return
+	}
+	if len(globalIDs) == 0 {
+		return
+	}
+	if err = purgeOrganizations(r, globalIDs); err != nil {
+		return
+	}
+	log.Infof("Purged %d deleted organizations", len(globalIDs))
+}
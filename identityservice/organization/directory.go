@@ -0,0 +1,212 @@
+package organization
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/user"
+)
+
+const (
+	defaultDirectoryPageSize = 50
+	maxDirectoryPageSize     = 1000
+)
+
+var errInvalidDirectoryQuery = errors.New("Invalid member directory query")
+
+//DirectoryMember is an owner or member of an organization with the profile it authorized the organization to see.
+// Role is owner or member, Roles are the custom roles of the organization the user has.
+type DirectoryMember struct {
+	Username       string                     `json:"username"`
+	Role           string                     `json:"role"`
+	Roles          []string                   `json:"roles,omitempty"`
+	Firstname      string                     `json:"firstname,omitempty"`
+	Lastname       string                     `json:"lastname,omitempty"`
+	EmailAddresses []user.EmailAddress        `json:"emailaddresses,omitempty"`
+	Phonenumbers   []user.Phonenumber         `json:"phonenumbers,omitempty"`
+	Addresses      []user.Address             `json:"addresses,omitempty"`
+	BankAccounts   []user.BankAccount         `json:"bankaccounts,omitempty"`
+	DigitalWallet  []user.DigitalAssetAddress `json:"digitalwallet,omitempty"`
+	Github         *user.GithubAccount        `json:"github,omitempty"`
+	Facebook       *user.FacebookAccount      `json:"facebook,omitempty"`
+}
+
+//Directory is a page of the members of an organization, Total is the number of members that match the query
+type Directory struct {
+	Total   int               `json:"total"`
+	Start   int               `json:"start"`
+	Members []DirectoryMember `json:"members"`
+}
+
+//directoryQuery selects, orders and pages the members of an organization
+type directoryQuery struct {
+	Role       string
+	Search     string
+	Sort       string
+	Descending bool
+	Start      int
+	Max        int
+}
+
+//parseDirectoryQuery reads the role, search, sort, start and max query parameters.
+// sort is username, firstname, lastname or role, prefixed with a - for descending order.
+func parseDirectoryQuery(values url.Values) (query *directoryQuery, err error) {
+	query = &directoryQuery{
+		Role:   values.Get("role"),
+		Search: strings.ToLower(strings.TrimSpace(values.Get("search"))),
+		Sort:   values.Get("sort"),
+		Max:    defaultDirectoryPageSize,
+	}
+	if strings.HasPrefix(query.Sort, "-") {
+		query.Sort, query.Descending = query.Sort[1:], true
+	}
+	switch query.Sort {
+	case "":
+		query.Sort = "username"
+	case "username", "firstname", "lastname", "role":
+	default:
+		return nil, errInvalidDirectoryQuery
+	}
+	if start := values.Get("start"); start != "" {
+		if query.Start, err = strconv.Atoi(start); err != nil || query.Start < 0 {
+			return nil, errInvalidDirectoryQuery
+		}
+	}
+	if max := values.Get("max"); max != "" {
+		if query.Max, err = strconv.Atoi(max); err != nil || query.Max < 1 {
+			return nil, errInvalidDirectoryQuery
+		}
+	}
+	if query.Max > maxDirectoryPageSize {
+		query.Max = maxDirectoryPageSize
+	}
+	return
+}
+
+//needsNames checks if the members need their names to be selected and ordered
+func (query *directoryQuery) needsNames() bool {
+	return query.Search != "" || query.Sort == "firstname" || query.Sort == "lastname"
+}
+
+//directoryMembers lists the owners and members of an organization, a user in both lists is only listed as owner
+func directoryMembers(org *organization.Organization) (members []DirectoryMember) {
+	members = []DirectoryMember{}
+	listed := make(map[string]bool)
+	add := func(username string, role string) {
+		if listed[username] {
+			return
+		}
+		listed[username] = true
+		member := DirectoryMember{Username: username, Role: role}
+		for _, assignment := range org.RoleAssignments {
+			if assignment.Username == username {
+				member.Roles = append(member.Roles, assignment.Role)
+			}
+		}
+		members = append(members, member)
+	}
+	for _, username := range org.Owners {
+		add(username, organization.RoleOwner)
+	}
+	for _, username := range org.Members {
+		add(username, organization.RoleMember)
+	}
+	return
+}
+
+//apply selects the members with the role that match the search, orders them and returns the requested page.
+// The search matches the username and the names of the members that authorized the organization to see them.
+func (query *directoryQuery) apply(members []DirectoryMember) (page []DirectoryMember, total int) {
+	selected := []DirectoryMember{}
+	for _, member := range members {
+		if query.Role != "" && member.Role != query.Role && !contains(member.Roles, query.Role) {
+			continue
+		}
+		if query.Search != "" && !member.matches(query.Search) {
+			continue
+		}
+		selected = append(selected, member)
+	}
+	sort.Stable(&directorySorter{members: selected, field: query.Sort, descending: query.Descending})
+	total = len(selected)
+	if query.Start >= total {
+		return []DirectoryMember{}, total
+	}
+	end := query.Start + query.Max
+	if end > total {
+		end = total
+	}
+	return selected[query.Start:end], total
+}
+
+func (member *DirectoryMember) matches(search string) bool {
+	name := strings.ToLower(strings.TrimSpace(member.Firstname + " " + member.Lastname))
+	return strings.Contains(strings.ToLower(member.Username), search) || name != "" && strings.Contains(name, search)
+}
+
+//project adds the profile the member authorized the organization to see
+func (member *DirectoryMember) project(filtered *user.User) {
+	member.Firstname, member.Lastname = filtered.Firstname, filtered.Lastname
+	member.EmailAddresses = filtered.EmailAddresses
+	member.Phonenumbers = filtered.Phonenumbers
+	member.Addresses = filtered.Addresses
+	member.BankAccounts = filtered.BankAccounts
+	member.DigitalWallet = filtered.DigitalWallet
+	if filtered.Github.Login != "" {
+		member.Github = &filtered.Github
+	}
+	if filtered.Facebook.Id != "" {
+		member.Facebook = &filtered.Facebook
+	}
+}
+
+//directorySorter orders members on a field, members without a value for it come last, ties are ordered by username
+type directorySorter struct {
+	members    []DirectoryMember
+	field      string
+	descending bool
+}
+
+func (s *directorySorter) Len() int { return len(s.members) }
+
+func (s *directorySorter) Swap(i, j int) { s.members[i], s.members[j] = s.members[j], s.members[i] }
+
+func (s *directorySorter) Less(i, j int) bool {
+	a, b := s.value(&s.members[i]), s.value(&s.members[j])
+	if a == b {
+		return s.members[i].Username < s.members[j].Username
+	}
+	if a == "" || b == "" {
+		return b == ""
+	}
+	return (a < b) != s.descending
+}
+
+func (s *directorySorter) value(member *DirectoryMember) string {
+	switch s.field {
+	case "firstname":
+		return strings.ToLower(member.Firstname)
+	case "lastname":
+		return strings.ToLower(member.Lastname)
+	case "role":
+		//Owners before members
+		if member.Role == organization.RoleOwner {
+			return "0"
+		}
+		return "1"
+	}
+	return member.Username
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package organization
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDirectoryQuery(t *testing.T) {
+	query, err := parseDirectoryQuery(url.Values{})
+	assert.NoError(t, err)
+	assert.Equal(t, &directoryQuery{Sort: "username", Max: defaultDirectoryPageSize}, query)
+
+	query, err = parseDirectoryQuery(url.Values{"sort": {"-lastname"}, "search": {" Smith "}, "start": {"10"}, "max": {"5000"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &directoryQuery{Search: "smith", Sort: "lastname", Descending: true, Start: 10, Max: maxDirectoryPageSize}, query)
+	assert.True(t, query.needsNames())
+
+	for _, values := range []url.Values{
+		{"sort": {"email"}},
+		{"start": {"-1"}},
+		{"start": {"x"}},
+		{"max": {"0"}},
+	} {
+		_, err = parseDirectoryQuery(values)
+		assert.Equal(t, errInvalidDirectoryQuery, err, "%v", values)
+	}
+}
+
+func TestDirectoryMembers(t *testing.T) {
+	org := &organization.Organization{
+		Owners:          []string{"bob"},
+		Members:         []string{"alice", "bob"},
+		RoleAssignments: []organization.RoleAssignment{{Username: "alice", Role: "support"}},
+	}
+	members := directoryMembers(org)
+	assert.Equal(t, []DirectoryMember{
+		{Username: "bob", Role: organization.RoleOwner},
+		{Username: "alice", Role: organization.RoleMember, Roles: []string{"support"}},
+	}, members)
+}
+
+func TestApplyDirectoryQuery(t *testing.T) {
+	members := []DirectoryMember{
+		{Username: "dave", Role: organization.RoleOwner},
+		{Username: "carol", Role: organization.RoleMember, Firstname: "Carol", Lastname: "Zimmer"},
+		{Username: "bob", Role: organization.RoleMember, Roles: []string{"support"}},
+		{Username: "alice", Role: organization.RoleMember, Firstname: "Alice", Lastname: "Adams"},
+	}
+	usernames := func(page []DirectoryMember) (names []string) {
+		names = []string{}
+		for _, member := range page {
+			names = append(names, member.Username)
+		}
+		return
+	}
+
+	page, total := (&directoryQuery{Sort: "username", Max: 2}).apply(members)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"alice", "bob"}, usernames(page))
+
+	page, total = (&directoryQuery{Sort: "username", Start: 3, Max: 2}).apply(members)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"dave"}, usernames(page))
+
+	page, _ = (&directoryQuery{Sort: "username", Start: 4, Max: 2}).apply(members)
+	assert.Empty(t, page)
+
+	page, _ = (&directoryQuery{Sort: "lastname", Descending: true, Max: 50}).apply(members)
+	assert.Equal(t, []string{"carol", "alice", "bob", "dave"}, usernames(page))
+
+	page, _ = (&directoryQuery{Sort: "role", Max: 50}).apply(members)
+	assert.Equal(t, []string{"dave", "alice", "bob", "carol"}, usernames(page))
+
+	page, total = (&directoryQuery{Role: "support", Sort: "username", Max: 50}).apply(members)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []string{"bob"}, usernames(page))
+
+	page, _ = (&directoryQuery{Search: "zim", Sort: "username", Max: 50}).apply(members)
+	assert.Equal(t, []string{"carol"}, usernames(page))
+
+	page, _ = (&directoryQuery{Search: "a", Role: organization.RoleOwner, Sort: "username", Max: 50}).apply(members)
+	assert.Equal(t, []string{"dave"}, usernames(page))
+}
@@ -2,6 +2,8 @@ package organization
 
 type searchMember struct {
 	SearchString string `json:"searchstring"`
+	//Message is an optional note of the inviter
+	Message string `json:"message"`
 }
 
 type Membership struct {
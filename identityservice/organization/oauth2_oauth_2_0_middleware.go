@@ -24,6 +24,17 @@ func newOauth2oauth_2_0Middleware(scopes []string) *Oauth2oauth_2_0Middleware {
 	return &om
 }
 
+//newPermissionMiddleware creates an Oauth2oauth_2_0Middleware that lets the owners through
+// and the members that have a role granting the permission
+func newPermissionMiddleware(permission string) *Oauth2oauth_2_0Middleware {
+	return newOauth2oauth_2_0Middleware([]string{"organization:owner", permissionScope(permission)})
+}
+
+//permissionScope is the scope a member gets for a permission granted by one of its roles
+func permissionScope(permission string) string {
+	return "organization:" + permission
+}
+
 // CheckScopes checks whether user has needed scopes
 func (om *Oauth2oauth_2_0Middleware) CheckScopes(scopes []string) bool {
 	if len(om.Scopes) == 0 {
@@ -84,6 +95,8 @@ func (om *Oauth2oauth_2_0Middleware) Handler(next http.Handler) http.Handler {
 		}
 
 		context.Set(r, "authenticateduser", username)
+		context.Set(r, "client_id", clientID)
+		context.Set(r, "availablescopes", atscopestring)
 		if globalID == protectedOrganization {
 			scopes = []string{atscopestring}
 		} else {
@@ -106,11 +119,20 @@ func (om *Oauth2oauth_2_0Middleware) Handler(next http.Handler) http.Handler {
 				}
 				if isMember && clientID == "itsyouonline" && atscopestring == "admin" {
 					scopes = []string{"organization:member"}
+					permissions, err := orgMgr.GetPermissions(protectedOrganization, username)
+					if err != nil {
+						log.Error(err)
+						http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+						return
+					}
+					for _, permission := range permissions {
+						scopes = append(scopes, permissionScope(permission))
+					}
 				}
 			}
 		}
 
-		//TODO: scopes "organization:info", "organization:contracts:read"
+		//TODO: scope "organization:info"
 
 		log.Debug("Available scopes: ", scopes)
 
@@ -2,7 +2,10 @@ package organization
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/mail"
+	"net/url"
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
@@ -12,16 +15,24 @@ import (
 
 	"time"
 
+	"github.com/go-ldap/ldap/v3"
 	"github.com/gorilla/context"
+	"github.com/itsyouonline/identityserver/communication"
 	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/domainverification"
 	contractdb "github.com/itsyouonline/identityserver/db/contract"
 	"github.com/itsyouonline/identityserver/db/organization"
 	"github.com/itsyouonline/identityserver/db/registry"
+	samldb "github.com/itsyouonline/identityserver/db/saml"
 	"github.com/itsyouonline/identityserver/db/user"
 	validationdb "github.com/itsyouonline/identityserver/db/validation"
 	"github.com/itsyouonline/identityserver/identityservice/contract"
 	"github.com/itsyouonline/identityserver/identityservice/invitations"
+	"github.com/itsyouonline/identityserver/ldapsync"
+	"github.com/itsyouonline/identityserver/messages"
 	"github.com/itsyouonline/identityserver/oauthservice"
+	"github.com/itsyouonline/identityserver/phonenumbers"
+	"github.com/itsyouonline/identityserver/saml"
 	"gopkg.in/mgo.v2"
 )
 
@@ -33,6 +44,9 @@ const (
 
 // OrganizationsAPI is the implementation for /organizations root endpoint
 type OrganizationsAPI struct {
+	SmsService     communication.SMSService
+	EmailService   communication.EmailService
+	DomainResolver domainverification.Resolver
 }
 
 // byGlobalID implements sort.Interface for []Organization based on
@@ -83,7 +97,10 @@ func (api OrganizationsAPI) GetOrganizationTree(w http.ResponseWriter, r *http.R
 	var orgTree *OrganizationTreeItem
 	orgTreeIndex := make(map[string]*OrganizationTreeItem)
 	for _, org := range allOrganizations {
-		newTreeItem := &OrganizationTreeItem{GlobalID: org.Globalid, Children: make([]*OrganizationTreeItem, 0, 0)}
+		newTreeItem := &OrganizationTreeItem{GlobalID: org.Globalid, Children: make([]*OrganizationTreeItem, 0, 0), OrgMembers: org.OrgMembers}
+		if newTreeItem.OrgMembers == nil {
+			newTreeItem.OrgMembers = []string{}
+		}
 		orgTreeIndex[org.Globalid] = newTreeItem
 		if orgTree == nil {
 			orgTree = newTreeItem
@@ -163,6 +180,9 @@ func (api OrganizationsAPI) actualOrganizationCreation(org organization.Organiza
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
+	// Domains can only be verified after the organization is created
+	org.DomainVerifications = nil
+	org.Deleted, org.Purge = nil, nil
 
 	username := context.Get(r, "authenticateduser").(string)
 	orgMgr := organization.NewManager(r)
@@ -277,6 +297,10 @@ func (api OrganizationsAPI) AddOrganizationMember(w http.ResponseWriter, r *http
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
+	if len(s.Message) > invitations.MaxMessageLength {
+		writeErrorResponse(w, 422, "invalid_message")
+		return
+	}
 
 	orgMgr := organization.NewManager(r)
 
@@ -298,7 +322,7 @@ func (api OrganizationsAPI) AddOrganizationMember(w http.ResponseWriter, r *http
 			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 			return
 		}
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		api.inviteByContact(w, r, globalid, invitations.RoleMember, s)
 		return
 	}
 	for _, membername := range org.Members {
@@ -334,6 +358,8 @@ func (api OrganizationsAPI) AddOrganizationMember(w http.ResponseWriter, r *http
 		User:         u.Username,
 		Status:       invitations.RequestPending,
 		Created:      db.DateTime(time.Now()),
+		Inviter:      inviter(r),
+		Message:      s.Message,
 	}
 
 	if err := invitationMgr.Save(orgReq); err != nil {
@@ -348,6 +374,94 @@ func (api OrganizationsAPI) AddOrganizationMember(w http.ResponseWriter, r *http
 	json.NewEncoder(w).Encode(orgReq)
 }
 
+//inviteByContact invites a person that has no account with the email address or phone number yet.
+// The invitation is sent to the contact and attached to the user that validates it later on.
+// If the contact is neither an email address nor a phone number in international format, there is nobody to invite.
+func (api OrganizationsAPI) inviteByContact(w http.ResponseWriter, r *http.Request, globalid string, role string, s searchMember) {
+	invite := &invitations.JoinOrganizationInvitation{
+		Role:         role,
+		Organization: globalid,
+		Status:       invitations.RequestPending,
+		Created:      db.DateTime(time.Now()),
+		Inviter:      inviter(r),
+		Message:      s.Message,
+	}
+	if address, err := mail.ParseAddress(s.SearchString); err == nil && address.Address == s.SearchString {
+		invite.EmailAddress = s.SearchString
+	} else if phonenumber, err := phonenumbers.Normalize(s.SearchString, ""); err == nil {
+		invite.PhoneNumber = phonenumber
+	} else {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	invitationMgr := invitations.NewInvitationManager(r)
+	count, err := invitationMgr.CountByOrganization(globalid)
+	if handleServerError(w, "counting the invitations", err) {
+		return
+	}
+	if count >= MAX_AMOUNT_INVITATIONS_PER_ORGANIZATION {
+		log.Error("Reached invitation limit for organization ", globalid)
+		writeErrorResponse(w, 422, "max_amount_of_invitations_reached")
+		return
+	}
+	err = invitationMgr.ReserveContactInvitations(globalid, invite.Inviter, 1, time.Now())
+	if err == invitations.ErrTooManyInvitationsSent {
+		writeErrorResponse(w, http.StatusTooManyRequests, "too_many_invitations_sent")
+		return
+	}
+	if handleServerError(w, "limiting the invitations", err) {
+		return
+	}
+
+	if invite.EmailAddress != "" {
+		err = invitationMgr.SaveEmailInvitation(invite)
+	} else {
+		err = invitationMgr.SavePhonenumberInvitation(invite)
+	}
+	if handleServerError(w, "saving the invitation", err) {
+		return
+	}
+	if handleServerError(w, "sending the invitation", api.sendContactInvitation(r, invite)) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(invite)
+}
+
+//sendContactInvitation sends an invitation for an email address or phone number by email or sms.
+// The note of the inviter is only added to emails, an sms can not show who it comes from.
+func (api OrganizationsAPI) sendContactInvitation(r *http.Request, invite *invitations.JoinOrganizationInvitation) error {
+	msgContext := messages.NewContext(r, "", invite.Organization)
+	data := &messages.Data{
+		EmailAddress: invite.EmailAddress,
+		URL:          fmt.Sprintf("https://%s/register", r.Host),
+		Inviter:      invite.Inviter,
+	}
+	if invite.EmailAddress == "" {
+		message, err := msgContext.RenderSMS(messages.OrganizationInvitation, data)
+		if err != nil {
+			return err
+		}
+		return api.SmsService.Send(invite.PhoneNumber, message)
+	}
+	data.Note = invite.Message
+	message, err := msgContext.RenderEmail(messages.OrganizationInvitation, data)
+	if err != nil {
+		return err
+	}
+	message.Recipients = []string{invite.EmailAddress}
+	return api.EmailService.SendMessage(message)
+}
+
+//inviter returns the username of the user that sends an invitation, empty if it is sent with an api key
+func inviter(r *http.Request) string {
+	username, _ := context.Get(r, "authenticateduser").(string)
+	return username
+}
+
 func (api OrganizationsAPI) UpdateOrganizationMemberShip(w http.ResponseWriter, r *http.Request) {
 	globalid := mux.Vars(r)["globalid"]
 	var membership Membership
@@ -365,6 +479,10 @@ func (api OrganizationsAPI) UpdateOrganizationMemberShip(w http.ResponseWriter,
 		}
 		return
 	}
+	if membership.Role != "members" && membership.Role != "owners" {
+		writeErrorResponse(w, 422, "invalid_role")
+		return
+	}
 	var oldRole string
 	for _, v := range org.Members {
 		if v == membership.Username {
@@ -376,6 +494,10 @@ func (api OrganizationsAPI) UpdateOrganizationMemberShip(w http.ResponseWriter,
 			oldRole = "owners"
 		}
 	}
+	if oldRole == "" {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
 	err = orgMgr.UpdateMembership(globalid, membership.Username, oldRole, membership.Role)
 	if err != nil {
 		handleServerError(w, "updating organization membership", err)
@@ -425,6 +547,10 @@ func (api OrganizationsAPI) AddOrganizationOwner(w http.ResponseWriter, r *http.
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
+	if len(s.Message) > invitations.MaxMessageLength {
+		writeErrorResponse(w, 422, "invalid_message")
+		return
+	}
 
 	orgMgr := organization.NewManager(r)
 
@@ -439,6 +565,10 @@ func (api OrganizationsAPI) AddOrganizationOwner(w http.ResponseWriter, r *http.
 	}
 
 	u, err := SearchUser(r, s.SearchString)
+	if err == mgo.ErrNotFound {
+		api.inviteByContact(w, r, globalid, invitations.RoleOwner, s)
+		return
+	}
 	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
@@ -459,6 +589,8 @@ func (api OrganizationsAPI) AddOrganizationOwner(w http.ResponseWriter, r *http.
 		User:         u.Username,
 		Status:       invitations.RequestPending,
 		Created:      db.DateTime(time.Now()),
+		Inviter:      inviter(r),
+		Message:      s.Message,
 	}
 
 	if err := invitationMgr.Save(orgReq); err != nil {
@@ -518,9 +650,14 @@ func (api OrganizationsAPI) GetPendingInvitations(w http.ResponseWriter, r *http
 	pendingInvites := make([]organization.Invitation, len(requests), len(requests))
 	for index, request := range requests {
 		pendingInvites[index] = organization.Invitation{
-			Role:    request.Role,
-			User:    request.User,
-			Created: request.Created,
+			Role:         request.Role,
+			User:         request.User,
+			EmailAddress: request.EmailAddress,
+			PhoneNumber:  request.PhoneNumber,
+			Status:       string(request.Status),
+			Created:      request.Created,
+			Expires:      db.DateTime(request.ExpirationTime()),
+			Inviter:      request.Inviter,
 		}
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -530,8 +667,77 @@ func (api OrganizationsAPI) GetPendingInvitations(w http.ResponseWriter, r *http
 
 // RemovePendingInvitation is the handler for DELETE /organizations/{globalid}/invitations/{username}
 // Cancel a pending invitation.
+// The username can also be the email address or phone number of an invitation for someone without an account.
 func (api OrganizationsAPI) RemovePendingInvitation(w http.ResponseWriter, r *http.Request) {
-	log.Error("RemovePendingInvitation is not implemented")
+	globalid := mux.Vars(r)["globalid"]
+	invitee := mux.Vars(r)["username"]
+
+	removed, err := invitations.NewInvitationManager(r).RemovePendingByInvitee(globalid, invitee)
+	if handleServerError(w, "removing the invitation", err) {
+		return
+	}
+	if removed == 0 {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendInvitation is the handler for POST /organizations/{globalid}/invitations/{username}/resend
+// Renew the pending or expired invitations of a user, email address or phone number.
+// Invitations for an email address or phone number are sent again, at most once every InvitationResendInterval.
+func (api OrganizationsAPI) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	invitee := mux.Vars(r)["username"]
+
+	invitationMgr := invitations.NewInvitationManager(r)
+	invites, err := invitationMgr.GetPendingByInvitee(globalid, invitee)
+	if handleServerError(w, "getting the invitations", err) {
+		return
+	}
+	if len(invites) == 0 {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	now := time.Now()
+	toSend := 0
+	for i := range invites {
+		if !invites[i].CanBeResentAt(now) {
+			writeErrorResponse(w, http.StatusTooManyRequests, "invitation_recently_sent")
+			return
+		}
+		if invites[i].User == "" {
+			toSend++
+		}
+	}
+	if toSend > 0 {
+		err = invitationMgr.ReserveContactInvitations(globalid, inviter(r), toSend, now)
+		if err == invitations.ErrTooManyInvitationsSent {
+			writeErrorResponse(w, http.StatusTooManyRequests, "too_many_invitations_sent")
+			return
+		}
+		if handleServerError(w, "limiting the invitations", err) {
+			return
+		}
+	}
+	for i := range invites {
+		invite := &invites[i]
+		invite.Renew(now)
+		if handleServerError(w, "renewing the invitation", invitationMgr.UpdateExpiration(invite)) {
+			return
+		}
+		if invite.User != "" {
+			continue
+		}
+		if handleServerError(w, "sending the invitation", api.sendContactInvitation(r, invite)) {
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invites)
 }
 
 // GetContracts is the handler for GET /organizations/{globalid}/contracts
@@ -575,13 +781,6 @@ func isValidAPIKeyLabel(label string) (valid bool) {
 	return valid
 }
 
-func isValidDNSName(label string) (valid bool) {
-	valid = true
-	labelLength := len(label)
-	valid = valid && labelLength > 2 && labelLength < 250
-	return valid
-}
-
 // GetAPIKey is the handler for GET /organizations/{globalid}/apikeys/{label}
 func (api OrganizationsAPI) GetAPIKey(w http.ResponseWriter, r *http.Request) {
 	organization := mux.Vars(r)["globalid"]
@@ -709,7 +908,7 @@ func (api OrganizationsAPI) CreateDns(w http.ResponseWriter, r *http.Request) {
 	globalid := mux.Vars(r)["globalid"]
 	dnsName := mux.Vars(r)["dnsname"]
 
-	if !isValidDNSName(dnsName) {
+	if !domainverification.IsValidDomain(dnsName) {
 		log.Debug("Invalid DNS name: ", dnsName)
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
@@ -732,16 +931,15 @@ func (api OrganizationsAPI) CreateDns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := struct {
-		Name string `json:"name"`
-	}{
-		Name: dnsName,
+	verification, err := getDomainVerification(orgMgr, organization, dnsName)
+	if handleServerError(w, "starting the domain verification", err) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(newDNSVerificationResponse(verification))
 }
 
 func (api OrganizationsAPI) UpdateDns(w http.ResponseWriter, r *http.Request) {
@@ -756,7 +954,7 @@ func (api OrganizationsAPI) UpdateDns(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
-	if !isValidDNSName(body.Name) {
+	if !domainverification.IsValidDomain(body.Name) {
 		log.Debug("Invalid DNS name: ", body.Name)
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
@@ -780,24 +978,27 @@ func (api OrganizationsAPI) UpdateDns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := struct {
-		Name string `json:"name"`
-	}{
-		Name: body.Name,
+	// The verification of the old name was removed, the new name needs to be verified again
+	organization.DomainVerifications = nil
+	verification, err := getDomainVerification(orgMgr, organization, body.Name)
+	if handleServerError(w, "starting the domain verification", err) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(newDNSVerificationResponse(verification))
 }
 
-func (api OrganizationsAPI) DeleteDns(w http.ResponseWriter, r *http.Request) {
+// GetDns is the handler for GET /organizations/{globalid}/dns/{dnsname}
+// Get the verification status of a dns name and how to verify it
+func (api OrganizationsAPI) GetDns(w http.ResponseWriter, r *http.Request) {
 	globalid := mux.Vars(r)["globalid"]
 	dnsName := mux.Vars(r)["dnsname"]
 
 	orgMgr := organization.NewManager(r)
-	organization, err := orgMgr.GetByName(globalid)
+	org, err := orgMgr.GetByName(globalid)
 	if err != nil {
 		if err == mgo.ErrNotFound {
 			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
@@ -806,190 +1007,493 @@ func (api OrganizationsAPI) DeleteDns(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	sort.Strings(organization.DNS)
-	if sort.SearchStrings(organization.DNS, dnsName) == len(organization.DNS) {
+	if !hasDNSName(org, dnsName) {
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
-
-	err = orgMgr.RemoveDNS(organization, dnsName)
-
-	if err != nil {
-		log.Error("Error removing DNS name", err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	verification, err := getDomainVerification(orgMgr, org, dnsName)
+	if handleServerError(w, "getting the domain verification", err) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-
-	w.WriteHeader(http.StatusNoContent)
+	json.NewEncoder(w).Encode(newDNSVerificationResponse(verification))
 }
 
-// DeleteOrganization is the handler for DELETE /organizations/{globalid}
-// Deletes an organization and all data linked to it (join-organization-invitations, oauth_access_tokens, oauth_clients, authorizations)
-func (api OrganizationsAPI) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+// VerifyDns is the handler for POST /organizations/{globalid}/dns/{dnsname}/verify
+// Check if the verification token of a dns name is published in its TXT record or well-known file
+func (api OrganizationsAPI) VerifyDns(w http.ResponseWriter, r *http.Request) {
 	globalid := mux.Vars(r)["globalid"]
+	dnsName := mux.Vars(r)["dnsname"]
+
 	orgMgr := organization.NewManager(r)
-	logoMgr := organization.NewLogoManager(r)
-	if !orgMgr.Exists(globalid) {
-		writeErrorResponse(w, http.StatusNotFound, "organization_not_found")
-		return
-	}
-	suborganizations, err := orgMgr.GetSubOrganizations(globalid)
-	if handleServerError(w, "fetching suborganizations", err) {
-		return
-	}
-	if len(suborganizations) != 0 {
-		writeErrorResponse(w, 422, "organization_has_children")
+	org, err := orgMgr.GetByName(globalid)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else {
+			handleServerError(w, "getting organization", err)
+		}
 		return
 	}
-	err = orgMgr.Remove(globalid)
-	if handleServerError(w, "removing organization", err) {
+	if !hasDNSName(org, dnsName) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
-	if logoMgr.Exists(globalid) {
-		err = logoMgr.Remove(globalid)
-		if handleServerError(w, "removing organization logo", err) {
-			return
-		}
-	}
-	orgReqMgr := invitations.NewInvitationManager(r)
-	err = orgReqMgr.RemoveAll(globalid)
-	if handleServerError(w, "removing organization invitations", err) {
+	verification, err := getDomainVerification(orgMgr, org, dnsName)
+	if handleServerError(w, "getting the domain verification", err) {
 		return
 	}
 
-	oauthMgr := oauthservice.NewManager(r)
-	err = oauthMgr.RemoveTokensByGlobalId(globalid)
-	if handleServerError(w, "removing organization oauth accesstokens", err) {
+	verification.Started = true
+	verifyErr := domainverification.Verify(api.DomainResolver, verification, time.Now(), false)
+	if handleServerError(w, "saving the domain verification", orgMgr.SaveDomainVerification(globalid, verification)) {
 		return
 	}
-	err = oauthMgr.DeleteAllForOrganization(globalid)
-	if handleServerError(w, "removing client secrets", err) {
+	if verifyErr != nil {
+		log.Debug("Verification of ", dnsName, " failed: ", verifyErr)
+		writeErrorResponse(w, 422, "domain_not_verified")
 		return
 	}
-	err = oauthMgr.RemoveClientsById(globalid)
-	if handleServerError(w, "removing organization oauth clients", err) {
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newDNSVerificationResponse(verification))
+}
+
+// SetDomainMembership is the handler for PUT /organizations/{globalid}/domainmembership
+// Set if users that validate an email address at a verified domain are invited, made members or left alone
+func (api OrganizationsAPI) SetDomainMembership(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	body := struct {
+		DomainMembership string `json:"domainmembership"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
-	userMgr := user.NewManager(r)
-	err = userMgr.DeleteAllAuthorizations(globalid)
-	if handleServerError(w, "removing all authorizations", err) {
+	if !organization.IsValidDomainMembership(body.DomainMembership) {
+		writeErrorResponse(w, 422, "invalid_domainmembership")
 		return
 	}
-	err = oauthMgr.RemoveClientsById(globalid)
-	if handleServerError(w, "removing organization oauth clients", err) {
+
+	orgMgr := organization.NewManager(r)
+	if !orgMgr.Exists(globalid) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
-	l2faMgr := organization.NewLast2FAManager(r)
-	err = l2faMgr.RemoveByOrganization(globalid)
-	if handleServerError(w, "removing organization 2FA history", err) {
+	if handleServerError(w, "saving the domain membership rule", orgMgr.SetDomainMembership(globalid, body.DomainMembership)) {
 		return
 	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// ListOrganizationRegistry is the handler for GET /organizations/{globalid}/registry
-// Lists the Registry entries
-func (api OrganizationsAPI) ListOrganizationRegistry(w http.ResponseWriter, r *http.Request) {
-	globalid := mux.Vars(r)["globalid"]
+//dnsVerificationResponse is a domain verification with the places its token can be published
+type dnsVerificationResponse struct {
+	*organization.DomainVerification
+	TXTRecord    string `json:"txtrecord"`
+	TXTValue     string `json:"txtvalue"`
+	WellKnownURL string `json:"wellknownurl"`
+}
 
-	mgr := registry.NewManager(r)
-	registryEntries, err := mgr.ListRegistryEntries("", globalid)
-	if err != nil {
-		log.Error(err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
+func newDNSVerificationResponse(verification *organization.DomainVerification) *dnsVerificationResponse {
+	return &dnsVerificationResponse{
+		DomainVerification: verification,
+		TXTRecord:          domainverification.TXTRecordName(verification.Name),
+		TXTValue:           domainverification.TXTRecordValue(verification.Token),
+		WellKnownURL:       domainverification.WellKnownURL(verification.Name),
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(registryEntries)
 }
 
-// AddOrganizationRegistryEntry is the handler for POST /organizations/{globalid}/registry
-// Adds a RegistryEntry to the organization's registry, if the key is already used, it is overwritten.
-func (api OrganizationsAPI) AddOrganizationRegistryEntry(w http.ResponseWriter, r *http.Request) {
-	globalid := mux.Vars(r)["globalid"]
-
-	registryEntry := registry.RegistryEntry{}
+func hasDNSName(org *organization.Organization, dnsName string) bool {
+	for _, name := range org.DNS {
+		if strings.EqualFold(name, dnsName) {
+			return true
+		}
+	}
+	return false
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&registryEntry); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+//getDomainVerification returns the verification of a dns name of an organization,
+// a new one is started for the dns names that were added before domains could be verified
+func getDomainVerification(orgMgr *organization.Manager, org *organization.Organization, dnsName string) (verification *organization.DomainVerification, err error) {
+	if verification = org.GetDomainVerification(dnsName); verification != nil {
 		return
 	}
-	if err := registryEntry.Validate(); err != nil {
-		log.Debug("Invalid registry entry: ", registryEntry)
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	if verification, err = organization.NewDomainVerification(dnsName); err != nil {
 		return
 	}
-
-	mgr := registry.NewManager(r)
-	err := mgr.UpsertRegistryEntry("", globalid, registryEntry)
-
-	if err != nil {
-		log.Error(err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
+	err = orgMgr.AddDomainVerification(org.Globalid, verification)
+	if err == db.ErrDuplicate {
+		if org, err = orgMgr.GetByName(org.Globalid); err != nil {
+			return
+		}
+		verification = org.GetDomainVerification(dnsName)
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(registryEntry)
+	return
 }
 
-// GetOrganizationRegistryEntry is the handler for GET /organizations/{username}/globalid/{key}
-// Get a RegistryEntry from the organization's registry.
-func (api OrganizationsAPI) GetOrganizationRegistryEntry(w http.ResponseWriter, r *http.Request) {
+func (api OrganizationsAPI) DeleteDns(w http.ResponseWriter, r *http.Request) {
 	globalid := mux.Vars(r)["globalid"]
-	key := mux.Vars(r)["key"]
+	dnsName := mux.Vars(r)["dnsname"]
 
-	mgr := registry.NewManager(r)
-	registryEntry, err := mgr.GetRegistryEntry("", globalid, key)
+	orgMgr := organization.NewManager(r)
+	organization, err := orgMgr.GetByName(globalid)
 	if err != nil {
-		log.Error(err.Error())
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if err == mgo.ErrNotFound {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else {
+			handleServerError(w, "getting organization", err)
+		}
 		return
 	}
-	if registryEntry == nil {
+	sort.Strings(organization.DNS)
+	if sort.SearchStrings(organization.DNS, dnsName) == len(organization.DNS) {
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(registryEntry)
-}
-
-// DeleteOrganizationRegistryEntry is the handler for DELETE /organizations/{username}/globalid/{key}
-// Removes a RegistryEntry from the organization's registry
-func (api OrganizationsAPI) DeleteOrganizationRegistryEntry(w http.ResponseWriter, r *http.Request) {
-	globalid := mux.Vars(r)["globalid"]
-	key := mux.Vars(r)["key"]
 
-	mgr := registry.NewManager(r)
-	err := mgr.DeleteRegistryEntry("", globalid, key)
+	err = orgMgr.RemoveDNS(organization, dnsName)
 
 	if err != nil {
-		log.Error(err.Error())
+		log.Error("Error removing DNS name", err.Error())
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// SetOrganizationLogo is the handler for PUT /organizations/globalid/logo
-// Set the organization Logo for the organization
-func (api OrganizationsAPI) SetOrganizationLogo(w http.ResponseWriter, r *http.Request) {
+// DeleteOrganization is the handler for DELETE /organizations/{globalid}
+// Deletes an organization and all data linked to it (join-organization-invitations, oauth_access_tokens, oauth_clients, authorizations, registry, contracts)
+// With recursive=true its suborganizations are deleted as well, with dryrun=true the organizations that would be deleted are only listed.
+// With soft=true the organizations are disabled and only purged after the DeletionGracePeriod, until then they can be restored.
+func (api OrganizationsAPI) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
 	globalid := mux.Vars(r)["globalid"]
-
-	body := struct {
-		Logo string
-	}{}
-
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		log.Error("Error while saving logo: ", err.Error())
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	recursive := r.URL.Query().Get("recursive") == "true"
+	dryRun := r.URL.Query().Get("dryrun") == "true"
+	soft := r.URL.Query().Get("soft") == "true"
+	orgMgr := organization.NewManager(r)
+	if !orgMgr.Exists(globalid) {
+		writeErrorResponse(w, http.StatusNotFound, "organization_not_found")
 		return
 	}
-
+	suborganizations, err := orgMgr.GetSubOrganizations(globalid)
+	if handleServerError(w, "fetching suborganizations", err) {
+		return
+	}
+	result := deletion{Organizations: []string{globalid}}
+	for _, suborganization := range suborganizations {
+		if organization.IsDescendant(suborganization.Globalid, globalid) {
+			result.Organizations = append(result.Organizations, suborganization.Globalid)
+		}
+	}
+	if len(result.Organizations) > 1 && !recursive {
+		writeErrorResponse(w, 422, "organization_has_children")
+		return
+	}
+	now := time.Now()
+	purge := db.DateTime(now)
+	if soft {
+		purge = db.DateTime(now.Add(DeletionGracePeriod))
+		result.Purge = &purge
+	}
+	if dryRun {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&result)
+		return
+	}
+	//Marking the whole tree deleted at once takes it away from its users before anything is removed
+	err = orgMgr.MarkDeleted(result.Organizations, db.DateTime(now), purge)
+	if handleServerError(w, "marking the organizations deleted", err) {
+		return
+	}
+	err = disableOrganizations(r, result.Organizations, true)
+	if handleServerError(w, "disabling the oauth clients of the organizations", err) {
+		return
+	}
+	if soft {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&result)
+		return
+	}
+	if err = purgeOrganizations(r, result.Organizations); err != nil {
+		//The organizations stay marked deleted, the purge is finished in the background
+		log.Error("Purging deleted organization ", globalid, " failed, it is retried later: ", err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreOrganization is the handler for POST /organizations/{globalid}/restore
+// Enable a soft deleted organization again, together with the suborganizations that were deleted with it.
+// Deleted organizations give no ownership so the owners are checked here: the owners of the organization itself
+// and, unless it inherits nothing, of its parents can restore it.
+func (api OrganizationsAPI) RestoreOrganization(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	orgMgr := organization.NewManager(r)
+	org, err := orgMgr.GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		writeErrorResponse(w, http.StatusNotFound, "organization_not_found")
+		return
+	}
+	if handleServerError(w, "getting the organization", err) {
+		return
+	}
+	username, _ := context.Get(r, "authenticateduser").(string)
+	if !hasAdminAccess(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	isOwner := org.HasRole(username, organization.RoleOwner)
+	parent := organization.Parent(globalid)
+	if !isOwner && parent != "" && org.Inheritance != organization.InheritNone {
+		isOwner, err = orgMgr.IsOwner(parent, username)
+		if handleServerError(w, "checking the owners of the parent organization", err) {
+			return
+		}
+	}
+	if username == "" || !isOwner {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !org.IsDeleted() {
+		writeErrorResponse(w, 422, "organization_not_deleted")
+		return
+	}
+	if !org.CanBeRestoredAt(time.Now()) {
+		writeErrorResponse(w, http.StatusGone, "organization_purged")
+		return
+	}
+	if parent != "" {
+		parentOrg, err := orgMgr.GetByName(parent)
+		if handleServerError(w, "getting the parent organization", err) {
+			return
+		}
+		if parentOrg.IsDeleted() {
+			writeErrorResponse(w, 422, "parent_deleted")
+			return
+		}
+	}
+	suborganizations, err := orgMgr.GetSubOrganizations(globalid)
+	if handleServerError(w, "fetching suborganizations", err) {
+		return
+	}
+	restored := []string{globalid}
+	for _, suborganization := range suborganizations {
+		if organization.IsDescendant(suborganization.Globalid, globalid) && suborganization.DeletedWith(org) {
+			restored = append(restored, suborganization.Globalid)
+		}
+	}
+	err = orgMgr.Restore(restored, *org.Deleted)
+	if handleServerError(w, "restoring the organizations", err) {
+		return
+	}
+	err = disableOrganizations(r, restored, false)
+	if handleServerError(w, "enabling the oauth clients of the organizations", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&deletion{Organizations: restored})
+}
+
+// RenameOrganization is the handler for POST /organizations/{globalid}/rename
+// Give an organization a new globalid, a different parent in it moves the organization to another place in the tree.
+// The suborganizations move along and all references to the organizations are updated.
+func (api OrganizationsAPI) RenameOrganization(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	body := struct {
+		Globalid string `json:"globalid"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Debug("Error decoding the new globalid: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	orgMgr := organization.NewManager(r)
+	if !orgMgr.Exists(globalid) {
+		writeErrorResponse(w, http.StatusNotFound, "organization_not_found")
+		return
+	}
+	if organization.IsDescendant(body.Globalid, globalid) {
+		writeErrorResponse(w, 422, "invalid_parent")
+		return
+	}
+	parent := organization.Parent(body.Globalid)
+	oldParent := organization.Parent(globalid)
+	username, _ := context.Get(r, "authenticateduser").(string)
+	//Taking an organization away from its parent is up to the owners of that parent
+	if oldParent != "" && parent != oldParent {
+		isOwner, err := orgMgr.IsOwner(oldParent, username)
+		if handleServerError(w, "checking the owners of the parent organization", err) {
+			return
+		}
+		if username == "" || !isOwner {
+			writeErrorResponse(w, http.StatusForbidden, "not_an_owner_of_parent")
+			return
+		}
+	}
+	if parent != "" && parent != oldParent {
+		if !orgMgr.Exists(parent) {
+			writeErrorResponse(w, 422, "parent_not_found")
+			return
+		}
+		isOwner, err := orgMgr.IsOwner(parent, username)
+		if handleServerError(w, "checking the owners of the new parent organization", err) {
+			return
+		}
+		if username == "" || !isOwner {
+			writeErrorResponse(w, http.StatusForbidden, "not_an_owner_of_parent")
+			return
+		}
+	}
+	suborganizations, err := orgMgr.GetSubOrganizations(globalid)
+	if handleServerError(w, "fetching suborganizations", err) {
+		return
+	}
+	subIDs := make([]string, len(suborganizations))
+	for i, suborganization := range suborganizations {
+		subIDs[i] = suborganization.Globalid
+	}
+	renames := organization.Renames(globalid, body.Globalid, subIDs)
+	for _, rename := range renames {
+		renamed := organization.Organization{Globalid: rename.New}
+		if !renamed.IsValid() {
+			writeErrorResponse(w, 422, "invalid_globalid")
+			return
+		}
+		if rename.New == itsyouonlineGlobalID || orgMgr.Exists(rename.New) {
+			writeErrorResponse(w, http.StatusConflict, "duplicate_globalid")
+			return
+		}
+	}
+	err = renameOrganizations(r, renames)
+	if err == db.ErrDuplicate {
+		//Created in the meantime
+		writeErrorResponse(w, http.StatusConflict, "duplicate_globalid")
+		return
+	}
+	if handleServerError(w, "renaming the organization", err) {
+		return
+	}
+	org, err := orgMgr.GetByName(body.Globalid)
+	if handleServerError(w, "getting the renamed organization", err) {
+		return
+	}
+	//Moved to the root of the tree, the owners of the old parents no longer own it
+	if parent == "" && oldParent != "" && username != "" && !org.HasRole(username, organization.RoleOwner) {
+		if handleServerError(w, "adding the owner of the moved organization", orgMgr.SaveOwner(org, username)) {
+			return
+		}
+		org.Owners = append(org.Owners, username)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(org)
+}
+
+// ListOrganizationRegistry is the handler for GET /organizations/{globalid}/registry
+// Lists the Registry entries
+func (api OrganizationsAPI) ListOrganizationRegistry(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	mgr := registry.NewManager(r)
+	registryEntries, err := mgr.ListRegistryEntries("", globalid)
+	if err != nil {
+		log.Error(err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(registryEntries)
+}
+
+// AddOrganizationRegistryEntry is the handler for POST /organizations/{globalid}/registry
+// Adds a RegistryEntry to the organization's registry, if the key is already used, it is overwritten.
+func (api OrganizationsAPI) AddOrganizationRegistryEntry(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	registryEntry := registry.RegistryEntry{}
+
+	if err := json.NewDecoder(r.Body).Decode(&registryEntry); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if err := registryEntry.Validate(); err != nil {
+		log.Debug("Invalid registry entry: ", registryEntry)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	mgr := registry.NewManager(r)
+	err := mgr.UpsertRegistryEntry("", globalid, registryEntry)
+
+	if err != nil {
+		log.Error(err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(registryEntry)
+}
+
+// GetOrganizationRegistryEntry is the handler for GET /organizations/{username}/globalid/{key}
+// Get a RegistryEntry from the organization's registry.
+func (api OrganizationsAPI) GetOrganizationRegistryEntry(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	key := mux.Vars(r)["key"]
+
+	mgr := registry.NewManager(r)
+	registryEntry, err := mgr.GetRegistryEntry("", globalid, key)
+	if err != nil {
+		log.Error(err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if registryEntry == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(registryEntry)
+}
+
+// DeleteOrganizationRegistryEntry is the handler for DELETE /organizations/{username}/globalid/{key}
+// Removes a RegistryEntry from the organization's registry
+func (api OrganizationsAPI) DeleteOrganizationRegistryEntry(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	key := mux.Vars(r)["key"]
+
+	mgr := registry.NewManager(r)
+	err := mgr.DeleteRegistryEntry("", globalid, key)
+
+	if err != nil {
+		log.Error(err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetOrganizationLogo is the handler for PUT /organizations/globalid/logo
+// Set the organization Logo for the organization
+func (api OrganizationsAPI) SetOrganizationLogo(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	body := struct {
+		Logo string
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Error("Error while saving logo: ", err.Error())
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
 	logoMgr := organization.NewLogoManager(r)
 
 	// server side file size validation check. Normally uploaded files should never get this large due to size constraints, but check anyway
@@ -1051,6 +1555,54 @@ func (api OrganizationsAPI) DeleteOrganizationLogo(w http.ResponseWriter, r *htt
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetOrganizationBranding is the handler for GET /organizations/{globalid}/branding
+// Get how the emails and sms sent for the organization look
+func (api OrganizationsAPI) GetOrganizationBranding(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	branding, err := organization.NewBrandingManager(r).GetBranding(globalid)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "getting the branding", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(branding)
+}
+
+// SetOrganizationBranding is the handler for PUT /organizations/{globalid}/branding
+// Set how the emails and sms sent for the organization and its suborganizations look
+func (api OrganizationsAPI) SetOrganizationBranding(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	branding := &organization.Branding{}
+	if err := json.NewDecoder(r.Body).Decode(branding); err != nil {
+		log.Debug("Error decoding the branding: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !branding.IsValid() {
+		writeErrorResponse(w, 422, "invalid_branding")
+		return
+	}
+	branding.Globalid = globalid
+	if handleServerError(w, "saving the branding", organization.NewBrandingManager(r).SaveBranding(branding)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(branding)
+}
+
+// DeleteOrganizationBranding is the handler for DELETE /organizations/{globalid}/branding
+// Removes the branding of the organization
+func (api OrganizationsAPI) DeleteOrganizationBranding(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	if handleServerError(w, "removing the branding", organization.NewBrandingManager(r).RemoveBranding(globalid)) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Get2faValidityTime is the handler for GET /organizations/globalid/2fa/validity
 // Get the 2fa validity time for the organization, in seconds
 func (api OrganizationsAPI) Get2faValidityTime(w http.ResponseWriter, r *http.Request) {
@@ -1113,6 +1665,833 @@ func (api OrganizationsAPI) Set2faValidityTime(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(http.StatusOK)
 }
 
+// GetSAMLIdentityProvider is the handler for GET /organizations/{globalid}/saml
+// Get the SAML identity provider the members of the organization login with
+func (api OrganizationsAPI) GetSAMLIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	idp, err := samldb.NewManager(r).GetIdentityProvider(globalid)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "getting the SAML identity provider", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(idp)
+}
+
+// SetSAMLIdentityProvider is the handler for PUT /organizations/{globalid}/saml
+// Configure the SAML identity provider of the organization from its metadata
+func (api OrganizationsAPI) SetSAMLIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	body := struct {
+		Metadata string `json:"metadata"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Debug("Error decoding the SAML metadata: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if len(body.Metadata) > 1024*1024 {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	metadata, err := saml.ParseIdentityProviderMetadata([]byte(body.Metadata))
+	if err != nil {
+		switch err {
+		case saml.ErrNoIdentityProvider:
+			writeErrorResponse(w, 422, "no_identity_provider")
+		case saml.ErrNoSingleSignOnService:
+			writeErrorResponse(w, 422, "no_single_sign_on_service")
+		case saml.ErrNoSigningCertificate:
+			writeErrorResponse(w, 422, "no_signing_certificate")
+		default:
+			writeErrorResponse(w, 422, "invalid_metadata")
+		}
+		return
+	}
+	idp := &samldb.IdentityProvider{
+		Globalid:        globalid,
+		EntityID:        metadata.EntityID,
+		SingleSignOnURL: metadata.SingleSignOnURL,
+		Certificates:    metadata.Certificates,
+		Metadata:        body.Metadata,
+	}
+	if handleServerError(w, "saving the SAML identity provider", samldb.NewManager(r).SaveIdentityProvider(idp)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(idp)
+}
+
+// DeleteSAMLIdentityProvider is the handler for DELETE /organizations/{globalid}/saml
+// Remove the SAML identity provider of the organization
+func (api OrganizationsAPI) DeleteSAMLIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	err := samldb.NewManager(r).RemoveIdentityProvider(globalid)
+	if err != nil && err != mgo.ErrNotFound {
+		handleServerError(w, "removing the SAML identity provider", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSAMLServiceProviders is the handler for GET /organizations/{globalid}/saml/serviceproviders
+// Get the SAML service providers the organization registered
+func (api OrganizationsAPI) GetSAMLServiceProviders(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	sps, err := samldb.NewManager(r).GetServiceProviders(globalid)
+	if handleServerError(w, "listing the SAML service providers", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sps)
+}
+
+// CreateSAMLServiceProvider is the handler for POST /organizations/{globalid}/saml/serviceproviders
+// Register a SAML service provider that receives assertions about the users.
+// The service provider is described by its metadata or by its entity id and assertion consumer service url.
+func (api OrganizationsAPI) CreateSAMLServiceProvider(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	sp := &samldb.ServiceProvider{}
+	if err := json.NewDecoder(r.Body).Decode(sp); err != nil {
+		log.Debug("Error decoding the SAML service provider: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if len(sp.Metadata) > 1024*1024 {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !isValidAPIKeyLabel(sp.Label) {
+		writeErrorResponse(w, 422, "invalid_label")
+		return
+	}
+	if !isValidSAMLScopes(sp.Scopes) {
+		writeErrorResponse(w, 422, "invalid_scopes")
+		return
+	}
+	if sp.Metadata != "" {
+		metadata, err := saml.ParseServiceProviderMetadata([]byte(sp.Metadata))
+		if err != nil {
+			switch err {
+			case saml.ErrNoServiceProvider:
+				writeErrorResponse(w, 422, "no_service_provider")
+			case saml.ErrNoAssertionConsumerService:
+				writeErrorResponse(w, 422, "no_assertion_consumer_service")
+			default:
+				writeErrorResponse(w, 422, "invalid_metadata")
+			}
+			return
+		}
+		sp.EntityID = metadata.EntityID
+		sp.AssertionConsumerServiceURL = metadata.AssertionConsumerServiceURL
+	}
+	if sp.EntityID == "" {
+		writeErrorResponse(w, 422, "invalid_entityid")
+		return
+	}
+	if u, err := url.Parse(sp.AssertionConsumerServiceURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		writeErrorResponse(w, 422, "invalid_assertionconsumerserviceurl")
+		return
+	}
+	sp.Globalid = globalid
+	err := samldb.NewManager(r).CreateServiceProvider(sp)
+	if err == db.ErrDuplicate {
+		writeErrorResponse(w, http.StatusConflict, "duplicate_serviceprovider")
+		return
+	}
+	if handleServerError(w, "saving the SAML service provider", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sp)
+}
+
+//isValidSAMLScopes checks that only the scopes the SAML identity provider turns into attributes are requested
+func isValidSAMLScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if scope != "user:name" && scope != "user:email" && !strings.HasPrefix(scope, "user:email:") &&
+			!(strings.HasPrefix(scope, "user:memberof:") && len(scope) > len("user:memberof:")) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetSAMLServiceProvider is the handler for GET /organizations/{globalid}/saml/serviceproviders/{label}
+// Get a SAML service provider of the organization
+func (api OrganizationsAPI) GetSAMLServiceProvider(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	label := mux.Vars(r)["label"]
+	sp, err := samldb.NewManager(r).GetServiceProvider(globalid, label)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "loading the SAML service provider", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sp)
+}
+
+// DeleteSAMLServiceProvider is the handler for DELETE /organizations/{globalid}/saml/serviceproviders/{label}
+// Remove a SAML service provider of the organization
+func (api OrganizationsAPI) DeleteSAMLServiceProvider(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	label := mux.Vars(r)["label"]
+	err := samldb.NewManager(r).RemoveServiceProvider(globalid, label)
+	if err != nil && err != mgo.ErrNotFound {
+		handleServerError(w, "removing the SAML service provider", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLDAPSync is the handler for GET /organizations/{globalid}/ldapsync
+// Get the LDAP groups the members and owners of the organization are synchronized with
+func (api OrganizationsAPI) GetLDAPSync(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	config, err := ldapsync.LoadConfig(globalid)
+	if handleServerError(w, "getting the LDAP synchronization", err) {
+		return
+	}
+	if config == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	config.EncryptedBindPassword = ""
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(config)
+}
+
+// SetLDAPSync is the handler for PUT /organizations/{globalid}/ldapsync
+// Configure the LDAP groups the members and owners of the organization are synchronized with
+func (api OrganizationsAPI) SetLDAPSync(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	config := &ldapsync.Config{}
+	if err := json.NewDecoder(r.Body).Decode(config); err != nil {
+		log.Debug("Error decoding the LDAP synchronization: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	switch config.Validate() {
+	case ldapsync.ErrInvalidURL:
+		writeErrorResponse(w, 422, "invalid_url")
+		return
+	case ldapsync.ErrNoMemberGroup:
+		writeErrorResponse(w, 422, "invalid_membergroupdn")
+		return
+	}
+	err := ldapsync.SaveConfig(globalid, config)
+	if err == ldapsync.ErrNoEncryptionKey {
+		writeErrorResponse(w, 422, "bindpassword_not_supported")
+		return
+	}
+	if handleServerError(w, "saving the LDAP synchronization", err) {
+		return
+	}
+	config.EncryptedBindPassword = ""
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(config)
+}
+
+// DeleteLDAPSync is the handler for DELETE /organizations/{globalid}/ldapsync
+// Stop synchronizing the organization with LDAP
+func (api OrganizationsAPI) DeleteLDAPSync(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	if handleServerError(w, "removing the LDAP synchronization", ldapsync.RemoveConfig(globalid)) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RunLDAPSync is the handler for POST /organizations/{globalid}/ldapsync/run
+// Synchronize the organization with LDAP now, or list the changes it would make for a dry run
+func (api OrganizationsAPI) RunLDAPSync(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	body := struct {
+		DryRun bool `json:"dryrun"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Debug("Error decoding the LDAP synchronization run: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	result, err := ldapsync.Synchronize(r, globalid, body.DryRun)
+	if err == ldapsync.ErrNotConfigured {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if _, isLDAPError := err.(*ldap.Error); isLDAPError {
+		log.Debugf("LDAP synchronization of %s failed: %v", globalid, err)
+		writeErrorResponse(w, http.StatusBadGateway, "ldap_error")
+		return
+	}
+	if handleServerError(w, "synchronizing with LDAP", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetRoles is the handler for GET /organizations/{globalid}/roles
+// Get the roles of the organization and the permissions they grant
+func (api OrganizationsAPI) GetRoles(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	org, err := organization.NewManager(r).GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "loading the organization", err) {
+		return
+	}
+	roles := org.Roles
+	if roles == nil {
+		roles = []organization.Role{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(roles)
+}
+
+// CreateRole is the handler for POST /organizations/{globalid}/roles
+// Define a new role with a set of permissions
+func (api OrganizationsAPI) CreateRole(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	role := &organization.Role{}
+	if err := json.NewDecoder(r.Body).Decode(role); err != nil {
+		log.Debug("Error decoding the role: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	if !role.IsValid() {
+		writeErrorResponse(w, 422, "invalid_role")
+		return
+	}
+	err := organization.NewManager(r).CreateRole(globalid, role)
+	if err == db.ErrDuplicate {
+		writeErrorResponse(w, http.StatusConflict, "duplicate_role")
+		return
+	}
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "creating the role", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(role)
+}
+
+// UpdateRole is the handler for PUT /organizations/{globalid}/roles/{role}
+// Replace the permissions of a role
+func (api OrganizationsAPI) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	role := &organization.Role{}
+	if err := json.NewDecoder(r.Body).Decode(role); err != nil {
+		log.Debug("Error decoding the role: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	role.Name = mux.Vars(r)["role"]
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	if !role.IsValid() {
+		writeErrorResponse(w, 422, "invalid_role")
+		return
+	}
+	err := organization.NewManager(r).UpdateRole(globalid, role)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "updating the role", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(role)
+}
+
+// DeleteRole is the handler for DELETE /organizations/{globalid}/roles/{role}
+// Remove a role, the users it was assigned to lose its permissions
+func (api OrganizationsAPI) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	role := mux.Vars(r)["role"]
+	err := organization.NewManager(r).RemoveRole(globalid, role)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "removing the role", err) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignRole is the handler for POST /organizations/{globalid}/roles/{role}/members
+// Assign a role to a member or owner of the organization
+func (api OrganizationsAPI) AssignRole(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	assignment := &organization.RoleAssignment{}
+	if err := json.NewDecoder(r.Body).Decode(assignment); err != nil {
+		log.Debug("Error decoding the role assignment: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	assignment.Role = mux.Vars(r)["role"]
+	orgMgr := organization.NewManager(r)
+	org, err := orgMgr.GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "loading the organization", err) {
+		return
+	}
+	if org.GetRole(assignment.Role) == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if !org.HasRole(assignment.Username, organization.RoleMember) && !org.HasRole(assignment.Username, organization.RoleOwner) {
+		writeErrorResponse(w, 422, "user_not_in_organization")
+		return
+	}
+	err = orgMgr.AssignRole(globalid, assignment.Username, assignment.Role)
+	if err == mgo.ErrNotFound {
+		//The role was removed or the user left the organization in the meantime
+		writeErrorResponse(w, http.StatusConflict, "organization_changed")
+		return
+	}
+	if handleServerError(w, "assigning the role", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(assignment)
+}
+
+// UnassignRole is the handler for DELETE /organizations/{globalid}/roles/{role}/members/{username}
+// Take a role away from a user
+func (api OrganizationsAPI) UnassignRole(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	role := mux.Vars(r)["role"]
+	username := mux.Vars(r)["username"]
+	err := organization.NewManager(r).UnassignRole(globalid, username, role)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "removing the role assignment", err) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//hasAdminAccess checks if the request is made from the itsyou.online website or with an admin token of it,
+//the same condition the middleware uses to grant the organization:owner scope
+func hasAdminAccess(r *http.Request) bool {
+	clientID, _ := context.Get(r, "client_id").(string)
+	scopes, _ := context.Get(r, "availablescopes").(string)
+	return clientID == "itsyouonline" && scopes == "admin"
+}
+
+// SetInheritance is the handler for PUT /organizations/{globalid}/inheritance
+// Set if the owners, also the members or nobody of the parent organizations are owners and members of the organization.
+// Only the owners of the parent can change it, otherwise the owners of a suborganization could lock them out.
+// They keep that right when the suborganization inherits nothing, so the owners are checked here and not by the middleware.
+// Like the organization:owner scope, it is only granted to the itsyou.online website or an admin token of it.
+func (api OrganizationsAPI) SetInheritance(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	body := struct {
+		Inheritance string `json:"inheritance"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Debug("Error decoding the inheritance: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if body.Inheritance == "" || !organization.IsValidInheritance(body.Inheritance) {
+		writeErrorResponse(w, 422, "invalid_inheritance")
+		return
+	}
+	orgMgr := organization.NewManager(r)
+	username, _ := context.Get(r, "authenticateduser").(string)
+	if !hasAdminAccess(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	parent := organization.Parent(globalid)
+	owned := globalid
+	if parent != "" {
+		owned = parent
+	}
+	isOwner, err := orgMgr.IsOwner(owned, username)
+	if handleServerError(w, "checking the owners", err) {
+		return
+	}
+	if username == "" || !isOwner {
+		if parent != "" {
+			writeErrorResponse(w, http.StatusForbidden, "not_an_owner_of_parent")
+		} else {
+			w.WriteHeader(http.StatusForbidden)
+		}
+		return
+	}
+	err = orgMgr.SetInheritance(globalid, body.Inheritance)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "setting the inheritance", err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// GetEffectiveMembers is the handler for GET /organizations/{globalid}/effectivemembers
+// Get the owners and members of the organization, including the ones inherited from the parent organizations
+func (api OrganizationsAPI) GetEffectiveMembers(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	owners, members, err := organization.NewManager(r).GetEffectiveMembers(globalid)
+	if handleServerError(w, "loading the effective members", err) {
+		return
+	}
+	response := struct {
+		Owners  []string `json:"owners"`
+		Members []string `json:"members"`
+	}{Owners: owners, Members: members}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetOrganizationMembers is the handler for GET /organizations/{globalid}/members
+// Get a page of the owners and members of the organization with the profile information they authorized the organization to see
+func (api OrganizationsAPI) GetOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	query, err := parseDirectoryQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	org, err := organization.NewManager(r).GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "loading the organization", err) {
+		return
+	}
+	if query.Role != "" && query.Role != organization.RoleOwner && query.Role != organization.RoleMember && org.GetRole(query.Role) == nil {
+		writeErrorResponse(w, 422, "invalid_role")
+		return
+	}
+	members := directoryMembers(org)
+	usernames := make([]string, len(members))
+	for i, member := range members {
+		usernames[i] = member.Username
+	}
+	userMgr := user.NewManager(r)
+	authorizationList, err := userMgr.GetAuthorizationsGrantedTo(globalid, usernames)
+	if handleServerError(w, "loading the authorizations of the members", err) {
+		return
+	}
+	authorizations := make(map[string]*user.Authorization, len(authorizationList))
+	for i := range authorizationList {
+		authorizations[authorizationList[i].Username] = &authorizationList[i]
+	}
+	//Only the names the members authorized can be searched and sorted on
+	if query.needsNames() {
+		names, err := userMgr.GetNames(usernames)
+		if handleServerError(w, "loading the names of the members", err) {
+			return
+		}
+		byUsername := make(map[string]*user.User, len(names))
+		for i := range names {
+			byUsername[names[i].Username] = &names[i]
+		}
+		for i := range members {
+			if u, found := byUsername[members[i].Username]; found {
+				filtered := authorizations[members[i].Username].FilterUser(u)
+				members[i].Firstname, members[i].Lastname = filtered.Firstname, filtered.Lastname
+			}
+		}
+	}
+	page, total := query.apply(members)
+	pageUsernames := make([]string, len(page))
+	for i, member := range page {
+		pageUsernames[i] = member.Username
+	}
+	users, err := userMgr.GetByNames(pageUsernames)
+	if handleServerError(w, "loading the members", err) {
+		return
+	}
+	for i := range users {
+		for j := range page {
+			if page[j].Username == users[i].Username {
+				page[j].project(authorizations[users[i].Username].FilterUser(&users[i]))
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Directory{Total: total, Start: query.Start, Members: page})
+}
+
+// AddOrgMember is the handler for POST /organizations/{globalid}/orgmembers
+// Make another organization a member of the organization, the user needs to be an owner of both
+func (api OrganizationsAPI) AddOrgMember(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	body := struct {
+		OrgMember string `json:"orgmember"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Debug("Error decoding the member organization: ", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	orgMgr := organization.NewManager(r)
+	org, err := orgMgr.GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "loading the organization", err) {
+		return
+	}
+	if !orgMgr.Exists(body.OrgMember) {
+		writeErrorResponse(w, 422, "orgmember_not_found")
+		return
+	}
+	username, _ := context.Get(r, "authenticateduser").(string)
+	isOwner, err := orgMgr.IsOwner(body.OrgMember, username)
+	if handleServerError(w, "checking the owners of the member organization", err) {
+		return
+	}
+	if username == "" || !isOwner {
+		writeErrorResponse(w, http.StatusForbidden, "not_an_owner_of_orgmember")
+		return
+	}
+	for _, orgMember := range org.OrgMembers {
+		if orgMember == body.OrgMember {
+			http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+			return
+		}
+	}
+	//Adding an organization that already has this organization as a member would create a cycle
+	cycle, err := orgMgr.HasOrgMember(body.OrgMember, globalid)
+	if handleServerError(w, "checking the member organizations", err) {
+		return
+	}
+	if cycle || body.OrgMember == globalid {
+		writeErrorResponse(w, 422, "orgmember_cycle")
+		return
+	}
+	if handleServerError(w, "adding the member organization", orgMgr.AddOrgMember(globalid, body.OrgMember)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(body)
+}
+
+// RemoveOrgMember is the handler for DELETE /organizations/{globalid}/orgmembers/{orgmember}
+// Remove an organization from the members of the organization
+func (api OrganizationsAPI) RemoveOrgMember(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	orgMember := mux.Vars(r)["orgmember"]
+	err := organization.NewManager(r).RemoveOrgMember(globalid, orgMember)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "removing the member organization", err) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetJoinRequests is the handler for GET /organizations/{globalid}/joinrequests
+// Get the pending requests of users to join the organization
+func (api OrganizationsAPI) GetJoinRequests(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	requests, err := invitations.NewJoinRequestManager(r).GetPendingByOrganization(globalid)
+	if handleServerError(w, "getting the join requests", err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests)
+}
+
+// ApproveJoinRequest is the handler for POST /organizations/{globalid}/joinrequests/{username}
+// Approve the request of a user to join the organization, the user becomes a member
+func (api OrganizationsAPI) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	username := mux.Vars(r)["username"]
+
+	requestMgr := invitations.NewJoinRequestManager(r)
+	request, err := requestMgr.Get(username, globalid, invitations.RequestPending)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "getting the join request", err) {
+		return
+	}
+
+	orgMgr := organization.NewManager(r)
+	org, err := orgMgr.GetByName(globalid)
+	if handleServerError(w, "getting organization", err) {
+		return
+	}
+	if handleServerError(w, "adding the member", orgMgr.SaveMember(org, username)) {
+		return
+	}
+
+	answered := db.DateTime(time.Now())
+	request.Status, request.Answered = invitations.RequestAccepted, &answered
+	if handleServerError(w, "saving the join request", requestMgr.Save(request)) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(request)
+}
+
+// RejectJoinRequest is the handler for DELETE /organizations/{globalid}/joinrequests/{username}
+// Reject the request of a user to join the organization
+func (api OrganizationsAPI) RejectJoinRequest(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+	username := mux.Vars(r)["username"]
+
+	requestMgr := invitations.NewJoinRequestManager(r)
+	request, err := requestMgr.Get(username, globalid, invitations.RequestPending)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "getting the join request", err) {
+		return
+	}
+
+	answered := db.DateTime(time.Now())
+	request.Status, request.Answered = invitations.RequestRejected, &answered
+	if handleServerError(w, "saving the join request", requestMgr.Save(request)) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetJoinPolicy is the handler for PUT /organizations/{globalid}/joinpolicy
+// Set if every user, only users with an email address in one of the dns names or nobody can request to join
+func (api OrganizationsAPI) SetJoinPolicy(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	body := struct {
+		JoinPolicy string `json:"joinpolicy"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !organization.IsValidJoinPolicy(body.JoinPolicy) {
+		writeErrorResponse(w, 422, "invalid_joinpolicy")
+		return
+	}
+
+	orgMgr := organization.NewManager(r)
+	if !orgMgr.Exists(globalid) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "saving the join policy", orgMgr.SetJoinPolicy(globalid, body.JoinPolicy)) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetInvitationWebhook is the handler for GET /organizations/{globalid}/invitationwebhook
+// Get the url the organization is notified on when an invitation is accepted or rejected
+func (api OrganizationsAPI) GetInvitationWebhook(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	org, err := organization.NewManager(r).GetByName(globalid)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else {
+			handleServerError(w, "getting organization", err)
+		}
+		return
+	}
+	if org.InvitationWebhook == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(org.InvitationWebhook)
+}
+
+// SetInvitationWebhook is the handler for PUT /organizations/{globalid}/invitationwebhook
+// Post the accepted and rejected invitations to a url instead of emailing the owners
+func (api OrganizationsAPI) SetInvitationWebhook(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	webhook := &organization.InvitationWebhook{}
+	if err := json.NewDecoder(r.Body).Decode(webhook); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !webhook.IsValid() {
+		writeErrorResponse(w, 422, "invalid_webhook")
+		return
+	}
+
+	orgMgr := organization.NewManager(r)
+	if !orgMgr.Exists(globalid) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "saving the invitation webhook", orgMgr.SetInvitationWebhook(globalid, webhook)) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// DeleteInvitationWebhook is the handler for DELETE /organizations/{globalid}/invitationwebhook
+// Remove the invitation webhook, the owners are notified by email again
+func (api OrganizationsAPI) DeleteInvitationWebhook(w http.ResponseWriter, r *http.Request) {
+	globalid := mux.Vars(r)["globalid"]
+
+	orgMgr := organization.NewManager(r)
+	if !orgMgr.Exists(globalid) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if handleServerError(w, "removing the invitation webhook", orgMgr.RemoveInvitationWebhook(globalid)) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func writeErrorResponse(responseWriter http.ResponseWriter, httpStatusCode int, message string) {
 	log.Debug(httpStatusCode, message)
 	errorResponse := struct {
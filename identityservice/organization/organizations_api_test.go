@@ -1,9 +1,11 @@
 package organization
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/gorilla/context"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,3 +26,24 @@ func TestAPIKeyLabelValidation(t *testing.T) {
 		assert.Equal(t, test.valid, isValidAPIKeyLabel(test.label), test.label)
 	}
 }
+
+func TestHasAdminAccess(t *testing.T) {
+	type testcase struct {
+		clientID string
+		scopes   string
+		valid    bool
+	}
+	testcases := []testcase{
+		testcase{clientID: "itsyouonline", scopes: "admin", valid: true},
+		testcase{clientID: "itsyouonline", scopes: "user:name", valid: false},
+		testcase{clientID: "thirdparty", scopes: "admin", valid: false},
+		testcase{clientID: "", scopes: "", valid: false},
+	}
+	for _, test := range testcases {
+		r, _ := http.NewRequest("PUT", "/organizations/org/inheritance", nil)
+		context.Set(r, "client_id", test.clientID)
+		context.Set(r, "availablescopes", test.scopes)
+		assert.Equal(t, test.valid, hasAdminAccess(r), test.clientID+" "+test.scopes)
+		context.Clear(r)
+	}
+}
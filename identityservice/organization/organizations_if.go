@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/itsyouonline/identityserver/db/organization"
 	"github.com/justinas/alice"
 )
 
@@ -68,6 +69,9 @@ type OrganizationsInterface interface { // CreateNewOrganization is the handler
 	// RemovePendingInvitation is the handler for DELETE /organizations/{globalid}/invitations/{username}
 	// Cancel a pending invitation.
 	RemovePendingInvitation(http.ResponseWriter, *http.Request)
+	// ResendInvitation is the handler for POST /organizations/{globalid}/invitations/{username}/resend
+	// Renew the pending or expired invitations of a user, email address or phone number and send them again
+	ResendInvitation(http.ResponseWriter, *http.Request)
 	// CreateDns is the handler for POST /organizations/{globalid}/dns
 	// Creates a new DNS name associated with an organization
 	CreateDns(http.ResponseWriter, *http.Request)
@@ -99,12 +103,123 @@ type OrganizationsInterface interface { // CreateNewOrganization is the handler
 	// DeleteOrganizationLogo is the handler for DELETE /organizations/globalid/logo
 	// Removes the Logo from an organization
 	DeleteOrganizationLogo(http.ResponseWriter, *http.Request)
+	// GetOrganizationBranding is the handler for GET /organizations/{globalid}/branding
+	// Get how the emails and sms sent for the organization look
+	GetOrganizationBranding(http.ResponseWriter, *http.Request)
+	// SetOrganizationBranding is the handler for PUT /organizations/{globalid}/branding
+	// Set how the emails and sms sent for the organization and its suborganizations look
+	SetOrganizationBranding(http.ResponseWriter, *http.Request)
+	// DeleteOrganizationBranding is the handler for DELETE /organizations/{globalid}/branding
+	// Removes the branding of the organization
+	DeleteOrganizationBranding(http.ResponseWriter, *http.Request)
 	// Get2faValidityTime is the handler for GET /organizations/globalid/2fa/validity
 	// Get the 2fa validity time for the organization, in seconds
 	Get2faValidityTime(w http.ResponseWriter, r *http.Request)
 	// Set2faValidityTime is the handler for PUT /organizations/globalid/2fa/validity
 	// Sets the 2fa validity time for the organization, in seconds
 	Set2faValidityTime(w http.ResponseWriter, r *http.Request)
+	// GetSAMLIdentityProvider is the handler for GET /organizations/{globalid}/saml
+	// Get the SAML identity provider the members of the organization login with
+	GetSAMLIdentityProvider(http.ResponseWriter, *http.Request)
+	// SetSAMLIdentityProvider is the handler for PUT /organizations/{globalid}/saml
+	// Configure the SAML identity provider of the organization from its metadata
+	SetSAMLIdentityProvider(http.ResponseWriter, *http.Request)
+	// DeleteSAMLIdentityProvider is the handler for DELETE /organizations/{globalid}/saml
+	// Remove the SAML identity provider of the organization
+	DeleteSAMLIdentityProvider(http.ResponseWriter, *http.Request)
+	// GetSAMLServiceProviders is the handler for GET /organizations/{globalid}/saml/serviceproviders
+	// Get the SAML service providers the organization registered
+	GetSAMLServiceProviders(http.ResponseWriter, *http.Request)
+	// CreateSAMLServiceProvider is the handler for POST /organizations/{globalid}/saml/serviceproviders
+	// Register a SAML service provider that receives assertions about the users
+	CreateSAMLServiceProvider(http.ResponseWriter, *http.Request)
+	// GetSAMLServiceProvider is the handler for GET /organizations/{globalid}/saml/serviceproviders/{label}
+	// Get a SAML service provider of the organization
+	GetSAMLServiceProvider(http.ResponseWriter, *http.Request)
+	// DeleteSAMLServiceProvider is the handler for DELETE /organizations/{globalid}/saml/serviceproviders/{label}
+	// Remove a SAML service provider of the organization
+	DeleteSAMLServiceProvider(http.ResponseWriter, *http.Request)
+	// GetLDAPSync is the handler for GET /organizations/{globalid}/ldapsync
+	// Get the LDAP groups the members and owners of the organization are synchronized with
+	GetLDAPSync(http.ResponseWriter, *http.Request)
+	// SetLDAPSync is the handler for PUT /organizations/{globalid}/ldapsync
+	// Configure the LDAP groups the members and owners of the organization are synchronized with
+	SetLDAPSync(http.ResponseWriter, *http.Request)
+	// DeleteLDAPSync is the handler for DELETE /organizations/{globalid}/ldapsync
+	// Stop synchronizing the organization with LDAP
+	DeleteLDAPSync(http.ResponseWriter, *http.Request)
+	// RunLDAPSync is the handler for POST /organizations/{globalid}/ldapsync/run
+	// Synchronize the organization with LDAP now, or list the changes it would make for a dry run
+	RunLDAPSync(http.ResponseWriter, *http.Request)
+	// GetRoles is the handler for GET /organizations/{globalid}/roles
+	// Get the roles of the organization and the permissions they grant
+	GetRoles(http.ResponseWriter, *http.Request)
+	// CreateRole is the handler for POST /organizations/{globalid}/roles
+	// Define a new role with a set of permissions
+	CreateRole(http.ResponseWriter, *http.Request)
+	// UpdateRole is the handler for PUT /organizations/{globalid}/roles/{role}
+	// Replace the permissions of a role
+	UpdateRole(http.ResponseWriter, *http.Request)
+	// DeleteRole is the handler for DELETE /organizations/{globalid}/roles/{role}
+	// Remove a role, the users it was assigned to lose its permissions
+	DeleteRole(http.ResponseWriter, *http.Request)
+	// AssignRole is the handler for POST /organizations/{globalid}/roles/{role}/members
+	// Assign a role to a member or owner of the organization
+	AssignRole(http.ResponseWriter, *http.Request)
+	// UnassignRole is the handler for DELETE /organizations/{globalid}/roles/{role}/members/{username}
+	// Take a role away from a user
+	UnassignRole(http.ResponseWriter, *http.Request)
+	// SetInheritance is the handler for PUT /organizations/{globalid}/inheritance
+	// Set if the owners, also the members or nobody of the parent organizations are owners and members of the organization
+	SetInheritance(http.ResponseWriter, *http.Request)
+	// GetEffectiveMembers is the handler for GET /organizations/{globalid}/effectivemembers
+	// Get the owners and members of the organization, including the ones inherited from the parent organizations
+	GetEffectiveMembers(http.ResponseWriter, *http.Request)
+	// GetOrganizationMembers is the handler for GET /organizations/{globalid}/members
+	// Get a page of the owners and members of the organization with the profile information they authorized the organization to see
+	GetOrganizationMembers(http.ResponseWriter, *http.Request)
+	// AddOrgMember is the handler for POST /organizations/{globalid}/orgmembers
+	// Make another organization a member of the organization, the user needs to be an owner of both
+	AddOrgMember(http.ResponseWriter, *http.Request)
+	// RemoveOrgMember is the handler for DELETE /organizations/{globalid}/orgmembers/{orgmember}
+	// Remove an organization from the members of the organization
+	RemoveOrgMember(http.ResponseWriter, *http.Request)
+	// GetDns is the handler for GET /organizations/{globalid}/dns/{dnsname}
+	// Get the verification status of a dns name and how to verify it
+	GetDns(http.ResponseWriter, *http.Request)
+	// VerifyDns is the handler for POST /organizations/{globalid}/dns/{dnsname}/verify
+	// Check if the verification token of a dns name is published in its TXT record or well-known file
+	VerifyDns(http.ResponseWriter, *http.Request)
+	// SetDomainMembership is the handler for PUT /organizations/{globalid}/domainmembership
+	// Set if users that validate an email address at a verified domain are invited, made members or left alone
+	SetDomainMembership(http.ResponseWriter, *http.Request)
+	// GetJoinRequests is the handler for GET /organizations/{globalid}/joinrequests
+	// Get the pending requests of users to join the organization
+	GetJoinRequests(http.ResponseWriter, *http.Request)
+	// ApproveJoinRequest is the handler for POST /organizations/{globalid}/joinrequests/{username}
+	// Approve the request of a user to join the organization, the user becomes a member
+	ApproveJoinRequest(http.ResponseWriter, *http.Request)
+	// RejectJoinRequest is the handler for DELETE /organizations/{globalid}/joinrequests/{username}
+	// Reject the request of a user to join the organization
+	RejectJoinRequest(http.ResponseWriter, *http.Request)
+	// SetJoinPolicy is the handler for PUT /organizations/{globalid}/joinpolicy
+	// Set if every user, only users with an email address in one of the dns names or nobody can request to join
+	SetJoinPolicy(http.ResponseWriter, *http.Request)
+	// RestoreOrganization is the handler for POST /organizations/{globalid}/restore
+	// Enable a soft deleted organization and the suborganizations deleted with it again
+	RestoreOrganization(http.ResponseWriter, *http.Request)
+	// RenameOrganization is the handler for POST /organizations/{globalid}/rename
+	// Give an organization and its suborganizations a new globalid, possibly below another parent
+	RenameOrganization(http.ResponseWriter, *http.Request)
+	// GetInvitationWebhook is the handler for GET /organizations/{globalid}/invitationwebhook
+	// Get the url the organization is notified on when an invitation is accepted or rejected
+	GetInvitationWebhook(http.ResponseWriter, *http.Request)
+	// SetInvitationWebhook is the handler for PUT /organizations/{globalid}/invitationwebhook
+	// Post the accepted and rejected invitations to a url instead of emailing the owners
+	SetInvitationWebhook(http.ResponseWriter, *http.Request)
+	// DeleteInvitationWebhook is the handler for DELETE /organizations/{globalid}/invitationwebhook
+	// Remove the invitation webhook, the owners are notified by email again
+	DeleteInvitationWebhook(http.ResponseWriter, *http.Request)
 }
 
 // OrganizationsInterfaceRoutes is routing for /organizations root endpoint
@@ -114,32 +229,70 @@ func OrganizationsInterfaceRoutes(r *mux.Router, i OrganizationsInterface) {
 	r.Handle("/organizations/{globalid}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.CreateNewSubOrganization))).Methods("POST")
 	r.Handle("/organizations/{globalid}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.UpdateOrganization))).Methods("PUT")
 	r.Handle("/organizations/{globalid}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteOrganization))).Methods("DELETE")
-	r.Handle("/organizations/{globalid}/apikeys", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetAPIKeyLabels))).Methods("GET")
-	r.Handle("/organizations/{globalid}/apikeys", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.CreateNewAPIKey))).Methods("POST")
-	r.Handle("/organizations/{globalid}/apikeys/{label}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetAPIKey))).Methods("GET")
-	r.Handle("/organizations/{globalid}/apikeys/{label}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.UpdateAPIKey))).Methods("PUT")
-	r.Handle("/organizations/{globalid}/apikeys/{label}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteAPIKey))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/apikeys", alice.New(newPermissionMiddleware(organization.PermissionManageAPIKeys).Handler).Then(http.HandlerFunc(i.GetAPIKeyLabels))).Methods("GET")
+	r.Handle("/organizations/{globalid}/apikeys", alice.New(newPermissionMiddleware(organization.PermissionManageAPIKeys).Handler).Then(http.HandlerFunc(i.CreateNewAPIKey))).Methods("POST")
+	r.Handle("/organizations/{globalid}/apikeys/{label}", alice.New(newPermissionMiddleware(organization.PermissionManageAPIKeys).Handler).Then(http.HandlerFunc(i.GetAPIKey))).Methods("GET")
+	r.Handle("/organizations/{globalid}/apikeys/{label}", alice.New(newPermissionMiddleware(organization.PermissionManageAPIKeys).Handler).Then(http.HandlerFunc(i.UpdateAPIKey))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/apikeys/{label}", alice.New(newPermissionMiddleware(organization.PermissionManageAPIKeys).Handler).Then(http.HandlerFunc(i.DeleteAPIKey))).Methods("DELETE")
 	r.Handle("/organizations/{globalid}/tree", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.GetOrganizationTree))).Methods("GET")
-	r.Handle("/organizations/{globalid}/members", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.AddOrganizationMember))).Methods("POST")
+	r.Handle("/organizations/{globalid}/members", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetOrganizationMembers))).Methods("GET")
+	r.Handle("/organizations/{globalid}/members", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.AddOrganizationMember))).Methods("POST")
 	r.Handle("/organizations/{globalid}/members", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.UpdateOrganizationMemberShip))).Methods("PUT")
 	r.Handle("/organizations/{globalid}/members/{username}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.RemoveOrganizationMember))).Methods("DELETE")
 	r.Handle("/organizations/{globalid}/owners", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.AddOrganizationOwner))).Methods("POST")
 	r.Handle("/organizations/{globalid}/owners/{username}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.RemoveOrganizationOwner))).Methods("DELETE")
-	r.Handle("/organizations/{globalid}/contracts", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner", "organization:contracts:read"}).Handler).Then(http.HandlerFunc(i.GetContracts))).Methods("GET")
-	r.Handle("/organizations/{globalid}/invitations", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetPendingInvitations))).Methods("GET")
-	r.Handle("/organizations/{globalid}/invitations/{username}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.RemovePendingInvitation))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/contracts", alice.New(newPermissionMiddleware(organization.PermissionReadContracts).Handler).Then(http.HandlerFunc(i.GetContracts))).Methods("GET")
+	r.Handle("/organizations/{globalid}/invitations", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.GetPendingInvitations))).Methods("GET")
+	r.Handle("/organizations/{globalid}/invitations/{username}", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.RemovePendingInvitation))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/invitations/{username}/resend", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.ResendInvitation))).Methods("POST")
 	r.Handle("/organizations/{globalid}/suborganizations", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.CreateNewSubOrganization))).Methods("POST")
-	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.CreateDns))).Methods("POST")
-	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.UpdateDns))).Methods("PUT")
-	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.DeleteDns))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.CreateDns))).Methods("POST")
+	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.UpdateDns))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteDns))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/dns/{dnsname}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetDns))).Methods("GET")
+	r.Handle("/organizations/{globalid}/dns/{dnsname}/verify", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.VerifyDns))).Methods("POST")
+	r.Handle("/organizations/{globalid}/domainmembership", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetDomainMembership))).Methods("PUT")
 	r.Handle("/organizations/{globalid}/tree", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.GetOrganizationTree))).Methods("GET")
-	r.Handle("/organizations/{globalid}/registry", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.ListOrganizationRegistry))).Methods("GET")
-	r.Handle("/organizations/{globalid}/registry", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.AddOrganizationRegistryEntry))).Methods("POST")
+	r.Handle("/organizations/{globalid}/registry", alice.New(newPermissionMiddleware(organization.PermissionManageRegistry).Handler).Then(http.HandlerFunc(i.ListOrganizationRegistry))).Methods("GET")
+	r.Handle("/organizations/{globalid}/registry", alice.New(newPermissionMiddleware(organization.PermissionManageRegistry).Handler).Then(http.HandlerFunc(i.AddOrganizationRegistryEntry))).Methods("POST")
 	r.Handle("/organizations/{globalid}/registry/{key}", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.GetOrganizationRegistryEntry))).Methods("GET")
-	r.Handle("/organizations/{globalid}/registry/{key}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteOrganizationRegistryEntry))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/registry/{key}", alice.New(newPermissionMiddleware(organization.PermissionManageRegistry).Handler).Then(http.HandlerFunc(i.DeleteOrganizationRegistryEntry))).Methods("DELETE")
 	r.Handle("/organizations/{globalid}/logo", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetOrganizationLogo))).Methods("PUT")
 	r.Handle("/organizations/{globalid}/logo", http.HandlerFunc(i.GetOrganizationLogo)).Methods("GET")
 	r.Handle("/organizations/{globalid}/logo", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteOrganizationLogo))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/branding", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetOrganizationBranding))).Methods("GET")
+	r.Handle("/organizations/{globalid}/branding", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetOrganizationBranding))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/branding", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteOrganizationBranding))).Methods("DELETE")
 	r.Handle("/organizations/{globalid}/2fa/validity", http.HandlerFunc(i.Get2faValidityTime)).Methods("GET")
 	r.Handle("/organizations/{globalid}/2fa/validity", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.Set2faValidityTime))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/saml", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetSAMLIdentityProvider))).Methods("GET")
+	r.Handle("/organizations/{globalid}/saml", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetSAMLIdentityProvider))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/saml", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteSAMLIdentityProvider))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/saml/serviceproviders", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetSAMLServiceProviders))).Methods("GET")
+	r.Handle("/organizations/{globalid}/saml/serviceproviders", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.CreateSAMLServiceProvider))).Methods("POST")
+	r.Handle("/organizations/{globalid}/saml/serviceproviders/{label}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetSAMLServiceProvider))).Methods("GET")
+	r.Handle("/organizations/{globalid}/saml/serviceproviders/{label}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteSAMLServiceProvider))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/ldapsync", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetLDAPSync))).Methods("GET")
+	r.Handle("/organizations/{globalid}/ldapsync", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetLDAPSync))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/ldapsync", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteLDAPSync))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/ldapsync/run", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.RunLDAPSync))).Methods("POST")
+	r.Handle("/organizations/{globalid}/roles", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:member", "organization:owner"}).Handler).Then(http.HandlerFunc(i.GetRoles))).Methods("GET")
+	r.Handle("/organizations/{globalid}/roles", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.CreateRole))).Methods("POST")
+	r.Handle("/organizations/{globalid}/roles/{role}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.UpdateRole))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/roles/{role}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteRole))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/roles/{role}/members", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.AssignRole))).Methods("POST")
+	r.Handle("/organizations/{globalid}/roles/{role}/members/{username}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.UnassignRole))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/inheritance", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.SetInheritance))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/effectivemembers", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:member", "organization:owner"}).Handler).Then(http.HandlerFunc(i.GetEffectiveMembers))).Methods("GET")
+	r.Handle("/organizations/{globalid}/orgmembers", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.AddOrgMember))).Methods("POST")
+	r.Handle("/organizations/{globalid}/orgmembers/{orgmember}", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.RemoveOrgMember))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/joinrequests", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.GetJoinRequests))).Methods("GET")
+	r.Handle("/organizations/{globalid}/joinrequests/{username}", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.ApproveJoinRequest))).Methods("POST")
+	r.Handle("/organizations/{globalid}/joinrequests/{username}", alice.New(newPermissionMiddleware(organization.PermissionInviteMembers).Handler).Then(http.HandlerFunc(i.RejectJoinRequest))).Methods("DELETE")
+	r.Handle("/organizations/{globalid}/joinpolicy", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetJoinPolicy))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/restore", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.RestoreOrganization))).Methods("POST")
+	r.Handle("/organizations/{globalid}/rename", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.RenameOrganization))).Methods("POST")
+	r.Handle("/organizations/{globalid}/invitationwebhook", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.GetInvitationWebhook))).Methods("GET")
+	r.Handle("/organizations/{globalid}/invitationwebhook", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.SetInvitationWebhook))).Methods("PUT")
+	r.Handle("/organizations/{globalid}/invitationwebhook", alice.New(newOauth2oauth_2_0Middleware([]string{"organization:owner"}).Handler).Then(http.HandlerFunc(i.DeleteInvitationWebhook))).Methods("DELETE")
 }
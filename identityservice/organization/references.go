@@ -0,0 +1,74 @@
+package organization
+
+import (
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db/company"
+	contractdb "github.com/itsyouonline/identityserver/db/contract"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/registry"
+	samldb "github.com/itsyouonline/identityserver/db/saml"
+	scimdb "github.com/itsyouonline/identityserver/db/scim"
+	"github.com/itsyouonline/identityserver/db/upstreamaccount"
+	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+	"github.com/itsyouonline/identityserver/ldapsync"
+	"github.com/itsyouonline/identityserver/oauthservice"
+)
+
+//globalIDReference is a place the globalid of an organization is stored
+type globalIDReference struct {
+	name   string
+	rename func(oldGlobalID string, newGlobalID string) error
+	//remove removes the data of a purged organization, it does nothing if there is none
+	remove func(globalID string) error
+}
+
+//globalIDReferences lists everything that needs to follow when an organization gets a new globalid or is purged.
+// The organizations themselves come first, the unique globalid index makes a rename fail on a conflict before anything else changed.
+func globalIDReferences(r *http.Request) []globalIDReference {
+	orgMgr := organization.NewManager(r)
+	logoMgr := organization.NewLogoManager(r)
+	last2FAMgr := organization.NewLast2FAManager(r)
+	brandingMgr := organization.NewBrandingManager(r)
+	invitationMgr := invitations.NewInvitationManager(r)
+	joinRequestMgr := invitations.NewJoinRequestManager(r)
+	oauthMgr := oauthservice.NewManager(r)
+	userMgr := user.NewManager(r)
+	registryMgr := registry.NewManager(r)
+	contractMgr := contractdb.NewManager(r)
+	companyMgr := company.NewCompanyManager(r)
+	samlMgr := samldb.NewManager(r)
+	scimMgr := scimdb.NewManager(r)
+	upstreamAccountMgr := upstreamaccount.NewManager(r)
+	return []globalIDReference{
+		{"organization", orgMgr.Rename, orgMgr.Purge},
+		{"logo", logoMgr.Rename, func(globalID string) error { return ignoreNotFound(logoMgr.Remove(globalID)) }},
+		{"2FA history", last2FAMgr.Rename, last2FAMgr.RemoveByOrganization},
+		{"branding", brandingMgr.Rename, brandingMgr.RemoveBranding},
+		{"invitations", invitationMgr.RenameOrganization, invitationMgr.RemoveAll},
+		{"join requests", joinRequestMgr.RenameOrganization, joinRequestMgr.RemoveAll},
+		{"oauth clients and accesstokens", oauthMgr.RenameOrganization, oauthMgr.RemoveOrganization},
+		{"authorizations", userMgr.RenameOrganization, userMgr.DeleteAllAuthorizations},
+		{"registry", registryMgr.RenameOrganization, registryMgr.RemoveOrganization},
+		{"contracts", contractMgr.RenameOrganization, contractMgr.RemoveOrganization},
+		{"companies", companyMgr.RenameOrganization, companyMgr.RemoveOrganization},
+		{"SAML configuration", samlMgr.RenameOrganization, samlMgr.RemoveOrganization},
+		{"SAML account links", func(oldGlobalID string, newGlobalID string) error {
+			return upstreamAccountMgr.RenameProvider(upstreamaccount.SAMLProvider(oldGlobalID), upstreamaccount.SAMLProvider(newGlobalID))
+		}, func(globalID string) error {
+			return upstreamAccountMgr.RemoveProvider(upstreamaccount.SAMLProvider(globalID))
+		}},
+		{"SCIM provisioned users", scimMgr.RenameOrganization, scimMgr.RemoveOrganization},
+		{"LDAP synchronization", ldapsync.RenameConfig, ldapsync.RemoveConfig},
+	}
+}
+
+func ignoreNotFound(err error) error {
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,40 @@
+package organization
+
+import (
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+)
+
+//renameOrganizations gives organizations their new globalids and updates all references to them.
+// MongoDB can not update documents in different collections atomically,
+// if a step fails the steps that were already done are reverted in the opposite order and the error is returned.
+func renameOrganizations(r *http.Request, renames []organization.Rename) (err error) {
+	type step struct {
+		reference globalIDReference
+		rename    organization.Rename
+	}
+	done := []step{}
+	for _, reference := range globalIDReferences(r) {
+		for _, rename := range renames {
+			if err = reference.rename(rename.Old, rename.New); err != nil {
+				log.Error("Error renaming the ", reference.name, " of organization ", rename.Old, " to ", rename.New, ": ", err)
+				//A step can fail halfway, but a duplicate globalid belongs to another organization that must not be touched
+				if err != db.ErrDuplicate {
+					done = append(done, step{reference: reference, rename: rename})
+				}
+				for i := len(done) - 1; i >= 0; i-- {
+					if revertErr := done[i].reference.rename(done[i].rename.New, done[i].rename.Old); revertErr != nil {
+						log.Error("Error reverting the ", done[i].reference.name, " of organization ", done[i].rename.New, " to ", done[i].rename.Old, ": ", revertErr)
+					}
+				}
+				return
+			}
+			done = append(done, step{reference: reference, rename: rename})
+		}
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package security
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/context"
+)
+
+//Authentication method references as registered in RFC 8176
+const (
+	//AMRPassword is a login with a password
+	AMRPassword = "pwd"
+	//AMROTP is a one time password from an authenticator application
+	AMROTP = "otp"
+	//AMRSMS is a code sent by sms
+	AMRSMS = "sms"
+	//AMRSoftwareKey is an approval signed by a paired device
+	AMRSoftwareKey = "swk"
+	//AMREmail is a login link sent by email
+	AMREmail = "email"
+	//AMRFederated is a login with a linked account of an upstream identity provider
+	AMRFederated = "fed"
+	//AMRMultiFactor is added when more than one factor was used
+	AMRMultiFactor = "mfa"
+)
+
+//Authentication context class references, a higher level gives more assurance
+const (
+	//ACRSingleFactor means the user authenticated with a single factor
+	ACRSingleFactor = "1"
+	//ACRMultiFactor means the user authenticated with at least two factors
+	ACRMultiFactor = "2"
+)
+
+//RecentAuthenticationMaxAge is how long after a login sensitive operations are allowed without logging in again
+const RecentAuthenticationMaxAge = 15 * time.Minute
+
+//AuthenticationContext describes how and when a user authenticated
+type AuthenticationContext struct {
+	Methods  []string
+	AuthTime time.Time
+}
+
+//AMR returns the authentication method references
+func (ac *AuthenticationContext) AMR() (amr []string) {
+	amr = append(amr, ac.Methods...)
+	if len(ac.Methods) > 1 {
+		amr = append(amr, AMRMultiFactor)
+	}
+	return
+}
+
+//ACR returns the authentication context class reference
+func (ac *AuthenticationContext) ACR() string {
+	if len(ac.Methods) > 1 {
+		return ACRMultiFactor
+	}
+	return ACRSingleFactor
+}
+
+//SatisfiesACR checks if the authentication gives at least the assurance of the requested acr
+func (ac *AuthenticationContext) SatisfiesACR(acr string) bool {
+	return acr == "" || ac.ACR() >= acr
+}
+
+//IsAuthenticatedWithin checks if the user authenticated less than maxAge ago
+func (ac *AuthenticationContext) IsAuthenticatedWithin(maxAge time.Duration) bool {
+	return !ac.AuthTime.IsZero() && time.Since(ac.AuthTime) <= maxAge
+}
+
+//RequestedACR returns the lowest known level of a space separated acr_values list.
+// An empty string is returned if no known level is requested.
+func RequestedACR(acrValues string) (acr string) {
+	for _, value := range strings.Fields(acrValues) {
+		if value != ACRSingleFactor && value != ACRMultiFactor {
+			continue
+		}
+		if acr == "" || value < acr {
+			acr = value
+		}
+	}
+	return
+}
+
+//ParseMaxAge parses a max_age parameter in seconds, ok is false if it is not present or invalid
+func ParseMaxAge(maxAge string) (duration time.Duration, ok bool) {
+	seconds, err := strconv.ParseInt(maxAge, 10, 64)
+	if err != nil || seconds < 0 {
+		return
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+//SetAuthenticationContext puts the authentication context of the caller on the request context
+func SetAuthenticationContext(r *http.Request, ac *AuthenticationContext) {
+	context.Set(r, "authenticationcontext", ac)
+}
+
+//GetAuthenticationContext returns the authentication context of the caller, nil if it is unknown
+func GetAuthenticationContext(r *http.Request) *AuthenticationContext {
+	ac, _ := context.Get(r, "authenticationcontext").(*AuthenticationContext)
+	return ac
+}
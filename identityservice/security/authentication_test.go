@@ -0,0 +1,70 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticationContextClaims(t *testing.T) {
+	type testcase struct {
+		methods []string
+		amr     []string
+		acr     string
+	}
+	testcases := []testcase{
+		testcase{methods: []string{AMRPassword}, amr: []string{AMRPassword}, acr: ACRSingleFactor},
+		testcase{methods: []string{AMREmail}, amr: []string{AMREmail}, acr: ACRSingleFactor},
+		testcase{methods: []string{AMRPassword, AMRSMS}, amr: []string{AMRPassword, AMRSMS, AMRMultiFactor}, acr: ACRMultiFactor},
+	}
+	for _, test := range testcases {
+		ac := &AuthenticationContext{Methods: test.methods}
+		assert.Equal(t, test.amr, ac.AMR())
+		assert.Equal(t, test.acr, ac.ACR())
+		assert.True(t, ac.SatisfiesACR(""))
+		assert.True(t, ac.SatisfiesACR(ACRSingleFactor))
+		assert.Equal(t, test.acr == ACRMultiFactor, ac.SatisfiesACR(ACRMultiFactor))
+	}
+}
+
+func TestIsAuthenticatedWithin(t *testing.T) {
+	ac := &AuthenticationContext{}
+	assert.False(t, ac.IsAuthenticatedWithin(time.Hour))
+
+	ac.AuthTime = time.Now().Add(-time.Minute * 5)
+	assert.True(t, ac.IsAuthenticatedWithin(time.Minute*10))
+	assert.False(t, ac.IsAuthenticatedWithin(time.Minute))
+}
+
+func TestRequestedACR(t *testing.T) {
+	type testcase struct {
+		acrValues string
+		expected  string
+	}
+	testcases := []testcase{
+		testcase{acrValues: "", expected: ""},
+		testcase{acrValues: "urn:unknown", expected: ""},
+		testcase{acrValues: "2", expected: ACRMultiFactor},
+		testcase{acrValues: "2 1", expected: ACRSingleFactor},
+		testcase{acrValues: "urn:unknown 2", expected: ACRMultiFactor},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.expected, RequestedACR(test.acrValues), test.acrValues)
+	}
+}
+
+func TestParseMaxAge(t *testing.T) {
+	maxAge, ok := ParseMaxAge("300")
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute*5, maxAge)
+
+	maxAge, ok = ParseMaxAge("0")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), maxAge)
+
+	_, ok = ParseMaxAge("")
+	assert.False(t, ok)
+	_, ok = ParseMaxAge("-1")
+	assert.False(t, ok)
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/domainverification"
 	companydb "github.com/itsyouonline/identityserver/db/company"
 	contractdb "github.com/itsyouonline/identityserver/db/contract"
+	devicedb "github.com/itsyouonline/identityserver/db/device"
 	organizationdb "github.com/itsyouonline/identityserver/db/organization"
+	samldb "github.com/itsyouonline/identityserver/db/saml"
 	userdb "github.com/itsyouonline/identityserver/db/user"
 	validationdb "github.com/itsyouonline/identityserver/db/validation"
 	"github.com/itsyouonline/identityserver/globalconfig"
@@ -39,9 +42,9 @@ type Service struct {
 }
 
 //NewService creates and initializes a Service
-func NewService(smsService communication.SMSService, emailService communication.EmailService) (service *Service) {
+func NewService(smsService communication.SMSService, emailService communication.EmailService, voiceService communication.VoiceService) (service *Service) {
 	service = &Service{smsService: smsService, emailService: emailService}
-	p := &validation.IYOPhonenumberValidationService{SMSService: smsService}
+	p := &validation.IYOPhonenumberValidationService{SMSService: smsService, VoiceService: voiceService}
 	service.phonenumberValidationService = p
 	e := &validation.IYOEmailAddressValidationService{EmailService: emailService}
 	service.emailaddresValidationService = e
@@ -54,6 +57,7 @@ func (service *Service) AddRoutes(router *mux.Router) {
 	user.UsersInterfaceRoutes(router, user.UsersAPI{SmsService: service.smsService, PhonenumberValidationService: service.phonenumberValidationService, EmailService: service.emailService, EmailAddressValidationService: service.emailaddresValidationService})
 	userdb.InitModels()
 	totp.InitModels()
+	devicedb.InitModels()
 
 	// Company API
 	company.CompaniesInterfaceRoutes(router, company.CompaniesAPI{})
@@ -64,9 +68,11 @@ func (service *Service) AddRoutes(router *mux.Router) {
 	contractdb.InitModels()
 
 	// Organization API
-	organization.OrganizationsInterfaceRoutes(router, organization.OrganizationsAPI{})
-	userorganization.UsersusernameorganizationsInterfaceRoutes(router, userorganization.UsersusernameorganizationsAPI{})
+	organization.OrganizationsInterfaceRoutes(router, organization.OrganizationsAPI{SmsService: service.smsService, EmailService: service.emailService, DomainResolver: domainverification.NetResolver{}})
+	userorganization.UsersusernameorganizationsInterfaceRoutes(router, userorganization.UsersusernameorganizationsAPI{EmailService: service.emailService})
 	organizationdb.InitModels()
+	invitations.InitModels()
+	samldb.InitModels()
 
 	// Initialize Validation models
 	validationdb.InitModels()
@@ -146,6 +152,7 @@ func (service *Service) FilterAuthorizedScopes(r *http.Request, username string,
 
 //FilterPossibleScopes filters the requestedScopes to the relevant ones that are possible
 // For example, a `user:memberof:orgid1` is not possible if the user is not a member the `orgid1` organization and there is no outstanding invite for this organization
+// and a `user:memberof:orgid1:billing` is only possible if the user has the billing role in the `orgid1` organization.
 // If allowInvitations is true, invitations to organizations allows the "user:memberof:organization" as possible scopes
 func (service *Service) FilterPossibleScopes(r *http.Request, username string, requestedScopes []string, allowInvitations bool) (possibleScopes []string, err error) {
 	possibleScopes = make([]string, 0, len(requestedScopes))
@@ -154,7 +161,17 @@ func (service *Service) FilterPossibleScopes(r *http.Request, username string, r
 	for _, rawscope := range requestedScopes {
 		scope := strings.TrimSpace(rawscope)
 		if strings.HasPrefix(scope, "user:memberof:") {
-			orgid := strings.TrimPrefix(scope, "user:memberof:")
+			orgid, role := organizationdb.SplitMembership(strings.TrimPrefix(scope, "user:memberof:"))
+			if role != "" {
+				hasRole, err := orgmgr.HasRole(orgid, username, role)
+				if err != nil {
+					return nil, err
+				}
+				if hasRole {
+					possibleScopes = append(possibleScopes, scope)
+				}
+				continue
+			}
 			isMember, err := orgmgr.IsMember(orgid, username)
 			if err != nil {
 				return nil, err
@@ -3,6 +3,7 @@ package user
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"fmt"
 
@@ -69,6 +70,7 @@ func (om *Oauth2oauth_2_0Middleware) Handler(next http.Handler) http.Handler {
 			username = token.Claims["username"].(string)
 			clientID = token.Claims["aud"].(string)
 			atscopestring = token.Claims["scope"].(string)
+			security.SetAuthenticationContext(r, authenticationContextFromClaims(token.Claims))
 
 		} else if accessToken != "" {
 			//TODO: cache
@@ -86,6 +88,7 @@ func (om *Oauth2oauth_2_0Middleware) Handler(next http.Handler) http.Handler {
 			username = at.Username
 			atscopestring = at.Scope
 			clientID = at.ClientID
+			security.SetAuthenticationContext(r, at.AuthenticationContext())
 		} else {
 			if webuser, ok := context.GetOk(r, "webuser"); ok {
 				if parsedusername, ok := webuser.(string); ok && parsedusername != "" {
@@ -127,3 +130,19 @@ func (om *Oauth2oauth_2_0Middleware) Handler(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+//authenticationContextFromClaims returns the authentication context from the auth_time and amr claims of a JWT, nil if they are not present
+func authenticationContextFromClaims(claims map[string]interface{}) *security.AuthenticationContext {
+	authTime, ok := claims["auth_time"].(float64)
+	if !ok {
+		return nil
+	}
+	ac := &security.AuthenticationContext{AuthTime: time.Unix(int64(authTime), 0)}
+	amr, _ := claims["amr"].([]interface{})
+	for _, method := range amr {
+		if method, ok := method.(string); ok && method != security.AMRMultiFactor {
+			ac.Methods = append(ac.Methods, method)
+		}
+	}
+	return ac
+}
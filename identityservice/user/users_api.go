@@ -6,6 +6,7 @@ import (
 
 	"fmt"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/context"
@@ -13,14 +14,20 @@ import (
 	"github.com/itsyouonline/identityserver/communication"
 	"github.com/itsyouonline/identityserver/credentials/password"
 	"github.com/itsyouonline/identityserver/credentials/totp"
+	"github.com/itsyouonline/identityserver/db"
 	contractdb "github.com/itsyouonline/identityserver/db/contract"
+	"github.com/itsyouonline/identityserver/db/device"
 	organizationDb "github.com/itsyouonline/identityserver/db/organization"
 	"github.com/itsyouonline/identityserver/db/registry"
+	"github.com/itsyouonline/identityserver/db/upstreamaccount"
 	"github.com/itsyouonline/identityserver/db/user"
 	"github.com/itsyouonline/identityserver/db/user/apikey"
 	validationdb "github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/db/websession"
 	"github.com/itsyouonline/identityserver/identityservice/contract"
 	"github.com/itsyouonline/identityserver/identityservice/invitations"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+	"github.com/itsyouonline/identityserver/messages"
 	"github.com/itsyouonline/identityserver/validation"
 	"gopkg.in/mgo.v2"
 )
@@ -342,6 +349,9 @@ func (api UsersAPI) DeleteGithubAccount(w http.ResponseWriter, r *http.Request)
 	username := mux.Vars(r)["username"]
 	userMgr := user.NewManager(r)
 	err := userMgr.DeleteGithubAccount(username)
+	if err == nil {
+		err = unlinkUpstreamAccount(r, username, "github")
+	}
 	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -358,6 +368,9 @@ func (api UsersAPI) DeleteFacebookAccount(w http.ResponseWriter, r *http.Request
 
 	userMgr := user.NewManager(r)
 	err := userMgr.DeleteFacebookAccount(username)
+	if err == nil {
+		err = unlinkUpstreamAccount(r, username, "facebook")
+	}
 	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -370,6 +383,9 @@ func (api UsersAPI) DeleteFacebookAccount(w http.ResponseWriter, r *http.Request
 // UpdatePassword is the handler for PUT /users/{username}/password
 func (api UsersAPI) UpdatePassword(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
+	if !requireRecentAuthentication(w, r) {
+		return
+	}
 	body := struct {
 		Currentpassword string `json:"currentpassword"`
 		Newpassword     string `json:"newpassword"`
@@ -400,6 +416,12 @@ func (api UsersAPI) UpdatePassword(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponse(w, 422, err.Error())
 		return
 	}
+	//Keep the session that changed the password, log out all others
+	if err = websession.NewManager(r).DeleteByUser(username, currentWebSession(r)); err != nil {
+		log.Error("Failed to revoke the sessions after a password change: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -427,105 +449,18 @@ func (api UsersAPI) GetUserInformation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	filtered := authorization.FilterUser(userobj)
 	respBody := &Userview{
-		Username: userobj.Username,
-	}
-
-	if authorization.Name {
-		respBody.Firstname = userobj.Firstname
-		respBody.Lastname = userobj.Lastname
-	}
-	if authorization.Github {
-		respBody.Github = userobj.Github
-	}
-	if authorization.Facebook {
-		respBody.Facebook = userobj.Facebook
-	}
-	if authorization.Addresses != nil {
-		respBody.Addresses = make([]user.Address, 0)
-
-		for _, addressmap := range authorization.Addresses {
-			address, err := userobj.GetAddressByLabel(addressmap.RealLabel)
-			if err == nil {
-				newaddress := user.Address{
-					Label:      addressmap.RequestedLabel,
-					City:       address.City,
-					Country:    address.Country,
-					Nr:         address.Nr,
-					Other:      address.Other,
-					Postalcode: address.Postalcode,
-					Street:     address.Street,
-				}
-				respBody.Addresses = append(respBody.Addresses, newaddress)
-			} else {
-				log.Debug(err)
-			}
-		}
-	}
-
-	if authorization.EmailAddresses != nil {
-		respBody.EmailAddresses = make([]user.EmailAddress, 0)
-
-		for _, emailmap := range authorization.EmailAddresses {
-			email, err := userobj.GetEmailAddressByLabel(emailmap.RealLabel)
-			if err == nil {
-				newemail := user.EmailAddress{
-					Label:        emailmap.RequestedLabel,
-					EmailAddress: email.EmailAddress,
-				}
-				respBody.EmailAddresses = append(respBody.EmailAddresses, newemail)
-			} else {
-				log.Debug(err)
-			}
-		}
-	}
-
-	if authorization.Phonenumbers != nil {
-		respBody.Phonenumbers = make([]user.Phonenumber, 0)
-		for _, phonemap := range authorization.Phonenumbers {
-			phonenumber, err := userobj.GetPhonenumberByLabel(phonemap.RealLabel)
-			if err == nil {
-				newnumber := user.Phonenumber{
-					Label:       phonemap.RequestedLabel,
-					Phonenumber: phonenumber.Phonenumber,
-				}
-				respBody.Phonenumbers = append(respBody.Phonenumbers, newnumber)
-			} else {
-				log.Debug(err)
-			}
-		}
-	}
-
-	if authorization.BankAccounts != nil {
-		respBody.BankAccounts = make([]user.BankAccount, 0)
-
-		for _, bankmap := range authorization.BankAccounts {
-			bank, err := userobj.GetBankAccountByLabel(bankmap.RealLabel)
-			if err == nil {
-				newbank := user.BankAccount{
-					Label:   bankmap.RequestedLabel,
-					Bic:     bank.Bic,
-					Country: bank.Country,
-					Iban:    bank.Iban,
-				}
-				respBody.BankAccounts = append(respBody.BankAccounts, newbank)
-			} else {
-				log.Debug(err)
-			}
-		}
-	}
-	if authorization.DigitalWallet != nil {
-		respBody.DigitalWallet = make([]user.DigitalAssetAddress, 0)
-
-		for _, addressMap := range authorization.DigitalWallet {
-			walletAddress, err := userobj.GetDigitalAssetAddressByLabel(addressMap.RealLabel)
-			if err == nil {
-				walletAddress.Label = addressMap.RequestedLabel
-				respBody.DigitalWallet = append(respBody.DigitalWallet, walletAddress)
-			} else {
-				log.Debug(err)
-			}
-		}
+		Addresses:      filtered.Addresses,
+		BankAccounts:   filtered.BankAccounts,
+		DigitalWallet:  filtered.DigitalWallet,
+		EmailAddresses: filtered.EmailAddresses,
+		Facebook:       filtered.Facebook,
+		Github:         filtered.Github,
+		Phonenumbers:   filtered.Phonenumbers,
+		Username:       filtered.Username,
+		Firstname:      filtered.Firstname,
+		Lastname:       filtered.Lastname,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -565,6 +500,7 @@ func (api UsersAPI) RegisterNewPhonenumber(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	body.Normalize("")
 	if !body.IsValid() {
 		log.Debug("Invalid phonenumber: ", body.Phonenumber)
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
@@ -670,6 +606,15 @@ func (api UsersAPI) ValidatePhoneNumber(w http.ResponseWriter, r *http.Request)
 
 	validationKey := ""
 	validationKey, err = api.PhonenumberValidationService.RequestValidation(r, username, phonenumber, fmt.Sprintf("https://%s/phonevalidation", r.Host))
+	if err == validation.ErrCannotReceiveSMS {
+		writeErrorResponse(w, 422, "phonenumber_cannot_receive_sms")
+		return
+	}
+	if err != nil {
+		log.Error("Failed to request the validation of a phonenumber: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
 	response := struct {
 		ValidationKey string `json:"validationkey"`
 	}{
@@ -680,6 +625,46 @@ func (api UsersAPI) ValidatePhoneNumber(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 }
 
+// CallPhoneNumberValidation is the handler for POST /users/{username}/phonenumbers/{label}/validate/call
+// Reads out the code of a pending phone number validation in a voice call, for users that do not receive the sms
+func (api UsersAPI) CallPhoneNumberValidation(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	label := mux.Vars(r)["label"]
+
+	values := struct {
+		ValidationKey string `json:"validationkey"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	userobj, err := user.NewManager(r).GetByName(username)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if _, err = userobj.GetPhonenumberByLabel(label); err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	err = api.PhonenumberValidationService.CallValidationCode(r, username, values.ValidationKey)
+	if err == validation.ErrInvalidOrExpiredKey {
+		writeErrorResponse(w, 422, "invalid_validationkey")
+		return
+	}
+	if err == validation.ErrTooManyCalls {
+		writeErrorResponse(w, http.StatusTooManyRequests, "too_many_calls")
+		return
+	}
+	if err != nil {
+		log.Error("Failed to call with the phonenumber validation code: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // VerifyPhoneNumber is the handler for PUT /users/{username}/phonenumbers/{label}/validate
 func (api UsersAPI) VerifyPhoneNumber(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
@@ -738,6 +723,7 @@ func (api UsersAPI) UpdatePhonenumber(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body.Normalize("")
 	if !body.IsValid() {
 		http.Error(w, "Invalid phone number", http.StatusBadRequest)
 		return
@@ -866,6 +852,9 @@ func (api UsersAPI) DeletePhonenumber(w http.ResponseWriter, r *http.Request) {
 // Create new bank account
 func (api UsersAPI) CreateUserBankAccount(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
+	if !requireRecentAuthentication(w, r) {
+		return
+	}
 	userMgr := user.NewManager(r)
 
 	bank := user.BankAccount{}
@@ -949,6 +938,9 @@ func (api UsersAPI) GetUserBankAccountByLabel(w http.ResponseWriter, r *http.Req
 func (api UsersAPI) UpdateUserBankAccount(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
 	oldlabel := mux.Vars(r)["label"]
+	if !requireRecentAuthentication(w, r) {
+		return
+	}
 	userMgr := user.NewManager(r)
 
 	newbank := user.BankAccount{}
@@ -1006,6 +998,9 @@ func (api UsersAPI) UpdateUserBankAccount(w http.ResponseWriter, r *http.Request
 func (api UsersAPI) DeleteUserBankAccount(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
 	label := mux.Vars(r)["label"]
+	if !requireRecentAuthentication(w, r) {
+		return
+	}
 	userMgr := user.NewManager(r)
 
 	user, err := userMgr.GetByName(username)
@@ -1235,8 +1230,55 @@ func (api UsersAPI) GetNotifications(w http.ResponseWriter, r *http.Request) {
 
 	notifications.Invitations = userOrgRequests
 
-	// TODO: Get Approvals and Contract requests
-	notifications.Approvals = []invitations.JoinOrganizationInvitation{}
+	// Approvals are the requests to join the organizations the user owns, directly or inherited from a parent
+	orgMgr := organizationDb.NewManager(r)
+	orgs, err := orgMgr.AllByUser(username)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	candidates := []string{}
+	for _, org := range orgs {
+		if !org.HasRole(username, organizationDb.RoleOwner) {
+			continue
+		}
+		candidates = append(candidates, org.Globalid)
+		suborganizations, err := orgMgr.GetSubOrganizations(org.Globalid)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		for _, suborganization := range suborganizations {
+			candidates = append(candidates, suborganization.Globalid)
+		}
+	}
+	owned := []string{}
+	checked := make(map[string]bool)
+	for _, globalid := range candidates {
+		if checked[globalid] {
+			continue
+		}
+		checked[globalid] = true
+		isOwner, err := orgMgr.IsOwner(globalid, username)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if isOwner {
+			owned = append(owned, globalid)
+		}
+	}
+	notifications.Approvals, err = invitations.NewJoinRequestManager(r).GetPendingByOrganizations(owned)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// TODO: Get Contract requests
 	notifications.ContractRequests = []contractdb.ContractSigningRequest{}
 
 	w.Header().Set("Content-type", "application/json")
@@ -1439,6 +1481,35 @@ func (api UsersAPI) UpdateName(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateLanguage is the handler for PUT /users/{username}/language
+// Set the language the emails and sms to the user are sent in, an empty language removes the preference
+func (api UsersAPI) UpdateLanguage(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	values := struct {
+		Language string `json:"language"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if values.Language != "" && !messages.IsLocale(values.Language) {
+		writeErrorResponse(w, 422, "unsupported_language")
+		return
+	}
+	userMgr := user.NewManager(r)
+	exists, err := userMgr.Exists(username)
+	if !exists || err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err = userMgr.UpdateLanguage(username, values.Language); err != nil {
+		log.Error("Error updating the language of ", username, ": ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // GetTwoFAMethods is the handler for GET /users/{username}/twofamethods
 // Get the possible two factor authentication methods
 func (api UsersAPI) GetTwoFAMethods(w http.ResponseWriter, r *http.Request) {
@@ -1471,7 +1542,7 @@ func (api UsersAPI) GetTwoFAMethods(w http.ResponseWriter, r *http.Request) {
 	}
 	for _, validatedPhoneNumber := range verifiedPhones {
 		for _, number := range userFromDB.Phonenumbers {
-			if number.Phonenumber == string(validatedPhoneNumber.Phonenumber) {
+			if number.Phonenumber == string(validatedPhoneNumber.Phonenumber) && number.CanReceiveSMS() {
 				response.Sms = append(response.Sms, number)
 			}
 		}
@@ -1541,6 +1612,9 @@ func (api UsersAPI) SetupTOTP(w http.ResponseWriter, r *http.Request) {
 // Removes TOTP authentication for this user, if possible.
 func (api UsersAPI) RemoveTOTP(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
+	if !requireRecentAuthentication(w, r) {
+		return
+	}
 
 	valMngr := validationdb.NewManager(r)
 	hasValidatedPhones, err := valMngr.HasValidatedPhones(username)
@@ -1588,6 +1662,98 @@ func (api UsersAPI) LeaveOrganization(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// RequestToJoinOrganization is the handler for POST /users/{username}/organizations/{globalid}/join
+// Ask the owners of an organization to become a member, after a rejection the user can only ask again once JoinRequestRetryInterval passed
+func (api UsersAPI) RequestToJoinOrganization(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	globalid := mux.Vars(r)["globalid"]
+
+	orgMgr := organizationDb.NewManager(r)
+	org, err := orgMgr.GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		writeErrorResponse(w, http.StatusNotFound, "organization_not_found")
+		return
+	} else if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	isMember, err := orgMgr.IsMember(globalid, username)
+	if err == nil && !isMember {
+		isMember, err = orgMgr.IsOwner(globalid, username)
+	}
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if isMember {
+		writeErrorResponse(w, http.StatusConflict, "already_member")
+		return
+	}
+
+	validatedEmails, err := validationdb.NewManager(r).GetByUsernameValidatedEmailAddress(username)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	emailaddresses := make([]string, len(validatedEmails))
+	for i, validated := range validatedEmails {
+		emailaddresses[i] = validated.EmailAddress
+	}
+	if !org.AcceptsJoinRequest(emailaddresses) {
+		writeErrorResponse(w, http.StatusForbidden, "join_request_not_allowed")
+		return
+	}
+	requestMgr := invitations.NewJoinRequestManager(r)
+	rejected, err := requestMgr.RecentlyRejected(username, globalid)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if rejected {
+		writeErrorResponse(w, http.StatusTooManyRequests, "join_request_rejected")
+		return
+	}
+
+	request := &invitations.JoinOrganizationInvitation{
+		Organization: globalid,
+		Role:         invitations.RoleMember,
+		User:         username,
+		Status:       invitations.RequestPending,
+		Created:      db.DateTime(time.Now()),
+	}
+	if err = requestMgr.Save(request); err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(request)
+}
+
+// CancelJoinRequest is the handler for DELETE /users/{username}/organizations/{globalid}/join
+// Withdraw a pending request to join an organization
+func (api UsersAPI) CancelJoinRequest(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	globalid := mux.Vars(r)["globalid"]
+
+	err := invitations.NewJoinRequestManager(r).RemovePending(username, globalid)
+	if err == mgo.ErrNotFound {
+		writeErrorResponse(w, http.StatusNotFound, "join_request_not_found")
+		return
+	} else if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ListUserRegistry is the handler for GET /users/{username}/registry
 // Lists the Registry entries
 func (api UsersAPI) ListUserRegistry(w http.ResponseWriter, r *http.Request) {
@@ -1674,6 +1840,259 @@ func (api UsersAPI) DeleteUserRegistryEntry(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetSessions is the handler for GET /users/{username}/sessions
+// Lists the active sessions of the user on the itsyou.online website
+func (api UsersAPI) GetSessions(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	sessions, err := websession.NewManager(r).GetByUser(username)
+	if err != nil {
+		log.Error("Error while loading the sessions: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	current := currentWebSession(r)
+	for i := range sessions {
+		sessions[i].Current = current != "" && sessions[i].Key == current
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&sessions)
+}
+
+// DeleteSession is the handler for DELETE /users/{username}/sessions/{id}
+// Terminates a session
+func (api UsersAPI) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	id := mux.Vars(r)["id"]
+
+	err := websession.NewManager(r).Delete(username, id)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error while removing a session: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteOtherSessions is the handler for DELETE /users/{username}/sessions
+// Terminates all sessions except the one making the request
+func (api UsersAPI) DeleteOtherSessions(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	if err := websession.NewManager(r).DeleteByUser(username, currentWebSession(r)); err != nil {
+		log.Error("Error while removing the sessions: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDevices is the handler for GET /users/{username}/devices
+// Lists the paired devices that can approve logins
+func (api UsersAPI) GetDevices(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	devices, err := device.NewManager(r).GetByUser(username)
+	if err != nil {
+		log.Error("Error while loading the devices: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	//The push token is only needed by the server, it is never handed out again
+	for i := range devices {
+		devices[i].PushToken = ""
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&devices)
+}
+
+// PairDevice is the handler for POST /users/{username}/devices
+// Pairs a device, the device keeps the private key matching the public key and uses it to sign login approvals
+func (api UsersAPI) PairDevice(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	newDevice := &device.Device{}
+	if err := json.NewDecoder(r.Body).Decode(newDevice); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	newDevice.Username = username
+	if err := newDevice.Validate(); err != nil {
+		log.Debug("Invalid device: ", err)
+		writeErrorResponse(w, 422, "invalid_device")
+		return
+	}
+	err := device.NewManager(r).Create(newDevice)
+	if err == db.ErrDuplicate {
+		writeErrorResponse(w, http.StatusConflict, "duplicate_label")
+		return
+	}
+	if err != nil {
+		log.Error("Error while pairing a device: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	newDevice.PushToken = ""
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newDevice)
+}
+
+// DeleteDevice is the handler for DELETE /users/{username}/devices/{id}
+// Unpairs a device
+func (api UsersAPI) DeleteDevice(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	id := mux.Vars(r)["id"]
+
+	err := device.NewManager(r).Delete(username, id)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error while removing a device: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AnswerDeviceChallenge is the handler for POST /users/{username}/devices/{id}/challenge
+// Approves or denies a pending login, the request is authenticated by the signature of the device
+func (api UsersAPI) AnswerDeviceChallenge(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	id := mux.Vars(r)["id"]
+
+	body := struct {
+		Challenge string `json:"challenge"`
+		Approve   bool   `json:"approve"`
+		Signature string `json:"signature"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	deviceMgr := device.NewManager(r)
+	pairedDevice, err := deviceMgr.Get(username, id)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error while loading a device: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	challenge, err := deviceMgr.GetChallenge(body.Challenge)
+	if err != nil {
+		log.Error("Error while loading a device challenge: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if challenge == nil || challenge.Username != username || challenge.DeviceID != id {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if !pairedDevice.VerifySignature(challenge.SignedMessage(body.Approve), body.Signature) {
+		log.Debug("Invalid device signature for user ", username)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	status := device.ChallengeDenied
+	if body.Approve {
+		status = device.ChallengeApproved
+	}
+	err = deviceMgr.AnswerChallenge(challenge.Key, id, status)
+	if err == mgo.ErrNotFound {
+		writeErrorResponse(w, http.StatusConflict, "already_answered")
+		return
+	}
+	if err != nil {
+		log.Error("Error while answering a device challenge: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUpstreamAccounts is the handler for GET /users/{username}/upstreamaccounts
+// Lists the accounts at upstream identity providers the user can login with
+func (api UsersAPI) GetUpstreamAccounts(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	accounts, err := upstreamaccount.NewManager(r).GetByUser(username)
+	if err != nil {
+		log.Error("Error while loading the upstream accounts: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&accounts)
+}
+
+// DeleteUpstreamAccount is the handler for DELETE /users/{username}/upstreamaccounts/{provider}
+// Unlinks the account of an upstream identity provider
+func (api UsersAPI) DeleteUpstreamAccount(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+	provider := mux.Vars(r)["provider"]
+
+	userMgr := user.NewManager(r)
+	var err error
+	//Github and facebook accounts linked before the upstream providers existed are only kept on the user
+	switch provider {
+	case "github":
+		if err = userMgr.DeleteGithubAccount(username); err == nil {
+			err = unlinkUpstreamAccount(r, username, provider)
+		}
+	case "facebook":
+		if err = userMgr.DeleteFacebookAccount(username); err == nil {
+			err = unlinkUpstreamAccount(r, username, provider)
+		}
+	default:
+		err = upstreamaccount.NewManager(r).Unlink(username, provider)
+	}
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error while unlinking an upstream account: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//unlinkUpstreamAccount makes sure the user can no longer login with the account of the provider
+func unlinkUpstreamAccount(r *http.Request, username string, provider string) (err error) {
+	err = upstreamaccount.NewManager(r).Unlink(username, provider)
+	if err == mgo.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+//requireRecentAuthentication writes a 403 and returns false if the user did not authenticate within the RecentAuthenticationMaxAge,
+// sensitive operations use this to make sure it is the user making the request and not someone using a forgotten session
+func requireRecentAuthentication(w http.ResponseWriter, r *http.Request) bool {
+	ac := security.GetAuthenticationContext(r)
+	if ac == nil || !ac.IsAuthenticatedWithin(security.RecentAuthenticationMaxAge) {
+		writeErrorResponse(w, http.StatusForbidden, "reauthentication_required")
+		return false
+	}
+	return true
+}
+
+//currentWebSession returns the key of the website session making the request, or an empty string if there is none
+func currentWebSession(r *http.Request) (key string) {
+	key, _ = context.Get(r, "websession").(string)
+	return
+}
+
 func writeErrorResponse(responseWrite http.ResponseWriter, httpStatusCode int, message string) {
 	log.Debug(httpStatusCode, message)
 	errorResponse := struct {
@@ -18,6 +18,9 @@ type UsersInterface interface { // Post is the handler for POST /users
 	ValidateUsername(http.ResponseWriter, *http.Request)
 	// UpdateName is the handler for PUT / users/{username}/name
 	UpdateName(http.ResponseWriter, *http.Request)
+	// UpdateLanguage is the handler for PUT /users/{username}/language
+	// Set the language the emails and sms to the user are sent in
+	UpdateLanguage(http.ResponseWriter, *http.Request)
 	// UpdatePassword is the handler for PUT /users/{username}/password
 	UpdatePassword(http.ResponseWriter, *http.Request)
 	// GetUserPhoneNumbers is the handler for GET /users/{username}/phonenumbers
@@ -31,6 +34,9 @@ type UsersInterface interface { // Post is the handler for POST /users
 	// VerifyPhoneNumber is the handler for PUT /users/{username}/phonenumbers/{label}/validate
 	// Verifies a phone number
 	VerifyPhoneNumber(http.ResponseWriter, *http.Request)
+	// CallPhoneNumberValidation is the handler for POST /users/{username}/phonenumbers/{label}/validate/call
+	// Reads out the code of a pending phone number validation in a voice call
+	CallPhoneNumberValidation(http.ResponseWriter, *http.Request)
 	// GetUserPhonenumberByLabel is the handler for GET /users/{username}/phonenumbers/{label}
 	GetUserPhonenumberByLabel(http.ResponseWriter, *http.Request)
 	// UpdatePhonenumber is the handler for PUT /users/{username}/phonenumbers/{label}
@@ -129,6 +135,10 @@ type UsersInterface interface { // Post is the handler for POST /users
 	DeleteDigitalAssetAddress(http.ResponseWriter, *http.Request)
 	// LeaveOrganization is the handler for DELETE /users/{username}/organizations/{globalid}/leave
 	LeaveOrganization(http.ResponseWriter, *http.Request)
+	// RequestToJoinOrganization is the handler for POST /users/{username}/organizations/{globalid}/join
+	RequestToJoinOrganization(http.ResponseWriter, *http.Request)
+	// CancelJoinRequest is the handler for DELETE /users/{username}/organizations/{globalid}/join
+	CancelJoinRequest(http.ResponseWriter, *http.Request)
 
 	// ListUserRegistry is the handler for GET /users/{username}/registry
 	// Lists the Registry entries
@@ -142,6 +152,33 @@ type UsersInterface interface { // Post is the handler for POST /users
 	// DeleteUserRegistryEntry is the handler for DELETE /users/{username}/registry/{key}
 	// Removes a RegistryEntry from the user's registry
 	DeleteUserRegistryEntry(http.ResponseWriter, *http.Request)
+	// GetSessions is the handler for GET /users/{username}/sessions
+	// Lists the active sessions of the user on the itsyou.online website
+	GetSessions(http.ResponseWriter, *http.Request)
+	// DeleteOtherSessions is the handler for DELETE /users/{username}/sessions
+	// Terminates all sessions except the one making the request
+	DeleteOtherSessions(http.ResponseWriter, *http.Request)
+	// DeleteSession is the handler for DELETE /users/{username}/sessions/{id}
+	// Terminates a session
+	DeleteSession(http.ResponseWriter, *http.Request)
+	// GetDevices is the handler for GET /users/{username}/devices
+	// Lists the paired devices that can approve logins
+	GetDevices(http.ResponseWriter, *http.Request)
+	// PairDevice is the handler for POST /users/{username}/devices
+	// Pairs a device
+	PairDevice(http.ResponseWriter, *http.Request)
+	// DeleteDevice is the handler for DELETE /users/{username}/devices/{id}
+	// Unpairs a device
+	DeleteDevice(http.ResponseWriter, *http.Request)
+	// AnswerDeviceChallenge is the handler for POST /users/{username}/devices/{id}/challenge
+	// Approves or denies a pending login
+	AnswerDeviceChallenge(http.ResponseWriter, *http.Request)
+	// GetUpstreamAccounts is the handler for GET /users/{username}/upstreamaccounts
+	// Lists the accounts at upstream identity providers the user can login with
+	GetUpstreamAccounts(http.ResponseWriter, *http.Request)
+	// DeleteUpstreamAccount is the handler for DELETE /users/{username}/upstreamaccounts/{provider}
+	// Unlinks the account of an upstream identity provider
+	DeleteUpstreamAccount(http.ResponseWriter, *http.Request)
 }
 
 // UsersInterfaceRoutes is routing for /users root endpoint
@@ -149,6 +186,7 @@ func UsersInterfaceRoutes(r *mux.Router, i UsersInterface) {
 	r.Handle("/users", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.Post))).Methods("POST")
 	r.Handle("/users/{username}/validate", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.ValidateUsername))).Methods("GET")
 	r.Handle("/users/{username}/name", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.UpdateName))).Methods("PUT")
+	r.Handle("/users/{username}/language", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.UpdateLanguage))).Methods("PUT")
 	r.Handle("/users/{username}/password", alice.New(newOauth2oauth_2_0Middleware([]string{}).Handler).Then(http.HandlerFunc(i.UpdatePassword))).Methods("PUT")
 	r.Handle("/users/{username}/phonenumbers", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.GetUserPhoneNumbers))).Methods("GET")
 	r.Handle("/users/{username}/phonenumbers", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.RegisterNewPhonenumber))).Methods("POST")
@@ -157,6 +195,7 @@ func UsersInterfaceRoutes(r *mux.Router, i UsersInterface) {
 	r.Handle("/users/{username}/phonenumbers/{label}", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.DeletePhonenumber))).Methods("DELETE")
 	r.Handle("/users/{username}/phonenumbers/{label}/validate", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.ValidatePhoneNumber))).Methods("POST")
 	r.Handle("/users/{username}/phonenumbers/{label}/validate", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.VerifyPhoneNumber))).Methods("PUT")
+	r.Handle("/users/{username}/phonenumbers/{label}/validate/call", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.CallPhoneNumberValidation))).Methods("POST")
 	r.Handle("/users/{username}/banks", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.GetUserBankAccounts))).Methods("GET")
 	r.Handle("/users/{username}/banks", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.CreateUserBankAccount))).Methods("POST")
 	r.Handle("/users/{username}/notifications", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.GetNotifications))).Methods("GET")
@@ -198,9 +237,20 @@ func UsersInterfaceRoutes(r *mux.Router, i UsersInterface) {
 	r.Handle("/users/{username}/totp", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.SetupTOTP))).Methods("POST")
 	r.Handle("/users/{username}/totp", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.RemoveTOTP))).Methods("DELETE")
 	r.Handle("/users/{username}/organizations/{globalid}/leave", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.LeaveOrganization))).Methods("DELETE")
+	r.Handle("/users/{username}/organizations/{globalid}/join", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.RequestToJoinOrganization))).Methods("POST")
+	r.Handle("/users/{username}/organizations/{globalid}/join", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.CancelJoinRequest))).Methods("DELETE")
 	r.Handle("/users/{username}/registry", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.ListUserRegistry))).Methods("GET")
 	r.Handle("/users/{username}/registry", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.AddUserRegistryEntry))).Methods("POST")
 	r.Handle("/users/{username}/registry/{key}", http.HandlerFunc(i.GetUserRegistryEntry)).Methods("GET")
 	r.Handle("/users/{username}/registry/{key}", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.DeleteUserRegistryEntry))).Methods("DELETE")
+	r.Handle("/users/{username}/sessions", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.GetSessions))).Methods("GET")
+	r.Handle("/users/{username}/sessions", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.DeleteOtherSessions))).Methods("DELETE")
+	r.Handle("/users/{username}/sessions/{id}", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.DeleteSession))).Methods("DELETE")
+	r.Handle("/users/{username}/devices", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.GetDevices))).Methods("GET")
+	r.Handle("/users/{username}/devices", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.PairDevice))).Methods("POST")
+	r.Handle("/users/{username}/devices/{id}", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.DeleteDevice))).Methods("DELETE")
+	r.Handle("/users/{username}/devices/{id}/challenge", http.HandlerFunc(i.AnswerDeviceChallenge)).Methods("POST")
+	r.Handle("/users/{username}/upstreamaccounts", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.GetUpstreamAccounts))).Methods("GET")
+	r.Handle("/users/{username}/upstreamaccounts/{provider}", alice.New(newOauth2oauth_2_0Middleware([]string{"user:admin"}).Handler).Then(http.HandlerFunc(i.DeleteUpstreamAccount))).Methods("DELETE")
 
 }
@@ -5,15 +5,18 @@ package userorganization
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/mux"
 
+	"github.com/itsyouonline/identityserver/communication"
 	organizationdb "github.com/itsyouonline/identityserver/db/organization"
 	"github.com/itsyouonline/identityserver/identityservice/invitations"
 )
 
 type UsersusernameorganizationsAPI struct {
+	EmailService communication.EmailService
 }
 
 func exists(value string, list []string) bool {
@@ -80,6 +83,10 @@ func (api UsersusernameorganizationsAPI) globalidrolesrolePost(w http.ResponseWr
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
+	if orgRequest.IsExpiredAt(time.Now()) {
+		http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+		return
+	}
 
 	// TODO: Save member
 	orgMgr := organizationdb.NewManager(r)
@@ -112,6 +119,7 @@ func (api UsersusernameorganizationsAPI) globalidrolesrolePost(w http.ResponseWr
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
+	invitations.NotifyOrganization(r, api.EmailService, orgRequest)
 
 	w.Header().Set("Content-type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -134,6 +142,10 @@ func (api UsersusernameorganizationsAPI) globalidrolesroleDelete(w http.Response
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
+	if orgRequest.IsExpiredAt(time.Now()) {
+		http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+		return
+	}
 
 	orgMgr := organizationdb.NewManager(r)
 
@@ -164,6 +176,7 @@ func (api UsersusernameorganizationsAPI) globalidrolesroleDelete(w http.Response
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
+	invitations.NotifyOrganization(r, api.EmailService, orgRequest)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,240 @@
+package ldapsync
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/globalconfig"
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+const (
+	//configPrefix is followed by the globalid of the organization in the globalconfig key
+	configPrefix           = "ldapsync-"
+	defaultMemberAttribute = "member"
+	defaultMailAttribute   = "mail"
+)
+
+var (
+	//ErrInvalidURL is returned when the url of the LDAP server is not an ldap:// or ldaps:// url or points to an internal address
+	ErrInvalidURL = errors.New("The url of the LDAP server is invalid")
+	//ErrNoMemberGroup is returned when no group to take the members from is configured
+	ErrNoMemberGroup = errors.New("The member group is required")
+	//ErrUnencryptedPassword is returned when a plain bind password is used without saving the configuration
+	ErrUnencryptedPassword = errors.New("The bind password is not encrypted")
+	//ErrNoEncryptionKey is returned when a bind password needs to be encrypted or decrypted but the server has no key for it
+	ErrNoEncryptionKey = errors.New("No key to encrypt the bind passwords is configured")
+	//ErrInvalidEncryptionKey is returned when the configured key is not a base64 encoded 32 byte key
+	ErrInvalidEncryptionKey = errors.New("The key to encrypt the bind passwords needs to be 32 base64 encoded bytes")
+)
+
+//encryptionKey is the key the bind passwords are encrypted with. It is given to the server at startup
+// and never stored in the database, so a copy of the database does not reveal the passwords.
+var encryptionKey []byte
+
+//SetEncryptionKey sets the base64 encoded 32 byte key the bind passwords are encrypted with
+func SetEncryptionKey(encoded string) error {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return ErrInvalidEncryptionKey
+	}
+	encryptionKey = key
+	return nil
+}
+
+//Config describes the LDAP groups an organization mirrors.
+// StartTLS upgrades an ldap:// connection before binding. The BindPassword is only given to save the configuration,
+// it is stored as the EncryptedBindPassword. The OwnerGroupDN is optional, without it the owners are not synchronized.
+// With RemoveMissing, the members and owners that are no longer in the groups are removed.
+type Config struct {
+	URL                   string `json:"url"`
+	StartTLS              bool   `json:"starttls"`
+	BindDN                string `json:"binddn"`
+	BindPassword          string `json:"bindpassword,omitempty"`
+	EncryptedBindPassword string `json:"encryptedbindpassword,omitempty"`
+	MemberGroupDN         string `json:"membergroupdn"`
+	OwnerGroupDN          string `json:"ownergroupdn,omitempty"`
+	MemberAttribute       string `json:"memberattribute,omitempty"`
+	MailAttribute         string `json:"mailattribute,omitempty"`
+	RemoveMissing         bool   `json:"removemissing"`
+}
+
+//Validate checks the configuration
+func (c *Config) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" || tools.IsInternalHost(u.Hostname()) {
+		return ErrInvalidURL
+	}
+	if strings.TrimSpace(c.MemberGroupDN) == "" {
+		return ErrNoMemberGroup
+	}
+	return nil
+}
+
+func (c *Config) memberAttribute() string {
+	if c.MemberAttribute == "" {
+		return defaultMemberAttribute
+	}
+	return c.MemberAttribute
+}
+
+func (c *Config) mailAttribute() string {
+	if c.MailAttribute == "" {
+		return defaultMailAttribute
+	}
+	return c.MailAttribute
+}
+
+//LoadConfig loads the configuration of an organization, nil is returned if it has none
+func LoadConfig(globalid string) (config *Config, err error) {
+	configMgr := globalconfig.NewManager()
+	defer configMgr.Close()
+	stored, err := configMgr.GetByKey(configPrefix + globalid)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+	config = &Config{}
+	err = json.Unmarshal([]byte(stored.Value), config)
+	return
+}
+
+//LoadConfigs loads the configurations of all organizations, by globalid
+func LoadConfigs() (configs map[string]*Config, err error) {
+	configMgr := globalconfig.NewManager()
+	defer configMgr.Close()
+	stored, err := configMgr.ListByPrefix(configPrefix)
+	if err != nil {
+		return
+	}
+	configs = make(map[string]*Config)
+	for _, entry := range stored {
+		config := &Config{}
+		if err = json.Unmarshal([]byte(entry.Value), config); err != nil {
+			return
+		}
+		configs[strings.TrimPrefix(entry.Key, configPrefix)] = config
+	}
+	return
+}
+
+//SaveConfig stores the configuration of an organization with an encrypted bind password.
+// If no bind password is given, the one that is already stored is kept.
+// A bind password can only be saved when the server has an encryption key.
+func SaveConfig(globalid string, config *Config) (err error) {
+	if config.BindPassword != "" && encryptionKey == nil {
+		return ErrNoEncryptionKey
+	}
+	configMgr := globalconfig.NewManager()
+	defer configMgr.Close()
+	if config.BindPassword != "" {
+		if config.EncryptedBindPassword, err = encrypt(encryptionKey, config.BindPassword); err != nil {
+			return
+		}
+		config.BindPassword = ""
+	} else {
+		var existing *Config
+		if existing, err = LoadConfig(globalid); err != nil {
+			return
+		}
+		config.EncryptedBindPassword = ""
+		if existing != nil {
+			config.EncryptedBindPassword = existing.EncryptedBindPassword
+		}
+	}
+	value, err := json.Marshal(config)
+	if err != nil {
+		return
+	}
+	return configMgr.Save(&globalconfig.GlobalConfig{Key: configPrefix + globalid, Value: string(value)})
+}
+
+//RemoveConfig removes the configuration of an organization
+func RemoveConfig(globalid string) (err error) {
+	configMgr := globalconfig.NewManager()
+	defer configMgr.Close()
+	err = configMgr.Delete(configPrefix + globalid)
+	if err == mgo.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+//RenameConfig moves the configuration of an organization to its new globalid, if it has one
+func RenameConfig(oldGlobalID string, newGlobalID string) (err error) {
+	configMgr := globalconfig.NewManager()
+	defer configMgr.Close()
+	stored, err := configMgr.GetByKey(configPrefix + oldGlobalID)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+	if err = configMgr.Save(&globalconfig.GlobalConfig{Key: configPrefix + newGlobalID, Value: stored.Value}); err != nil {
+		return
+	}
+	return configMgr.Delete(configPrefix + oldGlobalID)
+}
+
+//bindPassword decrypts the stored bind password
+func (c *Config) bindPassword() (password string, err error) {
+	if c.EncryptedBindPassword == "" {
+		if c.BindPassword != "" {
+			err = ErrUnencryptedPassword
+		}
+		return
+	}
+	if encryptionKey == nil {
+		err = ErrNoEncryptionKey
+		return
+	}
+	return decrypt(encryptionKey, c.EncryptedBindPassword)
+}
+
+func encrypt(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+func decrypt(key []byte, ciphertext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	if len(raw) < gcm.NonceSize() {
+		return "", errors.New("The encrypted bind password is too short")
+	}
+	plaintext, err := gcm.Open(nil, raw[:gcm.NonceSize()], raw[gcm.NonceSize():], nil)
+	return string(plaintext), err
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
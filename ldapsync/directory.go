@@ -0,0 +1,102 @@
+package ldapsync
+
+import (
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+const connectionTimeout = 30 * time.Second
+
+//Directory is the part of an LDAP connection the synchronization uses
+type Directory interface {
+	Bind(username, password string) error
+	Search(request *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+//Dial connects to the LDAP server of the configuration and binds with its credentials.
+// Servers that resolve to an internal address are refused.
+func Dial(config *Config) (dir Directory, err error) {
+	password, err := config.bindPassword()
+	if err != nil {
+		return
+	}
+	if err = config.Validate(); err != nil {
+		return
+	}
+	conn, err := ldap.DialURL(config.URL, ldap.DialWithDialer(&net.Dialer{Timeout: connectionTimeout, Control: tools.RefuseInternalAddress}))
+	if err != nil {
+		return
+	}
+	conn.SetTimeout(connectionTimeout)
+	if config.StartTLS {
+		u, _ := url.Parse(config.URL)
+		if err = conn.StartTLS(&tls.Config{ServerName: u.Hostname()}); err != nil {
+			conn.Close()
+			return
+		}
+	}
+	if config.BindDN != "" {
+		if err = conn.Bind(config.BindDN, password); err != nil {
+			conn.Close()
+			return
+		}
+	}
+	dir = conn
+	return
+}
+
+//Entry is a member of an LDAP group
+type Entry struct {
+	DN     string
+	Emails []string
+}
+
+//ReadGroup reads the members of a group and their email addresses.
+// Members that no longer exist in the directory are skipped.
+func ReadGroup(dir Directory, groupDN string, memberAttribute string, mailAttribute string) (entries []Entry, err error) {
+	group, err := readEntry(dir, groupDN, memberAttribute)
+	if err != nil {
+		return
+	}
+	for _, memberDN := range group.GetEqualFoldAttributeValues(memberAttribute) {
+		var member *ldap.Entry
+		member, err = readEntry(dir, memberDN, mailAttribute)
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		entry := Entry{DN: memberDN}
+		for _, email := range member.GetEqualFoldAttributeValues(mailAttribute) {
+			entry.Emails = append(entry.Emails, strings.ToLower(strings.TrimSpace(email)))
+		}
+		entries = append(entries, entry)
+	}
+	return
+}
+
+//readEntry reads a single attribute of an entry
+func readEntry(dir Directory, dn string, attribute string) (entry *ldap.Entry, err error) {
+	request := ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, int(connectionTimeout.Seconds()), false,
+		"(objectClass=*)", []string{attribute}, nil)
+	result, err := dir.Search(request)
+	if err != nil {
+		return
+	}
+	if len(result.Entries) == 0 {
+		err = ldap.NewError(ldap.LDAPResultNoSuchObject, nil)
+		return
+	}
+	entry = result.Entries[0]
+	return
+}
@@ -0,0 +1,311 @@
+package ldapsync
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db"
+	organizationdb "github.com/itsyouonline/identityserver/db/organization"
+	validationdb "github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+)
+
+//ErrNotConfigured is returned when an organization that does not mirror LDAP groups is synchronized
+var ErrNotConfigured = errors.New("The organization is not synchronized with LDAP")
+
+//Invitation is sent to a person in an LDAP group that is not in the organization yet.
+// People with an itsyou.online account with a validated email address are invited as that user, the others by email.
+// The username is never reported, the organization only learns it when the user accepts.
+type Invitation struct {
+	EmailAddress string `json:"emailaddress"`
+	Role         string `json:"role"`
+	username     string
+}
+
+//Result lists the changes a synchronization makes, or would make for a dry run.
+// The added members are owners that are no longer in the owner group, everybody else has to accept an invitation.
+// Unmatched contains the DN's of the group members that have no email address to invite.
+type Result struct {
+	Organization   string       `json:"organization"`
+	DryRun         bool         `json:"dryrun"`
+	AddedMembers   []string     `json:"addedmembers"`
+	RemovedMembers []string     `json:"removedmembers"`
+	RemovedOwners  []string     `json:"removedowners"`
+	Invitations    []Invitation `json:"invitations"`
+	Unmatched      []string     `json:"unmatched"`
+	resolvedEmails []string
+}
+
+//Lookup returns the username that validated an email address, or an empty string if nobody did
+type Lookup func(email string) (username string, err error)
+
+type stringSet map[string]bool
+
+func newStringSet(values []string) stringSet {
+	s := stringSet{}
+	for _, value := range values {
+		s[value] = true
+	}
+	return s
+}
+
+//groupMember is an entry of an LDAP group that matched a user by one of its email addresses
+type groupMember struct {
+	username string
+	email    string
+}
+
+//Reconcile compares the LDAP groups with the members and owners of the organization and returns the changes to make.
+// Nothing is changed in the organization itself.
+func Reconcile(dir Directory, config *Config, org *organizationdb.Organization, lookup Lookup) (result *Result, err error) {
+	result = &Result{Organization: org.Globalid}
+	var ownerEntries, memberEntries []Entry
+	if config.OwnerGroupDN != "" {
+		if ownerEntries, err = ReadGroup(dir, config.OwnerGroupDN, config.memberAttribute(), config.mailAttribute()); err != nil {
+			return
+		}
+	}
+	if memberEntries, err = ReadGroup(dir, config.MemberGroupDN, config.memberAttribute(), config.mailAttribute()); err != nil {
+		return
+	}
+
+	invited := stringSet{}
+	resolve := func(entries []Entry, role string) (matched []groupMember, err error) {
+		for _, entry := range entries {
+			var username, email string
+			for _, email = range entry.Emails {
+				if username, err = lookup(email); err != nil {
+					return
+				}
+				if username != "" {
+					break
+				}
+			}
+			switch {
+			case username != "":
+				matched = append(matched, groupMember{username: username, email: email})
+				result.resolvedEmails = append(result.resolvedEmails, entry.Emails...)
+			case len(entry.Emails) == 0:
+				result.Unmatched = append(result.Unmatched, entry.DN)
+			case !invited[entry.Emails[0]]:
+				invited[entry.Emails[0]] = true
+				result.Invitations = append(result.Invitations, Invitation{EmailAddress: entry.Emails[0], Role: role})
+			}
+		}
+		return
+	}
+	//Owners first so people in both groups are only invited as owner
+	ldapOwners, err := resolve(ownerEntries, invitations.RoleOwner)
+	if err != nil {
+		return
+	}
+	ldapMembers, err := resolve(memberEntries, invitations.RoleMember)
+	if err != nil {
+		return
+	}
+
+	owners := newStringSet(org.Owners)
+	members := newStringSet(org.Members)
+	wantedOwners := stringSet{}
+	for _, member := range ldapOwners {
+		wantedOwners[member.username] = true
+	}
+	wantedMembers := stringSet{}
+	for _, member := range ldapMembers {
+		wantedMembers[member.username] = true
+	}
+	//Owners are only removed if the owner group is synchronized and it would not leave the organization without owners
+	removeOwners := config.RemoveMissing && config.OwnerGroupDN != "" && len(wantedOwners) > 0
+
+	invitedUsers := stringSet{}
+	invite := func(member groupMember, role string) {
+		if invitedUsers[member.username] {
+			return
+		}
+		invitedUsers[member.username] = true
+		result.Invitations = append(result.Invitations, Invitation{EmailAddress: member.email, Role: role, username: member.username})
+	}
+	for _, member := range ldapOwners {
+		if !owners[member.username] {
+			invite(member, invitations.RoleOwner)
+		}
+	}
+	demoted := stringSet{}
+	if removeOwners {
+		for _, username := range org.Owners {
+			if !wantedOwners[username] {
+				delete(owners, username)
+				demoted[username] = true
+				result.RemovedOwners = append(result.RemovedOwners, username)
+			}
+		}
+	}
+	for _, member := range ldapMembers {
+		switch {
+		case owners[member.username] || members[member.username]:
+		case demoted[member.username]:
+			//A demoted owner already is in the organization
+			members[member.username] = true
+			result.AddedMembers = append(result.AddedMembers, member.username)
+		default:
+			invite(member, invitations.RoleMember)
+		}
+	}
+	if config.RemoveMissing {
+		for _, username := range org.Members {
+			//Members that are invited as owner stay members until they accept
+			if !wantedMembers[username] && !wantedOwners[username] {
+				result.RemovedMembers = append(result.RemovedMembers, username)
+			}
+		}
+	}
+	return
+}
+
+//Synchronize reconciles an organization with its LDAP groups and applies the changes unless it is a dry run
+func Synchronize(r *http.Request, globalid string, dryRun bool) (result *Result, err error) {
+	config, err := LoadConfig(globalid)
+	if err != nil {
+		return
+	}
+	if config == nil {
+		err = ErrNotConfigured
+		return
+	}
+	return synchronize(r, globalid, config, dryRun)
+}
+
+func synchronize(r *http.Request, globalid string, config *Config, dryRun bool) (result *Result, err error) {
+	org, err := organizationdb.NewManager(r).GetByName(globalid)
+	if err != nil {
+		return
+	}
+	dir, err := Dial(config)
+	if err != nil {
+		return
+	}
+	defer dir.Close()
+
+	valMgr := validationdb.NewManager(r)
+	lookup := func(email string) (username string, err error) {
+		//The mail values are lowercased, the addresses are validated as the users typed them
+		validated, err := valMgr.GetByEmailAddressValidatedEmailAddressIgnoringCase(email)
+		if err == mgo.ErrNotFound {
+			return "", nil
+		}
+		if err != nil {
+			return
+		}
+		return validated.Username, nil
+	}
+	if result, err = Reconcile(dir, config, org, lookup); err != nil {
+		return
+	}
+	result.DryRun = dryRun
+	if !dryRun {
+		err = apply(r, org, result)
+	}
+	return
+}
+
+//apply makes the changes of a synchronization
+func apply(r *http.Request, org *organizationdb.Organization, result *Result) (err error) {
+	orgMgr := organizationdb.NewManager(r)
+	for _, username := range result.RemovedOwners {
+		if err = orgMgr.RemoveOwner(org, username); err != nil {
+			return
+		}
+	}
+	for _, username := range result.AddedMembers {
+		if err = orgMgr.SaveMember(org, username); err != nil {
+			return
+		}
+	}
+	for _, username := range result.RemovedMembers {
+		if err = orgMgr.RemoveMember(org, username); err != nil {
+			return
+		}
+	}
+
+	invitationMgr := invitations.NewInvitationManager(r)
+	now := time.Now()
+	for _, invitation := range result.Invitations {
+		invite := &invitations.JoinOrganizationInvitation{
+			Organization: org.Globalid,
+			Role:         invitation.Role,
+			Status:       invitations.RequestPending,
+			Created:      db.DateTime(now),
+		}
+		if invitation.username == "" {
+			invite.EmailAddress = invitation.EmailAddress
+			if err = invitationMgr.SaveEmailInvitation(invite); err != nil {
+				return
+			}
+			continue
+		}
+		var existing []invitations.JoinOrganizationInvitation
+		if existing, err = invitationMgr.GetByUser(invitation.username); err != nil {
+			return
+		}
+		if alreadyInvited(existing, org.Globalid, invitation.Role, now) {
+			continue
+		}
+		invite.User = invitation.username
+		if err = invitationMgr.Save(invite); err != nil {
+			return
+		}
+	}
+	//People that got an account in the meantime are invited as that user, their email invitations are no longer needed
+	if len(result.resolvedEmails) > 0 {
+		err = invitationMgr.RemoveEmailInvitations(org.Globalid, result.resolvedEmails)
+	}
+	return
+}
+
+//alreadyInvited checks if a user has a pending invitation for the role or rejected it,
+// a rejected invitation is not sent again by every synchronization
+func alreadyInvited(existing []invitations.JoinOrganizationInvitation, globalid string, role string, now time.Time) bool {
+	for _, invite := range existing {
+		if invite.Organization != globalid || invite.Role != role {
+			continue
+		}
+		if invite.Status == invitations.RequestRejected || invite.Status == invitations.RequestPending && !invite.IsExpiredAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
+//Run synchronizes all configured organizations every interval, it never returns
+func Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		synchronizeAll()
+	}
+}
+
+func synchronizeAll() {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		log.Warn("LDAP synchronization skipped, no database connection")
+		return
+	}
+	configs, err := LoadConfigs()
+	if err != nil {
+		log.Error("Failed to load the LDAP synchronization configurations: ", err)
+		return
+	}
+	for globalid, config := range configs {
+		result, err := synchronize(r, globalid, config, false)
+		if err != nil {
+			log.Errorf("LDAP synchronization of %s failed: %v", globalid, err)
+			continue
+		}
+		log.Infof("LDAP synchronization of %s: %d members added, %d members and %d owners removed, %d invitations",
+			globalid, len(result.AddedMembers), len(result.RemovedMembers), len(result.RemovedOwners), len(result.Invitations))
+	}
+}
@@ -0,0 +1,249 @@
+package ldapsync
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itsyouonline/identityserver/db"
+	organizationdb "github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+)
+
+//testDirectory is an in-process LDAP stand-in that answers base object searches from a map of entries
+type testDirectory struct {
+	entries  map[string]map[string][]string
+	password string
+	bound    bool
+}
+
+func (d *testDirectory) Bind(username, password string) error {
+	if _, exists := d.entries[username]; !exists || password != d.password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)
+	}
+	d.bound = true
+	return nil
+}
+
+func (d *testDirectory) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if !d.bound {
+		return nil, ldap.NewError(ldap.LDAPResultInsufficientAccessRights, nil)
+	}
+	if request.Scope != ldap.ScopeBaseObject {
+		return nil, ldap.NewError(ldap.LDAPResultUnwillingToPerform, nil)
+	}
+	for dn, attributes := range d.entries {
+		if strings.EqualFold(dn, request.BaseDN) {
+			selected := map[string][]string{}
+			for _, name := range request.Attributes {
+				selected[name] = attributes[name]
+			}
+			return &ldap.SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(dn, selected)}}, nil
+		}
+	}
+	return nil, ldap.NewError(ldap.LDAPResultNoSuchObject, nil)
+}
+
+func (d *testDirectory) Close() {}
+
+func newTestDirectory() *testDirectory {
+	dir := &testDirectory{
+		password: "secret",
+		entries: map[string]map[string][]string{
+			"cn=sync,dc=acme,dc=com":       {},
+			"cn=owners,ou=groups,dc=acme":  {"member": {"uid=alice,dc=acme", "uid=carol,dc=acme"}},
+			"cn=members,ou=groups,dc=acme": {"member": {"uid=bob,dc=acme", "uid=carol,dc=acme", "uid=dave,dc=acme", "uid=erin,dc=acme", "uid=gone,dc=acme"}},
+			"uid=alice,dc=acme":            {"mail": {"Alice@acme.com"}},
+			"uid=bob,dc=acme":              {"mail": {"bob@old.com", "bob@acme.com"}},
+			"uid=carol,dc=acme":            {"mail": {"carol@acme.com"}},
+			"uid=dave,dc=acme":             {"mail": {"dave@acme.com"}},
+			"uid=erin,dc=acme":             {},
+		},
+	}
+	dir.Bind("cn=sync,dc=acme,dc=com", "secret")
+	return dir
+}
+
+var testUsers = map[string]string{
+	"alice@acme.com": "alice",
+	"bob@acme.com":   "bob",
+}
+
+func testLookup(email string) (string, error) {
+	return testUsers[email], nil
+}
+
+func TestReadGroup(t *testing.T) {
+	dir := newTestDirectory()
+	entries, err := ReadGroup(dir, "cn=owners,ou=groups,dc=acme", "member", "mail")
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{DN: "uid=alice,dc=acme", Emails: []string{"alice@acme.com"}},
+		{DN: "uid=carol,dc=acme", Emails: []string{"carol@acme.com"}},
+	}, entries)
+
+	//Members that no longer exist are skipped
+	entries, err = ReadGroup(dir, "cn=members,ou=groups,dc=acme", "member", "mail")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	_, err = ReadGroup(dir, "cn=unknown,ou=groups,dc=acme", "member", "mail")
+	assert.True(t, ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject))
+	assert.Error(t, dir.Bind("cn=sync,dc=acme,dc=com", "wrong"))
+}
+
+func TestReconcile(t *testing.T) {
+	config := &Config{
+		URL:           "ldap://ldap.acme.com",
+		MemberGroupDN: "cn=members,ou=groups,dc=acme",
+		OwnerGroupDN:  "cn=owners,ou=groups,dc=acme",
+	}
+	org := &organizationdb.Organization{
+		Globalid: "acme",
+		Owners:   []string{"root"},
+		Members:  []string{"alice", "frank"},
+	}
+
+	result, err := Reconcile(newTestDirectory(), config, org, testLookup)
+	assert.NoError(t, err)
+	//Nobody is added without accepting an invitation, alice stays a member until she accepts to become owner.
+	// Carol is in both groups and is only invited as owner.
+	assert.Empty(t, result.AddedMembers)
+	assert.Empty(t, result.RemovedMembers)
+	assert.Empty(t, result.RemovedOwners)
+	assert.Equal(t, []Invitation{
+		{EmailAddress: "carol@acme.com", Role: "owner"},
+		{EmailAddress: "dave@acme.com", Role: "member"},
+		{EmailAddress: "alice@acme.com", Role: "owner", username: "alice"},
+		{EmailAddress: "bob@acme.com", Role: "member", username: "bob"},
+	}, result.Invitations)
+	assert.Equal(t, []string{"uid=erin,dc=acme"}, result.Unmatched)
+	assert.Equal(t, []string{"alice@acme.com", "bob@old.com", "bob@acme.com"}, result.resolvedEmails)
+	//The usernames of the people that are not in the organization yet are not reported
+	reported, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(reported), `"bob"`)
+
+	config.RemoveMissing = true
+	result, err = Reconcile(newTestDirectory(), config, org, testLookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"root"}, result.RemovedOwners)
+	assert.Equal(t, []string{"frank"}, result.RemovedMembers)
+
+	//A synchronized organization is left alone
+	org = &organizationdb.Organization{Globalid: "acme", Owners: []string{"alice"}, Members: []string{"bob"}}
+	result, err = Reconcile(newTestDirectory(), config, org, testLookup)
+	assert.NoError(t, err)
+	assert.Empty(t, result.RemovedOwners)
+	assert.Empty(t, result.AddedMembers)
+	assert.Empty(t, result.RemovedMembers)
+	assert.Equal(t, []Invitation{
+		{EmailAddress: "carol@acme.com", Role: "owner"},
+		{EmailAddress: "dave@acme.com", Role: "member"},
+	}, result.Invitations)
+}
+
+func TestReconcileKeepsOwners(t *testing.T) {
+	org := &organizationdb.Organization{Globalid: "acme", Owners: []string{"root", "bob"}}
+
+	//Without an owner group, the owners are not touched
+	config := &Config{MemberGroupDN: "cn=members,ou=groups,dc=acme", RemoveMissing: true}
+	result, err := Reconcile(newTestDirectory(), config, org, testLookup)
+	assert.NoError(t, err)
+	assert.Empty(t, result.RemovedOwners)
+	assert.Empty(t, result.AddedMembers)
+
+	//An owner group without known users does not remove all owners
+	dir := newTestDirectory()
+	dir.entries["cn=owners,ou=groups,dc=acme"] = map[string][]string{"member": {"uid=dave,dc=acme"}}
+	config.OwnerGroupDN = "cn=owners,ou=groups,dc=acme"
+	result, err = Reconcile(dir, config, org, testLookup)
+	assert.NoError(t, err)
+	assert.Empty(t, result.RemovedOwners)
+
+	//Owners that are demoted to members are added as member, they already are in the organization
+	dir.entries["cn=owners,ou=groups,dc=acme"] = map[string][]string{"member": {"uid=alice,dc=acme"}}
+	result, err = Reconcile(dir, config, org, testLookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []Invitation{{EmailAddress: "alice@acme.com", Role: "owner", username: "alice"}}, result.Invitations[len(result.Invitations)-1:])
+	assert.Equal(t, []string{"root", "bob"}, result.RemovedOwners)
+	assert.Equal(t, []string{"bob"}, result.AddedMembers)
+}
+
+func TestAlreadyInvited(t *testing.T) {
+	now := time.Now()
+	valid := db.DateTime(now.Add(time.Hour))
+	expired := db.DateTime(now.Add(-time.Hour))
+	assert.False(t, alreadyInvited(nil, "acme", "member", now))
+	assert.True(t, alreadyInvited([]invitations.JoinOrganizationInvitation{
+		{Organization: "acme", Role: "member", Status: invitations.RequestPending, Expires: valid},
+	}, "acme", "member", now))
+	//A rejected invitation is not sent again
+	assert.True(t, alreadyInvited([]invitations.JoinOrganizationInvitation{
+		{Organization: "acme", Role: "member", Status: invitations.RequestRejected, Expires: expired},
+	}, "acme", "member", now))
+	assert.False(t, alreadyInvited([]invitations.JoinOrganizationInvitation{
+		{Organization: "acme", Role: "member", Status: invitations.RequestPending, Expires: expired},
+		{Organization: "acme", Role: "member", Status: invitations.RequestAccepted, Expires: valid},
+		{Organization: "acme", Role: "owner", Status: invitations.RequestPending, Expires: valid},
+		{Organization: "other", Role: "member", Status: invitations.RequestPending, Expires: valid},
+	}, "acme", "member", now))
+}
+
+func TestConfig(t *testing.T) {
+	config := &Config{URL: "ldaps://ldap.acme.com:636", MemberGroupDN: "cn=members,dc=acme"}
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, "member", config.memberAttribute())
+	assert.Equal(t, "mail", config.mailAttribute())
+	config.URL = "http://ldap.acme.com"
+	assert.Equal(t, ErrInvalidURL, config.Validate())
+	for _, internal := range []string{"ldap://localhost", "ldap://127.0.0.1:389", "ldaps://10.0.0.5", "ldap://[::1]:389", "ldap://169.254.169.254"} {
+		config.URL = internal
+		assert.Equal(t, ErrInvalidURL, config.Validate(), internal)
+	}
+	config.URL = "ldap://ldap.acme.com"
+	config.MemberGroupDN = " "
+	assert.Equal(t, ErrNoMemberGroup, config.Validate())
+}
+
+func TestEncryptBindPassword(t *testing.T) {
+	key := make([]byte, 32)
+	encrypted, err := encrypt(key, "secret")
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "secret")
+	decrypted, err := decrypt(key, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	otherKey := make([]byte, 32)
+	otherKey[0] = 1
+	_, err = decrypt(otherKey, encrypted)
+	assert.Error(t, err)
+}
+
+func TestEncryptionKey(t *testing.T) {
+	defer func(key []byte) { encryptionKey = key }(encryptionKey)
+	encryptionKey = nil
+
+	config := &Config{EncryptedBindPassword: "c2VjcmV0"}
+	_, err := config.bindPassword()
+	assert.Equal(t, ErrNoEncryptionKey, err)
+	assert.Equal(t, ErrNoEncryptionKey, SaveConfig("acme", &Config{BindPassword: "secret"}))
+
+	assert.Equal(t, ErrInvalidEncryptionKey, SetEncryptionKey("not base64"))
+	assert.Equal(t, ErrInvalidEncryptionKey, SetEncryptionKey("c2hvcnQ="))
+	assert.Nil(t, encryptionKey)
+
+	key := make([]byte, 32)
+	assert.NoError(t, SetEncryptionKey(base64.StdEncoding.EncodeToString(key)))
+	config.EncryptedBindPassword, err = encrypt(key, "secret")
+	assert.NoError(t, err)
+	password, err := config.bindPassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", password)
+}
@@ -0,0 +1,152 @@
+package mailqueue
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db"
+	emaildb "github.com/itsyouonline/identityserver/db/email"
+	"github.com/itsyouonline/identityserver/oauthservice"
+)
+
+//AddRoutes adds the bounce and complaint hook and the admin views of the queue to the router
+func (q *Queue) AddRoutes(router *mux.Router) {
+	router.HandleFunc("/mail/bounces", q.ReportBounce).Methods("POST")
+	router.HandleFunc("/api/mail/deadletters", q.ListDeadLetters).Methods("GET")
+	router.HandleFunc("/api/mail/deadletters/{id}/requeue", q.RequeueDeadLetter).Methods("POST")
+	router.HandleFunc("/api/mail/undeliverable", q.ListUndeliverable).Methods("GET")
+	router.HandleFunc("/api/mail/undeliverable/{emailaddress}", q.RemoveUndeliverable).Methods("DELETE")
+
+	emaildb.InitModels()
+}
+
+//ReportBounce is the handler for POST /mail/bounces, the mail provider reports an email address
+// that bounced or whose owner complained, no more mail is sent to it.
+// The provider authenticates with the bounce token as bearer token.
+func (q *Queue) ReportBounce(w http.ResponseWriter, r *http.Request) {
+	if q.bounceToken == "" {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+q.bounceToken)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	report := struct {
+		EmailAddress string `json:"emailaddress"`
+		Type         string `json:"type"`
+		Detail       string `json:"detail"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if report.EmailAddress == "" || (report.Type != emaildb.ReasonBounce && report.Type != emaildb.ReasonComplaint) {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if len(report.Detail) > 1000 {
+		report.Detail = report.Detail[:1000]
+	}
+	log.Infof("Email address %s is marked undeliverable because of a %s", report.EmailAddress, report.Type)
+	err := emaildb.NewManager(r).MarkUndeliverable(&emaildb.Undeliverable{
+		EmailAddress: report.EmailAddress,
+		Reason:       report.Type,
+		Detail:       report.Detail,
+		Created:      db.DateTime(time.Now()),
+	})
+	if err != nil {
+		log.Error("Error marking an email address undeliverable: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//ListDeadLetters is the handler for GET /api/mail/deadletters, it lists the messages that are not retried anymore
+func (q *Queue) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if !q.checkAdmin(w, r) {
+		return
+	}
+	messages, err := emaildb.NewManager(r).GetDead()
+	if err != nil {
+		log.Error("Error listing the dead emails: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&messages)
+}
+
+//RequeueDeadLetter is the handler for POST /api/mail/deadletters/{id}/requeue, it tries to send a dead message again
+func (q *Queue) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if !q.checkAdmin(w, r) {
+		return
+	}
+	err := emaildb.NewManager(r).Requeue(mux.Vars(r)["id"])
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error requeueing an email: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	q.notify()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//ListUndeliverable is the handler for GET /api/mail/undeliverable, it lists the email addresses no mail is sent to
+func (q *Queue) ListUndeliverable(w http.ResponseWriter, r *http.Request) {
+	if !q.checkAdmin(w, r) {
+		return
+	}
+	addresses, err := emaildb.NewManager(r).GetUndeliverable()
+	if err != nil {
+		log.Error("Error listing the undeliverable email addresses: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&addresses)
+}
+
+//RemoveUndeliverable is the handler for DELETE /api/mail/undeliverable/{emailaddress}, mail is sent to the address again
+func (q *Queue) RemoveUndeliverable(w http.ResponseWriter, r *http.Request) {
+	if !q.checkAdmin(w, r) {
+		return
+	}
+	err := emaildb.NewManager(r).RemoveUndeliverable(mux.Vars(r)["emailaddress"])
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error removing an undeliverable email address: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//checkAdmin writes an error response and returns false if the request is not made by an admin
+func (q *Queue) checkAdmin(w http.ResponseWriter, r *http.Request) bool {
+	allowed, err := oauthservice.IsAdmin(r, q.adminOrganization)
+	if err != nil {
+		log.Error("Error checking the mail admin access: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return false
+	}
+	if !allowed {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return false
+	}
+	return true
+}
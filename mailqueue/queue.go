@@ -0,0 +1,213 @@
+package mailqueue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/communication"
+	"github.com/itsyouonline/identityserver/db"
+	emaildb "github.com/itsyouonline/identityserver/db/email"
+)
+
+const (
+	//maxAttempts is the number of times a message is tried before it is dead
+	maxAttempts = 10
+	//firstRetryDelay is the time before the first retry, it doubles with every attempt up to maxRetryDelay
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = time.Hour
+	//attemptLease keeps other workers from sending a message while it is being sent
+	attemptLease = 5 * time.Minute
+)
+
+//Queue is an EmailService that stores the messages and returns immediately,
+// background workers hand them to the smtp server and retry the ones that fail.
+type Queue struct {
+	service           communication.EmailService
+	wake              chan struct{}
+	adminOrganization string
+	bounceToken       string
+}
+
+//NewQueue creates a Queue that sends the messages with service.
+// The owners of the adminOrganization can look into the messages that could not be sent,
+// the bounceToken authenticates the bounce and complaint reports, they are refused if it is empty.
+func NewQueue(service communication.EmailService, adminOrganization string, bounceToken string) *Queue {
+	return &Queue{
+		service:           service,
+		wake:              make(chan struct{}, 1),
+		adminOrganization: adminOrganization,
+		bounceToken:       bounceToken,
+	}
+}
+
+//Send queues an html email
+func (q *Queue) Send(recipients []string, subject string, message string) (err error) {
+	return q.SendMessage(&communication.EmailMessage{Recipients: recipients, Subject: subject, HTML: message})
+}
+
+//SendMessage queues an EmailMessage, an error is only returned if it could not be stored
+func (q *Queue) SendMessage(message *communication.EmailMessage) (err error) {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		log.Warn("No database connection, sending email without the queue")
+		go q.service.SendMessage(message)
+		return
+	}
+	id, err := newMessageID()
+	if err != nil {
+		return
+	}
+	now := db.DateTime(time.Now())
+	queued := &emaildb.Message{
+		ID:          id,
+		Recipients:  message.Recipients,
+		SenderName:  message.SenderName,
+		Subject:     message.Subject,
+		Text:        message.Text,
+		HTML:        message.HTML,
+		Status:      emaildb.StatusQueued,
+		Created:     now,
+		Updated:     now,
+		NextAttempt: now,
+	}
+	if err = emaildb.NewManager(r).Save(queued); err != nil {
+		log.Error("Error storing email in the queue: ", err)
+		return
+	}
+	q.notify()
+	return
+}
+
+//notify wakes up an idle worker, if there is one
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+//Run sends the queued messages with a number of workers, they look for due retries every interval.
+// It never returns.
+func (q *Queue) Run(workers int, interval time.Duration) {
+	for i := 1; i < workers; i++ {
+		go q.work(interval)
+	}
+	q.work(interval)
+}
+
+func (q *Queue) work(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	for {
+		q.sendDue()
+		select {
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+//sendDue sends the messages that are due until there are none left
+func (q *Queue) sendDue() {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		return
+	}
+	mgr := emaildb.NewManager(r)
+	for {
+		now := time.Now()
+		message, err := mgr.Claim(now, now.Add(attemptLease))
+		if err != nil {
+			log.Error("Error getting queued email: ", err)
+			return
+		}
+		if message == nil {
+			return
+		}
+		//Let another worker help if more messages are waiting
+		q.notify()
+		q.deliver(mgr, message)
+	}
+}
+
+//deliver sends a message to the recipients that are not marked undeliverable and records the outcome
+func (q *Queue) deliver(mgr *emaildb.Manager, message *emaildb.Message) {
+	undeliverable, err := mgr.FilterUndeliverable(message.Recipients)
+	if err == nil {
+		recipients := withoutAddresses(message.Recipients, undeliverable)
+		switch {
+		case len(recipients) == 0:
+			err = mgr.MarkDead(message.ID, "All recipients are marked undeliverable")
+		case message.Text == "":
+			err = q.record(mgr, message, q.service.Send(recipients, message.Subject, message.HTML))
+		default:
+			err = q.record(mgr, message, q.service.SendMessage(&communication.EmailMessage{
+				Recipients: recipients,
+				SenderName: message.SenderName,
+				Subject:    message.Subject,
+				Text:       message.Text,
+				HTML:       message.HTML,
+			}))
+		}
+	}
+	if err != nil {
+		log.Error("Error updating email in the queue: ", err)
+	}
+}
+
+//record saves the outcome of an attempt to send a message
+func (q *Queue) record(mgr *emaildb.Manager, message *emaildb.Message, sendErr error) error {
+	attempts := message.Attempts + 1
+	switch {
+	case sendErr == nil:
+		return mgr.MarkSent(message.ID)
+	case communication.IsPermanentEmailError(sendErr):
+		log.Errorf("Email %s to %v was refused: %v", message.ID, message.Recipients, sendErr)
+		return mgr.MarkDead(message.ID, sendErr.Error())
+	case attempts >= maxAttempts:
+		log.Errorf("Email %s to %v failed after %d attempts: %v", message.ID, message.Recipients, attempts, sendErr)
+		return mgr.MarkDead(message.ID, sendErr.Error())
+	}
+	log.Warnf("Email %s to %v is retried: %v", message.ID, message.Recipients, sendErr)
+	return mgr.MarkRetry(message.ID, sendErr.Error(), time.Now().Add(retryDelay(attempts)))
+}
+
+//retryDelay is the time to wait after a number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+//withoutAddresses returns the recipients that are not in the excluded lowercase email addresses
+func withoutAddresses(recipients []string, excluded []string) (remaining []string) {
+	skip := make(map[string]bool, len(excluded))
+	for _, address := range excluded {
+		skip[address] = true
+	}
+	for _, recipient := range recipients {
+		if !skip[strings.ToLower(recipient)] {
+			remaining = append(remaining, recipient)
+		}
+	}
+	return
+}
+
+func newMessageID() (id string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	id = hex.EncodeToString(b)
+	return
+}
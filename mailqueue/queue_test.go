@@ -0,0 +1,23 @@
+package mailqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, 32*time.Minute, retryDelay(7))
+	assert.Equal(t, time.Hour, retryDelay(8))
+	assert.Equal(t, time.Hour, retryDelay(maxAttempts))
+}
+
+func TestWithoutAddresses(t *testing.T) {
+	recipients := []string{"Bob@Example.com", "alice@example.com"}
+	assert.Equal(t, []string{"alice@example.com"}, withoutAddresses(recipients, []string{"bob@example.com"}))
+	assert.Equal(t, recipients, withoutAddresses(recipients, nil))
+	assert.Empty(t, withoutAddresses(recipients, []string{"bob@example.com", "alice@example.com"}))
+}
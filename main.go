@@ -1,8 +1,13 @@
 package main
 
 import (
+	"crypto/tls"
+	"fmt"
 	"io/ioutil"
+	"net/mail"
 	"os"
+	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/codegangsta/cli"
@@ -10,13 +15,30 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/itsyouonline/identityserver/communication"
 	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/domainverification"
 	"github.com/itsyouonline/identityserver/globalconfig"
 	"github.com/itsyouonline/identityserver/https"
 	"github.com/itsyouonline/identityserver/identityservice"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+	"github.com/itsyouonline/identityserver/identityservice/organization"
 	"github.com/itsyouonline/identityserver/identityservice/security"
+	"github.com/itsyouonline/identityserver/ldapsync"
+	"github.com/itsyouonline/identityserver/mailqueue"
 	"github.com/itsyouonline/identityserver/oauthservice"
 	"github.com/itsyouonline/identityserver/routes"
+	"github.com/itsyouonline/identityserver/scimservice"
 	"github.com/itsyouonline/identityserver/siteservice"
+	"github.com/itsyouonline/identityserver/smsoutbox"
+	"github.com/itsyouonline/identityserver/validation"
+)
+
+const (
+	//smsRetryInterval is how often the sms outbox retries the messages none of the providers accepted
+	smsRetryInterval = 10 * time.Second
+	//mailRetryInterval is how often the mail queue workers look for messages that are due to be retried
+	mailRetryInterval = 10 * time.Second
+	//organizationPurgeInterval is how often the deleted organizations whose grace period ended are purged
+	organizationPurgeInterval = time.Hour
 )
 
 func main() {
@@ -30,9 +52,17 @@ func main() {
 	var debugLogging, ignoreDevcert bool
 	var bindAddress, dbConnectionString string
 	var tlsCert, tlsKey string
-	var twilioAccountSID, twilioAuthToken, twilioMessagingServiceSID string
-	var smtpserver, smtpuser, smtppassword string
-	var smtpport int
+	var twilioAccountSID, twilioAuthToken, twilioMessagingServiceSID, twilioVoiceFrom string
+	var smsGateway, smsGatewayToken, smsProviders, smsStatusCallback string
+	var smppServer, smppSystemID, smppPassword, smppSource string
+	var smppTLS bool
+	var adminOrganization string
+	var smtpserver, smtpuser, smtppassword, smtpsender string
+	var smtpport, mailWorkers int
+	var dkimDomain, dkimSelector, dkimKey, mailBounceToken string
+	var pushGateway string
+	var ldapSyncInterval, domainVerificationInterval time.Duration
+	var ldapSyncKey string
 
 	app.Flags = []cli.Flag{
 		cli.BoolFlag{
@@ -84,6 +114,67 @@ func main() {
 			Usage:       "Twilio MessagingServiceSID",
 			Destination: &twilioMessagingServiceSID,
 		},
+		cli.StringFlag{
+			Name:        "twilio-VoiceFrom",
+			Usage:       "Twilio phone number the calls reading out login and validation codes are made from",
+			Destination: &twilioVoiceFrom,
+		},
+		cli.StringFlag{
+			Name:        "sms-gateway",
+			Usage:       "Url of a generic http gateway used to send sms",
+			Destination: &smsGateway,
+		},
+		cli.StringFlag{
+			Name:        "sms-gateway-token",
+			Usage:       "Bearer token to authenticate with the sms gateway",
+			Destination: &smsGatewayToken,
+		},
+		cli.StringFlag{
+			Name:        "smpp-server",
+			Usage:       "Address (host:port) of an SMSC used to send sms with SMPP",
+			Destination: &smppServer,
+		},
+		cli.BoolFlag{
+			Name:        "smpp-tls",
+			Usage:       "Use TLS to connect to the SMSC",
+			Destination: &smppTLS,
+		},
+		cli.StringFlag{
+			Name:        "smpp-systemid",
+			Usage:       "SMPP system_id to bind to the SMSC",
+			Destination: &smppSystemID,
+		},
+		cli.StringFlag{
+			Name:        "smpp-password",
+			Usage:       "SMPP password to bind to the SMSC",
+			Destination: &smppPassword,
+		},
+		cli.StringFlag{
+			Name:        "smpp-source",
+			Usage:       "Sender of the sms sent with SMPP, a phone number or an alphanumeric name",
+			Value:       "itsyou.online",
+			Destination: &smppSource,
+		},
+		cli.StringFlag{
+			Name:        "sms-providers",
+			Usage:       "Order in which the configured sms providers (twilio, smpp, gateway) are tried, the default is twilio,smpp,gateway",
+			Destination: &smsProviders,
+		},
+		cli.StringSliceFlag{
+			Name:  "sms-route",
+			Usage: "Providers to use for the phone numbers of a country, like +32=smpp,twilio. Can be repeated",
+			Value: &cli.StringSlice{},
+		},
+		cli.StringFlag{
+			Name:        "sms-status-callback",
+			Usage:       "Public url of this server the sms providers report the delivery of the messages to, like https://itsyou.online",
+			Destination: &smsStatusCallback,
+		},
+		cli.StringFlag{
+			Name:        "admin-organization",
+			Usage:       "Globalid of the organization whose owners can view the operational state of the server, like the failed sms",
+			Destination: &adminOrganization,
+		},
 		cli.StringFlag{
 			Name:        "smtp-server",
 			Usage:       "Host of smtp server",
@@ -105,6 +196,74 @@ func main() {
 			Destination: &smtpport,
 			Value:       587,
 		},
+		cli.StringFlag{
+			Name:        "smtp-sender",
+			Usage:       "Address the emails are sent from, an organization branding can override the display name",
+			Value:       "ItsYou.Online <noreply@itsyou.online>",
+			Destination: &smtpsender,
+		},
+		cli.IntFlag{
+			Name:        "mail-workers",
+			Usage:       "Number of background workers that send the queued emails",
+			Destination: &mailWorkers,
+			Value:       2,
+		},
+		cli.StringFlag{
+			Name:        "dkim-domain",
+			Usage:       "Domain the emails are DKIM signed for, signing is disabled if empty",
+			Destination: &dkimDomain,
+		},
+		cli.StringFlag{
+			Name:        "dkim-selector",
+			Usage:       "Selector of the DNS record with the DKIM public key",
+			Destination: &dkimSelector,
+			Value:       "itsyouonline",
+		},
+		cli.StringFlag{
+			Name:        "dkim-key",
+			Usage:       "Pem encoded RSA private key file the emails are DKIM signed with",
+			Destination: &dkimKey,
+		},
+		cli.StringFlag{
+			Name:        "mail-bounce-token",
+			Usage:       "Bearer token the mail provider authenticates the bounce and complaint reports with, reports are refused if empty",
+			Destination: &mailBounceToken,
+		},
+		cli.StringFlag{
+			Name:        "push-gateway",
+			Usage:       "Url of the gateway used to send push notifications to paired devices",
+			Destination: &pushGateway,
+		},
+		cli.DurationFlag{
+			Name:        "ldapsync-interval",
+			Usage:       "Interval between synchronizations of organizations with their LDAP groups, 0 disables them",
+			Destination: &ldapSyncInterval,
+			Value:       time.Hour,
+		},
+		cli.StringFlag{
+			Name:        "ldapsync-key",
+			Usage:       "Base64 encoded 32 byte key the LDAP bind passwords of organizations are encrypted with, bind passwords can not be saved without it",
+			EnvVar:      "IYO_LDAPSYNC_KEY",
+			Destination: &ldapSyncKey,
+		},
+		cli.DurationFlag{
+			Name:        "domainverification-interval",
+			Usage:       "Interval between the checks that the verified domains of organizations still publish their token, 0 disables them",
+			Destination: &domainVerificationInterval,
+			Value:       24 * time.Hour,
+		},
+		cli.DurationFlag{
+			Name:        "invitation-ttl",
+			Usage:       "How long an invitation to join an organization can be accepted after it is sent or resent",
+			Destination: &invitations.InvitationExpiration,
+			Value:       invitations.InvitationExpiration,
+		},
+		cli.DurationFlag{
+			Name:        "organization-deletion-grace-period",
+			Usage:       "How long a soft deleted organization can be restored before its data is purged",
+			Destination: &organization.DeletionGracePeriod,
+			Value:       organization.DeletionGracePeriod,
+		},
 	}
 
 	app.Before = func(c *cli.Context) error {
@@ -122,20 +281,40 @@ func main() {
 		defer db.Close()
 
 		cookieSecret := identityservice.GetCookieSecret()
-		var smsService communication.SMSService
 		var emailService communication.EmailService
+		smsProviderList := map[string]communication.SMSProvider{}
 		if twilioAccountSID != "" {
-			smsService = &communication.TwilioSMSService{
+			smsProviderList["twilio"] = &communication.TwilioSMSService{
 				AccountSID:          twilioAccountSID,
 				AuthToken:           twilioAuthToken,
 				MessagingServiceSID: twilioMessagingServiceSID,
 			}
-		} else {
+		}
+		if smppServer != "" {
+			smsProviderList["smpp"] = &communication.SMPPSMSService{
+				Address:       smppServer,
+				TLS:           smppTLS,
+				SystemID:      smppSystemID,
+				Password:      smppPassword,
+				SourceAddress: smppSource,
+			}
+		}
+		if smsGateway != "" {
+			smsProviderList["gateway"] = &communication.HTTPSMSService{GatewayURL: smsGateway, Token: smsGatewayToken}
+		}
+		if len(smsProviderList) == 0 {
 			log.Warn("============================================================================")
-			log.Warn("No valid Twilio Account provided, falling back to development implementation")
+			log.Warn("No sms provider configured, falling back to development implementation")
 			log.Warn("============================================================================")
-			smsService = &communication.DevSMSService{}
+			smsProviderList["dev"] = &communication.DevSMSService{}
+		}
+		smsRegistry, err := newSMSProviderRegistry(smsProviderList, smsProviders, c.StringSlice("sms-route"))
+		if err != nil {
+			log.Fatal("Invalid sms provider configuration: ", err)
 		}
+		log.Info("Sending sms with ", strings.Join(smsRegistry.Providers(), ", "))
+		smsService := smsoutbox.NewOutbox(smsRegistry, strings.TrimSuffix(smsStatusCallback, "/"), adminOrganization)
+		go smsService.Run(smsRetryInterval)
 
 		if smtpserver == "" {
 			log.Warn("============================================================================")
@@ -144,16 +323,55 @@ func main() {
 			emailService = &communication.DevEmailService{}
 
 		} else {
-			emailService = communication.NewSMTPEmailService(smtpserver, smtpport, smtpuser, smtppassword)
+			sender, err := mail.ParseAddress(smtpsender)
+			if err != nil {
+				log.Fatal("Invalid smtp sender: ", err)
+			}
+			var dkim *communication.DKIMSigner
+			if dkimDomain != "" {
+				pemKey, err := ioutil.ReadFile(dkimKey)
+				if err != nil {
+					log.Fatal("Unable to read the DKIM key: ", err)
+				}
+				if dkim, err = communication.NewDKIMSigner(dkimDomain, dkimSelector, pemKey); err != nil {
+					log.Fatal(err)
+				}
+			}
+			emailService = communication.NewSMTPEmailService(smtpserver, smtpport, smtpuser, smtppassword, *sender, dkim)
+		}
+		mailQueue := mailqueue.NewQueue(emailService, adminOrganization, mailBounceToken)
+		go mailQueue.Run(mailWorkers, mailRetryInterval)
+
+		var pushService communication.PushService
+		if pushGateway == "" {
+			log.Warn("============================================================================")
+			log.Warn("No push gateway provided, falling back to development implementation")
+			log.Warn("============================================================================")
+			pushService = &communication.DevPushService{}
+		} else {
+			pushService = &communication.HTTPPushService{GatewayURL: pushGateway}
+		}
+
+		var voiceService communication.VoiceService
+		if twilioAccountSID == "" || twilioVoiceFrom == "" {
+			log.Warn("============================================================================")
+			log.Warn("No twilio voice number provided, falling back to development implementation")
+			log.Warn("============================================================================")
+			voiceService = &communication.DevVoiceService{}
+		} else {
+			voiceService = &communication.TwilioVoiceService{
+				AccountSID: twilioAccountSID,
+				AuthToken:  twilioAuthToken,
+				From:       twilioVoiceFrom,
+			}
 		}
 
-		sc := siteservice.NewService(cookieSecret, smsService, emailService)
-		is := identityservice.NewService(smsService, emailService)
+		sc := siteservice.NewService(cookieSecret, smsService, mailQueue, pushService, voiceService)
+		is := identityservice.NewService(smsService, mailQueue, voiceService)
 
 		config := globalconfig.NewManager()
 
 		var jwtKey []byte
-		var err error
 		exists, err := config.Exists("jwtkey")
 		if err == nil && exists {
 			var jwtKeyConfig *globalconfig.GlobalConfig
@@ -178,12 +396,40 @@ func main() {
 			log.Fatal("Unable to load a valid key for signing JWT's: ", err)
 		}
 		security.JWTPublicKey = ecdsaKey.PublicKey
+
+		samlCertificate, err := loadSAMLSigningCertificate(config)
+		if err != nil {
+			log.Fatal("Unable to load a valid key for signing SAML assertions: ", err)
+		}
+		if samlCertificate != nil {
+			sc.SetSAMLSigningCertificate(*samlCertificate)
+		} else {
+			log.Warning("No key for signing SAML assertions, the SAML identity provider is disabled")
+		}
 		oauthsc, err := oauthservice.NewService(sc, is, ecdsaKey)
 		if err != nil {
 			log.Fatal("Unable to create the oauthservice: ", err)
 		}
 
-		r := routes.GetRouter(sc, is, oauthsc)
+		if ldapSyncKey != "" {
+			if err = ldapsync.SetEncryptionKey(ldapSyncKey); err != nil {
+				log.Fatal("Invalid ldapsync-key: ", err)
+			}
+		} else {
+			log.Warning("No ldapsync-key given, organizations can not save LDAP bind passwords")
+		}
+		if ldapSyncInterval > 0 {
+			go ldapsync.Run(ldapSyncInterval)
+		}
+		if domainVerificationInterval > 0 {
+			go domainverification.Run(domainverification.NetResolver{}, domainVerificationInterval)
+		}
+		go organization.RunPurge(organizationPurgeInterval)
+		go validation.NormalizeStoredPhonenumbers()
+
+		scimsc := scimservice.NewService()
+
+		r := routes.GetRouter(sc, is, oauthsc, scimsc, smsService, mailQueue)
 
 		server := https.PrepareHTTP(bindAddress, r)
 		https.PrepareHTTPS(server, tlsCert, tlsKey, ignoreDevcert)
@@ -195,3 +441,64 @@ func main() {
 
 	app.Run(os.Args)
 }
+
+//newSMSProviderRegistry registers the sms providers in the given comma separated order, the ones not in it are tried last.
+// The routes have the form +32=smpp,twilio
+func newSMSProviderRegistry(providers map[string]communication.SMSProvider, order string, routes []string) (registry *communication.SMSProviderRegistry, err error) {
+	registry = communication.NewSMSProviderRegistry()
+	for _, name := range append(strings.Split(order, ","), "twilio", "smpp", "gateway", "dev") {
+		if provider, configured := providers[strings.TrimSpace(name)]; configured {
+			registry.Register(strings.TrimSpace(name), provider)
+		}
+	}
+	for _, route := range routes {
+		parts := strings.SplitN(route, "=", 2)
+		if len(parts) != 2 {
+			err = fmt.Errorf("Invalid sms route %q", route)
+			return
+		}
+		if err = registry.AddRoute(strings.TrimSpace(parts[0]), strings.Split(strings.Replace(parts[1], " ", "", -1), ",")...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//loadSAMLSigningCertificate loads the RSA key and certificate the SAML assertions are signed with from the
+// samlkey and samlcert globalconfig, nil is returned if they are not configured and there is no development key
+func loadSAMLSigningCertificate(config *globalconfig.Manager) (certificate *tls.Certificate, err error) {
+	var keyPEM, certPEM []byte
+	exists, err := config.Exists("samlkey")
+	if err != nil {
+		return
+	}
+	if exists {
+		var keyConfig, certConfig *globalconfig.GlobalConfig
+		if keyConfig, err = config.GetByKey("samlkey"); err != nil {
+			return
+		}
+		if certConfig, err = config.GetByKey("samlcert"); err != nil {
+			return
+		}
+		keyPEM, certPEM = []byte(keyConfig.Value), []byte(certConfig.Value)
+	} else {
+		if _, e := os.Stat("devcert/saml_key.pem"); e != nil {
+			return
+		}
+		log.Warning("=========================================================================================")
+		log.Warning("This instance uses a development SAML signing key, don't do this in production !")
+		log.Warning("=========================================================================================")
+		if keyPEM, err = ioutil.ReadFile("devcert/saml_key.pem"); err != nil {
+			return
+		}
+		if certPEM, err = ioutil.ReadFile("devcert/saml_cert.pem"); err != nil {
+			return
+		}
+	}
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return
+	}
+	certificate = &keyPair
+	return
+}
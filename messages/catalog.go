@@ -0,0 +1,325 @@
+package messages
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/itsyouonline/identityserver/communication"
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/templates/packaged"
+)
+
+//Names of the messages in the catalog
+const (
+	EmailValidation        = "emailvalidation"
+	PasswordReset          = "passwordreset"
+	LoginLink              = "loginlink"
+	PhonenumberValidation  = "phonenumbervalidation"
+	LoginCode              = "logincode"
+	OrganizationInvitation = "organizationinvitation"
+	InvitationAccepted     = "invitationaccepted"
+	InvitationRejected     = "invitationrejected"
+)
+
+const (
+	//DefaultLocale is used when none of the locales a user prefers is available,
+	// every message in the catalog exists in this locale
+	DefaultLocale = "en"
+
+	catalogDirectory    = "messages"
+	commonTemplate      = "common.tmpl"
+	htmlEmailLayout     = "emailwithbutton.html"
+	textEmailLayout     = "emailwithbutton.txt"
+	defaultEmailFooter  = "ItsYou.Online"
+	templateExtension   = ".tmpl"
+	maxAcceptedLanguage = 20
+)
+
+//Data is the information a message template can refer to
+type Data struct {
+	Username     string
+	EmailAddress string
+	Organization string
+	URL          string
+	Code         string
+	//Inviter and Invitee are the users that sent and received an invitation, Note is the message of the inviter
+	Inviter string
+	Invitee string
+	Note    string
+}
+
+//Context is the locale and branding a message is rendered in
+type Context struct {
+	Locale string
+	//Organization is the name shown for the organization the message is sent for
+	Organization string
+	Branding     *organization.Branding
+}
+
+//layoutParameters are the parameters of the email layouts
+type layoutParameters struct {
+	Title      string
+	Greeting   string
+	Text       string
+	ButtonText string
+	ButtonHelp string
+	Url        string
+	Reason     string
+	Footer     string
+	Color      string
+	LogoURL    string
+	Name       string
+}
+
+//Locales returns the locales the catalog has messages for
+func Locales() (locales []string) {
+	locales, err := templates.AssetDir(catalogDirectory)
+	if err != nil {
+		log.Error("Error listing the message locales: ", err)
+		return []string{DefaultLocale}
+	}
+	sort.Strings(locales)
+	return
+}
+
+//IsLocale checks if the catalog has messages for a locale
+func IsLocale(locale string) bool {
+	for _, l := range Locales() {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+//acceptedLanguage is a language range of an Accept-Language header
+type acceptedLanguage struct {
+	language string
+	quality  float64
+}
+
+type byQuality []acceptedLanguage
+
+func (a byQuality) Len() int           { return len(a) }
+func (a byQuality) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a byQuality) Less(i, j int) bool { return a[i].quality > a[j].quality }
+
+//parseAcceptLanguage returns the base languages of an Accept-Language header, most preferred first
+func parseAcceptLanguage(header string) (languages []string) {
+	var accepted []acceptedLanguage
+	for i, part := range strings.Split(header, ",") {
+		if i >= maxAcceptedLanguage {
+			break
+		}
+		fields := strings.Split(part, ";")
+		language := strings.ToLower(strings.TrimSpace(fields[0]))
+		if language == "" || language == "*" {
+			continue
+		}
+		quality := 1.0
+		for _, parameter := range fields[1:] {
+			parameter = strings.TrimSpace(parameter)
+			if strings.HasPrefix(parameter, "q=") {
+				if q, err := strconv.ParseFloat(parameter[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		accepted = append(accepted, acceptedLanguage{language: strings.SplitN(language, "-", 2)[0], quality: quality})
+	}
+	sort.Stable(byQuality(accepted))
+	for _, a := range accepted {
+		languages = append(languages, a.language)
+	}
+	return
+}
+
+//SelectLocale returns the locale to render a message in: the preference of the user if it is available,
+// otherwise the first available language of the Accept-Language header and the DefaultLocale if there is none.
+func SelectLocale(preference string, acceptLanguage string) string {
+	if preference != "" && IsLocale(preference) {
+		return preference
+	}
+	for _, language := range parseAcceptLanguage(acceptLanguage) {
+		if IsLocale(language) {
+			return language
+		}
+	}
+	return DefaultLocale
+}
+
+//NewContext creates the Context for a message to a user, sent because of a request.
+// The language preference of the user takes precedence over the Accept-Language header of the request.
+// If globalid is not empty, the branding of that organization or of its closest parent organization is used.
+func NewContext(r *http.Request, username string, globalid string) (c *Context) {
+	c = &Context{Organization: globalid}
+	if i := strings.LastIndex(globalid, "."); i >= 0 {
+		c.Organization = globalid[i+1:]
+	}
+	preference := ""
+	if db.GetDBSession(r) != nil {
+		if username != "" {
+			if u, err := user.NewManager(r).GetByName(username); err == nil {
+				preference = u.Language
+			}
+		}
+		if globalid != "" {
+			branding, err := organization.NewBrandingManager(r).GetInheritedBranding(globalid)
+			if err != nil {
+				log.Error("Error getting the branding of ", globalid, ": ", err)
+			}
+			c.Branding = branding
+		}
+	}
+	if c.Branding != nil && c.Branding.Name != "" {
+		c.Organization = c.Branding.Name
+	}
+	c.Locale = SelectLocale(preference, r.Header.Get("Accept-Language"))
+	return
+}
+
+//load parses the common templates and the templates of a message in the locale of the context,
+// falling back to the DefaultLocale if the message is not translated
+func (c *Context) load(name string) (t *template.Template, err error) {
+	locale := c.Locale
+	if _, err = templates.Asset(path.Join(catalogDirectory, locale, name+templateExtension)); err != nil {
+		locale = DefaultLocale
+	}
+	t = template.New(name)
+	for _, file := range []string{commonTemplate, name + templateExtension} {
+		var content []byte
+		if content, err = templates.Asset(path.Join(catalogDirectory, locale, file)); err != nil {
+			return
+		}
+		if _, err = t.Parse(string(content)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *Context) data(data *Data) *Data {
+	d := *data
+	if d.Organization == "" {
+		d.Organization = c.Organization
+	}
+	return &d
+}
+
+func execute(t *template.Template, name string, data interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	err := t.ExecuteTemplate(buf, name, data)
+	return buf.String(), err
+}
+
+//RenderSMS renders an sms message
+func (c *Context) RenderSMS(name string, data *Data) (message string, err error) {
+	t, err := c.load(name)
+	if err != nil {
+		log.Error("Error loading message ", name, ": ", err)
+		return
+	}
+	return execute(t, "sms", c.data(data))
+}
+
+//RenderVoice renders the message that is read out when calling a user and the language it is spoken in.
+// The digits of the code are separated so text-to-speech reads them one by one.
+func (c *Context) RenderVoice(name string, data *Data) (message string, language string, err error) {
+	t, err := c.load(name)
+	if err != nil {
+		log.Error("Error loading message ", name, ": ", err)
+		return
+	}
+	data = c.data(data)
+	data.Code = strings.Join(strings.Split(data.Code, ""), ", ")
+	if message, err = execute(t, "voice", data); err != nil {
+		return
+	}
+	language, err = execute(t, "voicelanguage", data)
+	return
+}
+
+//RenderEmail renders an email message with an html body and a plain text alternative,
+// the recipients still need to be filled in
+func (c *Context) RenderEmail(name string, data *Data) (message *communication.EmailMessage, err error) {
+	t, err := c.load(name)
+	if err != nil {
+		log.Error("Error loading message ", name, ": ", err)
+		return
+	}
+	data = c.data(data)
+	parameters := &layoutParameters{Url: data.URL, Footer: defaultEmailFooter}
+	message = &communication.EmailMessage{}
+	for _, part := range []struct {
+		name  string
+		value *string
+	}{
+		{"subject", &message.Subject},
+		{"title", &parameters.Title},
+		{"greeting", &parameters.Greeting},
+		{"text", &parameters.Text},
+		{"button", &parameters.ButtonText},
+		{"buttonhelp", &parameters.ButtonHelp},
+		{"reason", &parameters.Reason},
+	} {
+		if *part.value, err = execute(t, part.name, data); err != nil {
+			log.Error("Error rendering ", part.name, " of message ", name, ": ", err)
+			return nil, err
+		}
+	}
+	if b := c.Branding; b != nil {
+		parameters.Color = b.Color
+		parameters.LogoURL = b.LogoURL
+		parameters.Name = b.Name
+		message.SenderName = b.SenderName
+		if b.Footer != "" {
+			parameters.Footer = b.Footer
+		}
+	}
+	if message.HTML, err = renderLayout(htmlEmailLayout, true, parameters); err != nil {
+		return nil, err
+	}
+	if message.Text, err = renderLayout(textEmailLayout, false, parameters); err != nil {
+		return nil, err
+	}
+	return
+}
+
+//renderLayout renders an email layout, the html layout escapes the parameters
+func renderLayout(layout string, html bool, parameters *layoutParameters) (rendered string, err error) {
+	content, err := templates.Asset(layout)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	buf := &bytes.Buffer{}
+	if html {
+		var t *htmltemplate.Template
+		if t, err = htmltemplate.New(layout).Parse(string(content)); err == nil {
+			err = t.Execute(buf, parameters)
+		}
+	} else {
+		var t *template.Template
+		if t, err = template.New(layout).Parse(string(content)); err == nil {
+			err = t.Execute(buf, parameters)
+		}
+	}
+	if err != nil {
+		log.Error("Error rendering ", layout, ": ", err)
+		return
+	}
+	rendered = buf.String()
+	return
+}
@@ -0,0 +1,139 @@
+package messages
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectLocale(t *testing.T) {
+	assert.Equal(t, []string{"en", "nl"}, Locales())
+	assert.Equal(t, []string{"fr", "nl", "en"}, parseAcceptLanguage("nl-BE;q=0.8, fr, en;q=0.5, de;q=0, *;q=0.1"))
+
+	assert.Equal(t, "nl", SelectLocale("nl", "en-US"))
+	assert.Equal(t, "nl", SelectLocale("", "fr-FR, nl-BE;q=0.8, en;q=0.5"))
+	assert.Equal(t, "en", SelectLocale("xx", "en, nl"))
+	assert.Equal(t, DefaultLocale, SelectLocale("", "fr, de"))
+	assert.Equal(t, DefaultLocale, SelectLocale("", ""))
+}
+
+func TestNewContextWithoutDatabase(t *testing.T) {
+	r, _ := http.NewRequest("POST", "/login", nil)
+	r.Header.Set("Accept-Language", "nl-BE,nl;q=0.9")
+	c := NewContext(r, "bob", "acme.sales")
+	assert.Equal(t, "nl", c.Locale)
+	assert.Equal(t, "sales", c.Organization)
+	assert.Nil(t, c.Branding)
+}
+
+func TestRenderSMS(t *testing.T) {
+	c := &Context{Locale: "en", Organization: "acme"}
+	sms, err := c.RenderSMS(LoginCode, &Data{Code: "123456", URL: "https://itsyou.online/sc?c=123456&k=key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "To continue signing in at itsyou.online to authorize the organization acme, enter the code 123456 in the form or use this link: https://itsyou.online/sc?c=123456&k=key", sms)
+
+	c = &Context{Locale: "nl"}
+	sms, err = c.RenderSMS(PhonenumberValidation, &Data{Code: "654321", URL: "https://itsyou.online/pvc?c=654321&k=key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Om je telefoonnummer op itsyou.online te bevestigen, geef je de code 654321 in op het formulier of gebruik je deze link: https://itsyou.online/pvc?c=654321&k=key", sms)
+
+	_, err = c.RenderSMS("unknown", &Data{})
+	assert.Error(t, err)
+}
+
+func TestRenderEmail(t *testing.T) {
+	c := &Context{Locale: "nl"}
+	message, err := c.RenderEmail(EmailValidation, &Data{Username: "bob", EmailAddress: "bob@example.com", URL: "https://itsyou.online/emailvalidation?c=secret&k=key"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "ItsYou.Online e-mailverificatie", message.Subject)
+	assert.Empty(t, message.SenderName)
+	assert.True(t, strings.HasPrefix(message.Text, "Hallo bob!\n\nKlik op de knop hieronder om je e-mailadres bob@example.com"))
+	assert.Contains(t, message.Text, "E-mailadres bevestigen: https://itsyou.online/emailvalidation?c=secret&k=key")
+	assert.Contains(t, message.HTML, `href="https://itsyou.online/emailvalidation?c=secret&amp;k=key"`)
+	assert.Contains(t, message.HTML, "#929598")
+	assert.Contains(t, message.HTML, "<svg")
+
+	c = &Context{Locale: "en", Organization: "Acme <Corp>", Branding: &organization.Branding{
+		Name:       "Acme <Corp>",
+		LogoURL:    "https://acme.example.com/logo.png",
+		Color:      "#336699",
+		SenderName: "Acme",
+		Footer:     "Acme Corp, Main Street 1",
+	}}
+	message, err = c.RenderEmail(LoginLink, &Data{Username: "alice", URL: "https://itsyou.online/login/emaillink?c=secret"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Acme", message.SenderName)
+	assert.Contains(t, message.Text, "To log in to ItsYou.Online for Acme <Corp>, click the button below.")
+	assert.Contains(t, message.Text, "--\nAcme Corp, Main Street 1")
+	assert.Contains(t, message.HTML, "for Acme &lt;Corp&gt;, click")
+	assert.Contains(t, message.HTML, `<img src="https://acme.example.com/logo.png" alt="Acme &lt;Corp&gt;"`)
+	assert.Contains(t, message.HTML, "#336699")
+	assert.NotContains(t, message.HTML, "<svg")
+}
+
+func TestRenderFallsBackToDefaultLocale(t *testing.T) {
+	c := &Context{Locale: "xx"}
+	message, err := c.RenderEmail(PasswordReset, &Data{Username: "bob", URL: "https://itsyou.online/login#/resetpassword/token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ItsYou.Online password reset", message.Subject)
+}
+
+func TestRenderOrganizationInvitation(t *testing.T) {
+	c := &Context{Locale: "en", Organization: "acme"}
+	message, err := c.RenderEmail(OrganizationInvitation, &Data{EmailAddress: "jane@example.com", URL: "https://itsyou.online/register"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Invitation to join acme on ItsYou.Online", message.Subject)
+	assert.True(t, strings.HasPrefix(message.Text, "Hello!\n\nYou have been invited to join acme"))
+	assert.Contains(t, message.Text, "verify the email address jane@example.com")
+
+	sms, err := c.RenderSMS(OrganizationInvitation, &Data{URL: "https://itsyou.online/register"})
+	assert.NoError(t, err)
+	assert.Equal(t, "You have been invited to join acme on itsyou.online. Register with this phone number to accept the invitation: https://itsyou.online/register", sms)
+
+	message, err = c.RenderEmail(OrganizationInvitation, &Data{EmailAddress: "jane@example.com", Inviter: "bob", Note: "Welcome to the <team>"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, message.Text, "bob invited you to join acme on ItsYou.Online. “Welcome to the <team>”")
+	assert.Contains(t, message.HTML, "“Welcome to the &lt;team&gt;”")
+}
+
+func TestRenderInvitationResponse(t *testing.T) {
+	c := &Context{Locale: "en", Organization: "acme"}
+	message, err := c.RenderEmail(InvitationAccepted, &Data{Username: "bob", Invitee: "jane", URL: "https://itsyou.online/#/organization/acme"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "jane joined acme on ItsYou.Online", message.Subject)
+	assert.True(t, strings.HasPrefix(message.Text, "Hello bob!\n\njane accepted the invitation to join acme."))
+
+	c = &Context{Locale: "nl", Organization: "acme"}
+	message, err = c.RenderEmail(InvitationRejected, &Data{Username: "bob", Invitee: "jane"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, message.Text, "jane heeft de uitnodiging om lid te worden van acme geweigerd.")
+}
+
+func TestRenderVoice(t *testing.T) {
+	c := &Context{Locale: "nl"}
+	message, language, err := c.RenderVoice(LoginCode, &Data{Code: "012345"})
+	assert.NoError(t, err)
+	assert.Equal(t, "nl-NL", language)
+	assert.Equal(t, "Dit is itsyou.online. Je aanmeldcode is: 0, 1, 2, 3, 4, 5. Nogmaals, je aanmeldcode is: 0, 1, 2, 3, 4, 5.", message)
+
+	c = &Context{Locale: "en"}
+	message, language, err = c.RenderVoice(PhonenumberValidation, &Data{Code: "654321"})
+	assert.NoError(t, err)
+	assert.Equal(t, "en-US", language)
+	assert.Contains(t, message, "verify your phone number is: 6, 5, 4, 3, 2, 1.")
+}
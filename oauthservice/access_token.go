@@ -10,6 +10,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/itsyouonline/identityserver/db/user/apikey"
+	"github.com/itsyouonline/identityserver/identityservice/security"
 )
 
 //AccessTokenExpiration is the time in seconds an access token expires
@@ -24,6 +25,17 @@ type AccessToken struct {
 	Scope       string
 	ClientID    string //The client_id of the organization that was granted the token
 	CreatedAt   time.Time
+	//AuthenticationMethods and AuthTime describe how the user authenticated, empty for client credentials
+	AuthenticationMethods []string
+	AuthTime              time.Time
+}
+
+//AuthenticationContext returns how the user authenticated before the token was granted, nil if unknown
+func (at *AccessToken) AuthenticationContext() *security.AuthenticationContext {
+	if at.AuthTime.IsZero() {
+		return nil
+	}
+	return &security.AuthenticationContext{Methods: at.AuthenticationMethods, AuthTime: at.AuthTime}
 }
 
 //IsExpiredAt checks if the token is expired at a specific time
@@ -230,11 +242,17 @@ func convertCodeToAccessTokenHandler(code string, clientID string, secret string
 	}
 
 	at = newAccessToken(ar.Username, "", ar.ClientID, ar.Scope)
+	at.AuthenticationMethods = ar.AuthenticationMethods
+	at.AuthTime = ar.AuthTime
 	return
 }
 
-func (service *Service) createItsYouOnlineAdminToken(username string, r *http.Request) (token string, err error) {
+func (service *Service) createItsYouOnlineAdminToken(username string, ac *security.AuthenticationContext, r *http.Request) (token string, err error) {
 	at := newAccessToken(username, "", "itsyouonline", "admin")
+	if ac != nil {
+		at.AuthenticationMethods = ac.Methods
+		at.AuthTime = ac.AuthTime
+	}
 
 	mgr := NewManager(r)
 	err = mgr.saveAccessToken(at)
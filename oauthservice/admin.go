@@ -0,0 +1,39 @@
+package oauthservice
+
+import (
+	"net/http"
+
+	"github.com/gorilla/context"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+)
+
+//IsAdmin checks if the request is made by an owner of the adminOrganization, on the website or with an access token,
+// or with a client credentials access token of the adminOrganization itself.
+// Nobody is an admin if the adminOrganization is empty.
+func IsAdmin(r *http.Request, adminOrganization string) (allowed bool, err error) {
+	if adminOrganization == "" {
+		return
+	}
+	var username string
+	if accessToken := (&security.OAuth2Middleware{}).GetAccessToken(r); accessToken != "" {
+		at, err := NewManager(r).GetAccessToken(accessToken)
+		if err != nil || at == nil {
+			return false, err
+		}
+		if at.Username == "" {
+			return at.GlobalID == adminOrganization && at.Scope == "organization:owner", nil
+		}
+		if at.ClientID != "itsyouonline" || at.Scope != "admin" {
+			return false, nil
+		}
+		username = at.Username
+	} else if webuser, ok := context.Get(r, "webuser").(string); ok {
+		username = webuser
+	}
+	if username == "" {
+		return
+	}
+	return organization.NewManager(r).IsOwner(adminOrganization, username)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/itsyouonline/identityserver/identityservice/security"
 )
 
 type authorizationRequest struct {
@@ -19,6 +20,9 @@ type authorizationRequest struct {
 	State             string
 	Scope             string
 	CreatedAt         time.Time
+	//AuthenticationMethods and AuthTime describe how the user authenticated before granting the code
+	AuthenticationMethods []string
+	AuthTime              time.Time
 }
 
 func (ar *authorizationRequest) IsExpiredAt(testtime time.Time) bool {
@@ -87,6 +91,17 @@ func redirecToLoginPage(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/login?"+queryvalues.Encode(), http.StatusFound)
 }
 
+//requiresReauthentication checks the prompt, max_age and acr_values parameters against how the user authenticated
+func requiresReauthentication(r *http.Request, ac *security.AuthenticationContext) bool {
+	if ac == nil || r.Form.Get("prompt") == "login" {
+		return true
+	}
+	if maxAge, ok := security.ParseMaxAge(r.Form.Get("max_age")); ok && !ac.IsAuthenticatedWithin(maxAge) {
+		return true
+	}
+	return !ac.SatisfiesACR(security.RequestedACR(r.Form.Get("acr_values")))
+}
+
 func redirectToScopeRequestPage(w http.ResponseWriter, r *http.Request, possibleScopes []string) {
 	var possibleScopesString string
 	if possibleScopes != nil {
@@ -136,6 +151,16 @@ func (service *Service) AuthorizeHandler(w http.ResponseWriter, request *http.Re
 		redirecToLoginPage(w, request)
 		return
 	}
+	//Make the user authenticate again if the authentication is not recent or strong enough for the client
+	authenticationContext, err := service.sessionService.GetAuthenticationContext(request)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if requiresReauthentication(request, authenticationContext) {
+		redirecToLoginPage(w, request)
+		return
+	}
 
 	//Validate client and redirect_uri
 	redirectURI, err := url.QueryUnescape(request.Form.Get("redirect_uri"))
@@ -192,7 +217,7 @@ func (service *Service) AuthorizeHandler(w http.ResponseWriter, request *http.Re
 
 	//If no valid authorization, ask the user for authorizations
 	if !validAuthorization {
-		token, e := service.createItsYouOnlineAdminToken(username, request)
+		token, e := service.createItsYouOnlineAdminToken(username, authenticationContext, request)
 		if e != nil {
 			log.Error(e)
 			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -209,7 +234,7 @@ func (service *Service) AuthorizeHandler(w http.ResponseWriter, request *http.Re
 		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 		return
 	}
-	redirectURI, err = handleAuthorizationGrantCodeType(request, username, clientID, redirectURI, authorizedScopeString)
+	redirectURI, err = handleAuthorizationGrantCodeType(request, username, clientID, redirectURI, authorizedScopeString, authenticationContext)
 
 	if err != nil {
 		log.Error(err)
@@ -221,13 +246,17 @@ func (service *Service) AuthorizeHandler(w http.ResponseWriter, request *http.Re
 
 }
 
-func handleAuthorizationGrantCodeType(r *http.Request, username, clientID, redirectURI, scopes string) (correctedRedirectURI string, err error) {
+func handleAuthorizationGrantCodeType(r *http.Request, username, clientID, redirectURI, scopes string, ac *security.AuthenticationContext) (correctedRedirectURI string, err error) {
 	correctedRedirectURI = redirectURI
 	log.Debug("Handling authorization grant code type for user ", username, ", ", clientID, " is asking for ", scopes)
 	clientState := r.Form.Get("state")
 	//TODO: validate state (length and stuff)
 
 	ar := newAuthorizationRequest(username, clientID, clientState, scopes, redirectURI)
+	if ac != nil {
+		ar.AuthenticationMethods = ac.Methods
+		ar.AuthTime = ac.AuthTime
+	}
 	mgr := NewManager(r)
 	err = mgr.saveAuthorizationRequest(ar)
 	if err != nil {
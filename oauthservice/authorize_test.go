@@ -1,10 +1,12 @@
 package oauthservice
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/itsyouonline/identityserver/identityservice/security"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,3 +61,30 @@ func TestValidateRedirectURI(t *testing.T) {
 		assert.Equal(t, test.valid, valid, i)
 	}
 }
+
+func TestRequiresReauthentication(t *testing.T) {
+	type testcase struct {
+		query    string
+		ac       *security.AuthenticationContext
+		expected bool
+	}
+	recentMFA := &security.AuthenticationContext{Methods: []string{security.AMRPassword, security.AMROTP}, AuthTime: time.Now().Add(-time.Minute)}
+	oldPassword := &security.AuthenticationContext{Methods: []string{security.AMRPassword}, AuthTime: time.Now().Add(-time.Hour)}
+	testcases := []testcase{
+		testcase{query: "", ac: nil, expected: true},
+		testcase{query: "", ac: oldPassword, expected: false},
+		testcase{query: "prompt=login", ac: recentMFA, expected: true},
+		testcase{query: "max_age=300", ac: recentMFA, expected: false},
+		testcase{query: "max_age=300", ac: oldPassword, expected: true},
+		testcase{query: "max_age=invalid", ac: oldPassword, expected: false},
+		testcase{query: "acr_values=2", ac: recentMFA, expected: false},
+		testcase{query: "acr_values=2", ac: oldPassword, expected: true},
+		testcase{query: "acr_values=2+1", ac: oldPassword, expected: false},
+		testcase{query: "acr_values=unknown", ac: oldPassword, expected: false},
+	}
+	for _, test := range testcases {
+		r, _ := http.NewRequest("GET", "/v1/oauth/authorize?"+test.query, nil)
+		r.ParseForm()
+		assert.Equal(t, test.expected, requiresReauthentication(r, test.ac), test.query)
+	}
+}
@@ -12,6 +12,7 @@ type Oauth2Client struct {
 	Secret                     string
 	CallbackURL                string
 	ClientCredentialsGrantType bool //ClientCredentialsGrantType indicates if this client can be used in an oauth2 client credentials grant flow
+	Disabled                   bool //Disabled clients belong to a deleted organization, they can not be used until it is restored
 }
 
 //NewOauth2Client creates a new NewOauth2Client with a random secret
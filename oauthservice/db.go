@@ -203,14 +203,14 @@ func (m *Manager) GetClient(clientID, label string) (client *Oauth2Client, err e
 func (m *Manager) AllByClientID(clientID string) (clients []*Oauth2Client, err error) {
 	clients = make([]*Oauth2Client, 0)
 
-	err = m.getClientsCollection().Find(bson.M{"clientid": clientID}).All(&clients)
+	err = m.getClientsCollection().Find(bson.M{"clientid": clientID, "disabled": bson.M{"$ne": true}}).All(&clients)
 	return
 }
 
 //GetClientByCredentials retrieves a client given a clientid and a secret
 func (m *Manager) getClientByCredentials(clientID, secret string) (client *Oauth2Client, err error) {
 	client = &Oauth2Client{}
-	err = m.getClientsCollection().Find(bson.M{"clientid": clientID, "secret": secret}).One(client)
+	err = m.getClientsCollection().Find(bson.M{"clientid": clientID, "secret": secret, "disabled": bson.M{"$ne": true}}).One(client)
 	if err == mgo.ErrNotFound {
 		err = nil
 		client = nil
@@ -225,8 +225,63 @@ func (m *Manager) RemoveTokensByGlobalId(globalid string) error {
 	return err
 }
 
-//RemoveClientsById removes oauth clients by client id
-func (m *Manager) RemoveClientsById(clientid string) error {
+//RemoveTokensByClientId removes the oauth tokens granted to a client
+func (m *Manager) RemoveTokensByClientId(clientid string) error {
 	_, err := m.getAccessTokenCollection().RemoveAll(bson.M{"clientid": clientid})
 	return err
 }
+
+//DisableClients disables or enables the clients of an organization, disabling them also revokes their access tokens
+func (m *Manager) DisableClients(clientID string, disabled bool) (err error) {
+	_, err = m.getClientsCollection().UpdateAll(bson.M{"clientid": clientID}, bson.M{"$set": bson.M{"disabled": disabled}})
+	if err != nil || !disabled {
+		return
+	}
+	if err = m.RemoveTokensByGlobalId(clientID); err != nil {
+		return
+	}
+	return m.RemoveTokensByClientId(clientID)
+}
+
+//RemoveOrganization removes the clients of an organization and the access tokens granted to them or by the organization
+func (m *Manager) RemoveOrganization(globalID string) (err error) {
+	if err = m.DeleteAllForOrganization(globalID); err != nil {
+		return
+	}
+	if err = m.RemoveTokensByGlobalId(globalID); err != nil {
+		return
+	}
+	return m.RemoveTokensByClientId(globalID)
+}
+
+//RenameOrganization moves the clients and the access tokens of an organization to its new globalid
+// and replaces it in the user:memberof scopes of the access tokens of other clients
+func (m *Manager) RenameOrganization(oldGlobalID string, newGlobalID string) (err error) {
+	_, err = m.getClientsCollection().UpdateAll(bson.M{"clientid": oldGlobalID}, bson.M{"$set": bson.M{"clientid": newGlobalID}})
+	if err != nil {
+		return
+	}
+	tokens := m.getAccessTokenCollection()
+	if _, err = tokens.UpdateAll(bson.M{"globalid": oldGlobalID}, bson.M{"$set": bson.M{"globalid": newGlobalID}}); err != nil {
+		return
+	}
+	if _, err = tokens.UpdateAll(bson.M{"clientid": oldGlobalID}, bson.M{"$set": bson.M{"clientid": newGlobalID}}); err != nil {
+		return
+	}
+	var ats []AccessToken
+	qry := bson.M{"scope": bson.RegEx{Pattern: memberOfScopePattern(oldGlobalID).String()}}
+	if err = tokens.Find(qry).Select(bson.M{"accesstoken": 1, "scope": 1}).All(&ats); err != nil {
+		return
+	}
+	for _, at := range ats {
+		err = tokens.Update(
+			bson.M{"accesstoken": at.AccessToken},
+			bson.M{"$set": bson.M{"scope": renameOrganizationInScope(at.Scope, oldGlobalID, newGlobalID)}})
+		//The token may have expired in the meantime
+		if err != nil && err != mgo.ErrNotFound {
+			return
+		}
+	}
+	err = nil
+	return
+}
@@ -85,6 +85,12 @@ func (service *Service) convertAccessTokenToJWT(r *http.Request, at *AccessToken
 		token.Claims["scope"] = requestedScopes
 	}
 
+	if ac := at.AuthenticationContext(); ac != nil {
+		token.Claims["amr"] = ac.AMR()
+		token.Claims["acr"] = ac.ACR()
+		token.Claims["auth_time"] = ac.AuthTime.Unix()
+	}
+
 	audiences := []string{at.ClientID}
 	if extraAudiences != "" {
 		audiences = append(audiences, strings.Split(extraAudiences, ",")...)
@@ -1,6 +1,9 @@
 package oauthservice
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 func splitScopeString(scopestring string) (scopeList []string) {
 	scopeList = []string{}
@@ -12,3 +15,15 @@ func splitScopeString(scopestring string) (scopeList []string) {
 	}
 	return
 }
+
+//memberOfScopePattern matches the user:memberof scopes of an organization, with or without a role
+func memberOfScopePattern(globalID string) *regexp.Regexp {
+	return regexp.MustCompile(`user:memberof:` + regexp.QuoteMeta(globalID) + `([:, ]|$)`)
+}
+
+//renameOrganizationInScope replaces the globalid of an organization in the user:memberof scopes of a scope string
+func renameOrganizationInScope(scopestring string, oldGlobalID string, newGlobalID string) string {
+	return memberOfScopePattern(oldGlobalID).ReplaceAllStringFunc(scopestring, func(scope string) string {
+		return "user:memberof:" + newGlobalID + strings.TrimPrefix(scope, "user:memberof:"+oldGlobalID)
+	})
+}
@@ -1 +1,28 @@
 package oauthservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameOrganizationInScope(t *testing.T) {
+	type testcase struct {
+		scope   string
+		renamed string
+	}
+	testcases := []testcase{
+		testcase{scope: "", renamed: ""},
+		testcase{scope: "user:memberof:acme.dev", renamed: "user:memberof:globex.dev"},
+		testcase{scope: "user:memberof:acme.dev:admin", renamed: "user:memberof:globex.dev:admin"},
+		testcase{scope: "user:name,user:memberof:acme.dev,user:memberof:acme.dev:admin", renamed: "user:name,user:memberof:globex.dev,user:memberof:globex.dev:admin"},
+		testcase{scope: "user:memberof:acme.dev user:name", renamed: "user:memberof:globex.dev user:name"},
+		testcase{scope: "user:memberof:acme.development", renamed: "user:memberof:acme.development"},
+		testcase{scope: "user:memberof:acme.dev.backend", renamed: "user:memberof:acme.dev.backend"},
+		testcase{scope: "user:memberof:acmexdev", renamed: "user:memberof:acmexdev"},
+		testcase{scope: "organization:owner", renamed: "organization:owner"},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.renamed, renameOrganizationInScope(test.scope, "acme.dev", "globex.dev"), "Scope: \"%s\"", test.scope)
+	}
+}
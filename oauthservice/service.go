@@ -6,6 +6,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/mux"
+	"github.com/itsyouonline/identityserver/identityservice/security"
 )
 
 //SessionService declares a context where you can have a logged in user
@@ -14,6 +15,8 @@ type SessionService interface {
 	GetLoggedInUser(request *http.Request, w http.ResponseWriter) (username string, err error)
 	//SetAPIAccessToken sets the api access token for this session
 	SetAPIAccessToken(w http.ResponseWriter, token string) (err error)
+	//GetAuthenticationContext returns how the authenticated user logged in, nil if there is no authenticated user
+	GetAuthenticationContext(request *http.Request) (ac *security.AuthenticationContext, err error)
 }
 
 //IdentityService provides some basic knowledge about authorizations required for the oauthservice
@@ -0,0 +1,2768 @@
+//This file is generated from the phone number metadata of libphonenumber (https://github.com/googlei18n/libphonenumber,
+// Apache License 2.0) as distributed with github.com/ttacon/libphonenumber v1.2.1. Do not edit it by hand.
+
+package phonenumbers
+
+var regionMetadata = map[string]*metadata{
+	"AC": {
+		countryCode:         247,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[01589]\\d|[46])\\d{4}", []int{5, 6}},
+		fixedLine:           numberDesc{"6[2-467]\\d{3}", []int{5}},
+		mobile:              numberDesc{"4\\d{4}", []int{5}},
+		uan:                 numberDesc{"(?:0[1-9]|[1589]\\d)\\d{4}", []int{6}},
+	},
+	"AD": {
+		countryCode:         376,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:1|6\\d)\\d{7}|[136-9]\\d{5}", []int{6, 8, 9}},
+		fixedLine:           numberDesc{"[78]\\d{5}", []int{6}},
+		mobile:              numberDesc{"690\\d{6}|[36]\\d{5}", []int{6, 9}},
+		tollFree:            numberDesc{"180[02]\\d{4}", []int{8}},
+		premiumRate:         numberDesc{"[19]\\d{5}", []int{6}},
+	},
+	"AE": {
+		countryCode:              971,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[4-7]\\d|9[0-689])\\d{7}|800\\d{2,9}|[2-4679]\\d{7}", []int{5, 6, 7, 8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"[2-4679][2-8]\\d{6}", []int{8}},
+		mobile:                   numberDesc{"5[024-68]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"400\\d{6}|800\\d{2,9}", []int{}},
+		premiumRate:              numberDesc{"900[02]\\d{5}", []int{9}},
+		sharedCost:               numberDesc{"700[05]\\d{5}", []int{9}},
+		uan:                      numberDesc{"600[25]\\d{5}", []int{9}},
+	},
+	"AF": {
+		countryCode:              93,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2-7]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"(?:[25][0-8]|[34][0-4]|6[0-5])[2-9]\\d{6}", []int{}},
+		mobile:                   numberDesc{"7\\d{8}", []int{}},
+	},
+	"AG": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([457]\\d{6})$",
+		nationalPrefixTransformRule: "268$1",
+		leadingDigits:               "268",
+		general:                     numberDesc{"(?:268|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"268(?:4(?:6[0-38]|84)|56[0-2])\\d{4}", []int{}},
+		mobile:                      numberDesc{"268(?:464|7(?:1[3-9]|2\\d|3[246]|64|[78][0-689]))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		voip:                        numberDesc{"26848[01]\\d{4}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+		pager:                       numberDesc{"26840[69]\\d{4}", []int{}},
+	},
+	"AI": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2457]\\d{6})$",
+		nationalPrefixTransformRule: "264$1",
+		leadingDigits:               "264",
+		general:                     numberDesc{"(?:264|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"2644(?:6[12]|9[78])\\d{4}", []int{}},
+		mobile:                      numberDesc{"264(?:235|476|5(?:3[6-9]|8[1-4])|7(?:29|72))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"AL": {
+		countryCode:              355,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:700\\d\\d|900)\\d{3}|8\\d{5,7}|(?:[2-5]|6\\d)\\d{7}", []int{6, 7, 8, 9}},
+		fixedLine:                numberDesc{"(?:[2358](?:[16-9]\\d[2-9]|[2-5][2-9]\\d)|4(?:[2-57-9][2-9]|6\\d)\\d)\\d{4}", []int{8}},
+		mobile:                   numberDesc{"6(?:[78][2-9]|9\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{4}", []int{7}},
+		premiumRate:              numberDesc{"900[1-9]\\d\\d", []int{6}},
+		sharedCost:               numberDesc{"808[1-9]\\d\\d", []int{6}},
+		personalNumber:           numberDesc{"700[2-9]\\d{4}", []int{8}},
+	},
+	"AM": {
+		countryCode:              374,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[1-489]\\d|55|60|77)\\d{6}", []int{8}},
+		fixedLine:                numberDesc{"(?:(?:1[0-25]|47)\\d|2(?:2[2-46]|3[1-8]|4[2-69]|5[2-7]|6[1-9]|8[1-7])|3[12]2)\\d{5}", []int{}},
+		mobile:                   numberDesc{"(?:33|4[1349]|55|77|88|9[13-9])\\d{6}", []int{}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{}},
+		premiumRate:              numberDesc{"90[016]\\d{5}", []int{}},
+		sharedCost:               numberDesc{"80[1-4]\\d{5}", []int{}},
+		voip:                     numberDesc{"60(?:2[78]|3[5-9]|4[02-9]|5[0-46-9]|[6-8]\\d|90)\\d{4}", []int{}},
+	},
+	"AO": {
+		countryCode:         244,
+		internationalPrefix: "00",
+		general:             numberDesc{"[29]\\d{8}", []int{9}},
+		fixedLine:           numberDesc{"2\\d(?:[0134][25-9]|[25-9]\\d)\\d{5}", []int{}},
+		mobile:              numberDesc{"9[1-49]\\d{7}", []int{}},
+	},
+	"AR": {
+		countryCode:                 54,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "0?(?:(11|2(?:2(?:02?|[13]|2[13-79]|4[1-6]|5[2457]|6[124-8]|7[1-4]|8[13-6]|9[1267])|3(?:02?|1[467]|2[03-6]|3[13-8]|[49][2-6]|5[2-8]|[67])|4(?:7[3-578]|9)|6(?:[0136]|2[24-6]|4[6-8]?|5[15-8])|80|9(?:0[1-3]|[19]|2\\d|3[1-6]|4[02568]?|5[2-4]|6[2-46]|72?|8[23]?))|3(?:3(?:2[79]|6|8[2578])|4(?:0[0-24-9]|[12]|3[5-8]?|4[24-7]|5[4-68]?|6[02-9]|7[126]|8[2379]?|9[1-36-8])|5(?:1|2[1245]|3[237]?|4[1-46-9]|6[2-4]|7[1-6]|8[2-5]?)|6[24]|7(?:[069]|1[1568]|2[15]|3[145]|4[13]|5[14-8]|7[2-57]|8[126])|8(?:[01]|2[15-7]|3[2578]?|4[13-6]|5[4-8]?|6[1-357-9]|7[36-8]?|8[5-8]?|9[124])))15)?",
+		nationalPrefixTransformRule: "9$1",
+		general:                     numberDesc{"11\\d{8}|(?:[2368]|9\\d)\\d{9}", []int{10, 11}},
+		fixedLine:                   numberDesc{"(?:2954|3(?:777|865))[2-8]\\d{5}|3(?:7(?:1[15]|81)|8(?:21|4[16]|69|9[12]))[46]\\d{5}|(?:(?:11[1-8]|670)\\d|2(?:2(?:1[2-6]|3[3-6])|(?:3[06]|49)4|6(?:04|1[2-7]|4[4-6])|9(?:[17][4-6]|9[3-6]))|3(?:(?:36|64)4|4(?:1[2-7]|[235][4-6]|84)|5(?:1[2-8]|[38][4-6])|8(?:1[2-6]|[58][3-6]|7[24-6])))\\d{6}|(?:2(?:284|657|9(?:20|66))|3(?:4(?:8[27]|92)|755|878))[2-7]\\d{5}|(?:2(?:[28]0|37|6[36]|9[48])|3(?:62|7[069]|8[03]))[45]\\d{6}|(?:2(?:2(?:2[59]|44|52)|3(?:26|4[24])|473|9(?:[07]2|2[26]|34|46))|3327)[45]\\d{5}|(?:2(?:(?:26|62)2|3(?:02|2[03])|477|9(?:42|83))|3(?:4(?:[47]6|62|89)|5(?:41|64)|873))[2-6]\\d{5}|2(?:2(?:21|4[23]|6[145]|7[1-4]|8[356]|9[267])|3(?:16|3[13-8]|43|5[346-8]|9[3-5])|475|6(?:2[46]|4[78]|5[1568])|9(?:03|2[1457-9]|3[1356]|4[08]|[56][23]|82))4\\d{5}|(?:2(?:2(?:57|81)|3(?:24|46|92)|9(?:01|23|64))|3(?:329|4(?:42|71)|5(?:25|37|4[347]|71)|7(?:18|5[17])|888))[3-6]\\d{5}|(?:2(?:2(?:02|2[3467]|4[156]|5[45]|6[6-8]|91)|3(?:1[47]|[24]5|5[25]|96)|47[48]|625|932)|3(?:38[2578]|4(?:0[0-24-9]|3[78]|4[457]|58|6[03-9]|72|83|9[136-8])|5(?:2[124]|[368][23]|4[2689]|7[2-6])|7(?:16|2[15]|3[145]|4[13]|5[468]|7[2-5]|8[26])|8(?:2[5-7]|3[278]|4[3-5]|5[78]|6[1-378]|[78]7|94)))[4-6]\\d{5}", []int{10}},
+		mobile:                      numberDesc{"9(?:2954|3(?:777|865))[2-8]\\d{5}|93(?:7(?:1[15]|81)|8(?:21|4[16]|69|9[12]))[46]\\d{5}|(?:675\\d|9(?:11[1-8]\\d|2(?:2(?:1[2-6]|3[3-6])|(?:3[06]|49)4|6(?:04|1[2-7]|4[4-6])|9(?:[17][4-6]|9[3-6]))|3(?:(?:36|64)4|4(?:1[2-7]|[235][4-6]|84)|5(?:1[2-8]|[38][4-6])|8(?:1[2-6]|[58][3-6]|7[24-6]))))\\d{6}|9(?:2(?:284|657|9(?:20|66))|3(?:4(?:8[27]|92)|755|878))[2-7]\\d{5}|9(?:2(?:[28]0|37|6[36]|9[48])|3(?:62|7[069]|8[03]))[45]\\d{6}|9(?:2(?:2(?:2[59]|44|52)|3(?:26|4[24])|473|9(?:[07]2|2[26]|34|46))|3327)[45]\\d{5}|9(?:2(?:(?:26|62)2|3(?:02|2[03])|477|9(?:42|83))|3(?:4(?:[47]6|62|89)|5(?:41|64)|873))[2-6]\\d{5}|92(?:2(?:21|4[23]|6[145]|7[1-4]|8[356]|9[267])|3(?:16|3[13-8]|43|5[346-8]|9[3-5])|475|6(?:2[46]|4[78]|5[1568])|9(?:03|2[1457-9]|3[1356]|4[08]|[56][23]|82))4\\d{5}|9(?:2(?:2(?:57|81)|3(?:24|46|92)|9(?:01|23|64))|3(?:329|4(?:42|71)|5(?:25|37|4[347]|71)|7(?:18|5[17])|888))[3-6]\\d{5}|9(?:2(?:2(?:02|2[3467]|4[156]|5[45]|6[6-8]|91)|3(?:1[47]|[24]5|5[25]|96)|47[48]|625|932)|3(?:38[2578]|4(?:0[0-24-9]|3[78]|4[457]|58|6[03-9]|72|83|9[136-8])|5(?:2[124]|[368][23]|4[2689]|7[2-6])|7(?:16|2[15]|3[145]|4[13]|5[468]|7[2-5]|8[26])|8(?:2[5-7]|3[278]|4[3-5]|5[78]|6[1-378]|[78]7|94)))[4-6]\\d{5}", []int{}},
+		tollFree:                    numberDesc{"800\\d{7}", []int{10}},
+		premiumRate:                 numberDesc{"60[04579]\\d{7}", []int{10}},
+		uan:                         numberDesc{"810\\d{7}", []int{10}},
+	},
+	"AS": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([267]\\d{6})$",
+		nationalPrefixTransformRule: "684$1",
+		leadingDigits:               "684",
+		general:                     numberDesc{"(?:[58]\\d\\d|684|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"6846(?:22|33|44|55|77|88|9[19])\\d{4}", []int{}},
+		mobile:                      numberDesc{"684(?:2(?:5[2468]|72)|7(?:3[13]|70))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"AT": {
+		countryCode:              43,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{3,12}|2\\d{6,12}|43(?:(?:0\\d|5[02-9])\\d{3,9}|2\\d{4,5}|[3467]\\d{4}|8\\d{4,6}|9\\d{4,7})|5\\d{4,12}|8\\d{7,12}|9\\d{8,12}|(?:[367]\\d|4[0-24-9])\\d{4,11}", []int{4, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+		fixedLine:                numberDesc{"1(?:11\\d|[2-9]\\d{3,11})|(?:316|463|(?:51|66|73)2)\\d{3,10}|(?:2(?:1[467]|2[13-8]|5[2357]|6[1-46-8]|7[1-8]|8[124-7]|9[1458])|3(?:1[1-578]|3[23568]|4[5-7]|5[1378]|6[1-38]|8[3-68])|4(?:2[1-8]|35|7[1368]|8[2457])|5(?:2[1-8]|3[357]|4[147]|5[12578]|6[37])|6(?:13|2[1-47]|4[135-8]|5[468])|7(?:2[1-8]|35|4[13478]|5[68]|6[16-8]|7[1-6]|9[45]))\\d{4,10}", []int{}},
+		mobile:                   numberDesc{"6(?:5[0-3579]|6[013-9]|[7-9]\\d)\\d{4,10}", []int{7, 8, 9, 10, 11, 12, 13}},
+		tollFree:                 numberDesc{"800\\d{6,10}", []int{9, 10, 11, 12, 13}},
+		premiumRate:              numberDesc{"9(?:0[01]|3[019])\\d{6,10}", []int{9, 10, 11, 12, 13}},
+		sharedCost:               numberDesc{"8(?:10|2[018])\\d{6,10}|828\\d{5}", []int{8, 9, 10, 11, 12, 13}},
+		voip:                     numberDesc{"5(?:0[1-9]|17|[79]\\d)\\d{2,10}|7[28]0\\d{6,10}", []int{5, 6, 7, 8, 9, 10, 11, 12, 13}},
+	},
+	"AU": {
+		countryCode:              61,
+		internationalPrefix:      "001[14-689]|14(?:1[14]|34|4[17]|[56]6|7[47]|88)0011",
+		nationalPrefixForParsing: "0|(183[12])",
+		general:                  numberDesc{"1(?:[0-79]\\d{7,8}|8[0-24-9]\\d{7})|(?:[2-478]\\d\\d|550)\\d{6}|1\\d{4,7}", []int{5, 6, 7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:[237]\\d{5}|8(?:51(?:0(?:0[03-9]|[1247]\\d|3[2-9]|5[0-8]|6[1-9]|8[0-6])|1(?:1[69]|[23]\\d|4[0-4]))|(?:[6-8]\\d{3}|9(?:[02-9]\\d\\d|1(?:[0-57-9]\\d|6[0135-9])))\\d))\\d{3}", []int{9}},
+		mobile:                   numberDesc{"483[0-3]\\d{5}|4(?:[0-3]\\d|4[047-9]|5[0-25-9]|6[06-9]|7[02-9]|8[0-2457-9]|9[0-27-9])\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"180(?:0\\d{3}|2)\\d{3}", []int{7, 10}},
+		premiumRate:              numberDesc{"190[0-26]\\d{6}", []int{10}},
+		sharedCost:               numberDesc{"13(?:00\\d{3}|45[0-4])\\d{3}|13\\d{4}", []int{6, 8, 10}},
+		voip:                     numberDesc{"(?:14(?:5(?:1[0458]|[23][458])|71\\d)|550\\d\\d)\\d{4}", []int{9}},
+		pager:                    numberDesc{"16\\d{3,7}", []int{5, 6, 7, 8, 9}},
+	},
+	"AW": {
+		countryCode:         297,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[25-79]\\d\\d|800)\\d{4}", []int{7}},
+		fixedLine:           numberDesc{"5(?:2\\d|8[1-9])\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:290|5[69]\\d|6(?:[03]0|22|4[0-2]|[69]\\d)|7(?:[34]\\d|7[07])|9(?:6[45]|9[4-8]))\\d{4}", []int{}},
+		tollFree:            numberDesc{"800\\d{4}", []int{}},
+		premiumRate:         numberDesc{"900\\d{4}", []int{}},
+		voip:                numberDesc{"(?:28\\d|501)\\d{4}", []int{}},
+	},
+	"AX": {
+		countryCode:              358,
+		internationalPrefix:      "00|99(?:[01469]|5(?:[14]1|3[23]|5[59]|77|88|9[09]))",
+		nationalPrefixForParsing: "0",
+		leadingDigits:            "18",
+		general:                  numberDesc{"2\\d{4,9}|35\\d{4,5}|(?:60\\d\\d|800)\\d{4,6}|7\\d{5,11}|(?:[14]\\d|3[0-46-9]|50)\\d{4,8}", []int{5, 6, 7, 8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"18[1-8]\\d{3,6}", []int{6, 7, 8, 9}},
+		mobile:                   numberDesc{"(?:4[0-8]|50)\\d{4,8}", []int{6, 7, 8, 9, 10}},
+		tollFree:                 numberDesc{"800\\d{4,6}", []int{7, 8, 9}},
+		premiumRate:              numberDesc{"[67]00\\d{5,6}", []int{8, 9}},
+		uan:                      numberDesc{"20\\d{4,8}|60[12]\\d{5,6}|7(?:099\\d{4,5}|5[03-9]\\d{3,7})|20[2-59]\\d\\d|(?:606|7(?:0[78]|1|3\\d))\\d{7}|(?:10|29|3[09]|70[1-5]\\d)\\d{4,8}", []int{}},
+	},
+	"AZ": {
+		countryCode:              994,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"365\\d{6}|(?:[124579]\\d|60|88)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"365(?:[0-46-9]\\d|5[0-35-9])\\d{4}|(?:1[28]\\d|2(?:[045]2|1[24]|2[2-4]|33|6[23]))\\d{6}", []int{}},
+		mobile:                   numberDesc{"(?:36554|99[2-9]\\d\\d)\\d{4}|(?:4[04]|5[015]|60|7[07])\\d{7}", []int{}},
+		tollFree:                 numberDesc{"88\\d{7}", []int{}},
+		premiumRate:              numberDesc{"900200\\d{3}", []int{}},
+	},
+	"BA": {
+		countryCode:              387,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"6\\d{8}|(?:[35689]\\d|49|70)\\d{6}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:3(?:[05-79][2-9]|1[4579]|[23][24-9]|4[2-4689]|8[2457-9])|49[2-579]|5(?:0[2-49]|[13][2-9]|[268][2-4679]|4[4689]|5[2-79]|7[2-69]|9[2-4689]))\\d{5}", []int{8}},
+		mobile:                   numberDesc{"6040[0-4]\\d{4}|6(?:03|[1-356]|44|7\\d)\\d{6}", []int{}},
+		tollFree:                 numberDesc{"8[08]\\d{6}", []int{8}},
+		premiumRate:              numberDesc{"9[0246]\\d{6}", []int{8}},
+		sharedCost:               numberDesc{"8[12]\\d{6}", []int{8}},
+		uan:                      numberDesc{"70(?:3[0146]|[56]0)\\d{4}", []int{8}},
+	},
+	"BB": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-9]\\d{6})$",
+		nationalPrefixTransformRule: "246$1",
+		leadingDigits:               "246",
+		general:                     numberDesc{"(?:246|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"246(?:2(?:2[78]|7[0-4])|4(?:1[024-6]|2\\d|3[2-9])|5(?:20|[34]\\d|54|7[1-3])|6(?:2\\d|38)|7[35]7|9(?:1[89]|63))\\d{4}", []int{}},
+		mobile:                      numberDesc{"246(?:2(?:[356]\\d|4[0-57-9]|8[0-79])|45\\d|69[5-7]|8(?:[2-5]\\d|83))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"(?:246976|900[2-9]\\d\\d)\\d{4}", []int{}},
+		voip:                        numberDesc{"24631\\d{5}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+		uan:                         numberDesc{"246(?:292|367|4(?:1[7-9]|3[01]|44|67)|7(?:36|53))\\d{4}", []int{}},
+	},
+	"BD": {
+		countryCode:              880,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[13469]\\d{9}|8[0-79]\\d{7,8}|[2-7]\\d{8}|[2-9]\\d{7}|[3-689]\\d{6}|[57-9]\\d{5}", []int{6, 7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:3(?:03[56]|224)|4(?:22[25]|653))\\d{3,4}|(?:4(?:31\\d\\d|[46]23)|5(?:222|32[37]))\\d{3}(?:\\d{2})?|(?:3(?:42[47]|529|823)|4(?:027|525|658)|(?:56|73)2|6257|9[35]1)\\d{3}|(?:3(?:02[348]|22[35]|324|422)|4(?:22[67]|32[236-9]|6(?:2[46]|5[57])|953)|5526|6(?:024|6655)|81)\\d{4,5}|(?:2(?:7(?:1[0-267]|2[0-289]|3[0-29]|4[01]|5[1-3]|6[013]|7[0178]|91)|8(?:0[125]|1[1-6]|2[0157-9]|3[1-69]|41|6[1-35]|7[1-5]|8[1-8]|9[0-6])|9(?:0[0-2]|1[0-4]|2[568]|3[3-6]|5[5-7]|6[01367]|7[15]|8[014-9]))|3(?:0(?:2[025-79]|3[2-4])|22[12]|32[2356]|824)|4(?:02[09]|22[348]|32[045]|523|6(?:27|54))|666(?:22|53)|8(?:4[12]|[5-7]2)|9(?:[024]2|81))\\d{4}|(?:2[45]\\d\\d|3(?:1(?:2[5-7]|[5-7])|425|822)|4(?:033|1\\d|[257]1|332|4(?:2[246]|5[25])|6(?:25|56|62)|8(?:23|54)|92[2-5])|5(?:02[03489]|22[457]|32[569]|42[46]|6(?:[18]|53)|724|826)|6(?:023|2(?:2[2-5]|5[3-5]|8)|32[3478]|42[34]|52[47]|6(?:[18]|6(?:2[34]|5[24]))|[78]2[2-5]|92[2-6])|7(?:02|21\\d|[3-589]1|6[12]|72[24])|8(?:0|217|3[12]|[5-7]1)|9[24]1)\\d{5}|(?:(?:3[2-8]|5[2-57-9]|6[03-589])1|4[4689][18])\\d{5}|[59]1\\d{5}", []int{}},
+		mobile:                   numberDesc{"(?:1[13-9]\\d|644)\\d{7}|(?:3[78]|44|66)[02-9]\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"80[03]\\d{7}", []int{10}},
+		voip:                     numberDesc{"96(?:0[469]|1[0-47]|3[389]|6[69]|7[78])\\d{6}", []int{10}},
+	},
+	"BE": {
+		countryCode:              32,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"4\\d{8}|[1-9]\\d{7}", []int{8, 9}},
+		fixedLine:                numberDesc{"80[2-8]\\d{5}|(?:1[0-69]|[23][2-8]|4[23]|5\\d|6[013-57-9]|71|8[1-79]|9[2-4])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"4[5-9]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"800[1-9]\\d{4}", []int{8}},
+		premiumRate:              numberDesc{"(?:70(?:2[0-57]|3[0457]|44|69|7[0579])|90(?:0[0-35-8]|1[36]|2[0-3568]|3[0135689]|4[2-68]|5[1-68]|6[0-378]|7[23568]|9[34679]))\\d{4}", []int{8}},
+		sharedCost:               numberDesc{"7879\\d{4}", []int{8}},
+		uan:                      numberDesc{"78(?:0[57]|1[0458]|2[25]|3[5-8]|48|[56]0|7[078])\\d{4}", []int{8}},
+	},
+	"BF": {
+		countryCode:         226,
+		internationalPrefix: "00",
+		general:             numberDesc{"[025-7]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"2(?:0(?:49|5[23]|6[56]|9[016-9])|4(?:4[569]|5[4-6]|6[56]|7[0179])|5(?:[34]\\d|50|6[5-7]))\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:0[17]|5[1-8]|[67]\\d)\\d{6}", []int{}},
+	},
+	"BG": {
+		countryCode:              359,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2-7]\\d{6,7}|[89]\\d{6,8}|2\\d{5}", []int{6, 7, 8, 9}},
+		fixedLine:                numberDesc{"2\\d{5,7}|(?:43[1-6]|70[1-9])\\d{4,5}|(?:[36]\\d|4[124-7]|[57][1-9]|8[1-6]|9[1-7])\\d{5,6}", []int{6, 7, 8}},
+		mobile:                   numberDesc{"43[07-9]\\d{5}|(?:48|8[7-9]\\d|9(?:8\\d|9[69]))\\d{6}", []int{8, 9}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{8}},
+		premiumRate:              numberDesc{"90\\d{6}", []int{8}},
+		sharedCost:               numberDesc{"700\\d{5}", []int{8}},
+	},
+	"BH": {
+		countryCode:         973,
+		internationalPrefix: "00",
+		general:             numberDesc{"[136-9]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"(?:1(?:3[1356]|6[0156]|7\\d)\\d|6(?:1[16]\\d|500|6(?:0\\d|3[12]|44|7[7-9]|88)|9[69][69])|7(?:1(?:11|78)|7\\d\\d))\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:3(?:[1-79]\\d|8[0-47-9])\\d|6(?:3(?:00|33|6[16])|6(?:3[03-9]|[69]\\d|7[0-6])))\\d{4}", []int{}},
+		tollFree:            numberDesc{"80\\d{6}", []int{}},
+		premiumRate:         numberDesc{"(?:87|9[014578])\\d{6}", []int{}},
+		sharedCost:          numberDesc{"84\\d{6}", []int{}},
+	},
+	"BI": {
+		countryCode:         257,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[267]\\d|31)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"22\\d{6}", []int{}},
+		mobile:              numberDesc{"(?:29|31|6[1289]|7[125-9])\\d{6}", []int{}},
+	},
+	"BJ": {
+		countryCode:         229,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2689]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"2(?:02|1[037]|2[45]|3[68])\\d{5}", []int{}},
+		mobile:              numberDesc{"(?:6\\d|9[013-9])\\d{6}", []int{}},
+		voip:                numberDesc{"857[58]\\d{4}", []int{}},
+		uan:                 numberDesc{"81\\d{6}", []int{}},
+	},
+	"BL": {
+		countryCode:              590,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:590|69\\d|976)\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"590(?:2[7-9]|5[12]|87)\\d{4}", []int{}},
+		mobile:                   numberDesc{"69(?:0\\d\\d|1(?:2[29]|3[0-5]))\\d{4}", []int{}},
+		voip:                     numberDesc{"976[01]\\d{5}", []int{}},
+	},
+	"BM": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-8]\\d{6})$",
+		nationalPrefixTransformRule: "441$1",
+		leadingDigits:               "441",
+		general:                     numberDesc{"(?:441|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"441(?:2(?:02|23|[3479]\\d|61)|[46]\\d\\d|5(?:4\\d|60|89)|824)\\d{4}", []int{}},
+		mobile:                      numberDesc{"441(?:[37]\\d|5[0-39])\\d{5}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"BN": {
+		countryCode:         673,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-578]\\d{6}", []int{7}},
+		fixedLine:           numberDesc{"22[0-7]\\d{4}|(?:2[013-9]|[34]\\d|5[0-25-9])\\d{5}", []int{}},
+		mobile:              numberDesc{"(?:22[89]|[78]\\d\\d)\\d{4}", []int{}},
+		voip:                numberDesc{"5[34]\\d{5}", []int{}},
+	},
+	"BO": {
+		countryCode:              591,
+		internationalPrefix:      "00(?:1\\d)?",
+		nationalPrefixForParsing: "0(1\\d)?",
+		general:                  numberDesc{"(?:[2-467]\\d\\d|8001)\\d{5}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:2(?:2\\d\\d|5(?:11|[258]\\d|9[67])|6(?:12|2\\d|9[34])|8(?:2[34]|39|62))|3(?:3\\d\\d|4(?:6\\d|8[24])|8(?:25|42|5[257]|86|9[25])|9(?:[27]\\d|3[2-4]|4[248]|5[24]|6[2-6]))|4(?:4\\d\\d|6(?:11|[24689]\\d|72)))\\d{4}", []int{8}},
+		mobile:                   numberDesc{"[67]\\d{7}", []int{8}},
+		tollFree:                 numberDesc{"8001[07]\\d{4}", []int{9}},
+	},
+	"BQ": {
+		countryCode:         599,
+		internationalPrefix: "00",
+		leadingDigits:       "[347]",
+		general:             numberDesc{"(?:[34]1|7\\d)\\d{5}", []int{7}},
+		fixedLine:           numberDesc{"(?:318[023]|41(?:6[023]|70)|7(?:1[578]|50)\\d)\\d{3}", []int{}},
+		mobile:              numberDesc{"(?:31(?:8[14-8]|9[14578])|416[14-9]|7(?:0[01]|7[07]|8\\d|9[056])\\d)\\d{3}", []int{}},
+	},
+	"BR": {
+		countryCode:                 55,
+		internationalPrefix:         "00(?:1[245]|2[1-35]|31|4[13]|[56]5|99)",
+		nationalPrefixForParsing:    "0(?:(1[245]|2[1-35]|31|4[13]|[56]5|99)(\\d{10,11}))?",
+		nationalPrefixTransformRule: "$2",
+		general:                     numberDesc{"(?:[1-46-9]\\d\\d|5(?:[0-46-9]\\d|5[0-24679]))\\d{8}|[1-9]\\d{9}|[3589]\\d{8}|[34]\\d{7}", []int{8, 9, 10, 11}},
+		fixedLine:                   numberDesc{"(?:[14689][1-9]|2[12478]|3[1-578]|5[13-5]|7[13-579])[2-5]\\d{7}", []int{10}},
+		mobile:                      numberDesc{"(?:[14689][1-9]|2[12478]|3[1-578]|5[13-5]|7[13-579])(?:7|9\\d)\\d{7}", []int{10, 11}},
+		tollFree:                    numberDesc{"800\\d{6,7}", []int{9, 10}},
+		premiumRate:                 numberDesc{"300\\d{6}|[59]00\\d{6,7}", []int{9, 10}},
+		sharedCost:                  numberDesc{"300\\d{7}|[34]00\\d{5}|4(?:02|37)0\\d{4}", []int{8, 10}},
+	},
+	"BS": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([3-8]\\d{6})$",
+		nationalPrefixTransformRule: "242$1",
+		leadingDigits:               "242",
+		general:                     numberDesc{"(?:242|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"242(?:3(?:02|[236][1-9]|4[0-24-9]|5[0-68]|7[347]|8[0-4]|9[2-467])|461|502|6(?:0[1-4]|12|2[013]|[45]0|7[67]|8[78]|9[89])|7(?:02|88))\\d{4}", []int{}},
+		mobile:                      numberDesc{"242(?:3(?:5[79]|7[56]|95)|4(?:[23][1-9]|4[1-35-9]|5[1-8]|6[2-8]|7\\d|81)|5(?:2[45]|3[35]|44|5[1-46-9]|65|77)|6[34]6|7(?:27|38)|8(?:0[1-9]|1[02-9]|2\\d|[89]9))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"242300\\d{4}|8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+		uan:                         numberDesc{"242225[0-46-9]\\d{3}", []int{}},
+	},
+	"BT": {
+		countryCode:         975,
+		internationalPrefix: "00",
+		general:             numberDesc{"[17]\\d{7}|[2-8]\\d{6}", []int{7, 8}},
+		fixedLine:           numberDesc{"(?:2[3-6]|[34][5-7]|5[236]|6[2-46]|7[246]|8[2-4])\\d{5}", []int{7}},
+		mobile:              numberDesc{"(?:1[67]|77)\\d{6}", []int{8}},
+	},
+	"BW": {
+		countryCode:         267,
+		internationalPrefix: "00",
+		general:             numberDesc{"90\\d{5}|(?:[2-6]|7\\d)\\d{6}", []int{7, 8}},
+		fixedLine:           numberDesc{"(?:2(?:4[0-48]|6[0-24]|9[0578])|3(?:1[0-35-9]|55|[69]\\d|7[013])|4(?:6[03]|7[1267]|9[0-5])|5(?:3[0389]|4[0489]|7[1-47]|88|9[0-49])|6(?:2[1-35]|5[149]|8[067]))\\d{4}", []int{7}},
+		mobile:              numberDesc{"77200\\d{3}|7(?:[1-6]\\d|7[014-8])\\d{5}", []int{8}},
+		premiumRate:         numberDesc{"90\\d{5}", []int{7}},
+		voip:                numberDesc{"79(?:1(?:[01]\\d|20)|2[0-2]\\d)\\d{3}", []int{8}},
+	},
+	"BY": {
+		countryCode:              375,
+		internationalPrefix:      "810",
+		nationalPrefixForParsing: "0|80?",
+		general:                  numberDesc{"(?:[12]\\d|33|44|902)\\d{7}|8(?:0[0-79]\\d{5,7}|[1-7]\\d{9})|8(?:1[0-489]|[5-79]\\d)\\d{7}|8[1-79]\\d{6,7}|8[0-79]\\d{5}|8\\d{5}", []int{6, 7, 8, 9, 10, 11}},
+		fixedLine:                numberDesc{"(?:1(?:5(?:1[1-5]|[24]\\d|6[2-4]|9[1-7])|6(?:[235]\\d|4[1-7])|7\\d\\d)|2(?:1(?:[246]\\d|3[0-35-9]|5[1-9])|2(?:[235]\\d|4[0-8])|3(?:[26]\\d|3[02-79]|4[024-7]|5[03-7])))\\d{5}", []int{9}},
+		mobile:                   numberDesc{"(?:2(?:5[5-79]|9[1-9])|(?:33|44)\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{3,7}|8(?:0[13]|20\\d)\\d{7}", []int{}},
+		premiumRate:              numberDesc{"(?:810|902)\\d{7}", []int{10}},
+		voip:                     numberDesc{"249\\d{6}", []int{9}},
+	},
+	"BZ": {
+		countryCode:         501,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:0800\\d|[2-8])\\d{6}", []int{7, 11}},
+		fixedLine:           numberDesc{"(?:236|732)\\d{4}|[2-578][02]\\d{5}", []int{7}},
+		mobile:              numberDesc{"6[0-35-7]\\d{5}", []int{7}},
+		tollFree:            numberDesc{"0800\\d{7}", []int{11}},
+	},
+	"CA": {
+		countryCode:                   1,
+		internationalPrefix:           "011",
+		nationalPrefixForParsing:      "1",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"(?:[2-8]\\d|90)\\d{8}", []int{10}},
+		fixedLine:                     numberDesc{"(?:2(?:04|[23]6|[48]9|50)|3(?:06|43|65)|4(?:03|1[68]|3[178]|50)|5(?:06|1[49]|48|79|8[17])|6(?:04|13|39|47)|7(?:0[59]|78|8[02])|8(?:[06]7|19|25|73)|90[25])[2-9]\\d{6}", []int{}},
+		mobile:                        numberDesc{"(?:2(?:04|[23]6|[48]9|50)|3(?:06|43|65)|4(?:03|1[68]|3[178]|50)|5(?:06|1[49]|48|79|8[17])|6(?:04|13|39|47)|7(?:0[59]|78|8[02])|8(?:[06]7|19|25|73)|90[25])[2-9]\\d{6}", []int{}},
+		tollFree:                      numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"900[2-9]\\d{6}", []int{}},
+		voip:                          numberDesc{"600[2-9]\\d{6}", []int{}},
+		personalNumber:                numberDesc{"(?:5(?:00|2[12]|33|44|66|77|88)|622)[2-9]\\d{6}", []int{}},
+	},
+	"CC": {
+		countryCode:                 61,
+		internationalPrefix:         "001[14-689]|14(?:1[14]|34|4[17]|[56]6|7[47]|88)0011",
+		nationalPrefixForParsing:    "0|([59]\\d{7})$",
+		nationalPrefixTransformRule: "8$1",
+		general:                     numberDesc{"1(?:[0-79]\\d|8[0-24-9])\\d{7}|(?:[148]\\d\\d|550)\\d{6}|1\\d{5,7}", []int{6, 7, 8, 9, 10}},
+		fixedLine:                   numberDesc{"8(?:51(?:0(?:02|31|60)|118)|91(?:0(?:1[0-2]|29)|1(?:[28]2|50|79)|2(?:10|64)|3(?:[06]8|22)|4[29]8|62\\d|70[23]|959))\\d{3}", []int{9}},
+		mobile:                      numberDesc{"483[0-3]\\d{5}|4(?:[0-3]\\d|4[047-9]|5[0-25-9]|6[06-9]|7[02-9]|8[0-2457-9]|9[0-27-9])\\d{6}", []int{9}},
+		tollFree:                    numberDesc{"180(?:0\\d{3}|2)\\d{3}", []int{7, 10}},
+		premiumRate:                 numberDesc{"190[0-26]\\d{6}", []int{10}},
+		sharedCost:                  numberDesc{"13(?:00\\d{3}|45[0-4])\\d{3}|13\\d{4}", []int{6, 8, 10}},
+		voip:                        numberDesc{"(?:14(?:5(?:1[0458]|[23][458])|71\\d)|550\\d\\d)\\d{4}", []int{9}},
+	},
+	"CD": {
+		countryCode:              243,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[189]\\d{8}|[1-68]\\d{6}", []int{7, 9}},
+		fixedLine:                numberDesc{"12\\d{7}|[1-6]\\d{6}", []int{}},
+		mobile:                   numberDesc{"88\\d{5}|(?:8[0-2459]|9[017-9])\\d{7}", []int{}},
+	},
+	"CF": {
+		countryCode:         236,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[27]\\d{3}|8776)\\d{4}", []int{8}},
+		fixedLine:           numberDesc{"2[12]\\d{6}", []int{}},
+		mobile:              numberDesc{"7[0257]\\d{6}", []int{}},
+		premiumRate:         numberDesc{"8776\\d{4}", []int{}},
+	},
+	"CG": {
+		countryCode:         242,
+		internationalPrefix: "00",
+		general:             numberDesc{"222\\d{6}|(?:0\\d|80)\\d{7}", []int{9}},
+		fixedLine:           numberDesc{"222[1-589]\\d{5}", []int{}},
+		mobile:              numberDesc{"0[14-6]\\d{7}", []int{}},
+		premiumRate:         numberDesc{"80(?:0\\d\\d|11[0-4])\\d{4}", []int{}},
+	},
+	"CH": {
+		countryCode:              41,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"8\\d{11}|[2-9]\\d{8}", []int{9, 12}},
+		fixedLine:                numberDesc{"(?:2[12467]|3[1-4]|4[134]|5[256]|6[12]|[7-9]1)\\d{7}", []int{9}},
+		mobile:                   numberDesc{"7[35-9]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:              numberDesc{"90[016]\\d{6}", []int{9}},
+		sharedCost:               numberDesc{"84[0248]\\d{6}", []int{9}},
+		personalNumber:           numberDesc{"878\\d{6}", []int{9}},
+		pager:                    numberDesc{"74[0248]\\d{6}", []int{9}},
+		uan:                      numberDesc{"5[18]\\d{7}", []int{9}},
+		voicemail:                numberDesc{"860\\d{9}", []int{12}},
+	},
+	"CI": {
+		countryCode:         225,
+		internationalPrefix: "00",
+		general:             numberDesc{"[02-9]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"(?:2(?:0[023]|1[02357]|[23][045]|4[03-5])|3(?:0[06]|1[069]|[2-4][07]|5[09]|6[08]))\\d{5}", []int{}},
+		mobile:              numberDesc{"97[0-3]\\d{5}|(?:0[1-9]|[457]\\d|6[014-9]|8[4-9]|95)\\d{6}", []int{}},
+	},
+	"CK": {
+		countryCode:         682,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-578]\\d{4}", []int{5}},
+		fixedLine:           numberDesc{"(?:2\\d|3[13-7]|4[1-5])\\d{3}", []int{}},
+		mobile:              numberDesc{"[578]\\d{4}", []int{}},
+	},
+	"CL": {
+		countryCode:                   56,
+		internationalPrefix:           "(?:0|1(?:1[0-69]|2[0-57]|5[13-58]|69|7[0167]|8[018]))0",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"12300\\d{6}|6\\d{9,10}|[2-9]\\d{8}", []int{9, 10, 11}},
+		fixedLine:                     numberDesc{"(?:2(?:1962|3(?:2\\d\\d|300))|80[1-9]\\d\\d)\\d{4}|(?:22|3[2-5]|[47][1-35]|5[1-3578]|6[13-57]|8[1-9]|9[2-9])\\d{7}", []int{9}},
+		mobile:                        numberDesc{"(?:2(?:1962|3(?:2\\d\\d|300))|80[1-9]\\d\\d)\\d{4}|(?:22|3[2-5]|[47][1-35]|5[1-3578]|6[13-57]|8[1-9]|9[2-9])\\d{7}", []int{9}},
+		tollFree:                      numberDesc{"(?:123|8)00\\d{6}", []int{9, 11}},
+		sharedCost:                    numberDesc{"600\\d{7,8}", []int{10, 11}},
+		voip:                          numberDesc{"44\\d{7}", []int{9}},
+	},
+	"CM": {
+		countryCode:         237,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[26]\\d\\d|88)\\d{6}", []int{8, 9}},
+		fixedLine:           numberDesc{"2(?:22|33|4[23])\\d{6}", []int{9}},
+		mobile:              numberDesc{"6[5-9]\\d{7}", []int{9}},
+		tollFree:            numberDesc{"88\\d{6}", []int{8}},
+	},
+	"CN": {
+		countryCode:              86,
+		internationalPrefix:      "00|1(?:[12]\\d|79|9[0235-7])\\d\\d00",
+		nationalPrefixForParsing: "0|(1(?:[12]\\d|79|9[0235-7])\\d\\d)",
+		general:                  numberDesc{"1[1279]\\d{8,9}|2\\d{9}(?:\\d{2})?|[12]\\d{6,7}|86\\d{6}|(?:1[03-68]\\d|6)\\d{7,9}|(?:[3-579]\\d|8[0-57-9])\\d{6,9}", []int{7, 8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"(?:10(?:[02-79]\\d\\d|[18](?:0[1-9]|[1-9]\\d))|21(?:[18](?:0[1-9]|[1-9]\\d)|[2-79]\\d\\d))\\d{5}|(?:43[35]|754)\\d{7,8}|8(?:078\\d{7}|51\\d{7,8})|(?:10|(?:2|85)1|43[35]|754)(?:100\\d\\d|95\\d{3,4})|(?:2[02-57-9]|3(?:11|7[179])|4(?:[15]1|3[12])|5(?:1\\d|2[37]|3[12]|51|7[13-79]|9[15])|7(?:[39]1|5[57]|6[09])|8(?:71|98))(?:[02-8]\\d{7}|1(?:0(?:0\\d\\d(?:\\d{3})?|[1-9]\\d{5})|[1-9]\\d{6})|9(?:[0-46-9]\\d{6}|5\\d{3}(?:\\d(?:\\d{2})?)?))|(?:3(?:1[02-9]|35|49|5\\d|7[02-68]|9[1-68])|4(?:1[02-9]|2[179]|3[46-9]|5[2-9]|6[47-9]|7\\d|8[23])|5(?:3[03-9]|4[36]|5[02-9]|6[1-46]|7[028]|80|9[2-46-9])|6(?:3[1-5]|6[0238]|9[12])|7(?:01|[17]\\d|2[248]|3[04-9]|4[3-6]|5[0-3689]|6[2368]|9[02-9])|8(?:1[236-8]|2[5-7]|3\\d|5[2-9]|7[02-9]|8[36-8]|9[1-7])|9(?:0[1-3689]|1[1-79]|[379]\\d|4[13]|5[1-5]))(?:[02-8]\\d{6}|1(?:0(?:0\\d\\d(?:\\d{2})?|[1-9]\\d{4})|[1-9]\\d{5})|9(?:[0-46-9]\\d{5}|5\\d{3,5}))", []int{7, 8, 9, 10, 11}},
+		mobile:                   numberDesc{"1740[0-5]\\d{6}|1(?:[38]\\d|4[56789]|5[0-35-9]|6[25-7]|7[0-35-8]|9[0135689])\\d{8}", []int{11}},
+		tollFree:                 numberDesc{"(?:(?:10|21)8|8)00\\d{7}", []int{10, 12}},
+		premiumRate:              numberDesc{"16[08]\\d{5}", []int{8}},
+		sharedCost:               numberDesc{"400\\d{7}|950\\d{7,8}|(?:10|2[0-57-9]|3(?:[157]\\d|35|49|9[1-68])|4(?:[17]\\d|2[179]|[35][1-9]|6[47-9]|8[23])|5(?:[1357]\\d|2[37]|4[36]|6[1-46]|80|9[1-9])|6(?:3[1-5]|6[0238]|9[12])|7(?:01|[1579]\\d|2[248]|3[014-9]|4[3-6]|6[023689])|8(?:1[236-8]|2[5-7]|[37]\\d|5[14-9]|8[36-8]|9[1-8])|9(?:0[1-3689]|1[1-79]|[379]\\d|4[13]|5[1-5]))96\\d{3,4}", []int{7, 8, 9, 10, 11}},
+	},
+	"CO": {
+		countryCode:              57,
+		internationalPrefix:      "00(?:4(?:[14]4|56)|[579])",
+		nationalPrefixForParsing: "0([3579]|4(?:[14]4|56))?",
+		general:                  numberDesc{"(?:1\\d|3)\\d{9}|[124-8]\\d{7}", []int{8, 10, 11}},
+		fixedLine:                numberDesc{"[124-8][2-9]\\d{6}", []int{8}},
+		mobile:                   numberDesc{"3333(?:0(?:0\\d|1[0-5])|[4-9]\\d\\d)\\d{3}|33(?:00|3[0-24-9])\\d{6}|3(?:0[0-5]|1\\d|2[0-3]|5[01]|70)\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"1800\\d{7}", []int{11}},
+		premiumRate:              numberDesc{"19(?:0[01]|4[78])\\d{7}", []int{11}},
+	},
+	"CR": {
+		countryCode:              506,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "(19(?:0[0-2468]|1[09]|20|66|77|99))",
+		general:                  numberDesc{"(?:8\\d|90)\\d{8}|[24-8]\\d{7}", []int{8, 10}},
+		fixedLine:                numberDesc{"210[7-9]\\d{4}|2(?:[024-7]\\d|1[1-9])\\d{5}", []int{8}},
+		mobile:                   numberDesc{"6500[01]\\d{3}|5(?:0[01]|7[0-3])\\d{5}|(?:6[0-4]|7[0-3]|8[3-9])\\d{6}", []int{8}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{10}},
+		premiumRate:              numberDesc{"90[059]\\d{7}", []int{10}},
+		voip:                     numberDesc{"(?:210[0-6]|4\\d{3}|5100)\\d{4}", []int{8}},
+	},
+	"CU": {
+		countryCode:              53,
+		internationalPrefix:      "119",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[27]\\d{6,7}|[34]\\d{5,7}|(?:5|8\\d\\d)\\d{7}", []int{6, 7, 8, 10}},
+		fixedLine:                numberDesc{"(?:3[23]|48)\\d{4,6}|(?:31|4[36]|8(?:0[25]|78)\\d)\\d{6}|(?:2[1-4]|4[1257]|7\\d)\\d{5,6}", []int{}},
+		mobile:                   numberDesc{"5\\d{7}", []int{8}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{10}},
+		sharedCost:               numberDesc{"807\\d{7}", []int{10}},
+	},
+	"CV": {
+		countryCode:         238,
+		internationalPrefix: "0",
+		general:             numberDesc{"(?:[2-59]\\d\\d|800)\\d{4}", []int{7}},
+		fixedLine:           numberDesc{"2(?:2[1-7]|3[0-8]|4[12]|5[1256]|6\\d|7[1-3]|8[1-5])\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:[34][36]|5[1-389]|9\\d)\\d{5}", []int{}},
+		tollFree:            numberDesc{"800\\d{4}", []int{}},
+	},
+	"CW": {
+		countryCode:         599,
+		internationalPrefix: "00",
+		leadingDigits:       "[69]",
+		general:             numberDesc{"(?:[34]1|60|(?:7|9\\d)\\d)\\d{5}", []int{7, 8}},
+		fixedLine:           numberDesc{"9(?:4(?:3[0-5]|4[14]|6\\d)|50\\d|7(?:2[014]|3[02-9]|4[4-9]|6[357]|77|8[7-9])|8(?:3[39]|[46]\\d|7[01]|8[57-9]))\\d{4}", []int{}},
+		mobile:              numberDesc{"953[01]\\d{4}|9(?:5[12467]|6[5-9])\\d{5}", []int{}},
+		sharedCost:          numberDesc{"60[0-2]\\d{4}", []int{7}},
+		pager:               numberDesc{"955\\d{5}", []int{8}},
+	},
+	"CX": {
+		countryCode:                 61,
+		internationalPrefix:         "001[14-689]|14(?:1[14]|34|4[17]|[56]6|7[47]|88)0011",
+		nationalPrefixForParsing:    "0|([59]\\d{7})$",
+		nationalPrefixTransformRule: "8$1",
+		general:                     numberDesc{"1(?:[0-79]\\d|8[0-24-9])\\d{7}|(?:[148]\\d\\d|550)\\d{6}|1\\d{5,7}", []int{6, 7, 8, 9, 10}},
+		fixedLine:                   numberDesc{"8(?:51(?:0(?:01|30|59)|117)|91(?:00[6-9]|1(?:[28]1|49|78)|2(?:09|63)|3(?:12|26|75)|4(?:56|97)|64\\d|7(?:0[01]|1[0-2])|958))\\d{3}", []int{9}},
+		mobile:                      numberDesc{"483[0-3]\\d{5}|4(?:[0-3]\\d|4[047-9]|5[0-25-9]|6[06-9]|7[02-9]|8[0-2457-9]|9[0-27-9])\\d{6}", []int{9}},
+		tollFree:                    numberDesc{"180(?:0\\d{3}|2)\\d{3}", []int{7, 10}},
+		premiumRate:                 numberDesc{"190[0-26]\\d{6}", []int{10}},
+		sharedCost:                  numberDesc{"13(?:00\\d{3}|45[0-4])\\d{3}|13\\d{4}", []int{6, 8, 10}},
+		voip:                        numberDesc{"(?:14(?:5(?:1[0458]|[23][458])|71\\d)|550\\d\\d)\\d{4}", []int{9}},
+	},
+	"CY": {
+		countryCode:         357,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[279]\\d|[58]0)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"2[2-6]\\d{6}", []int{}},
+		mobile:              numberDesc{"9[4-79]\\d{6}", []int{}},
+		tollFree:            numberDesc{"800\\d{5}", []int{}},
+		premiumRate:         numberDesc{"90[09]\\d{5}", []int{}},
+		sharedCost:          numberDesc{"80[1-9]\\d{5}", []int{}},
+		personalNumber:      numberDesc{"700\\d{5}", []int{}},
+		uan:                 numberDesc{"(?:50|77)\\d{6}", []int{}},
+	},
+	"CZ": {
+		countryCode:         420,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[2-578]\\d|60)\\d{7}|9\\d{8,11}", []int{9, 10, 11, 12}},
+		fixedLine:           numberDesc{"(?:2\\d|3[1257-9]|4[16-9]|5[13-9])\\d{7}", []int{9}},
+		mobile:              numberDesc{"(?:60[1-8]|7(?:0[2-5]|[2379]\\d))\\d{6}", []int{9}},
+		tollFree:            numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:         numberDesc{"9(?:0[05689]|76)\\d{6}", []int{9}},
+		sharedCost:          numberDesc{"8[134]\\d{7}", []int{9}},
+		voip:                numberDesc{"9[17]0\\d{6}", []int{9}},
+		personalNumber:      numberDesc{"70[01]\\d{6}", []int{9}},
+		uan:                 numberDesc{"9(?:5\\d|7[2-4])\\d{6}", []int{9}},
+		voicemail:           numberDesc{"9(?:3\\d{9}|6\\d{7,10})", []int{}},
+	},
+	"DE": {
+		countryCode:              49,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2579]\\d{5,14}|49(?:[05]\\d{10}|[46][1-8]\\d{4,9})|49(?:[0-25]\\d|3[1-689]|7[1-7])\\d{4,8}|49(?:[0-2579]\\d|[34][1-9]|6[0-8])\\d{3}|49\\d{3,4}|(?:1|[368]\\d|4[0-8])\\d{3,13}", []int{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}},
+		fixedLine:                numberDesc{"(?:32|49[4-6]\\d)\\d{9}|49[0-7]\\d{3,9}|(?:[34]0|[68]9)\\d{3,13}|(?:2(?:0[1-689]|[1-3569]\\d|4[0-8]|7[1-7]|8[0-7])|3(?:[3569]\\d|4[0-79]|7[1-7]|8[1-8])|4(?:1[02-9]|[2-48]\\d|5[0-6]|6[0-8]|7[0-79])|5(?:0[2-8]|[124-6]\\d|[38][0-8]|[79][0-7])|6(?:0[02-9]|[1-358]\\d|[47][0-8]|6[1-9])|7(?:0[2-8]|1[1-9]|[27][0-7]|3\\d|[4-6][0-8]|8[0-5]|9[013-7])|8(?:0[2-9]|1[0-79]|2\\d|3[0-46-9]|4[0-6]|5[013-9]|6[1-8]|7[0-8]|8[0-24-6])|9(?:0[6-9]|[1-4]\\d|[589][0-7]|6[0-8]|7[0-467]))\\d{3,12}", []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}},
+		mobile:                   numberDesc{"15[0-25-9]\\d{8}|1(?:6[023]|7\\d)\\d{7,8}", []int{10, 11}},
+		tollFree:                 numberDesc{"800\\d{7,12}", []int{10, 11, 12, 13, 14, 15}},
+		premiumRate:              numberDesc{"(?:137[7-9]|900(?:[135]|9\\d))\\d{6}", []int{10, 11}},
+		sharedCost:               numberDesc{"180\\d{5,11}|13(?:7[1-6]\\d\\d|8)\\d{4}", []int{7, 8, 9, 10, 11, 12, 13, 14}},
+		personalNumber:           numberDesc{"700\\d{8}", []int{11}},
+		pager:                    numberDesc{"16(?:4\\d{1,10}|[89]\\d{1,11})", []int{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}},
+		uan:                      numberDesc{"18(?:1\\d{5,11}|[2-9]\\d{8})", []int{8, 9, 10, 11, 12, 13, 14}},
+		voicemail:                numberDesc{"1(?:6(?:013|255|399)|7(?:(?:[015]1|[69]3)3|[2-4]55|[78]99))\\d{7,8}|15(?:(?:[03-68]00|113)\\d|2\\d55|7\\d99|9\\d33)\\d{7}", []int{12, 13}},
+	},
+	"DJ": {
+		countryCode:         253,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:2\\d|77)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"2(?:1[2-5]|7[45])\\d{5}", []int{}},
+		mobile:              numberDesc{"77\\d{6}", []int{}},
+	},
+	"DK": {
+		countryCode:                   45,
+		internationalPrefix:           "00",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"[2-9]\\d{7}", []int{8}},
+		fixedLine:                     numberDesc{"(?:[2-7]\\d|8[126-9]|9[1-46-9])\\d{6}", []int{}},
+		mobile:                        numberDesc{"(?:[2-7]\\d|8[126-9]|9[1-46-9])\\d{6}", []int{}},
+		tollFree:                      numberDesc{"80\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"90\\d{6}", []int{}},
+	},
+	"DM": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-7]\\d{6})$",
+		nationalPrefixTransformRule: "767$1",
+		leadingDigits:               "767",
+		general:                     numberDesc{"(?:[58]\\d\\d|767|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"767(?:2(?:55|66)|4(?:2[01]|4[0-25-9])|50[0-4]|70[1-3])\\d{4}", []int{}},
+		mobile:                      numberDesc{"767(?:2(?:[2-4689]5|7[5-7])|31[5-7]|61[1-7])\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"DO": {
+		countryCode:              1,
+		internationalPrefix:      "011",
+		nationalPrefixForParsing: "1",
+		leadingDigits:            "8[024]9",
+		general:                  numberDesc{"(?:[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                numberDesc{"8(?:[04]9[2-9]\\d\\d|29(?:2(?:[0-59]\\d|6[04-9]|7[0-27]|8[0237-9])|3(?:[0-35-9]\\d|4[7-9])|[45]\\d\\d|6(?:[0-27-9]\\d|[3-5][1-9]|6[0135-8])|7(?:0[013-9]|[1-37]\\d|4[1-35689]|5[1-4689]|6[1-57-9]|8[1-79]|9[1-8])|8(?:0[146-9]|1[0-48]|[248]\\d|3[1-79]|5[01589]|6[013-68]|7[124-8]|9[0-8])|9(?:[0-24]\\d|3[02-46-9]|5[0-79]|60|7[0169]|8[57-9]|9[02-9])))\\d{4}", []int{}},
+		mobile:                   numberDesc{"8[024]9[2-9]\\d{6}", []int{}},
+		tollFree:                 numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:              numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:           numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"DZ": {
+		countryCode:              213,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[1-4]|[5-79]\\d|80)\\d{7}", []int{8, 9}},
+		fixedLine:                numberDesc{"9619\\d{5}|(?:1\\d|2[013-79]|3[0-8]|4[0135689])\\d{6}", []int{}},
+		mobile:                   numberDesc{"(?:5(?:4[0-29]|5\\d|6[01])|6(?:[569]\\d|7[0-6])|7[7-9]\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:              numberDesc{"80[3-689]1\\d{5}", []int{9}},
+		sharedCost:               numberDesc{"80[12]1\\d{5}", []int{9}},
+		voip:                     numberDesc{"98[23]\\d{6}", []int{9}},
+	},
+	"EC": {
+		countryCode:              593,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1800\\d{6,7}|(?:[2-7]|9\\d)\\d{7}", []int{8, 9, 10, 11}},
+		fixedLine:                numberDesc{"[2-7][2-7]\\d{6}", []int{8}},
+		mobile:                   numberDesc{"964[0-2]\\d{5}|9(?:39|[57][89]|6[0-37-9]|[89]\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"1800\\d{6,7}", []int{10, 11}},
+		voip:                     numberDesc{"[2-7]890\\d{4}", []int{8}},
+	},
+	"EE": {
+		countryCode:         372,
+		internationalPrefix: "00",
+		general:             numberDesc{"8\\d{9}|[4578]\\d{7}|(?:[3-8]\\d\\d|900)\\d{4}", []int{7, 8, 10}},
+		fixedLine:           numberDesc{"(?:3[23589]|4[3-8]|6\\d|7[1-9]|88)\\d{5}", []int{7}},
+		mobile:              numberDesc{"(?:5\\d|8[1-4])\\d{6}|5(?:(?:[02]\\d|5[0-478])\\d|1(?:[0-8]\\d|95)|6(?:4[0-4]|5[1-589]))\\d{3}", []int{7, 8}},
+		tollFree:            numberDesc{"800(?:(?:0\\d\\d|1)\\d|[2-9])\\d{3}", []int{}},
+		premiumRate:         numberDesc{"(?:40\\d\\d|900)\\d{4}", []int{7, 8}},
+		personalNumber:      numberDesc{"70[0-2]\\d{5}", []int{8}},
+	},
+	"EG": {
+		countryCode:              20,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[189]\\d{8,9}|[24-6]\\d{8}|[135]\\d{7}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"(?:15\\d|57[23])\\d{5,6}|(?:13[23]|(?:2[2-4]|3)\\d|4(?:0[2-5]|[578][23]|64)|5(?:0[2-7]|5\\d)|6[24-689]3|8(?:2[2-57]|4[26]|6[237]|8[2-4])|9(?:2[27]|3[24]|52|6[2356]|7[2-4]))\\d{6}", []int{8, 9}},
+		mobile:                   numberDesc{"1[0-25]\\d{8}", []int{10}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{10}},
+		premiumRate:              numberDesc{"900\\d{7}", []int{10}},
+	},
+	"EH": {
+		countryCode:              212,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		leadingDigits:            "528[89]",
+		general:                  numberDesc{"[5-8]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"528[89]\\d{5}", []int{}},
+		mobile:                   numberDesc{"(?:6(?:[0-79]\\d|8[0-247-9])|7(?:0[06-8]|6[1267]|7[0-27]))\\d{6}", []int{}},
+		tollFree:                 numberDesc{"80\\d{7}", []int{}},
+		premiumRate:              numberDesc{"89\\d{7}", []int{}},
+		voip:                     numberDesc{"592(?:4[0-2]|93)\\d{4}", []int{}},
+	},
+	"ER": {
+		countryCode:              291,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[178]\\d{6}", []int{7}},
+		fixedLine:                numberDesc{"(?:1(?:1[12568]|[24]0|55|6[146])|8\\d\\d)\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:17[1-3]|7\\d\\d)\\d{4}", []int{}},
+	},
+	"ES": {
+		countryCode:         34,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:51|[6-9]\\d)\\d{7}", []int{9}},
+		fixedLine:           numberDesc{"96906(?:0[0-8]|1[1-9]|[2-9]\\d)\\d\\d|9(?:69(?:0[0-57-9]|[1-9]\\d)|73(?:[0-8]\\d|9[1-9]))\\d{4}|(?:8(?:[1356]\\d|[28][0-8]|[47][1-9])|9(?:[135]\\d|[268][0-8]|4[1-9]|7[124-9]))\\d{6}", []int{}},
+		mobile:              numberDesc{"9(?:6906(?:09|10)|7390\\d\\d)\\d\\d|(?:6\\d|7[1-48])\\d{7}", []int{}},
+		tollFree:            numberDesc{"[89]00\\d{6}", []int{}},
+		premiumRate:         numberDesc{"80[367]\\d{6}", []int{}},
+		sharedCost:          numberDesc{"90[12]\\d{6}", []int{}},
+		personalNumber:      numberDesc{"70\\d{7}", []int{}},
+		uan:                 numberDesc{"51\\d{7}", []int{}},
+	},
+	"ET": {
+		countryCode:              251,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:11|[2-59]\\d)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"(?:11(?:1(?:1[124]|2[2-57]|3[1-5]|5[5-8]|8[6-8])|2(?:13|3[6-8]|5[89]|7[05-9]|8[2-6])|3(?:2[01]|3[0-289]|4[1289]|7[1-4]|87)|4(?:1[69]|3[2-49]|4[0-3]|6[5-8])|5(?:1[578]|44|5[0-4])|6(?:1[78]|2[69]|39|4[5-7]|5[1-5]|6[0-59]|8[015-8]))|2(?:2(?:11[1-9]|22[0-7]|33\\d|44[1467]|66[1-68])|5(?:11[124-6]|33[2-8]|44[1467]|55[14]|66[1-3679]|77[124-79]|880))|3(?:3(?:11[0-46-8]|(?:22|55)[0-6]|33[0134689]|44[04]|66[01467])|4(?:44[0-8]|55[0-69]|66[0-3]|77[1-5]))|4(?:6(?:119|22[0-24-7]|33[1-5]|44[13-69]|55[14-689]|660|88[1-4])|7(?:(?:11|22)[1-9]|33[13-7]|44[13-6]|55[1-689]))|5(?:7(?:227|55[05]|(?:66|77)[14-8])|8(?:11[149]|22[013-79]|33[0-68]|44[013-8]|550|66[1-5]|77\\d)))\\d{4}", []int{}},
+		mobile:                   numberDesc{"9\\d{8}", []int{}},
+	},
+	"FI": {
+		countryCode:              358,
+		internationalPrefix:      "00|99(?:[01469]|5(?:[14]1|3[23]|5[59]|77|88|9[09]))",
+		nationalPrefixForParsing: "0",
+		leadingDigits:            "1[03-79]|[2-9]",
+		general:                  numberDesc{"[1-35689]\\d{4}|7\\d{10,11}|(?:[124-7]\\d|3[0-46-9])\\d{8}|[1-9]\\d{5,8}", []int{5, 6, 7, 8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"(?:1[3-79][1-8]|[235689][1-8]\\d)\\d{2,6}", []int{5, 6, 7, 8, 9}},
+		mobile:                   numberDesc{"(?:4[0-8]|50)\\d{4,8}", []int{6, 7, 8, 9, 10}},
+		tollFree:                 numberDesc{"800\\d{4,6}", []int{7, 8, 9}},
+		premiumRate:              numberDesc{"[67]00\\d{5,6}", []int{8, 9}},
+		uan:                      numberDesc{"20\\d{4,8}|60[12]\\d{5,6}|7(?:099\\d{4,5}|5[03-9]\\d{3,7})|20[2-59]\\d\\d|(?:606|7(?:0[78]|1|3\\d))\\d{7}|(?:10|29|3[09]|70[1-5]\\d)\\d{4,8}", []int{}},
+	},
+	"FJ": {
+		countryCode:         679,
+		internationalPrefix: "0(?:0|52)",
+		general:             numberDesc{"45\\d{5}|(?:0800\\d|[235-9])\\d{6}", []int{7, 11}},
+		fixedLine:           numberDesc{"603\\d{4}|(?:3[0-5]|6[25-7]|8[58])\\d{5}", []int{7}},
+		mobile:              numberDesc{"(?:[279]\\d|45|5[01568]|8[034679])\\d{5}", []int{7}},
+		tollFree:            numberDesc{"0800\\d{7}", []int{11}},
+	},
+	"FK": {
+		countryCode:         500,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-7]\\d{4}", []int{5}},
+		fixedLine:           numberDesc{"[2-47]\\d{4}", []int{}},
+		mobile:              numberDesc{"[56]\\d{4}", []int{}},
+	},
+	"FM": {
+		countryCode:         691,
+		internationalPrefix: "00",
+		general:             numberDesc{"[39]\\d{6}", []int{7}},
+		fixedLine:           numberDesc{"(?:3[2357]0[1-9]|9[2-6]\\d\\d)\\d{3}", []int{}},
+		mobile:              numberDesc{"(?:3[2357]0[1-9]|9[2-7]\\d\\d)\\d{3}", []int{}},
+	},
+	"FO": {
+		countryCode:              298,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "(10(?:01|[12]0|88))",
+		general:                  numberDesc{"(?:[2-8]\\d|90)\\d{4}", []int{6}},
+		fixedLine:                numberDesc{"(?:20|[34]\\d|8[19])\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:[27][1-9]|5\\d)\\d{4}", []int{}},
+		tollFree:                 numberDesc{"80[257-9]\\d{3}", []int{}},
+		premiumRate:              numberDesc{"90(?:[13-5][15-7]|2[125-7]|99)\\d\\d", []int{}},
+		voip:                     numberDesc{"(?:6[0-36]|88)\\d{4}", []int{}},
+	},
+	"FR": {
+		countryCode:              33,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[1-9]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"(?:[1-35]\\d|4[1-9])\\d{7}", []int{}},
+		mobile:                   numberDesc{"700\\d{6}|(?:6\\d|7[3-9])\\d{7}", []int{}},
+		tollFree:                 numberDesc{"80[0-5]\\d{6}", []int{}},
+		premiumRate:              numberDesc{"836(?:0[0-36-9]|[1-9]\\d)\\d{4}|8(?:1[2-9]|2[2-47-9]|3[0-57-9]|[569]\\d|8[0-35-9])\\d{6}", []int{}},
+		sharedCost:               numberDesc{"8(?:1[01]|2[0156]|84)\\d{6}", []int{}},
+		voip:                     numberDesc{"9\\d{8}", []int{}},
+		uan:                      numberDesc{"80[6-9]\\d{6}", []int{}},
+	},
+	"GA": {
+		countryCode:                 241,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "0(11\\d{6}|6[256]\\d{6}|7[47]\\d{6})",
+		nationalPrefixTransformRule: "$1",
+		general:                     numberDesc{"(?:[067]\\d|11)\\d{6}|[2-7]\\d{6}", []int{7, 8}},
+		fixedLine:                   numberDesc{"[01]1\\d{6}", []int{8}},
+		mobile:                      numberDesc{"(?:0[2-7]|6[256]|7[47])\\d{6}|[2-7]\\d{6}", []int{}},
+	},
+	"GB": {
+		countryCode:              44,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[1-357-9]\\d{9}|[18]\\d{8}|8\\d{6}", []int{7, 9, 10}},
+		fixedLine:                numberDesc{"(?:1(?:(?:1(?:3[0-58]|4[0-5]|5[0-26-9]|6[0-4]|[78][0-49])|3(?:0\\d|1[0-8]|[25][02-9]|3[02-579]|[468][0-46-9]|7[1-35-79]|9[2-578])|4(?:0[03-9]|[137]\\d|[28][02-57-9]|4[02-69]|5[0-8]|[69][0-79])|5(?:0[1-35-9]|[16]\\d|2[024-9]|3[015689]|4[02-9]|5[03-9]|7[0-35-9]|8[0-468]|9[0-57-9])|6(?:0[034689]|1\\d|2[0-35689]|[38][013-9]|4[1-467]|5[0-69]|6[13-9]|7[0-8]|9[0-24578])|7(?:0[0246-9]|2\\d|3[0236-8]|4[03-9]|5[0-46-9]|6[013-9]|7[0-35-9]|8[024-9]|9[02-9])|8(?:0[35-9]|2[1-57-9]|3[02-578]|4[0-578]|5[124-9]|6[2-69]|7\\d|8[02-9]|9[02569])|9(?:0[02-589]|[18]\\d|2[02-689]|3[1-57-9]|4[2-9]|5[0-579]|6[2-47-9]|7[0-24578]|9[2-57]))\\d\\d|2(?:(?:0[024-9]|2[3-9]|3[3-79]|4[1-689]|[58][02-9]|6[0-47-9]|7[013-9]|9\\d)\\d\\d|1(?:[0-7]\\d\\d|80[04589])))|2(?:0[01378]|3[0189]|4[017]|8[0-46-9]|9[0-2])\\d{3})\\d{4}|1(?:2(?:0(?:46[1-4]|87[2-9])|545[1-79]|76(?:2\\d|3[1-8]|6[1-6])|9(?:7(?:2[0-4]|3[2-5])|8(?:2[2-8]|7[0-47-9]|8[3-5])))|3(?:6(?:38[2-5]|47[23])|8(?:47[04-9]|64[0157-9]))|4(?:044[1-7]|20(?:2[23]|8\\d)|6(?:0(?:30|5[2-57]|6[1-8]|7[2-8])|140)|8(?:052|87[1-3]))|5(?:2(?:4(?:3[2-79]|6\\d)|76\\d)|6(?:26[06-9]|686))|6(?:06(?:4\\d|7[4-79])|295[5-7]|35[34]\\d|47(?:24|61)|59(?:5[08]|6[67]|74)|9(?:55[0-4]|77[23]))|7(?:26(?:6[13-9]|7[0-7])|(?:442|688)\\d|50(?:2[0-3]|[3-68]2|76))|8(?:27[56]\\d|37(?:5[2-5]|8[239])|843[2-58])|9(?:0(?:0(?:6[1-8]|85)|52\\d)|3583|4(?:66[1-8]|9(?:2[01]|81))|63(?:23|3[1-4])|9561))\\d{3}", []int{9, 10}},
+		mobile:                   numberDesc{"7(?:457[0-57-9]|700[01]|911[028])\\d{5}|7(?:[1-3]\\d\\d|4(?:[0-46-9]\\d|5[0-689])|5(?:0[0-8]|[13-9]\\d|2[0-35-9])|7(?:0[1-9]|[1-7]\\d|8[02-9]|9[0-689])|8(?:[014-9]\\d|[23][0-8])|9(?:[024-9]\\d|1[02-9]|3[0-689]))\\d{6}", []int{10}},
+		tollFree:                 numberDesc{"80[08]\\d{7}|800\\d{6}|8001111", []int{}},
+		premiumRate:              numberDesc{"(?:8(?:4[2-5]|7[0-3])|9(?:[01]\\d|8[2-49]))\\d{7}|845464\\d", []int{7, 10}},
+		voip:                     numberDesc{"56\\d{8}", []int{10}},
+		personalNumber:           numberDesc{"70\\d{8}", []int{10}},
+		pager:                    numberDesc{"76(?:0[0-2]|2[356]|4[0134]|5[49]|6[0-369]|77|81|9[39])\\d{6}", []int{10}},
+		uan:                      numberDesc{"(?:3[0347]|55)\\d{8}", []int{10}},
+	},
+	"GD": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-9]\\d{6})$",
+		nationalPrefixTransformRule: "473$1",
+		leadingDigits:               "473",
+		general:                     numberDesc{"(?:473|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"473(?:2(?:3[0-2]|69)|3(?:2[89]|86)|4(?:[06]8|3[5-9]|4[0-49]|5[5-79]|73|90)|63[68]|7(?:58|84)|800|938)\\d{4}", []int{}},
+		mobile:                      numberDesc{"473(?:4(?:0[2-79]|1[04-9]|2[0-5]|58)|5(?:2[01]|3[3-8])|901)\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"GE": {
+		countryCode:              995,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[3-57]\\d\\d|800)\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"(?:3(?:[256]\\d|4[124-9]|7[0-4])|4(?:1\\d|2[2-7]|3[1-79]|4[2-8]|7[239]|9[1-7]))\\d{6}", []int{}},
+		mobile:                   numberDesc{"5(?:0555[5-9]|757(?:7[7-9]|8[01]))\\d{3}|5(?:000\\d|(?:52|75)00|8(?:58[89]|888))\\d{4}|5(?:0050|1111|2222|3333)[0-4]\\d{3}|(?:5(?:[14]4|5[0157-9]|68|7[0147-9]|9[1-35-9])|790)\\d{6}", []int{}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{}},
+		voip:                     numberDesc{"706\\d{6}", []int{}},
+	},
+	"GF": {
+		countryCode:              594,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[56]94|976)\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"594(?:[023]\\d|1[01]|4[03-9]|5[6-9]|6[0-3]|80|9[014])\\d{4}", []int{}},
+		mobile:                   numberDesc{"694(?:[0-249]\\d|3[0-48])\\d{4}", []int{}},
+		voip:                     numberDesc{"976\\d{6}", []int{}},
+	},
+	"GG": {
+		countryCode:                 44,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "0|([25-9]\\d{5})$",
+		nationalPrefixTransformRule: "1481$1",
+		general:                     numberDesc{"(?:1481|[357-9]\\d{3})\\d{6}|8\\d{6}(?:\\d{2})?", []int{7, 9, 10}},
+		fixedLine:                   numberDesc{"1481[25-9]\\d{5}", []int{10}},
+		mobile:                      numberDesc{"7(?:(?:781|839)\\d|911[17])\\d{5}", []int{10}},
+		tollFree:                    numberDesc{"80[08]\\d{7}|800\\d{6}|8001111", []int{}},
+		premiumRate:                 numberDesc{"(?:8(?:4[2-5]|7[0-3])|9(?:[01]\\d|8[0-3]))\\d{7}|845464\\d", []int{7, 10}},
+		voip:                        numberDesc{"56\\d{8}", []int{10}},
+		personalNumber:              numberDesc{"70\\d{8}", []int{10}},
+		pager:                       numberDesc{"76(?:0[0-2]|2[356]|4[0134]|5[49]|6[0-369]|77|81|9[39])\\d{6}", []int{10}},
+		uan:                         numberDesc{"(?:3[0347]|55)\\d{8}", []int{10}},
+	},
+	"GH": {
+		countryCode:              233,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[235]\\d{3}|800)\\d{5}", []int{8, 9}},
+		fixedLine:                numberDesc{"3(?:[167]2[0-6]|22[0-5]|32[0-3]|4(?:2[013-9]|3[01])|52[0-7]|82[0-2])\\d{5}|3(?:[0-8]8|9[28])0\\d{5}|3(?:0[237]|[1-9]7)\\d{6}", []int{9}},
+		mobile:                   numberDesc{"(?:2[0346-8]\\d|5(?:[0457]\\d|6[01]|9[1-6]))\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{8}},
+	},
+	"GI": {
+		countryCode:         350,
+		internationalPrefix: "00",
+		general:             numberDesc{"[256]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"21(?:6[24-7]\\d|90[0-2])\\d{3}|2(?:00|2[25])\\d{5}", []int{}},
+		mobile:              numberDesc{"(?:5[146-8]\\d|6(?:06|29))\\d{5}", []int{}},
+	},
+	"GL": {
+		countryCode:         299,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:19|[2-689]\\d)\\d{4}", []int{6}},
+		fixedLine:           numberDesc{"(?:19|3[1-7]|6[14689]|8[14-79]|9\\d)\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:[25][1-9]|4[2-9])\\d{4}", []int{}},
+		tollFree:            numberDesc{"80\\d{4}", []int{}},
+		voip:                numberDesc{"3[89]\\d{4}", []int{}},
+	},
+	"GM": {
+		countryCode:         220,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-9]\\d{6}", []int{7}},
+		fixedLine:           numberDesc{"(?:4(?:[23]\\d\\d|4(?:1[024679]|[6-9]\\d))|5(?:54[0-7]|6[67]\\d|7(?:1[04]|2[035]|3[58]|48))|8\\d{3})\\d{3}", []int{}},
+		mobile:              numberDesc{"(?:[23679]\\d|5[0-3])\\d{5}", []int{}},
+	},
+	"GN": {
+		countryCode:         224,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:30|6\\d\\d|722)\\d{6}", []int{8, 9}},
+		fixedLine:           numberDesc{"30(?:24|3[12]|4[1-35-7]|5[13]|6[189]|[78]1|9[1478])\\d{4}", []int{8}},
+		mobile:              numberDesc{"6[02356]\\d{7}", []int{9}},
+		voip:                numberDesc{"722\\d{6}", []int{9}},
+	},
+	"GP": {
+		countryCode:              590,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:590|69\\d|976)\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"590(?:0[1-68]|1[0-2]|2[0-68]|3[1289]|4[0-24-9]|5[3-579]|6[0189]|7[08]|8[0-689]|9\\d)\\d{4}", []int{}},
+		mobile:                   numberDesc{"69(?:0\\d\\d|1(?:2[29]|3[0-5]))\\d{4}", []int{}},
+		voip:                     numberDesc{"976[01]\\d{5}", []int{}},
+	},
+	"GQ": {
+		countryCode:         240,
+		internationalPrefix: "00",
+		general:             numberDesc{"222\\d{6}|(?:3\\d|55|[89]0)\\d{7}", []int{9}},
+		fixedLine:           numberDesc{"33[0-24-9]\\d[46]\\d{4}|3(?:33|5\\d)\\d[7-9]\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:222|55[015])\\d{6}", []int{}},
+		tollFree:            numberDesc{"80\\d[1-9]\\d{5}", []int{}},
+		premiumRate:         numberDesc{"90\\d[1-9]\\d{5}", []int{}},
+	},
+	"GR": {
+		countryCode:         30,
+		internationalPrefix: "00",
+		general:             numberDesc{"5005000\\d{3}|(?:[2689]\\d|70)\\d{8}", []int{10}},
+		fixedLine:           numberDesc{"2(?:1\\d\\d|2(?:2[1-46-9]|[36][1-8]|4[1-7]|5[1-4]|7[1-5]|[89][1-9])|3(?:1\\d|2[1-57]|[35][1-3]|4[13]|7[1-7]|8[124-6]|9[1-79])|4(?:1\\d|2[1-8]|3[1-4]|4[13-5]|6[1-578]|9[1-5])|5(?:1\\d|[29][1-4]|3[1-5]|4[124]|5[1-6])|6(?:1\\d|[269][1-6]|3[1245]|4[1-7]|5[13-9]|7[14]|8[1-5])|7(?:1\\d|2[1-5]|3[1-6]|4[1-7]|5[1-57]|6[135]|9[125-7])|8(?:1\\d|2[1-5]|[34][1-4]|9[1-57]))\\d{6}", []int{}},
+		mobile:              numberDesc{"68[57-9]\\d{7}|(?:69|94)\\d{8}", []int{}},
+		tollFree:            numberDesc{"800\\d{7}", []int{}},
+		premiumRate:         numberDesc{"90[19]\\d{7}", []int{}},
+		sharedCost:          numberDesc{"8(?:0[16]|12|25)\\d{7}", []int{}},
+		personalNumber:      numberDesc{"70\\d{8}", []int{}},
+		uan:                 numberDesc{"5005000\\d{3}", []int{}},
+	},
+	"GT": {
+		countryCode:         502,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:1\\d{3}|[2-7])\\d{7}", []int{8, 11}},
+		fixedLine:           numberDesc{"[267][2-9]\\d{6}", []int{8}},
+		mobile:              numberDesc{"[3-5]\\d{7}", []int{8}},
+		tollFree:            numberDesc{"18[01]\\d{8}", []int{11}},
+		premiumRate:         numberDesc{"19\\d{9}", []int{11}},
+	},
+	"GU": {
+		countryCode:                   1,
+		internationalPrefix:           "011",
+		nationalPrefixForParsing:      "1|([3-9]\\d{6})$",
+		nationalPrefixTransformRule:   "671$1",
+		leadingDigits:                 "671",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"(?:[58]\\d\\d|671|900)\\d{7}", []int{10}},
+		fixedLine:                     numberDesc{"671(?:3(?:00|3[39]|4[349]|55|6[26])|4(?:00|56|7[1-9]|8[0236-9])|5(?:55|6[2-5]|88)|6(?:3[2-578]|4[24-9]|5[34]|78|8[235-9])|7(?:[0479]7|2[0167]|3[45]|8[7-9])|8(?:[2-57-9]8|6[48])|9(?:2[29]|6[79]|7[1279]|8[7-9]|9[78]))\\d{4}", []int{}},
+		mobile:                        numberDesc{"671(?:3(?:00|3[39]|4[349]|55|6[26])|4(?:00|56|7[1-9]|8[0236-9])|5(?:55|6[2-5]|88)|6(?:3[2-578]|4[24-9]|5[34]|78|8[235-9])|7(?:[0479]7|2[0167]|3[45]|8[7-9])|8(?:[2-57-9]8|6[48])|9(?:2[29]|6[79]|7[1279]|8[7-9]|9[78]))\\d{4}", []int{}},
+		tollFree:                      numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:                numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"GW": {
+		countryCode:         245,
+		internationalPrefix: "00",
+		general:             numberDesc{"[49]\\d{8}|4\\d{6}", []int{7, 9}},
+		fixedLine:           numberDesc{"443\\d{6}", []int{9}},
+		mobile:              numberDesc{"9(?:5\\d|6[569]|77)\\d{6}", []int{9}},
+		voip:                numberDesc{"40\\d{5}", []int{7}},
+	},
+	"GY": {
+		countryCode:         592,
+		internationalPrefix: "001",
+		general:             numberDesc{"(?:862\\d|9008)\\d{3}|(?:[2-46]\\d|77)\\d{5}", []int{7}},
+		fixedLine:           numberDesc{"(?:2(?:1[6-9]|2[0-35-9]|3[1-4]|5[3-9]|6\\d|7[0-24-79])|3(?:2[25-9]|3\\d)|4(?:4[0-24]|5[56])|77[1-57])\\d{4}", []int{}},
+		mobile:              numberDesc{"6\\d{6}", []int{}},
+		tollFree:            numberDesc{"(?:289|862)\\d{4}", []int{}},
+		premiumRate:         numberDesc{"9008\\d{3}", []int{}},
+	},
+	"HK": {
+		countryCode:         852,
+		internationalPrefix: "00(?:30|5[09]|[126-9]?)",
+		general:             numberDesc{"8[0-46-9]\\d{6,7}|9\\d{4}(?:\\d(?:\\d(?:\\d{4})?)?)?|(?:[235-79]\\d|46)\\d{6}", []int{5, 6, 7, 8, 9, 11}},
+		fixedLine:           numberDesc{"(?:384[0-24]|58(?:0[1-8]|1[2-9]))\\d{4}|(?:2(?:[13-8]\\d|2[013-9]|9[0-24-9])|3(?:[1569][0-24-9]|4[0-246-9]|7[0-24-69]|89))\\d{5}", []int{8}},
+		mobile:              numberDesc{"(?:46(?:0[0-6]|1[0-2]|4[0-57-9])|5730|(?:626|848)[01]|707[1-5]|929[03-9])\\d{4}|(?:5(?:[1-59][0-46-9]|6[0-4689]|7[0-2469])|6(?:0[1-9]|[13-59]\\d|[268][0-57-9]|7[0-79])|9(?:0[1-9]|1[02-9]|[2358][0-8]|[467]\\d))\\d{5}", []int{8}},
+		tollFree:            numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:         numberDesc{"900(?:[0-24-9]\\d{7}|3\\d{1,4})", []int{5, 6, 7, 8, 11}},
+		personalNumber:      numberDesc{"8(?:1[0-4679]\\d|2(?:[0-36]\\d|7[0-4])|3(?:[034]\\d|2[09]|70))\\d{4}", []int{8}},
+		pager:               numberDesc{"7(?:1(?:0[0-38]|1[0-3679]|3[013]|69|9[136])|2(?:[02389]\\d|1[18]|7[27-9])|3(?:[0-38]\\d|7[0-369]|9[2357-9])|47\\d|5(?:[178]\\d|5[0-5])|6(?:0[0-7]|2[236-9]|[35]\\d)|7(?:[27]\\d|8[7-9])|8(?:[23689]\\d|7[1-9])|9(?:[025]\\d|6[0-246-8]|7[0-36-9]|8[238]))\\d{4}", []int{8}},
+		uan:                 numberDesc{"30(?:0[1-9]|[15-7]\\d|2[047]|89)\\d{4}", []int{8}},
+	},
+	"HN": {
+		countryCode:         504,
+		internationalPrefix: "00",
+		general:             numberDesc{"8\\d{10}|[237-9]\\d{7}", []int{8, 11}},
+		fixedLine:           numberDesc{"2(?:2(?:0[019]|1[1-36]|[23]\\d|4[04-6]|5[57]|6[24]|7[0135689]|8[01346-9]|9[0-2])|4(?:07|2[3-59]|3[13-689]|4[0-68]|5[1-35])|5(?:0[78]|16|4[03-5]|5\\d|6[014-6]|74|80)|6(?:[056]\\d|17|2[07]|3[04]|4[0-378]|[78][0-8]|9[01])|7(?:6[46-9]|7[02-9]|8[034]|91)|8(?:79|8[0-357-9]|9[1-57-9]))\\d{4}", []int{8}},
+		mobile:              numberDesc{"[37-9]\\d{7}", []int{8}},
+		tollFree:            numberDesc{"8002\\d{7}", []int{11}},
+	},
+	"HR": {
+		countryCode:              385,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[24-69]\\d|3[0-79])\\d{7}|80\\d{5,7}|[1-79]\\d{7}|6\\d{5,6}", []int{6, 7, 8, 9}},
+		fixedLine:                numberDesc{"1\\d{7}|(?:2[0-3]|3[1-5]|4[02-47-9]|5[1-3])\\d{6,7}", []int{8, 9}},
+		mobile:                   numberDesc{"9(?:751\\d{5}|8\\d{6,7})|9(?:0[1-9]|[1259]\\d|7[0679])\\d{6}", []int{8, 9}},
+		tollFree:                 numberDesc{"80[01]\\d{4,6}", []int{7, 8, 9}},
+		premiumRate:              numberDesc{"6[01459]\\d{6}|6[01]\\d{4,5}", []int{6, 7, 8}},
+		personalNumber:           numberDesc{"7[45]\\d{6}", []int{8}},
+		uan:                      numberDesc{"62\\d{6,7}|72\\d{6}", []int{8, 9}},
+	},
+	"HT": {
+		countryCode:         509,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-489]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"2(?:2\\d|5[1-5]|81|9[149])\\d{5}", []int{}},
+		mobile:              numberDesc{"[34]\\d{7}", []int{}},
+		tollFree:            numberDesc{"8\\d{7}", []int{}},
+		voip:                numberDesc{"9(?:[67][0-4]|8[0-3589]|9\\d)\\d{5}", []int{}},
+	},
+	"HU": {
+		countryCode:              36,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "06",
+		general:                  numberDesc{"[2357]\\d{8}|[1-9]\\d{7}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:1\\d|[27][2-9]|3[2-7]|4[24-9]|5[2-79]|6[23689]|8[2-57-9]|9[2-69])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"(?:[257]0|3[01])\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"[48]0\\d{6}", []int{8}},
+		premiumRate:              numberDesc{"9[01]\\d{6}", []int{8}},
+		voip:                     numberDesc{"21\\d{7}", []int{9}},
+		uan:                      numberDesc{"38\\d{7}", []int{9}},
+	},
+	"ID": {
+		countryCode:              62,
+		internationalPrefix:      "00[189]",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:(?:007803|8\\d{4})\\d|[1-36])\\d{6}|[1-9]\\d{8,10}|[2-9]\\d{7}", []int{7, 8, 9, 10, 11, 12, 13}},
+		fixedLine:                numberDesc{"2[124]\\d{7,8}|619\\d{8}|2(?:1(?:14|500)|2\\d{3})\\d{3}|61\\d{5,8}|(?:2(?:[35][1-4]|6[0-8]|7[1-6]|8\\d|9[1-8])|3(?:1|[25][1-8]|3[1-68]|4[1-3]|6[1-3568]|7[0-469]|8\\d)|4(?:0[1-589]|1[01347-9]|2[0-36-8]|3[0-24-68]|43|5[1-378]|6[1-5]|7[134]|8[1245])|5(?:1[1-35-9]|2[25-8]|3[124-9]|4[1-3589]|5[1-46]|6[1-8])|6(?:[25]\\d|3[1-69]|4[1-6])|7(?:02|[125][1-9]|[36]\\d|4[1-8]|7[0-36-9])|9(?:0[12]|1[013-8]|2[0-479]|5[125-8]|6[23679]|7[159]|8[01346]))\\d{5,8}", []int{7, 8, 9, 10, 11}},
+		mobile:                   numberDesc{"8[1-35-9]\\d{7,10}", []int{9, 10, 11, 12}},
+		tollFree:                 numberDesc{"007803\\d{7}|(?:177\\d|800)\\d{5,7}", []int{8, 9, 10, 11, 13}},
+		premiumRate:              numberDesc{"809\\d{7}", []int{10}},
+		sharedCost:               numberDesc{"804\\d{7}", []int{10}},
+		uan:                      numberDesc{"(?:1500|8071\\d{3})\\d{3}", []int{7, 10}},
+	},
+	"IE": {
+		countryCode:              353,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:1\\d|[2569])\\d{6,8}|4\\d{6,9}|7\\d{8}|8\\d{8,9}", []int{7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:1\\d|21)\\d{6,7}|(?:2[24-9]|4(?:0[24]|5\\d|7)|5(?:0[45]|1\\d|8)|6(?:1\\d|[237-9])|9(?:1\\d|[35-9]))\\d{5}|(?:23|4(?:[1-469]|8\\d)|5[23679]|6[4-6]|7[14]|9[04])\\d{7}", []int{}},
+		mobile:                   numberDesc{"8(?:22|[35-9]\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"1800\\d{6}", []int{10}},
+		premiumRate:              numberDesc{"15(?:1[2-8]|[2-8]0|9[089])\\d{6}", []int{10}},
+		sharedCost:               numberDesc{"18[59]0\\d{6}", []int{10}},
+		voip:                     numberDesc{"76\\d{7}", []int{9}},
+		personalNumber:           numberDesc{"700\\d{6}", []int{9}},
+		uan:                      numberDesc{"818\\d{6}", []int{9}},
+		voicemail:                numberDesc{"88210[1-9]\\d{4}|8(?:[35-79]5\\d\\d|8(?:[013-9]\\d\\d|2(?:[01][1-9]|[2-9]\\d)))\\d{5}", []int{10}},
+	},
+	"IL": {
+		countryCode:              972,
+		internationalPrefix:      "0(?:0|1[2-9])",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{6}(?:\\d{3,5})?|[57]\\d{8}|[1-489]\\d{7}", []int{7, 8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"153\\d{8,9}|[2-489]\\d{7}", []int{8, 11, 12}},
+		mobile:                   numberDesc{"5(?:(?:[0-389][2-9]|4[1-9]|6\\d)\\d|5(?:01|2[2-7]|3[23]|4[45]|5[05689]|6[6-8]|7[0-267]|8[7-9]|9[1-9]))\\d{5}", []int{9}},
+		tollFree:                 numberDesc{"1(?:255|80[019]\\d{3})\\d{3}", []int{7, 10}},
+		premiumRate:              numberDesc{"1212\\d{4}|1(?:200|9(?:0[01]|19))\\d{6}", []int{8, 10}},
+		sharedCost:               numberDesc{"1700\\d{6}", []int{10}},
+		voip:                     numberDesc{"78(?:33|55|77|81)\\d{5}|7(?:18|2[23]|3[237]|47|6[58]|7\\d|82|9[235-9])\\d{6}", []int{9}},
+		uan:                      numberDesc{"1599\\d{6}", []int{10}},
+		voicemail:                numberDesc{"151\\d{8,9}", []int{11, 12}},
+	},
+	"IM": {
+		countryCode:                 44,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "0|([5-8]\\d{5})$",
+		nationalPrefixTransformRule: "1624$1",
+		leadingDigits:               "74576|(?:16|7[56])24",
+		general:                     numberDesc{"1624\\d{6}|(?:[3578]\\d|90)\\d{8}", []int{10}},
+		fixedLine:                   numberDesc{"1624[5-8]\\d{5}", []int{}},
+		mobile:                      numberDesc{"76245[06]\\d{4}|7(?:4576|[59]24\\d|624[0-4689])\\d{5}", []int{}},
+		tollFree:                    numberDesc{"808162\\d{4}", []int{}},
+		premiumRate:                 numberDesc{"8(?:440[49]06|72299\\d)\\d{3}|(?:8(?:45|70)|90[0167])624\\d{4}", []int{}},
+		voip:                        numberDesc{"56\\d{8}", []int{}},
+		personalNumber:              numberDesc{"70\\d{8}", []int{}},
+		uan:                         numberDesc{"3440[49]06\\d{3}|(?:3(?:08162|3\\d{4}|45624|7(?:0624|2299))|55\\d{4})\\d{4}", []int{}},
+	},
+	"IN": {
+		countryCode:              91,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:000800|[2-9]\\d\\d)\\d{7}|1\\d{7,12}", []int{8, 9, 10, 11, 12, 13}},
+		fixedLine:                numberDesc{"2717(?:[2-7]\\d|95)\\d{4}|(?:271[0-689]|782[0-6])[2-7]\\d{5}|(?:170[24]|2(?:(?:[02][2-79]|90)\\d|80[13468])|(?:3(?:23|80)|683|79[1-7])\\d|4(?:20[24]|72[2-8])|552[1-7])\\d{6}|(?:11|33|4[04]|80)[2-7]\\d{7}|(?:342|674|788)(?:[0189][2-7]|[2-7]\\d)\\d{5}|(?:1(?:2[0-249]|3[0-25]|4[145]|[59][14]|6[014]|7[1257]|8[01346])|2(?:1[257]|3[013]|4[01]|5[0137]|6[0158]|78|8[1568]|9[14])|3(?:26|4[13]|5[34]|6[01489]|7[02-46]|8[159])|4(?:1[36]|2[1-47]|3[15]|5[12]|6[0-26-9]|7[014-9]|8[013-57]|9[014-7])|5(?:1[025]|22|[36][25]|4[28]|[578]1|9[15])|6(?:12|[2-47]1|5[17]|6[13]|80)|7(?:12|2[14]|3[134]|4[47]|5[15]|[67]1)|8(?:16|2[014]|3[126]|6[136]|7[078]|8[34]|91))[2-7]\\d{6}|(?:1(?:2[35-8]|3[346-9]|4[236-9]|[59][0235-9]|6[235-9]|7[34689]|8[257-9])|2(?:1[134689]|3[24-8]|4[2-8]|5[25689]|6[2-4679]|7[3-79]|8[2-479]|9[235-9])|3(?:01|1[79]|2[1245]|4[5-8]|5[125689]|6[235-7]|7[157-9]|8[2-46-8])|4(?:1[14578]|2[5689]|3[2-467]|5[4-7]|6[35]|73|8[2689]|9[2389])|5(?:[16][146-9]|2[14-8]|3[1346]|4[14-69]|5[46]|7[2-4]|8[2-8]|9[246])|6(?:1[1358]|2[2457]|3[2-4]|4[235-7]|5[2-689]|6[24578]|7[235689]|8[124-6])|7(?:1[013-9]|2[0235-9]|3[2679]|4[1-35689]|5[2-46-9]|[67][02-9]|8[013-7]|9[089])|8(?:1[1357-9]|2[235-8]|3[03-57-9]|4[0-24-9]|5\\d|6[2457-9]|7[1-6]|8[1256]|9[2-4]))\\d[2-7]\\d{5}", []int{10}},
+		mobile:                   numberDesc{"(?:61279|7(?:887[02-9]|9(?:313|79[07-9]))|8(?:079[04-9]|(?:84|91)7[02-8]))\\d{5}|(?:6(?:12|[2-47]1|5[17]|6[13]|80)[0189]|7(?:1(?:2[0189]|9[0-5])|2(?:[14][017-9]|8[0-59])|3(?:2[5-8]|[34][017-9]|9[016-9])|4(?:1[015-9]|[29][89]|39|8[389])|5(?:[15][017-9]|2[04-9]|9[7-9])|6(?:0[0-47]|1[0-257-9]|2[0-4]|3[19]|5[4589])|70[0289]|88[089]|97[02-8])|8(?:0(?:6[67]|7[02-8])|70[017-9]|84[01489]|91[0-289]))\\d{6}|(?:7(?:31|4[47])|8(?:16|2[014]|3[126]|6[136]|7[78]|83))(?:[0189]\\d|7[02-8])\\d{5}|(?:6(?:[09]\\d|1[04679]|2[03689]|3[05-9]|4[0489]|50|6[069]|7[07]|8[7-9])|7(?:0\\d|2[0235-79]|3[05-8]|40|5[0346-8]|6[6-9]|7[1-9]|8[0-79]|9[089])|8(?:0[01589]|1[0-57-9]|2[235-9]|3[03-57-9]|[45]\\d|6[02457-9]|7[1-69]|8[0-25-9]|9[02-9])|9\\d\\d)\\d{7}|(?:6(?:(?:1[1358]|2[2457]|3[2-4]|4[235-7]|5[2-689]|6[24578]|8[124-6])\\d|7(?:[235689]\\d|4[0189]))|7(?:1(?:[013-8]\\d|9[6-9])|28[6-8]|3(?:2[0-49]|9[2-5])|4(?:1[2-4]|[29][0-7]|3[0-8]|[56]\\d|8[0-24-7])|5(?:2[1-3]|9[0-6])|6(?:0[5689]|2[5-9]|3[02-8]|4\\d|5[0-367])|70[13-7]|881))[0189]\\d{5}", []int{10}},
+		tollFree:                 numberDesc{"000800\\d{7}|1(?:600\\d{6}|80(?:0\\d{4,9}|3\\d{9}))", []int{}},
+		premiumRate:              numberDesc{"186[12]\\d{9}", []int{13}},
+		sharedCost:               numberDesc{"1860\\d{7}", []int{11}},
+		uan:                      numberDesc{"140\\d{7}", []int{10}},
+	},
+	"IO": {
+		countryCode:         246,
+		internationalPrefix: "00",
+		general:             numberDesc{"3\\d{6}", []int{7}},
+		fixedLine:           numberDesc{"37\\d{5}", []int{}},
+		mobile:              numberDesc{"38\\d{5}", []int{}},
+	},
+	"IQ": {
+		countryCode:              964,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:1|7\\d\\d)\\d{7}|[2-6]\\d{7,8}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"1\\d{7}|(?:2[13-5]|3[02367]|4[023]|5[03]|6[026])\\d{6,7}", []int{8, 9}},
+		mobile:                   numberDesc{"7[3-9]\\d{8}", []int{10}},
+	},
+	"IR": {
+		countryCode:              98,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[1-9]\\d{9}|(?:[1-8]\\d\\d|9)\\d{3,4}", []int{4, 5, 6, 7, 10}},
+		fixedLine:                numberDesc{"(?:1[137]|2[13-68]|3[1458]|4[145]|5[1468]|6[16]|7[1467]|8[13467])(?:[03-57]\\d{7}|[16]\\d{3}(?:\\d{4})?|[289]\\d{3}(?:\\d(?:\\d{3})?)?)|94(?:000[09]|2(?:121|[2689]0\\d)|30[0-2]\\d|4(?:111|40\\d))\\d{4}", []int{6, 7, 10}},
+		mobile:                   numberDesc{"9(?:(?:0(?:[1-35]\\d|44)|(?:[13]\\d|2[0-2])\\d)\\d|9(?:(?:[0-2]\\d|44)\\d|5[15]0|8(?:1\\d|88)|9(?:0[013]|1[0134]|21|77|9[6-9])))\\d{5}", []int{10}},
+		voip:                     numberDesc{"993\\d{7}", []int{10}},
+		uan:                      numberDesc{"96(?:0[12]|2[16-8]|3(?:08|[14]5|[23]|66)|4(?:0|80)|5[01]|6[89]|86|9[19])", []int{4, 5}},
+	},
+	"IS": {
+		countryCode:         354,
+		internationalPrefix: "00|1(?:0(?:01|[12]0)|100)",
+		general:             numberDesc{"(?:38\\d|[4-9])\\d{6}", []int{7, 9}},
+		fixedLine:           numberDesc{"(?:4(?:1[0-24-69]|2[0-7]|[37][0-8]|4[0-245]|5[0-68]|6\\d|8[0-36-8])|5(?:05|[156]\\d|2[02578]|3[0-579]|4[03-7]|7[0-2578]|8[0-35-9]|9[013-689])|872)\\d{4}", []int{7}},
+		mobile:              numberDesc{"(?:38[589]\\d\\d|6(?:1[1-8]|2[0-6]|3[027-9]|4[014679]|5[0159]|6[0-69]|70|8[06-8]|9\\d)|7(?:5[057]|[6-9]\\d)|8(?:2[0-59]|[3-69]\\d|8[28]))\\d{4}", []int{}},
+		tollFree:            numberDesc{"80[08]\\d{4}", []int{7}},
+		premiumRate:         numberDesc{"90(?:0\\d|1[5-79]|2[015-79]|3[135-79]|4[125-7]|5[25-79]|7[1-37]|8[0-35-7])\\d{3}", []int{7}},
+		voip:                numberDesc{"49[0-24-79]\\d{4}", []int{7}},
+		uan:                 numberDesc{"809\\d{4}", []int{7}},
+		voicemail:           numberDesc{"(?:689|8(?:7[18]|80)|95[48])\\d{4}", []int{7}},
+	},
+	"IT": {
+		countryCode:         39,
+		internationalPrefix: "00",
+		general:             numberDesc{"0\\d{5,10}|3[0-8]\\d{7,10}|55\\d{8}|8\\d{5}(?:\\d{2,4})?|(?:1\\d|39)\\d{7,8}", []int{6, 7, 8, 9, 10, 11, 12}},
+		fixedLine:           numberDesc{"0669[0-79]\\d{1,6}|0(?:1(?:[0159]\\d|[27][1-5]|31|4[1-4]|6[1356]|8[2-57])|2\\d\\d|3(?:[0159]\\d|2[1-4]|3[12]|[48][1-6]|6[2-59]|7[1-7])|4(?:[0159]\\d|[23][1-9]|4[245]|6[1-5]|7[1-4]|81)|5(?:[0159]\\d|2[1-5]|3[2-6]|4[1-79]|6[4-6]|7[1-578]|8[3-8])|6(?:[0-57-9]\\d|6[0-8])|7(?:[0159]\\d|2[12]|3[1-7]|4[2-46]|6[13569]|7[13-6]|8[1-59])|8(?:[0159]\\d|2[3-578]|3[1-356]|[6-8][1-5])|9(?:[0159]\\d|[238][1-5]|4[12]|6[1-8]|7[1-6]))\\d{2,7}", []int{6, 7, 8, 9, 10, 11}},
+		mobile:              numberDesc{"3[1-9]\\d{8}|3[2-9]\\d{7}", []int{9, 10}},
+		tollFree:            numberDesc{"80(?:0\\d{3}|3)\\d{3}", []int{6, 9}},
+		premiumRate:         numberDesc{"(?:0878\\d\\d|89(?:2|4[5-9]\\d))\\d{3}|89[45][0-4]\\d\\d|(?:1(?:44|6[346])|89(?:5[5-9]|9))\\d{6}", []int{6, 8, 9, 10}},
+		sharedCost:          numberDesc{"84(?:[08]\\d{3}|[17])\\d{3}", []int{6, 9}},
+		voip:                numberDesc{"55\\d{8}", []int{10}},
+		personalNumber:      numberDesc{"1(?:78\\d|99)\\d{6}", []int{9, 10}},
+		voicemail:           numberDesc{"3[2-8]\\d{9,10}", []int{11, 12}},
+	},
+	"JE": {
+		countryCode:                 44,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "0|([0-24-8]\\d{5})$",
+		nationalPrefixTransformRule: "1534$1",
+		general:                     numberDesc{"1534\\d{6}|(?:[3578]\\d|90)\\d{8}", []int{10}},
+		fixedLine:                   numberDesc{"1534[0-24-8]\\d{5}", []int{}},
+		mobile:                      numberDesc{"7(?:(?:(?:50|82)9|937)\\d|7(?:00[378]|97[7-9]))\\d{5}", []int{}},
+		tollFree:                    numberDesc{"80(?:07(?:35|81)|8901)\\d{4}", []int{}},
+		premiumRate:                 numberDesc{"(?:8(?:4(?:4(?:4(?:05|42|69)|703)|5(?:041|800))|7(?:0002|1206))|90(?:066[59]|1810|71(?:07|55)))\\d{4}", []int{}},
+		voip:                        numberDesc{"56\\d{8}", []int{}},
+		personalNumber:              numberDesc{"701511\\d{4}", []int{}},
+		pager:                       numberDesc{"76(?:0[0-2]|2[356]|4[0134]|5[49]|6[0-369]|77|81|9[39])\\d{6}", []int{}},
+		uan:                         numberDesc{"(?:3(?:0(?:07(?:35|81)|8901)|3\\d{4}|4(?:4(?:4(?:05|42|69)|703)|5(?:041|800))|7(?:0002|1206))|55\\d{4})\\d{4}", []int{}},
+	},
+	"JM": {
+		countryCode:              1,
+		internationalPrefix:      "011",
+		nationalPrefixForParsing: "1",
+		leadingDigits:            "658|876",
+		general:                  numberDesc{"(?:[58]\\d\\d|658|900)\\d{7}", []int{10}},
+		fixedLine:                numberDesc{"(?:658(?:2(?:[0-8]\\d|9[0-46-9])|[3-9]\\d\\d)|876(?:5(?:02|1[0-468]|2[35]|63)|6(?:0[1-3579]|1[0237-9]|[23]\\d|40|5[06]|6[2-589]|7[05]|8[04]|9[4-9])|7(?:0[2-689]|[1-6]\\d|8[056]|9[45])|9(?:0[1-8]|1[02378]|[2-8]\\d|9[2-468])))\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:658295|876(?:(?:2[14-9]|[348]\\d)\\d|5(?:0[13-9]|17|[2-57-9]\\d|6[0-24-9])|7(?:0[07]|7\\d|8[1-47-9]|9[0-36-9])|9(?:[01]9|9[0579])))\\d{4}", []int{}},
+		tollFree:                 numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:              numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:           numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"JO": {
+		countryCode:              962,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"900\\d{5}|(?:(?:[268]|7\\d)\\d|32|53)\\d{6}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:2(?:6(?:2[0-35-9]|3[0-578]|4[24-7]|5[0-24-8]|[6-8][023]|9[0-3])|7(?:0[1-79]|10|2[014-7]|3[0-689]|4[019]|5[0-3578]))|32(?:0[1-69]|1[1-35-7]|2[024-7]|3\\d|4[0-3]|[57][023]|6[03])|53(?:0[0-3]|[13][023]|2[0-59]|49|5[0-35-9]|6[15]|7[45]|8[1-6]|9[0-36-9])|6(?:2(?:[05]0|22)|3(?:00|33)|4(?:0[0-25]|1[2-7]|2[0569]|[38][07-9]|4[025689]|6[0-589]|7\\d|9[0-2])|5(?:[01][056]|2[034]|3[0-57-9]|4[178]|5[0-69]|6[0-35-9]|7[1-379]|8[0-68]|9[0239]))|87(?:[029]0|7[08]))\\d{4}", []int{8}},
+		mobile:                   numberDesc{"7(?:55[0-49]|(?:7[025-9]|8[0-25-9]|9\\d)\\d)\\d{5}", []int{9}},
+		tollFree:                 numberDesc{"80\\d{6}", []int{8}},
+		premiumRate:              numberDesc{"900\\d{5}", []int{8}},
+		sharedCost:               numberDesc{"85\\d{6}", []int{8}},
+		personalNumber:           numberDesc{"70\\d{7}", []int{9}},
+		pager:                    numberDesc{"74(?:66|77)\\d{5}", []int{9}},
+		uan:                      numberDesc{"8(?:10|8\\d)\\d{5}", []int{8}},
+	},
+	"JP": {
+		countryCode:              81,
+		internationalPrefix:      "010",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"00[1-9]\\d{6,14}|[257-9]\\d{9}|(?:00|[1-9]\\d\\d)\\d{6}", []int{8, 9, 10, 11, 12, 13, 14, 15, 16, 17}},
+		fixedLine:                numberDesc{"(?:1(?:1[235-8]|2[3-6]|3[3-9]|4[2-6]|[58][2-8]|6[2-7]|7[2-9]|9[1-9])|(?:2[2-9]|[36][1-9])\\d|4(?:[2-578]\\d|6[02-8]|9[2-59])|5(?:[2-589]\\d|6[1-9]|7[2-8])|7(?:[25-9]\\d|3[4-9]|4[02-9])|8(?:[2679]\\d|3[2-9]|4[5-9]|5[1-9]|8[03-9])|9(?:[2-58]\\d|[679][1-9]))\\d{6}", []int{9}},
+		mobile:                   numberDesc{"[7-9]0[1-9]\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"00(?:(?:37|66)\\d{6,13}|(?:777(?:[01]|(?:5|8\\d)\\d)|882[1245]\\d\\d)\\d\\d)|(?:120|800\\d)\\d{6}", []int{}},
+		premiumRate:              numberDesc{"990\\d{6}", []int{9}},
+		voip:                     numberDesc{"50[1-9]\\d{7}", []int{10}},
+		personalNumber:           numberDesc{"60\\d{7}", []int{9}},
+		pager:                    numberDesc{"20\\d{8}", []int{10}},
+		uan:                      numberDesc{"570\\d{6}", []int{9}},
+	},
+	"KE": {
+		countryCode:              254,
+		internationalPrefix:      "000",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[17]\\d\\d|900)\\d{6}|(?:2|80)0\\d{6,7}|[4-6]\\d{6,8}", []int{7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:4[245]|5[2-79]|6[01457-9])\\d{5,7}|(?:4[136]|5[08]|62)\\d{7}|(?:[24]0|51|66)\\d{6,7}", []int{7, 8, 9}},
+		mobile:                   numberDesc{"(?:1(?:0[0-2]|1[01])|7\\d\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800[24-8]\\d{5,6}", []int{9, 10}},
+		premiumRate:              numberDesc{"900[02-9]\\d{5}", []int{9}},
+	},
+	"KG": {
+		countryCode:              996,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"8\\d{9}|(?:[235-8]\\d|99)\\d{7}", []int{9, 10}},
+		fixedLine:                numberDesc{"312(?:5[0-79]\\d|9(?:[0-689]\\d|7[0-24-9]))\\d{3}|(?:3(?:1(?:2[0-46-8]|3[1-9]|47|[56]\\d)|2(?:22|3[0-479]|6[0-7])|4(?:22|5[6-9]|6\\d)|5(?:22|3[4-7]|59|6\\d)|6(?:22|5[35-7]|6\\d)|7(?:22|3[468]|4[1-9]|59|[67]\\d)|9(?:22|4[1-8]|6\\d))|6(?:09|12|2[2-4])\\d)\\d{5}", []int{9}},
+		mobile:                   numberDesc{"(?:312(?:58\\d|973)|8801\\d\\d)\\d{3}|(?:2(?:0[0-35]|2\\d)|5[0-24-7]\\d|7(?:[07]\\d|55)|99[05-9])\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{6,7}", []int{}},
+	},
+	"KH": {
+		countryCode:              855,
+		internationalPrefix:      "00[14-9]",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{9}|[1-9]\\d{7,8}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"23(?:4(?:[2-4]|[56]\\d)|[568]\\d\\d)\\d{4}|23[236-9]\\d{5}|(?:2[4-6]|3[2-6]|4[2-4]|[5-7][2-5])(?:(?:[237-9]|4[56]|5\\d)\\d{5}|6\\d{5,6})", []int{8, 9}},
+		mobile:                   numberDesc{"(?:(?:1[28]|3[18]|9[67])\\d|6[016-9]|7(?:[07-9]|[16]\\d)|8(?:[013-79]|8\\d))\\d{6}|(?:1\\d|9[0-57-9])\\d{6}|(?:2[3-6]|3[2-6]|4[2-4]|[5-7][2-5])48\\d{5}", []int{8, 9}},
+		tollFree:                 numberDesc{"1800(?:1\\d|2[019])\\d{4}", []int{10}},
+		premiumRate:              numberDesc{"1900(?:1\\d|2[09])\\d{4}", []int{10}},
+	},
+	"KI": {
+		countryCode:              686,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[37]\\d|6[0-79])\\d{6}|(?:[2-48]\\d|50)\\d{3}", []int{5, 8}},
+		fixedLine:                numberDesc{"(?:[24]\\d|3[1-9]|50|65(?:02[12]|12[56]|22[89]|[3-5]00)|7(?:27\\d\\d|3100|5(?:02[12]|12[56]|22[89]|[34](?:00|81)|500))|8[0-5])\\d{3}", []int{}},
+		mobile:                   numberDesc{"73140\\d{3}|(?:630[01]|730[0-5])\\d{4}|[67]200[01]\\d{3}", []int{8}},
+		voip:                     numberDesc{"30(?:0[01]\\d\\d|12(?:11|20))\\d\\d", []int{8}},
+	},
+	"KM": {
+		countryCode:         269,
+		internationalPrefix: "00",
+		general:             numberDesc{"[3478]\\d{6}", []int{7}},
+		fixedLine:           numberDesc{"7[4-7]\\d{5}", []int{}},
+		mobile:              numberDesc{"[34]\\d{6}", []int{}},
+		premiumRate:         numberDesc{"8\\d{6}", []int{}},
+	},
+	"KN": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-7]\\d{6})$",
+		nationalPrefixTransformRule: "869$1",
+		leadingDigits:               "869",
+		general:                     numberDesc{"(?:[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"869(?:2(?:29|36)|302|4(?:6[015-9]|70))\\d{4}", []int{}},
+		mobile:                      numberDesc{"869(?:5(?:5[6-8]|6[5-7])|66\\d|76[02-7])\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"KP": {
+		countryCode:              850,
+		internationalPrefix:      "00|99",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"85\\d{6}|(?:19\\d|2)\\d{7}", []int{8, 10}},
+		fixedLine:                numberDesc{"(?:2\\d|85)\\d{6}", []int{8}},
+		mobile:                   numberDesc{"19[1-3]\\d{7}", []int{10}},
+	},
+	"KR": {
+		countryCode:              82,
+		internationalPrefix:      "00(?:[125689]|3(?:[46]5|91)|7(?:00|27|3|55|6[126]))",
+		nationalPrefixForParsing: "0(8(?:[1-46-8]|5\\d\\d))?",
+		general:                  numberDesc{"00[1-9]\\d{8,11}|(?:[12]|5\\d{3})\\d{7}|[13-6]\\d{9}|(?:[1-6]\\d|80)\\d{7}|[3-6]\\d{4,5}|(?:00|7)0\\d{8}", []int{5, 6, 8, 9, 10, 11, 12, 13, 14}},
+		fixedLine:                numberDesc{"(?:2|3[1-3]|[46][1-4]|5[1-5])[1-9]\\d{6,7}|(?:3[1-3]|[46][1-4]|5[1-5])1\\d{2,3}", []int{5, 6, 8, 9, 10}},
+		mobile:                   numberDesc{"1(?:05(?:[0-8]\\d|9[1-5])|22[13]\\d)\\d{4,5}|1(?:0[1-46-9]|[16-9]\\d|2[013-9])\\d{6,7}", []int{9, 10}},
+		tollFree:                 numberDesc{"00(?:308\\d{6,7}|798\\d{7,9})|(?:00368|80)\\d{7}", []int{9, 11, 12, 13, 14}},
+		premiumRate:              numberDesc{"60[2-9]\\d{6}", []int{9}},
+		voip:                     numberDesc{"70\\d{8}", []int{10}},
+		personalNumber:           numberDesc{"50\\d{8,9}", []int{10, 11}},
+		pager:                    numberDesc{"15\\d{7,8}", []int{9, 10}},
+		uan:                      numberDesc{"1(?:5(?:22|44|66|77|88|99)|6(?:[07]0|44|6[16]|88)|8(?:00|33|55|77|99))\\d{4}", []int{8}},
+	},
+	"KW": {
+		countryCode:         965,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:18|[2569]\\d\\d)\\d{5}", []int{7, 8}},
+		fixedLine:           numberDesc{"2(?:[23]\\d\\d|4(?:[1-35-9]\\d|44)|5(?:0[034]|[2-46]\\d|5[1-3]|7[1-7]))\\d{4}", []int{8}},
+		mobile:              numberDesc{"(?:5(?:2(?:22|5[25])|88[58])|6(?:222|444|70[013-9]|888|93[039])|9(?:11[01]|333|500))\\d{4}|(?:5(?:[05]\\d|1[0-7]|6[56])|6(?:0[034679]|5[015-9]|6\\d|7[67]|9[069])|9(?:0[09]|22|[4679]\\d|55|8[057-9]))\\d{5}", []int{8}},
+		tollFree:            numberDesc{"18\\d{5}", []int{7}},
+	},
+	"KY": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-9]\\d{6})$",
+		nationalPrefixTransformRule: "345$1",
+		leadingDigits:               "345",
+		general:                     numberDesc{"(?:345|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"345(?:2(?:22|44)|444|6(?:23|38|40)|7(?:4[35-79]|6[6-9]|77)|8(?:00|1[45]|25|[48]8)|9(?:14|4[035-9]))\\d{4}", []int{}},
+		mobile:                      numberDesc{"345(?:32[1-9]|5(?:1[67]|2[5-79]|4[6-9]|50|76)|649|9(?:1[67]|2[2-9]|3[689]))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"(?:345976|900[2-9]\\d\\d)\\d{4}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+		pager:                       numberDesc{"345849\\d{4}", []int{}},
+	},
+	"KZ": {
+		countryCode:              7,
+		internationalPrefix:      "810",
+		nationalPrefixForParsing: "8",
+		leadingDigits:            "33|7",
+		general:                  numberDesc{"33622\\d{5}|(?:7\\d|80)\\d{8}", []int{10}},
+		fixedLine:                numberDesc{"(?:33622|7(?:1(?:0(?:[23]\\d|4[0-3]|59|63)|1(?:[23]\\d|4[0-79]|59)|2(?:[23]\\d|59)|3(?:2\\d|3[0-79]|4[0-35-9]|59)|4(?:[24]\\d|3[013-9]|5[1-9])|5(?:2\\d|3[1-9]|4[0-7]|59)|6(?:[2-4]\\d|5[19]|61)|72\\d|8(?:[27]\\d|3[1-46-9]|4[0-5]))|2(?:1(?:[23]\\d|4[46-9]|5[3469])|2(?:2\\d|3[0679]|46|5[12679])|3(?:[2-4]\\d|5[139])|4(?:2\\d|3[1-35-9]|59)|5(?:[23]\\d|4[0-246-8]|59|61)|6(?:2\\d|3[1-9]|4[0-4]|59)|7(?:[2379]\\d|40|5[279])|8(?:[23]\\d|4[0-3]|59)|9(?:2\\d|3[124578]|59))))\\d{5}", []int{}},
+		mobile:                   numberDesc{"7(?:0[0-25-8]|47|6[02-4]|7[15-8]|85)\\d{7}", []int{}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{}},
+		premiumRate:              numberDesc{"809\\d{7}", []int{}},
+		voip:                     numberDesc{"751\\d{7}", []int{}},
+		personalNumber:           numberDesc{"808\\d{7}", []int{}},
+	},
+	"LA": {
+		countryCode:              856,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:2\\d|3)\\d{8}|(?:[235-8]\\d|41)\\d{6}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"(?:2[13]|[35-7][14]|41|8[1468])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"20(?:[29]\\d|5[24-689]|7[6-8])\\d{6}", []int{10}},
+		uan:                      numberDesc{"30\\d{7}", []int{9}},
+	},
+	"LB": {
+		countryCode:              961,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[7-9]\\d{7}|[13-9]\\d{6}", []int{7, 8}},
+		fixedLine:                numberDesc{"(?:(?:[14-69]\\d|8[02-9])\\d|7(?:[2-57]\\d|62|8[0-7]|9[04-9]))\\d{4}", []int{7}},
+		mobile:                   numberDesc{"793(?:[01]\\d|2[0-4])\\d{3}|(?:(?:3|81)\\d|7(?:[01]\\d|6[013-9]|8[89]|9[12]))\\d{5}", []int{}},
+		premiumRate:              numberDesc{"9[01]\\d{6}", []int{8}},
+		sharedCost:               numberDesc{"80\\d{6}", []int{8}},
+	},
+	"LC": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-7]\\d{6})$",
+		nationalPrefixTransformRule: "758$1",
+		leadingDigits:               "758",
+		general:                     numberDesc{"(?:[58]\\d\\d|758|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"758(?:4(?:30|5\\d|6[2-9]|8[0-2])|57[0-2]|638)\\d{4}", []int{}},
+		mobile:                      numberDesc{"758(?:28[4-7]|384|4(?:6[01]|8[4-9])|5(?:1[89]|20|84)|7(?:1[2-9]|2\\d|3[01]))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"LI": {
+		countryCode:              423,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0|(1001)",
+		general:                  numberDesc{"90\\d{5}|(?:[2378]|6\\d\\d)\\d{6}", []int{7, 9}},
+		fixedLine:                numberDesc{"(?:2(?:01|1[27]|22|3\\d|6[02-578]|96)|3(?:33|40|7[0135-7]|8[048]|9[0269]))\\d{4}", []int{7}},
+		mobile:                   numberDesc{"(?:6(?:4(?:89|9\\d)|5[0-3]\\d|6(?:0[0-7]|10|2[06-9]|39))\\d|7(?:[37-9]\\d|42|56))\\d{4}", []int{}},
+		tollFree:                 numberDesc{"80(?:02[28]|9\\d\\d)\\d\\d", []int{7}},
+		premiumRate:              numberDesc{"90(?:02[258]|1(?:23|3[14])|66[136])\\d\\d", []int{7}},
+		uan:                      numberDesc{"870(?:28|87)\\d\\d", []int{7}},
+		voicemail:                numberDesc{"697(?:42|56|[78]\\d)\\d{4}", []int{9}},
+	},
+	"LK": {
+		countryCode:              94,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[1-7]\\d|[89]1)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"(?:[189]1|2[13-7]|3[1-8]|4[157]|5[12457]|6[35-7])[2-57]\\d{6}", []int{}},
+		mobile:                   numberDesc{"7[0-25-8]\\d{7}", []int{}},
+		uan:                      numberDesc{"1973\\d{5}", []int{}},
+	},
+	"LR": {
+		countryCode:              231,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:2|33|5\\d|77|88)\\d{7}|[45]\\d{6}", []int{7, 8, 9}},
+		fixedLine:                numberDesc{"(?:2\\d{3}|33333)\\d{4}", []int{8, 9}},
+		mobile:                   numberDesc{"(?:(?:330|555|(?:77|88)\\d)\\d|4[67])\\d{5}|5\\d{6}", []int{7, 9}},
+		premiumRate:              numberDesc{"332(?:02|[34]\\d)\\d{4}", []int{9}},
+	},
+	"LS": {
+		countryCode:         266,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[256]\\d\\d|800)\\d{5}", []int{8}},
+		fixedLine:           numberDesc{"2\\d{7}", []int{}},
+		mobile:              numberDesc{"[56]\\d{7}", []int{}},
+		tollFree:            numberDesc{"800[256]\\d{4}", []int{}},
+	},
+	"LT": {
+		countryCode:              370,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "[08]",
+		general:                  numberDesc{"(?:[3469]\\d|52|[78]0)\\d{6}", []int{8}},
+		fixedLine:                numberDesc{"(?:3[1478]|4[124-6]|52)\\d{6}", []int{}},
+		mobile:                   numberDesc{"6\\d{7}", []int{}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{}},
+		premiumRate:              numberDesc{"9(?:0[0239]|10)\\d{5}", []int{}},
+		sharedCost:               numberDesc{"808\\d{5}", []int{}},
+		personalNumber:           numberDesc{"700\\d{5}", []int{}},
+		uan:                      numberDesc{"70[67]\\d{5}", []int{}},
+	},
+	"LU": {
+		countryCode:              352,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "(15(?:0[06]|1[12]|[35]5|4[04]|6[26]|77|88|99)\\d)",
+		general:                  numberDesc{"35[013-9]\\d{4,8}|6\\d{8}|35\\d{2,4}|(?:[2457-9]\\d|3[0-46-9])\\d{2,9}", []int{4, 5, 6, 7, 8, 9, 10, 11}},
+		fixedLine:                numberDesc{"(?:35[013-9]|80[2-9]|90[89])\\d{1,8}|(?:2[2-9]|3[0-46-9]|[457]\\d|8[13-9]|9[2-579])\\d{2,9}", []int{}},
+		mobile:                   numberDesc{"6(?:[269][18]|5[158]|7[189]|81)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{8}},
+		premiumRate:              numberDesc{"90[015]\\d{5}", []int{8}},
+		sharedCost:               numberDesc{"801\\d{5}", []int{8}},
+		voip:                     numberDesc{"20(?:1\\d{5}|[2-689]\\d{1,7})", []int{4, 5, 6, 7, 8, 9, 10}},
+	},
+	"LV": {
+		countryCode:         371,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[268]\\d|90)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"6\\d{7}", []int{}},
+		mobile:              numberDesc{"2\\d{7}", []int{}},
+		tollFree:            numberDesc{"80\\d{6}", []int{}},
+		premiumRate:         numberDesc{"90\\d{6}", []int{}},
+		sharedCost:          numberDesc{"81\\d{6}", []int{}},
+	},
+	"LY": {
+		countryCode:              218,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2-9]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"(?:2(?:0[56]|[1-6]\\d|7[124579]|8[124])|3(?:1\\d|2[2356])|4(?:[17]\\d|2[1-357]|5[2-4]|8[124])|5(?:[1347]\\d|2[1-469]|5[13-5]|8[1-4])|6(?:[1-479]\\d|5[2-57]|8[1-5])|7(?:[13]\\d|2[13-79])|8(?:[124]\\d|5[124]|84))\\d{6}", []int{}},
+		mobile:                   numberDesc{"9[1-6]\\d{7}", []int{}},
+	},
+	"MA": {
+		countryCode:              212,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[5-8]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"5(?:29|38)[89]0\\d{4}|5(?:2(?:[015-7]\\d|2[02-9]|3[2-578]|4[2-46-8]|8[235-7]|90)|3(?:[0-4]\\d|[57][2-9]|6[2-8]|80|9[3-9])|(?:4[067]|5[03])\\d)\\d{5}", []int{}},
+		mobile:                   numberDesc{"(?:6(?:[0-79]\\d|8[0-247-9])|7(?:0[06-8]|6[1267]|7[0-27]))\\d{6}", []int{}},
+		tollFree:                 numberDesc{"80\\d{7}", []int{}},
+		premiumRate:              numberDesc{"89\\d{7}", []int{}},
+		voip:                     numberDesc{"592(?:4[0-2]|93)\\d{4}", []int{}},
+	},
+	"MC": {
+		countryCode:              377,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"870\\d{5}|(?:[349]|6\\d)\\d{7}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:870|9[2-47-9]\\d)\\d{5}", []int{8}},
+		mobile:                   numberDesc{"4(?:4\\d|5[1-9])\\d{5}|(?:3|6\\d)\\d{7}", []int{}},
+		tollFree:                 numberDesc{"90\\d{6}", []int{8}},
+	},
+	"MD": {
+		countryCode:              373,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[235-7]\\d|[89]0)\\d{6}", []int{8}},
+		fixedLine:                numberDesc{"(?:(?:2[1-9]|3[1-79])\\d|5(?:33|5[257]))\\d{5}", []int{}},
+		mobile:                   numberDesc{"562\\d{5}|(?:6\\d|7[16-9])\\d{6}", []int{}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{}},
+		premiumRate:              numberDesc{"90[056]\\d{5}", []int{}},
+		sharedCost:               numberDesc{"808\\d{5}", []int{}},
+		voip:                     numberDesc{"3[08]\\d{6}", []int{}},
+		uan:                      numberDesc{"803\\d{5}", []int{}},
+	},
+	"ME": {
+		countryCode:              382,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:20|[3-79]\\d)\\d{6}|80\\d{6,7}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:20[2-8]|3(?:[0-2][2-7]|3[24-7])|4(?:0[2-467]|1[2467])|5(?:[01][2467]|2[2-467]))\\d{5}", []int{8}},
+		mobile:                   numberDesc{"6(?:00|3[024]|6[0-25]|[7-9]\\d)\\d{5}", []int{8}},
+		tollFree:                 numberDesc{"80(?:[0-2578]|9\\d)\\d{5}", []int{}},
+		premiumRate:              numberDesc{"9(?:4[1568]|5[178])\\d{5}", []int{8}},
+		voip:                     numberDesc{"78[1-49]\\d{5}", []int{8}},
+		uan:                      numberDesc{"77[1-9]\\d{5}", []int{8}},
+	},
+	"MF": {
+		countryCode:              590,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:590|69\\d|976)\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"590(?:0[079]|[14]3|[27][79]|30|5[0-268]|87)\\d{4}", []int{}},
+		mobile:                   numberDesc{"69(?:0\\d\\d|1(?:2[29]|3[0-5]))\\d{4}", []int{}},
+		voip:                     numberDesc{"976[01]\\d{5}", []int{}},
+	},
+	"MG": {
+		countryCode:                 261,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "0|([24-9]\\d{6})$",
+		nationalPrefixTransformRule: "20$1",
+		general:                     numberDesc{"[23]\\d{8}", []int{9}},
+		fixedLine:                   numberDesc{"2072[29]\\d{4}|20(?:2\\d|4[47]|5[3467]|6[279]|7[35]|8[268]|9[245])\\d{5}", []int{}},
+		mobile:                      numberDesc{"3[2-49]\\d{7}", []int{}},
+		voip:                        numberDesc{"22\\d{7}", []int{}},
+	},
+	"MH": {
+		countryCode:              692,
+		internationalPrefix:      "011",
+		nationalPrefixForParsing: "1",
+		general:                  numberDesc{"329\\d{4}|(?:[256]\\d|45)\\d{5}", []int{7}},
+		fixedLine:                numberDesc{"(?:247|528|625)\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:(?:23|54)5|329|45[56])\\d{4}", []int{}},
+		voip:                     numberDesc{"635\\d{4}", []int{}},
+	},
+	"MK": {
+		countryCode:              389,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2-578]\\d{7}", []int{8}},
+		fixedLine:                numberDesc{"(?:2(?:[23]\\d|5[0-24578]|6[01]|82)|3(?:1[3-68]|[23][2-68]|4[23568])|4(?:[23][2-68]|4[3-68]|5[2568]|6[25-8]|7[24-68]|8[4-68]))\\d{5}", []int{}},
+		mobile:                   numberDesc{"7(?:(?:[0-25-8]\\d|3[2-4]|9[23])\\d|4(?:21|60))\\d{4}", []int{}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{}},
+		premiumRate:              numberDesc{"5[02-9]\\d{6}", []int{}},
+		sharedCost:               numberDesc{"8(?:0[1-9]|[1-9]\\d)\\d{5}", []int{}},
+	},
+	"ML": {
+		countryCode:         223,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[246-9]\\d|50)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"2(?:07[0-8]|12[67])\\d{4}|(?:2(?:02|1[4-689])|4(?:0[0-4]|4[1-39]))\\d{5}", []int{}},
+		mobile:              numberDesc{"2(?:079|17\\d)\\d{4}|(?:50|[679]\\d|8[239])\\d{6}", []int{}},
+		tollFree:            numberDesc{"80\\d{6}", []int{}},
+	},
+	"MM": {
+		countryCode:              95,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{5,7}|95\\d{6}|(?:[4-7]|9[0-46-9])\\d{6,8}|(?:2|8\\d)\\d{5,8}", []int{6, 7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:1(?:(?:2\\d|3[56]|[89][0-6])\\d|4(?:2[2-469]|39|46|6[25]|7[0-3]|83)|6)|2(?:2(?:00|8[34])|4(?:0\\d|2[246]|39|46|62|7[0-3]|83)|51\\d\\d)|4(?:2(?:2\\d\\d|48[0-3])|3(?:20\\d|4(?:70|83)|56)|420\\d|5470)|6(?:0(?:[23]|88\\d)|(?:124|[56]2\\d)\\d|247[23]|3(?:20\\d|470)|4(?:2[04]\\d|47[23])|7(?:(?:3\\d|8[01459])\\d|4(?:39|60|7[013]))))\\d{4}|5(?:2(?:2\\d{5,6}|47[023]\\d{4})|(?:347[23]|4(?:2(?:1|86)|470)|522\\d|6(?:20\\d|483)|7(?:20\\d|48[0-2])|8(?:20\\d|47[02])|9(?:20\\d|47[01]))\\d{4})|7(?:(?:0470|4(?:25\\d|470)|5(?:202|470|96\\d))\\d{4}|1(?:20\\d{4,5}|4(?:70|83)\\d{4}))|8(?:1(?:2\\d{5,6}|4(?:10|7[01]\\d)\\d{3})|2(?:2\\d{5,6}|(?:320|490\\d)\\d{3})|(?:3(?:2\\d\\d|470)|4[24-7]|5(?:2\\d|4[1-9]|51)\\d|6[23])\\d{4})|(?:1[2-6]\\d|4(?:2[24-8]|3[2-7]|[46][2-6]|5[3-5])|5(?:[27][2-8]|3[2-68]|4[24-8]|5[23]|6[2-4]|8[24-7]|9[2-7])|6(?:[19]20|42[03-6]|(?:52|7[45])\\d)|7(?:[04][24-8]|[15][2-7]|22|3[2-4])|8(?:1[2-689]|2[2-8]|[35]2\\d))\\d{4}|25\\d{5,6}|(?:2[2-9]|6(?:1[2356]|[24][2-6]|3[24-6]|5[2-4]|6[2-8]|7[235-7]|8[245]|9[24])|8(?:3[24]|5[245]))\\d{4}", []int{6, 7, 8, 9}},
+		mobile:                   numberDesc{"(?:17[01]|9(?:2(?:[0-4]|[56]\\d\\d)|(?:3(?:[0-36]|4\\d)|6(?:6[0-2]|[7-9]\\d)|7(?:3|[5-9]\\d)|8(?:8[4-9]|9\\d)|9[5-8]\\d)\\d|4(?:(?:[0245]\\d|[1379])\\d|88)|5[0-6])\\d)\\d{4}|9[69]1\\d{6}|9(?:[68]\\d|9[089])\\d{5}", []int{7, 8, 9, 10}},
+		tollFree:                 numberDesc{"80080(?:[01][1-9]|2\\d)\\d{3}", []int{10}},
+		voip:                     numberDesc{"1333\\d{4}|[12]468\\d{4}", []int{8}},
+	},
+	"MN": {
+		countryCode:              976,
+		internationalPrefix:      "001",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[12]\\d{7,9}|[57-9]\\d{7}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"[12](?:3[2-8]|4[2-68]|5[1-4689])\\d{6,7}|(?:11(?:3\\d|4[568])|(?:(?:21|5[0568])\\d|70[0-5])\\d)\\d{4}|[12]2(?:[1-3]\\d{5,6}|7\\d{6})", []int{}},
+		mobile:                   numberDesc{"(?:8(?:[05689]\\d|3[01])|9(?:[014-9]\\d|20|3[0-4]))\\d{5}", []int{8}},
+		voip:                     numberDesc{"7(?:100|5(?:0[0579]|1[015]|[389]5|[57][57])|(?:6[0167]|7\\d|8[01])\\d)\\d{4}", []int{8}},
+	},
+	"MO": {
+		countryCode:         853,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:28|[68]\\d)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"(?:28[2-57-9]|8(?:11|[2-57-9]\\d))\\d{5}", []int{}},
+		mobile:              numberDesc{"6(?:[2356]\\d\\d|8(?:[02][5-9]|[1478]\\d|[356][0-4]))\\d{4}", []int{}},
+	},
+	"MP": {
+		countryCode:                   1,
+		internationalPrefix:           "011",
+		nationalPrefixForParsing:      "1|([2-9]\\d{6})$",
+		nationalPrefixTransformRule:   "670$1",
+		leadingDigits:                 "670",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"[58]\\d{9}|(?:67|90)0\\d{7}", []int{10}},
+		fixedLine:                     numberDesc{"670(?:2(?:3[3-7]|56|8[5-8])|32[1-38]|4(?:33|8[348])|5(?:32|55|88)|6(?:64|70|82)|78[3589]|8[3-9]8|989)\\d{4}", []int{}},
+		mobile:                        numberDesc{"670(?:2(?:3[3-7]|56|8[5-8])|32[1-38]|4(?:33|8[348])|5(?:32|55|88)|6(?:64|70|82)|78[3589]|8[3-9]8|989)\\d{4}", []int{}},
+		tollFree:                      numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:                numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"MQ": {
+		countryCode:              596,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"69\\d{7}|(?:59|97)6\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"596(?:0[0-7]|10|2[7-9]|3[05-9]|4[0-46-8]|[5-7]\\d|8[09]|9[4-8])\\d{4}", []int{}},
+		mobile:                   numberDesc{"69(?:6(?:[0-47-9]\\d|5[0-6]|6[0-4])|727)\\d{4}", []int{}},
+		voip:                     numberDesc{"976(?:6[1-9]|7[0-367])\\d{4}", []int{}},
+	},
+	"MR": {
+		countryCode:         222,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[2-4]\\d\\d|800)\\d{5}", []int{8}},
+		fixedLine:           numberDesc{"(?:25[08]|35\\d|45[1-7])\\d{5}", []int{}},
+		mobile:              numberDesc{"[2-4][0-46-9]\\d{6}", []int{}},
+		tollFree:            numberDesc{"800\\d{5}", []int{}},
+	},
+	"MS": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|(4\\d{6})$",
+		nationalPrefixTransformRule: "664$1",
+		leadingDigits:               "664",
+		general:                     numberDesc{"66449\\d{5}|(?:[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"664491\\d{4}", []int{}},
+		mobile:                      numberDesc{"66449[2-6]\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"MT": {
+		countryCode:         356,
+		internationalPrefix: "00",
+		general:             numberDesc{"3550\\d{4}|(?:[2579]\\d\\d|800)\\d{5}", []int{8}},
+		fixedLine:           numberDesc{"2(?:0(?:[19]\\d|3[1-4]|6[059])|[1-357]\\d\\d)\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:7(?:210|[79]\\d\\d)|9(?:2(?:1[01]|31)|69[67]|8(?:1[1-3]|89|97)|9\\d\\d))\\d{4}", []int{}},
+		tollFree:            numberDesc{"800[3467]\\d{4}", []int{}},
+		premiumRate:         numberDesc{"5(?:0(?:0(?:37|43)|(?:6\\d|70|9[0168])\\d)|[12]\\d0[1-5])\\d{3}", []int{}},
+		voip:                numberDesc{"3550\\d{4}", []int{}},
+		pager:               numberDesc{"7117\\d{4}", []int{}},
+		uan:                 numberDesc{"501\\d{5}", []int{}},
+	},
+	"MU": {
+		countryCode:         230,
+		internationalPrefix: "0(?:0|[24-7]0|3[03])",
+		general:             numberDesc{"(?:[2-468]|5\\d)\\d{6}", []int{7, 8}},
+		fixedLine:           numberDesc{"(?:2(?:[03478]\\d|1[0-7]|6[0-79])|4(?:[013568]\\d|2[4-7])|54(?:[34]\\d|71)|6\\d\\d|8(?:14|3[129]))\\d{4}", []int{}},
+		mobile:              numberDesc{"5(?:4(?:2[1-389]|7[1-9])|87[15-8])\\d{4}|5(?:2[589]|4[3489]|7\\d|8[0-689]|9[0-8])\\d{5}", []int{8}},
+		tollFree:            numberDesc{"80[0-2]\\d{4}", []int{7}},
+		premiumRate:         numberDesc{"30\\d{5}", []int{7}},
+		voip:                numberDesc{"3(?:20|9\\d)\\d{4}", []int{7}},
+	},
+	"MV": {
+		countryCode:         960,
+		internationalPrefix: "0(?:0|19)",
+		general:             numberDesc{"(?:800|9[0-57-9]\\d)\\d{7}|[34679]\\d{6}", []int{7, 10}},
+		fixedLine:           numberDesc{"(?:3(?:0[0-3]|3[0-59])|6(?:[57][02468]|6[024-68]|8[024689]))\\d{4}", []int{7}},
+		mobile:              numberDesc{"46[46]\\d{4}|(?:7[2-9]|9[13-9])\\d{5}", []int{7}},
+		tollFree:            numberDesc{"800\\d{7}", []int{10}},
+		premiumRate:         numberDesc{"900\\d{7}", []int{10}},
+		uan:                 numberDesc{"4[05]0\\d{4}", []int{7}},
+	},
+	"MW": {
+		countryCode:              265,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{6}(?:\\d{2})?|(?:[23]1|77|88|99)\\d{7}", []int{7, 9}},
+		fixedLine:                numberDesc{"(?:1[2-9]|21\\d\\d)\\d{5}", []int{}},
+		mobile:                   numberDesc{"111\\d{6}|(?:77|88|99)\\d{7}", []int{9}},
+		voip:                     numberDesc{"31\\d{7}", []int{9}},
+	},
+	"MX": {
+		countryCode:              52,
+		internationalPrefix:      "0[09]",
+		nationalPrefixForParsing: "0(?:[12]|4[45])|1",
+		general:                  numberDesc{"(?:1(?:[01467]\\d|[2359][1-9]|8[1-79])|[2-9]\\d)\\d{8}", []int{10, 11}},
+		fixedLine:                numberDesc{"(?:2(?:0[01]|2[1-9]|3[1-35-8]|4[13-9]|7[1-689]|8[1-578]|9[467])|3(?:1[1-79]|[2458][1-9]|3\\d|7[1-8]|9[1-5])|4(?:1[1-57-9]|[24-7][1-9]|3[1-8]|8[1-35-9]|9[2-689])|5(?:[56]\\d|88|9[1-79])|6(?:1[2-68]|[2-4][1-9]|5[1-3689]|6[1-57-9]|7[1-7]|8[67]|9[4-8])|7(?:[1-467][1-9]|5[13-9]|8[1-69]|9[17])|8(?:1\\d|2[13-689]|3[1-6]|4[124-6]|6[1246-9]|7[1-378]|9[12479])|9(?:1[346-9]|2[1-4]|3[2-46-8]|5[1348]|[69][1-9]|7[12]|8[1-8]))\\d{7}", []int{10}},
+		mobile:                   numberDesc{"(?:1(?:2(?:2[1-9]|3[1-35-8]|4[13-9]|7[1-689]|8[1-578]|9[467])|3(?:1[1-79]|[2458][1-9]|3\\d|7[1-8]|9[1-5])|4(?:1[1-57-9]|[24-7][1-9]|3[1-8]|8[1-35-9]|9[2-689])|5(?:[56]\\d|88|9[1-79])|6(?:1[2-68]|[2-4][1-9]|5[1-3689]|6[1-57-9]|7[1-7]|8[67]|9[4-8])|7(?:[1-467][1-9]|5[13-9]|8[1-69]|9[17])|8(?:1\\d|2[13-689]|3[1-6]|4[124-6]|6[1246-9]|7[1-378]|9[12479])|9(?:1[346-9]|2[1-4]|3[2-46-8]|5[1348]|[69][1-9]|7[12]|8[1-8]))|2(?:2[1-9]|3[1-35-8]|4[13-9]|7[1-689]|8[1-578]|9[467])|3(?:1[1-79]|[2458][1-9]|3\\d|7[1-8]|9[1-5])|4(?:1[1-57-9]|[24-7][1-9]|3[1-8]|8[1-35-9]|9[2-689])|5(?:[56]\\d|88|9[1-79])|6(?:1[2-68]|[2-4][1-9]|5[1-3689]|6[1-57-9]|7[1-7]|8[67]|9[4-8])|7(?:[1-467][1-9]|5[13-9]|8[1-69]|9[17])|8(?:1\\d|2[13-689]|3[1-6]|4[124-6]|6[1246-9]|7[1-378]|9[12479])|9(?:1[346-9]|2[1-4]|3[2-46-8]|5[1348]|[69][1-9]|7[12]|8[1-8]))\\d{7}", []int{}},
+		tollFree:                 numberDesc{"8(?:00|88)\\d{7}", []int{10}},
+		premiumRate:              numberDesc{"900\\d{7}", []int{10}},
+		sharedCost:               numberDesc{"300\\d{7}", []int{10}},
+		personalNumber:           numberDesc{"500\\d{7}", []int{10}},
+	},
+	"MY": {
+		countryCode:              60,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{8,9}|(?:3\\d|[4-9])\\d{7}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"(?:3(?:2[0-36-9]|3[0-368]|4[0-278]|5[0-24-8]|6[0-467]|7[1246-9]|8\\d|9[0-57])\\d|4(?:2[0-689]|[3-79]\\d|8[1-35689])|5(?:2[0-589]|[3468]\\d|5[0-489]|7[1-9]|9[23])|6(?:2[2-9]|3[1357-9]|[46]\\d|5[0-6]|7[0-35-9]|85|9[015-8])|7(?:[2579]\\d|3[03-68]|4[0-8]|6[5-9]|8[0-35-9])|8(?:[24][2-8]|3[2-5]|5[2-7]|6[2-589]|7[2-578]|[89][2-9])|9(?:0[57]|13|[25-7]\\d|[3489][0-8]))\\d{5}", []int{8, 9}},
+		mobile:                   numberDesc{"1(?:4400|8(?:47|8[27])[0-4])\\d{4}|1(?:0(?:[23568]\\d|4[0-6]|7[016-9]|9[0-8])|1(?:[1-5]\\d\\d|6(?:0[5-9]|[1-9]\\d)|7(?:0[3-9]|1[01]))|(?:[2379][2-9]|4[235-9]|(?:59|6)\\d)\\d|8(?:1[23]|[236]\\d|4[06]|5[7-9]|7[016-9]|8[01]|9[0-8]))\\d{5}", []int{9, 10}},
+		tollFree:                 numberDesc{"1[378]00\\d{6}", []int{10}},
+		premiumRate:              numberDesc{"1600\\d{6}", []int{10}},
+		voip:                     numberDesc{"154(?:6(?:0\\d|1[0-3])|8(?:[25]1|4[0189]|7[0-4679]))\\d{4}", []int{10}},
+	},
+	"MZ": {
+		countryCode:         258,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:2|8\\d)\\d{7}", []int{8, 9}},
+		fixedLine:           numberDesc{"2(?:[1346]\\d|5[0-2]|[78][12]|93)\\d{5}", []int{8}},
+		mobile:              numberDesc{"8[2-7]\\d{7}", []int{9}},
+		tollFree:            numberDesc{"800\\d{6}", []int{9}},
+	},
+	"NA": {
+		countryCode:              264,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[68]\\d{7,8}", []int{8, 9}},
+		fixedLine:                numberDesc{"6(?:1(?:[02-4]\\d\\d|17)|2(?:17|54\\d|69|70)|3(?:17|2[0237]\\d|34|6[289]|7[01]|81)|4(?:17|(?:27|41|5[25])\\d|69|7[01])|5(?:17|2[236-8]\\d|69|7[01])|6(?:17|26\\d|38|42|69|7[01])|7(?:17|(?:2[2-4]|30)\\d|6[89]|7[01]))\\d{4}|6(?:1(?:2[2-7]|3[01378]|4[0-4]|69|7[014])|25[0-46-8]|32\\d|4(?:2[0-27]|4[016]|5[0-357])|52[02-9]|62[56]|7(?:2[2-69]|3[013]))\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:60|8[1245])\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"80\\d{7}", []int{9}},
+		premiumRate:              numberDesc{"8701\\d{5}", []int{9}},
+		voip:                     numberDesc{"8(?:3\\d\\d|86)\\d{5}", []int{}},
+	},
+	"NC": {
+		countryCode:         687,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-57-9]\\d{5}", []int{6}},
+		fixedLine:           numberDesc{"(?:2[03-9]|3[0-5]|4[1-7]|88)\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:5[0-4]|[79]\\d|8[0-79])\\d{4}", []int{}},
+		premiumRate:         numberDesc{"36\\d{4}", []int{}},
+	},
+	"NE": {
+		countryCode:         227,
+		internationalPrefix: "00",
+		general:             numberDesc{"[0289]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"2(?:0(?:20|3[1-8]|4[13-5]|5[14]|6[14578]|7[1-578])|1(?:4[145]|5[14]|6[14-68]|7[169]|88))\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:8[014589]|9\\d)\\d{6}", []int{}},
+		tollFree:            numberDesc{"08\\d{6}", []int{}},
+		premiumRate:         numberDesc{"09\\d{6}", []int{}},
+	},
+	"NF": {
+		countryCode:                 672,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "([0-258]\\d{4})$",
+		nationalPrefixTransformRule: "3$1",
+		general:                     numberDesc{"[13]\\d{5}", []int{6}},
+		fixedLine:                   numberDesc{"(?:1(?:06|17|28|39)|3[0-2]\\d)\\d{3}", []int{}},
+		mobile:                      numberDesc{"3[58]\\d{4}", []int{}},
+	},
+	"NG": {
+		countryCode:              234,
+		internationalPrefix:      "009",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[124-7]|9\\d{3})\\d{6}|[1-9]\\d{7}|[78]\\d{9,13}", []int{7, 8, 10, 11, 12, 13, 14}},
+		fixedLine:                numberDesc{"(?:(?:[1-356]\\d|4[02-8]|7[0-79]|8[2-9])\\d|9(?:0[3-9]|[1-9]\\d))\\d{5}|(?:[12]\\d|4[147]|5[14579]|6[1578]|7[0-3578])\\d{5}", []int{7, 8}},
+		mobile:                   numberDesc{"(?:707[0-3]|8(?:01|19)[01])\\d{6}|(?:70[1-689]|8(?:0[2-9]|1[0-8])|90[1-35-9])\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"800\\d{7,11}", []int{10, 11, 12, 13, 14}},
+		uan:                      numberDesc{"700\\d{7,11}", []int{10, 11, 12, 13, 14}},
+	},
+	"NI": {
+		countryCode:         505,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:1800|[25-8]\\d{3})\\d{4}", []int{8}},
+		fixedLine:           numberDesc{"2\\d{7}", []int{}},
+		mobile:              numberDesc{"(?:5(?:5[0-7]|[78]\\d)|6(?:20|3[035]|4[045]|5[05]|77|8[1-9]|9[059])|(?:7[5-8]|8\\d)\\d)\\d{5}", []int{}},
+		tollFree:            numberDesc{"1800\\d{4}", []int{}},
+	},
+	"NL": {
+		countryCode:              31,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[124-7]\\d\\d|3(?:[02-9]\\d|1[0-8]))\\d{6}|[89]\\d{6,9}|1\\d{4,5}", []int{5, 6, 7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:1(?:[035]\\d|1[13-578]|6[124-8]|7[24]|8[0-467])|2(?:[0346]\\d|2[2-46-9]|5[125]|9[479])|3(?:[03568]\\d|1[3-8]|2[01]|4[1-8])|4(?:[0356]\\d|1[1-368]|7[58]|8[15-8]|9[23579])|5(?:[0358]\\d|[19][1-9]|2[1-57-9]|4[13-8]|6[126]|7[0-3578])|7\\d\\d)\\d{6}", []int{9}},
+		mobile:                   numberDesc{"6[1-58]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{4,7}", []int{7, 8, 9, 10}},
+		premiumRate:              numberDesc{"90[069]\\d{4,7}", []int{7, 8, 9, 10}},
+		voip:                     numberDesc{"(?:85|91)\\d{7}", []int{9}},
+		pager:                    numberDesc{"66\\d{7}", []int{9}},
+		uan:                      numberDesc{"140(?:1[035]|2[0346]|3[03568]|4[0356]|5[0358]|8[458])|(?:140(?:1[16-8]|2[259]|3[124]|4[17-9]|5[124679]|7)|8[478]\\d{6})\\d", []int{5, 6, 9}},
+	},
+	"NO": {
+		countryCode:         47,
+		internationalPrefix: "00",
+		leadingDigits:       "[02-689]|7[0-8]",
+		general:             numberDesc{"(?:0|[2-9]\\d{3})\\d{4}", []int{5, 8}},
+		fixedLine:           numberDesc{"(?:2[1-4]|3[1-3578]|5[1-35-7]|6[1-4679]|7[0-8])\\d{6}", []int{8}},
+		mobile:              numberDesc{"(?:4[015-8]|5[89]|9\\d)\\d{6}", []int{8}},
+		tollFree:            numberDesc{"80[01]\\d{5}", []int{8}},
+		premiumRate:         numberDesc{"82[09]\\d{5}", []int{8}},
+		sharedCost:          numberDesc{"810(?:0[0-6]|[2-8]\\d)\\d{3}", []int{8}},
+		voip:                numberDesc{"85[0-5]\\d{5}", []int{8}},
+		personalNumber:      numberDesc{"880\\d{5}", []int{8}},
+		uan:                 numberDesc{"(?:0[2-9]|81(?:0(?:0[7-9]|1\\d)|5\\d\\d))\\d{3}", []int{}},
+		voicemail:           numberDesc{"81[23]\\d{5}", []int{8}},
+	},
+	"NP": {
+		countryCode:              977,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"9\\d{9}|[1-9]\\d{7}", []int{8, 10}},
+		fixedLine:                numberDesc{"1[0-6]\\d{6}|(?:2[13-79]|3[135-8]|4[146-9]|5[135-7]|6[13-9]|7[15-9]|8[1-46-9]|9[1-79])[2-6]\\d{5}", []int{8}},
+		mobile:                   numberDesc{"9(?:6[0-3]|7[245]|8[0-24-68])\\d{7}", []int{10}},
+	},
+	"NR": {
+		countryCode:         674,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:444|55\\d|888)\\d{4}", []int{7}},
+		fixedLine:           numberDesc{"(?:444|888)\\d{4}", []int{}},
+		mobile:              numberDesc{"55[4-9]\\d{4}", []int{}},
+	},
+	"NU": {
+		countryCode:         683,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[47]|888\\d)\\d{3}", []int{4, 7}},
+		fixedLine:           numberDesc{"[47]\\d{3}", []int{4}},
+		mobile:              numberDesc{"888[4-9]\\d{3}", []int{7}},
+	},
+	"NZ": {
+		countryCode:              64,
+		internationalPrefix:      "0(?:0|161)",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[28]\\d{7,9}|[346]\\d{7}|(?:508|[79]\\d)\\d{6,7}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"24099\\d{3}|(?:3[2-79]|[49][2-9]|6[235-9]|7[2-57-9])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"2[0-28]\\d{8}|2[0-27-9]\\d{7}|21\\d{6}", []int{}},
+		tollFree:                 numberDesc{"508\\d{6,7}|80\\d{6,8}", []int{}},
+		premiumRate:              numberDesc{"90\\d{6,7}", []int{8, 9}},
+		personalNumber:           numberDesc{"70\\d{7}", []int{9}},
+		pager:                    numberDesc{"[28]6\\d{6,7}", []int{8, 9}},
+	},
+	"OM": {
+		countryCode:         968,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[279]\\d{3}|500)\\d{4}|8007\\d{4,5}", []int{7, 8, 9}},
+		fixedLine:           numberDesc{"2[2-6]\\d{6}", []int{8}},
+		mobile:              numberDesc{"90[1-9]\\d{5}|(?:7[1289]|9[1-9])\\d{6}", []int{8}},
+		tollFree:            numberDesc{"500\\d{4}|8007\\d{4,5}", []int{}},
+		premiumRate:         numberDesc{"900\\d{5}", []int{8}},
+	},
+	"PA": {
+		countryCode:         507,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[1-57-9]|6\\d)\\d{6}", []int{7, 8}},
+		fixedLine:           numberDesc{"(?:1(?:0\\d|1[479]|2[37]|3[0137]|4[17]|5[05]|[68][58]|7[0167]|9[39])|2(?:[0235-79]\\d|1[0-7]|4[013-9]|8[026-9])|3(?:[089]\\d|1[014-7]|2[0-35]|33|4[0-579]|55|6[068]|7[06-8])|4(?:00|3[0-579]|4\\d|7[0-57-9])|5(?:[01]\\d|2[0-7]|[56]0|79)|7(?:0[09]|2[0-26-8]|3[03]|4[04]|5[05-9]|6[05]|7[0-24-9]|8[7-9]|90)|8(?:09|2[89]|3\\d|4[0-24-689]|5[014]|8[02])|9(?:0[5-9]|1[0135-8]|2[036-9]|3[35-79]|40|5[0457-9]|6[05-9]|7[04-9]|8[35-8]|9\\d))\\d{4}", []int{7}},
+		mobile:              numberDesc{"(?:1[16]1|21[89]|6(?:[02-9]\\d|1[0-6])\\d|8(?:1[01]|7[23]))\\d{4}", []int{}},
+		tollFree:            numberDesc{"800\\d{4}", []int{7}},
+		premiumRate:         numberDesc{"(?:8(?:22|55|60|7[78]|86)|9(?:00|81))\\d{4}", []int{7}},
+	},
+	"PE": {
+		countryCode:              51,
+		internationalPrefix:      "19(?:1[124]|77|90)00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[14-8]|9\\d)\\d{7}", []int{8, 9}},
+		fixedLine:                numberDesc{"19(?:[02-68]\\d|1[035-9]|7[0-689]|9[1-9])\\d{4}|(?:1[0-8]|4[1-4]|5[1-46]|6[1-7]|7[2-46]|8[2-4])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"9\\d{8}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{8}},
+		premiumRate:              numberDesc{"805\\d{5}", []int{8}},
+		sharedCost:               numberDesc{"801\\d{5}", []int{8}},
+		personalNumber:           numberDesc{"80[24]\\d{5}", []int{8}},
+	},
+	"PF": {
+		countryCode:         689,
+		internationalPrefix: "00",
+		general:             numberDesc{"[48]\\d{7}|4\\d{5}", []int{6, 8}},
+		fixedLine:           numberDesc{"4(?:[09][4-689]\\d|4)\\d{4}", []int{}},
+		mobile:              numberDesc{"8[7-9]\\d{6}", []int{8}},
+	},
+	"PG": {
+		countryCode:         675,
+		internationalPrefix: "00|140[1-3]",
+		general:             numberDesc{"(?:180|[78]\\d{3})\\d{4}|(?:[2-589]\\d|64)\\d{5}", []int{7, 8}},
+		fixedLine:           numberDesc{"(?:64[1-9]|7730|85[02-46-9])\\d{4}|(?:3[0-2]|4[257]|5[34]|77[0-24]|9[78])\\d{5}", []int{}},
+		mobile:              numberDesc{"775\\d{5}|(?:7[0-689]|81)\\d{6}", []int{8}},
+		tollFree:            numberDesc{"180\\d{4}", []int{7}},
+		voip:                numberDesc{"2(?:0[0-47]|7[568])\\d{4}", []int{7}},
+	},
+	"PH": {
+		countryCode:              63,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1800\\d{7,9}|(?:2|[89]\\d{4})\\d{5}|[2-8]\\d{8}|[28]\\d{7}", []int{6, 8, 9, 10, 11, 12, 13}},
+		fixedLine:                numberDesc{"(?:(?:2[3-8]|3[2-68]|4[2-9]|5[2-6]|6[2-58]|7[24578])\\d{3}|88(?:22\\d\\d|42))\\d{4}|2\\d{5}(?:\\d{2})?|8[2-8]\\d{7}", []int{6, 8, 9, 10}},
+		mobile:                   numberDesc{"(?:81[37]|9(?:0[5-9]|1[0-24-9]|2[0-35-9]|[35]\\d|4[235-9]|6[0-25-8]|7[1-9]|8[19]|9[4-9]))\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"1800\\d{7,9}", []int{11, 12, 13}},
+	},
+	"PK": {
+		countryCode:              92,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"122\\d{6}|[24-8]\\d{10,11}|9(?:[013-9]\\d{8,10}|2(?:[01]\\d\\d|2(?:[025-8]\\d|1[01]))\\d{7})|(?:[2-8]\\d{3}|92(?:[0-7]\\d|8[1-9]))\\d{6}|[24-9]\\d{8}|[89]\\d{7}", []int{8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"(?:(?:21|42)[2-9]|58[126])\\d{7}|(?:2[25]|4[0146-9]|5[1-35-7]|6[1-8]|7[14]|8[16]|91)[2-9]\\d{6}|(?:2(?:3[2358]|4[2-4]|9[2-8])|45[3479]|54[2-467]|60[468]|72[236]|8(?:2[2-689]|3[23578]|4[3478]|5[2356])|9(?:2[2-8]|3[27-9]|4[2-6]|6[3569]|9[25-8]))[2-9]\\d{5,6}", []int{9, 10}},
+		mobile:                   numberDesc{"3(?:[014]\\d|2[0-5]|3[0-7]|55|64)\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{8}},
+		premiumRate:              numberDesc{"900\\d{5}", []int{8}},
+		personalNumber:           numberDesc{"122\\d{6}", []int{9}},
+		uan:                      numberDesc{"(?:2(?:[125]|3[2358]|4[2-4]|9[2-8])|4(?:[0-246-9]|5[3479])|5(?:[1-35-7]|4[2-467])|6(?:0[468]|[1-8])|7(?:[14]|2[236])|8(?:[16]|2[2-689]|3[23578]|4[3478]|5[2356])|9(?:1|22|3[27-9]|4[2-6]|6[3569]|9[2-7]))111\\d{6}", []int{11, 12}},
+	},
+	"PL": {
+		countryCode:         48,
+		internationalPrefix: "00",
+		general:             numberDesc{"[1-57-9]\\d{6}(?:\\d{2})?|6\\d{5,8}", []int{6, 7, 8, 9}},
+		fixedLine:           numberDesc{"(?:1[2-8]|2[2-69]|3[2-4]|4[1-468]|5[24-689]|6[1-3578]|7[14-7]|8[1-79]|9[145])(?:[02-9]\\d{6}|1(?:[0-8]\\d{5}|9\\d{3}(?:\\d{2})?))", []int{7, 9}},
+		mobile:              numberDesc{"(?:45|5[0137]|6[069]|7[2389]|88)\\d{7}", []int{9}},
+		tollFree:            numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:         numberDesc{"70[01346-8]\\d{6}", []int{9}},
+		sharedCost:          numberDesc{"801\\d{6}", []int{9}},
+		voip:                numberDesc{"39\\d{7}", []int{9}},
+		pager:               numberDesc{"64\\d{4,7}", []int{}},
+		uan:                 numberDesc{"804\\d{6}", []int{9}},
+	},
+	"PM": {
+		countryCode:              508,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[45]\\d{5}", []int{6}},
+		fixedLine:                numberDesc{"(?:4[1-3]|50)\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:4[02-4]|5[05])\\d{4}", []int{}},
+	},
+	"PR": {
+		countryCode:                   1,
+		internationalPrefix:           "011",
+		nationalPrefixForParsing:      "1",
+		leadingDigits:                 "787|939",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"(?:[589]\\d\\d|787)\\d{7}", []int{10}},
+		fixedLine:                     numberDesc{"(?:787|939)[2-9]\\d{6}", []int{}},
+		mobile:                        numberDesc{"(?:787|939)[2-9]\\d{6}", []int{}},
+		tollFree:                      numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:                numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"PS": {
+		countryCode:              970,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2489]2\\d{6}|(?:1\\d|5)\\d{8}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"(?:22[2-47-9]|42[45]|82[01458]|92[369])\\d{5}", []int{8}},
+		mobile:                   numberDesc{"5[69]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"1800\\d{6}", []int{10}},
+		sharedCost:               numberDesc{"1700\\d{6}", []int{10}},
+	},
+	"PT": {
+		countryCode:         351,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[26-9]\\d|30)\\d{7}", []int{9}},
+		fixedLine:           numberDesc{"2(?:[12]\\d|[35][1-689]|4[1-59]|6[1-35689]|7[1-9]|8[1-69]|9[1256])\\d{6}", []int{}},
+		mobile:              numberDesc{"6[356]9230\\d{3}|(?:6[036]93|9(?:[1-36]\\d\\d|480))\\d{5}", []int{}},
+		tollFree:            numberDesc{"80[02]\\d{6}", []int{}},
+		premiumRate:         numberDesc{"(?:6(?:0[178]|4[68])\\d|76(?:0[1-57]|1[2-47]|2[237]))\\d{5}", []int{}},
+		sharedCost:          numberDesc{"80(?:8\\d|9[1579])\\d{5}", []int{}},
+		voip:                numberDesc{"30\\d{7}", []int{}},
+		personalNumber:      numberDesc{"884[0-4689]\\d{5}", []int{}},
+		uan:                 numberDesc{"70(?:7\\d|8[17])\\d{5}", []int{}},
+		voicemail:           numberDesc{"600\\d{6}", []int{}},
+	},
+	"PW": {
+		countryCode:         680,
+		internationalPrefix: "01[12]",
+		general:             numberDesc{"(?:[25-8]\\d\\d|345|488|900)\\d{4}", []int{7}},
+		fixedLine:           numberDesc{"(?:2(?:55|77)|345|488|5(?:35|44|87)|6(?:22|54|79)|7(?:33|47)|8(?:24|55|76)|900)\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:6[2-4689]0|77\\d|88[0-4])\\d{4}", []int{}},
+	},
+	"PY": {
+		countryCode:              595,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"59\\d{4,6}|(?:[2-46-9]\\d|5[0-8])\\d{4,7}", []int{6, 7, 8, 9}},
+		fixedLine:                numberDesc{"(?:[26]1|3[289]|4[1246-8]|7[1-3]|8[1-36])\\d{5,7}|(?:2(?:2[4-68]|7[15]|9[1-5])|3(?:18|3[167]|4[2357]|51)|4(?:3[12]|5[13]|9[1-47])|5(?:[1-4]\\d|5[02-4])|6(?:3[1-3]|44|7[1-46-8])|7(?:4[0-4]|6[1-578]|75|8[0-8])|858)\\d{5,6}", []int{7, 8, 9}},
+		mobile:                   numberDesc{"9(?:51|6[129]|[78][1-6]|9[1-5])\\d{6}", []int{9}},
+		voip:                     numberDesc{"8700[0-4]\\d{4}", []int{9}},
+		uan:                      numberDesc{"[2-9]0\\d{4,7}", []int{}},
+	},
+	"QA": {
+		countryCode:         974,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-7]\\d{7}|(?:2\\d\\d|800)\\d{4}", []int{7, 8}},
+		fixedLine:           numberDesc{"4[04]\\d{6}", []int{8}},
+		mobile:              numberDesc{"(?:28|[35-7]\\d)\\d{6}", []int{8}},
+		tollFree:            numberDesc{"800\\d{4}", []int{7}},
+		pager:               numberDesc{"2(?:[12]\\d|61)\\d{4}", []int{7}},
+	},
+	"RE": {
+		countryCode:              262,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		leadingDigits:            "26[23]|69|[89]",
+		general:                  numberDesc{"9769\\d{5}|(?:26|[68]\\d)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"26(?:2\\d\\d|30[01])\\d{4}", []int{}},
+		mobile:                   numberDesc{"(?:69(?:2\\d\\d|3(?:0[0-46]|1[013]|2[0-2]|3[0-39]|4\\d|5[05]|6[0-26]|7[0-27]|8[03-8]|9[0-479]))|9769\\d)\\d{4}", []int{}},
+		tollFree:                 numberDesc{"80\\d{7}", []int{}},
+		premiumRate:              numberDesc{"89[1-37-9]\\d{6}", []int{}},
+		sharedCost:               numberDesc{"8(?:1[019]|2[0156]|84|90)\\d{6}", []int{}},
+	},
+	"RO": {
+		countryCode:              40,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[237]\\d|[89]0)\\d{7}|[23]\\d{5}", []int{6, 9}},
+		fixedLine:                numberDesc{"[23][13-6]\\d{7}|(?:2(?:19\\d|[3-6]\\d9)|31\\d\\d)\\d\\d", []int{}},
+		mobile:                   numberDesc{"7120\\d{5}|7(?:[02-7]\\d|1[01]|8[03-8]|9[09])\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:              numberDesc{"90[036]\\d{6}", []int{9}},
+		sharedCost:               numberDesc{"801\\d{6}", []int{9}},
+		uan:                      numberDesc{"37\\d{7}", []int{9}},
+	},
+	"RS": {
+		countryCode:              381,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"38[02-9]\\d{6,9}|6\\d{7,9}|90\\d{4,8}|38\\d{5,6}|(?:7\\d\\d|800)\\d{3,9}|(?:[12]\\d|3[0-79])\\d{5,10}", []int{6, 7, 8, 9, 10, 11, 12}},
+		fixedLine:                numberDesc{"(?:11[1-9]\\d|(?:2[389]|39)(?:0[2-9]|[2-9]\\d))\\d{3,8}|(?:1[02-9]|2[0-24-7]|3[0-8])[2-9]\\d{4,9}", []int{7, 8, 9, 10, 11, 12}},
+		mobile:                   numberDesc{"6(?:[0-689]|7\\d)\\d{6,7}", []int{8, 9, 10}},
+		tollFree:                 numberDesc{"800\\d{3,9}", []int{}},
+		premiumRate:              numberDesc{"(?:78\\d|90[0169])\\d{3,7}", []int{6, 7, 8, 9, 10}},
+		uan:                      numberDesc{"7[06]\\d{4,10}", []int{}},
+	},
+	"RU": {
+		countryCode:              7,
+		internationalPrefix:      "810",
+		nationalPrefixForParsing: "8",
+		leadingDigits:            "3[04-689]|[489]",
+		general:                  numberDesc{"[347-9]\\d{9}", []int{10}},
+		fixedLine:                numberDesc{"(?:3(?:0[12]|4[1-35-79]|5[1-3]|65|8[1-58]|9[0145])|4(?:01|1[1356]|2[13467]|7[1-5]|8[1-7]|9[1-689])|8(?:1[1-8]|2[01]|3[13-6]|4[0-8]|5[15]|6[1-35-79]|7[1-37-9]))\\d{7}", []int{}},
+		mobile:                   numberDesc{"9\\d{9}", []int{}},
+		tollFree:                 numberDesc{"80[04]\\d{7}", []int{}},
+		premiumRate:              numberDesc{"80[39]\\d{7}", []int{}},
+		personalNumber:           numberDesc{"808\\d{7}", []int{}},
+	},
+	"RW": {
+		countryCode:              250,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:06|[27]\\d\\d|[89]00)\\d{6}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:06|2[258]\\d)\\d{6}", []int{}},
+		mobile:                   numberDesc{"7[238]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:              numberDesc{"900\\d{6}", []int{9}},
+	},
+	"SA": {
+		countryCode:              966,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"92\\d{7}|(?:[15]|8\\d)\\d{8}", []int{9, 10}},
+		fixedLine:                numberDesc{"1(?:1\\d|2[24-8]|3[35-8]|4[3-68]|6[2-5]|7[235-7])\\d{6}", []int{9}},
+		mobile:                   numberDesc{"5(?:[013-689]\\d|7[0-36-8])\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{10}},
+		premiumRate:              numberDesc{"925\\d{6}", []int{9}},
+		sharedCost:               numberDesc{"920\\d{6}", []int{9}},
+		uan:                      numberDesc{"811\\d{7}", []int{10}},
+	},
+	"SB": {
+		countryCode:         677,
+		internationalPrefix: "0[01]",
+		general:             numberDesc{"(?:[1-6]|[7-9]\\d\\d)\\d{4}", []int{5, 7}},
+		fixedLine:           numberDesc{"(?:1[4-79]|[23]\\d|4[0-2]|5[03]|6[0-37])\\d{3}", []int{5}},
+		mobile:              numberDesc{"48\\d{3}|(?:(?:7[1-9]|8[4-9])\\d|9(?:1[2-9]|2[013-9]|3[0-2]|[46]\\d|5[0-46-9]|7[0-689]|8[0-79]|9[0-8]))\\d{4}", []int{}},
+		tollFree:            numberDesc{"1[38]\\d{3}", []int{5}},
+		voip:                numberDesc{"5[12]\\d{3}", []int{5}},
+	},
+	"SC": {
+		countryCode:         248,
+		internationalPrefix: "010|0[0-2]",
+		general:             numberDesc{"8000\\d{3}|(?:[249]\\d|64)\\d{5}", []int{7}},
+		fixedLine:           numberDesc{"4[2-46]\\d{5}", []int{}},
+		mobile:              numberDesc{"2[5-8]\\d{5}", []int{}},
+		tollFree:            numberDesc{"8000\\d{3}", []int{}},
+		voip:                numberDesc{"971\\d{4}|(?:64|95)\\d{5}", []int{}},
+	},
+	"SD": {
+		countryCode:              249,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[19]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"1(?:5[3-7]|8[35-7])\\d{6}", []int{}},
+		mobile:                   numberDesc{"(?:1[0-2]|9[0-3569])\\d{7}", []int{}},
+	},
+	"SE": {
+		countryCode:              46,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[26]\\d\\d|9)\\d{9}|[1-9]\\d{8}|[1-689]\\d{7}|[1-4689]\\d{6}|2\\d{5}", []int{6, 7, 8, 9, 10, 12}},
+		fixedLine:                numberDesc{"10[1-8]\\d{6}|90[1-9]\\d{4,6}|(?:[12][136]|3[356]|4[0246]|6[03]|8\\d)\\d{5,7}|(?:1(?:2[0-35]|4[0-4]|5[0-25-9]|7[13-6]|[89]\\d)|2(?:2[0-7]|4[0136-8]|5[0138]|7[018]|8[01]|9[0-57])|3(?:0[0-4]|1\\d|2[0-25]|4[056]|7[0-2]|8[0-3]|9[023])|4(?:1[013-8]|3[0135]|5[14-79]|7[0-246-9]|8[0156]|9[0-689])|5(?:0[0-6]|[15][0-5]|2[0-68]|3[0-4]|4\\d|6[03-5]|7[013]|8[0-79]|9[01])|6(?:1[1-3]|2[0-4]|4[02-57]|5[0-37]|6[0-3]|7[0-2]|8[0247]|9[0-356])|9(?:1[0-68]|2\\d|3[02-5]|4[0-3]|5[0-4]|[68][01]|7[0135-8]))\\d{5,6}", []int{7, 8, 9}},
+		mobile:                   numberDesc{"7[02369]\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"20\\d{4,7}", []int{6, 7, 8, 9}},
+		premiumRate:              numberDesc{"649\\d{6}|9(?:00|39|44)[1-8]\\d{3,6}", []int{7, 8, 9, 10}},
+		sharedCost:               numberDesc{"77[0-7]\\d{6}", []int{9}},
+		personalNumber:           numberDesc{"75[1-8]\\d{6}", []int{9}},
+		pager:                    numberDesc{"74[02-9]\\d{6}", []int{9}},
+		voicemail:                numberDesc{"(?:25[245]|67[3-68])\\d{9}", []int{12}},
+	},
+	"SG": {
+		countryCode:         65,
+		internationalPrefix: "0[0-3]\\d",
+		general:             numberDesc{"(?:(?:1\\d|8)\\d\\d|7000)\\d{7}|[3689]\\d{7}", []int{8, 10, 11}},
+		fixedLine:           numberDesc{"662[0-24-9]\\d{4}|6(?:[1-578]\\d|6[013-57-9]|9[0-35-9])\\d{5}", []int{8}},
+		mobile:              numberDesc{"(?:8(?:[1-8]\\d\\d|9(?:[01]\\d|2[4-8]|3[0-4]))|9[0-8]\\d\\d)\\d{4}", []int{8}},
+		tollFree:            numberDesc{"(?:18|8)00\\d{7}", []int{10, 11}},
+		premiumRate:         numberDesc{"1900\\d{7}", []int{11}},
+		voip:                numberDesc{"(?:3[12]\\d\\d|6666)\\d{4}", []int{8}},
+		uan:                 numberDesc{"7000\\d{7}", []int{11}},
+	},
+	"SH": {
+		countryCode:         290,
+		internationalPrefix: "00",
+		leadingDigits:       "[256]",
+		general:             numberDesc{"(?:[256]\\d|8)\\d{3}", []int{4, 5}},
+		fixedLine:           numberDesc{"2(?:[0-57-9]\\d|6[4-9])\\d\\d", []int{}},
+		mobile:              numberDesc{"[56]\\d{4}", []int{5}},
+		voip:                numberDesc{"262\\d\\d", []int{5}},
+	},
+	"SI": {
+		countryCode:              386,
+		internationalPrefix:      "00|10(?:22|66|88|99)",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[1-7]\\d{7}|8\\d{4,7}|90\\d{4,6}", []int{5, 6, 7, 8}},
+		fixedLine:                numberDesc{"(?:[1-357][2-8]|4[24-8])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"65(?:1\\d|55|[67]0)\\d{4}|(?:[37][01]|4[0139]|51|6[489])\\d{6}", []int{8}},
+		tollFree:                 numberDesc{"80\\d{4,6}", []int{6, 7, 8}},
+		premiumRate:              numberDesc{"89[1-3]\\d{2,5}|90\\d{4,6}", []int{}},
+		voip:                     numberDesc{"(?:59\\d\\d|8(?:1(?:[67]\\d|8[01389])|2(?:0\\d|2[0378]|8[0-2489])|3[389]\\d))\\d{4}", []int{8}},
+	},
+	"SJ": {
+		countryCode:         47,
+		internationalPrefix: "00",
+		leadingDigits:       "79",
+		general:             numberDesc{"0\\d{4}|(?:[4589]\\d|79)\\d{6}", []int{5, 8}},
+		fixedLine:           numberDesc{"79\\d{6}", []int{8}},
+		mobile:              numberDesc{"(?:4[015-8]|5[89]|9\\d)\\d{6}", []int{8}},
+		tollFree:            numberDesc{"80[01]\\d{5}", []int{8}},
+		premiumRate:         numberDesc{"82[09]\\d{5}", []int{8}},
+		sharedCost:          numberDesc{"810(?:0[0-6]|[2-8]\\d)\\d{3}", []int{8}},
+		voip:                numberDesc{"85[0-5]\\d{5}", []int{8}},
+		personalNumber:      numberDesc{"880\\d{5}", []int{8}},
+		uan:                 numberDesc{"(?:0[2-9]|81(?:0(?:0[7-9]|1\\d)|5\\d\\d))\\d{3}", []int{}},
+		voicemail:           numberDesc{"81[23]\\d{5}", []int{8}},
+	},
+	"SK": {
+		countryCode:              421,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2-689]\\d{8}|[2-59]\\d{6}|[2-5]\\d{5}", []int{6, 7, 9}},
+		fixedLine:                numberDesc{"(?:2(?:16|[2-9]\\d{3})|[3-5][1-8]\\d{3})\\d{4}|(?:2|[3-5][1-8])1[67]\\d{3}|[3-5][1-8]16\\d\\d", []int{}},
+		mobile:                   numberDesc{"909[1-9]\\d{5}|9(?:0[1-8]|1[0-24-9]|[45]\\d)\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{9}},
+		premiumRate:              numberDesc{"9(?:00|[78]\\d)\\d{6}", []int{9}},
+		sharedCost:               numberDesc{"8[5-9]\\d{7}", []int{9}},
+		voip:                     numberDesc{"6(?:02|5[0-4]|9[0-6])\\d{6}", []int{9}},
+		pager:                    numberDesc{"9090\\d{3}", []int{7}},
+		uan:                      numberDesc{"96\\d{7}", []int{9}},
+	},
+	"SL": {
+		countryCode:              232,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[2378]\\d|99)\\d{6}", []int{8}},
+		fixedLine:                numberDesc{"22\\d{6}", []int{}},
+		mobile:                   numberDesc{"(?:25|3[0134]|7[5-9]|8[08]|99)\\d{6}", []int{}},
+	},
+	"SM": {
+		countryCode:                 378,
+		internationalPrefix:         "00",
+		nationalPrefixForParsing:    "([89]\\d{5})$",
+		nationalPrefixTransformRule: "0549$1",
+		general:                     numberDesc{"(?:0549|[5-7]\\d)\\d{6}", []int{8, 10}},
+		fixedLine:                   numberDesc{"0549(?:8[0157-9]|9\\d)\\d{4}", []int{10}},
+		mobile:                      numberDesc{"6[16]\\d{6}", []int{8}},
+		premiumRate:                 numberDesc{"7[178]\\d{6}", []int{8}},
+		voip:                        numberDesc{"5[158]\\d{6}", []int{8}},
+	},
+	"SN": {
+		countryCode:         221,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[378]\\d{4}|93330)\\d{4}", []int{9}},
+		fixedLine:           numberDesc{"3(?:0(?:1[0-2]|80)|282|3(?:8[1-9]|9[3-9])|611)\\d{5}", []int{}},
+		mobile:              numberDesc{"7(?:[06-8]\\d|21|90)\\d{6}", []int{}},
+		tollFree:            numberDesc{"800\\d{6}", []int{}},
+		premiumRate:         numberDesc{"88[4689]\\d{6}", []int{}},
+		sharedCost:          numberDesc{"81[02468]\\d{6}", []int{}},
+		voip:                numberDesc{"93330\\d{4}|3(?:392|9[01]\\d)\\d{5}", []int{}},
+	},
+	"SO": {
+		countryCode:              252,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[346-9]\\d{8}|[12679]\\d{7}|(?:[1-4]\\d|59)\\d{5}|[1348]\\d{5}", []int{6, 7, 8, 9}},
+		fixedLine:                numberDesc{"(?:1\\d|2[0-79]|3[0-46-8]|4[0-7]|59)\\d{5}|(?:[134]\\d|8[125])\\d{4}", []int{6, 7}},
+		mobile:                   numberDesc{"28\\d{5}|(?:6[1-9]|79)\\d{6,7}|(?:15|24|(?:3[59]|4[89]|8[08])\\d|60|7[1-8]|9(?:0[67]|[2-9]))\\d{6}", []int{7, 8, 9}},
+	},
+	"SR": {
+		countryCode:         597,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[2-5]|68|[78]\\d)\\d{5}", []int{6, 7}},
+		fixedLine:           numberDesc{"(?:2[1-3]|3[0-7]|(?:4|68)\\d|5[2-58])\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:7[124-7]|8[125-9])\\d{5}", []int{7}},
+		voip:                numberDesc{"56\\d{4}", []int{6}},
+	},
+	"SS": {
+		countryCode:              211,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[19]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"18\\d{7}", []int{}},
+		mobile:                   numberDesc{"(?:12|9[1257])\\d{7}", []int{}},
+	},
+	"ST": {
+		countryCode:         239,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:22|9\\d)\\d{5}", []int{7}},
+		fixedLine:           numberDesc{"22\\d{5}", []int{}},
+		mobile:              numberDesc{"900[5-9]\\d{3}|9(?:0[1-9]|[89]\\d)\\d{4}", []int{}},
+	},
+	"SV": {
+		countryCode:         503,
+		internationalPrefix: "00",
+		general:             numberDesc{"[267]\\d{7}|[89]00\\d{4}(?:\\d{4})?", []int{7, 8, 11}},
+		fixedLine:           numberDesc{"2[1-6]\\d{6}", []int{8}},
+		mobile:              numberDesc{"[67]\\d{7}", []int{8}},
+		tollFree:            numberDesc{"800\\d{4}(?:\\d{4})?", []int{7, 11}},
+		premiumRate:         numberDesc{"900\\d{4}(?:\\d{4})?", []int{7, 11}},
+	},
+	"SX": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|(5\\d{6})$",
+		nationalPrefixTransformRule: "721$1",
+		leadingDigits:               "721",
+		general:                     numberDesc{"7215\\d{6}|(?:[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"7215(?:4[2-8]|8[239]|9[056])\\d{4}", []int{}},
+		mobile:                      numberDesc{"7215(?:1[02]|2\\d|5[034679]|8[014-8])\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"SY": {
+		countryCode:              963,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[1-39]\\d{8}|[1-5]\\d{7}", []int{8, 9}},
+		fixedLine:                numberDesc{"[12]1\\d{6,7}|(?:1(?:[2356]|4\\d)|2[235]|3(?:[13]\\d|4)|4[13]|5[1-3])\\d{6}", []int{}},
+		mobile:                   numberDesc{"9(?:22|[3-589]\\d|6[024-9])\\d{6}", []int{9}},
+	},
+	"SZ": {
+		countryCode:         268,
+		internationalPrefix: "00",
+		general:             numberDesc{"0800\\d{4}|(?:[237]\\d|900)\\d{6}", []int{8, 9}},
+		fixedLine:           numberDesc{"[23][2-5]\\d{6}", []int{8}},
+		mobile:              numberDesc{"7[6-9]\\d{6}", []int{8}},
+		tollFree:            numberDesc{"0800\\d{4}", []int{8}},
+		premiumRate:         numberDesc{"900\\d{6}", []int{9}},
+		voip:                numberDesc{"70\\d{6}", []int{8}},
+	},
+	"TA": {
+		countryCode:         290,
+		internationalPrefix: "00",
+		leadingDigits:       "8",
+		general:             numberDesc{"8\\d{3}", []int{4}},
+		fixedLine:           numberDesc{"8\\d{3}", []int{}},
+	},
+	"TC": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-479]\\d{6})$",
+		nationalPrefixTransformRule: "649$1",
+		leadingDigits:               "649",
+		general:                     numberDesc{"(?:[58]\\d\\d|649|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"649(?:712|9(?:4\\d|50))\\d{4}", []int{}},
+		mobile:                      numberDesc{"649(?:2(?:3[129]|4[1-7])|3(?:3[1-389]|4[1-8])|4[34][1-3])\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		voip:                        numberDesc{"64971[01]\\d{4}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"TD": {
+		countryCode:         235,
+		internationalPrefix: "00|16",
+		general:             numberDesc{"(?:22|[69]\\d|77)\\d{6}", []int{8}},
+		fixedLine:           numberDesc{"22(?:[37-9]0|5[0-5]|6[89])\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:6[023568]|77|9\\d)\\d{6}", []int{}},
+	},
+	"TG": {
+		countryCode:         228,
+		internationalPrefix: "00",
+		general:             numberDesc{"[279]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"2(?:2[2-7]|3[23]|4[45]|55|6[67]|77)\\d{5}", []int{}},
+		mobile:              numberDesc{"(?:7[09]|9[0-36-9])\\d{6}", []int{}},
+	},
+	"TH": {
+		countryCode:              66,
+		internationalPrefix:      "00[1-9]",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"1\\d{8,9}|(?:[2-57]|[689]\\d)\\d{7}", []int{8, 9, 10}},
+		fixedLine:                numberDesc{"(?:2\\d|3[2-9]|4[2-5]|5[2-6]|7[3-7])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"(?:14|6[1-6]|[89]\\d)\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"1800\\d{6}", []int{10}},
+		premiumRate:              numberDesc{"1900\\d{6}", []int{10}},
+		voip:                     numberDesc{"6[08]\\d{7}", []int{9}},
+	},
+	"TJ": {
+		countryCode:              992,
+		internationalPrefix:      "810",
+		nationalPrefixForParsing: "8",
+		general:                  numberDesc{"(?:00|[3-59]\\d|77|88)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"(?:3(?:1[3-5]|2[245]|3[12]|4[24-7]|5[25]|72)|4(?:46|74|87))\\d{6}", []int{}},
+		mobile:                   numberDesc{"41[18]\\d{6}|(?:00|5[05]|77|88|9\\d)\\d{7}", []int{}},
+	},
+	"TK": {
+		countryCode:         690,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-47]\\d{3,6}", []int{4, 5, 6, 7}},
+		fixedLine:           numberDesc{"(?:2[2-4]|[34]\\d)\\d{2,5}", []int{}},
+		mobile:              numberDesc{"7[2-4]\\d{2,5}", []int{}},
+	},
+	"TL": {
+		countryCode:         670,
+		internationalPrefix: "00",
+		general:             numberDesc{"7\\d{7}|(?:[2-47]\\d|[89]0)\\d{5}", []int{7, 8}},
+		fixedLine:           numberDesc{"(?:2[1-5]|3[1-9]|4[1-4])\\d{5}", []int{7}},
+		mobile:              numberDesc{"7[3-8]\\d{6}", []int{8}},
+		tollFree:            numberDesc{"80\\d{5}", []int{7}},
+		premiumRate:         numberDesc{"90\\d{5}", []int{7}},
+		personalNumber:      numberDesc{"70\\d{5}", []int{7}},
+	},
+	"TM": {
+		countryCode:              993,
+		internationalPrefix:      "810",
+		nationalPrefixForParsing: "8",
+		general:                  numberDesc{"[1-6]\\d{7}", []int{8}},
+		fixedLine:                numberDesc{"(?:1(?:2\\d|3[1-9])|2(?:22|4[0-35-8])|3(?:22|4[03-9])|4(?:22|3[128]|4\\d|6[15])|5(?:22|5[7-9]|6[014-689]))\\d{5}", []int{}},
+		mobile:                   numberDesc{"6[1-9]\\d{6}", []int{}},
+	},
+	"TN": {
+		countryCode:         216,
+		internationalPrefix: "00",
+		general:             numberDesc{"[2-57-9]\\d{7}", []int{8}},
+		fixedLine:           numberDesc{"81200\\d{3}|(?:3[0-2]|7\\d)\\d{6}", []int{}},
+		mobile:              numberDesc{"3(?:001|[12]40)\\d{4}|(?:(?:[259]\\d|4[0-6])\\d|3(?:1[1-35]|6[0-4]|91))\\d{5}", []int{}},
+		tollFree:            numberDesc{"8010\\d{4}", []int{}},
+		premiumRate:         numberDesc{"88\\d{6}", []int{}},
+		sharedCost:          numberDesc{"8[12]10\\d{4}", []int{}},
+	},
+	"TO": {
+		countryCode:         676,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:0800|[5-8]\\d{3})\\d{3}|[2-8]\\d{4}", []int{5, 7}},
+		fixedLine:           numberDesc{"(?:2\\d|3[0-8]|4[0-4]|50|6[09]|7[0-24-69]|8[05])\\d{3}", []int{5}},
+		mobile:              numberDesc{"(?:6(?:3[02]|85|90)|7(?:[2-46]0|[578]\\d)|8[46-9]\\d)\\d{4}", []int{7}},
+		tollFree:            numberDesc{"0800\\d{3}", []int{7}},
+		premiumRate:         numberDesc{"55[04]\\d{4}", []int{7}},
+	},
+	"TR": {
+		countryCode:              90,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[2-58]\\d\\d|900)\\d{7}|4\\d{6}", []int{7, 10}},
+		fixedLine:                numberDesc{"(?:2(?:[13][26]|[28][2468]|[45][268]|[67][246])|3(?:[13][28]|[24-6][2468]|[78][02468]|92)|4(?:[16][246]|[23578][2468]|4[26]))\\d{7}", []int{10}},
+		mobile:                   numberDesc{"56161\\d{5}|5(?:0[15-7]|1[06]|24|[34]\\d|5[1-59]|9[46])\\d{7}", []int{10}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{10}},
+		premiumRate:              numberDesc{"(?:8[89]8|900)\\d{7}", []int{10}},
+		personalNumber:           numberDesc{"592(?:21[12]|461)\\d{4}", []int{10}},
+		pager:                    numberDesc{"512\\d{7}", []int{10}},
+		uan:                      numberDesc{"(?:444|850\\d{3})\\d{4}", []int{}},
+	},
+	"TT": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-46-8]\\d{6})$",
+		nationalPrefixTransformRule: "868$1",
+		leadingDigits:               "868",
+		general:                     numberDesc{"(?:[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"868(?:2(?:01|1[89]|[23]\\d|4[0-2])|6(?:0[7-9]|1[02-8]|2[1-9]|[3-69]\\d|7[0-79])|82[124])\\d{4}", []int{}},
+		mobile:                      numberDesc{"868(?:2(?:6[6-9]|[7-9]\\d)|[37](?:0[1-9]|1[02-9]|[2-9]\\d)|4[6-9]\\d|6(?:20|78|8\\d))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+		voicemail:                   numberDesc{"868619\\d{4}", []int{}},
+	},
+	"TV": {
+		countryCode:         688,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:2|7\\d\\d|90)\\d{4}", []int{5, 6, 7}},
+		fixedLine:           numberDesc{"2[02-9]\\d{3}", []int{5}},
+		mobile:              numberDesc{"(?:7[01]\\d|90)\\d{4}", []int{6, 7}},
+	},
+	"TW": {
+		countryCode:              886,
+		internationalPrefix:      "0(?:0[25-79]|19)",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[2-689]\\d{8}|7\\d{9,10}|[2-8]\\d{7}|2\\d{6}", []int{7, 8, 9, 10, 11}},
+		fixedLine:                numberDesc{"(?:2[2-8]\\d|370|55[01]|7[1-9])\\d{6}|4(?:(?:0(?:0[1-9]|[2-48]\\d)|1[023]\\d)\\d{4,5}|(?:[239]\\d\\d|4(?:0[56]|12|49))\\d{5})|6(?:[01]\\d{7}|4(?:0[56]|12|24|4[09])\\d{4,5})|8(?:(?:2(?:3\\d|4[0-269]|[578]0|66)|36[24-9]|90\\d\\d)\\d{4}|4(?:0[56]|12|24|4[09])\\d{4,5})|(?:2(?:2(?:0\\d\\d|4(?:0[68]|[249]0|3[0-467]|5[0-25-9]|6[0235689]))|(?:3(?:[09]\\d|1[0-4])|(?:4\\d|5[0-49]|6[0-29]|7[0-5])\\d)\\d)|(?:(?:3[2-9]|5[2-8]|6[0-35-79]|8[7-9])\\d\\d|4(?:2(?:[089]\\d|7[1-9])|(?:3[0-4]|[78]\\d|9[01])\\d))\\d)\\d{3}", []int{8, 9}},
+		mobile:                   numberDesc{"(?:40001[0-2]|9[0-8]\\d{4})\\d{3}", []int{9}},
+		tollFree:                 numberDesc{"80[0-79]\\d{6}|800\\d{5}", []int{8, 9}},
+		premiumRate:              numberDesc{"20(?:[013-9]\\d\\d|2)\\d{4}", []int{7, 9}},
+		voip:                     numberDesc{"7010(?:[0-2679]\\d|3[0-7]|8[0-5])\\d{5}|70\\d{8}", []int{10, 11}},
+		personalNumber:           numberDesc{"99\\d{7}", []int{9}},
+		uan:                      numberDesc{"50[0-46-9]\\d{6}", []int{9}},
+	},
+	"TZ": {
+		countryCode:              255,
+		internationalPrefix:      "00[056]",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[26-8]\\d|41|90)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"2[2-8]\\d{7}", []int{}},
+		mobile:                   numberDesc{"(?:6[2-9]|7[13-9])\\d{7}", []int{}},
+		tollFree:                 numberDesc{"80[08]\\d{6}", []int{}},
+		premiumRate:              numberDesc{"90\\d{7}", []int{}},
+		sharedCost:               numberDesc{"8(?:40|6[01])\\d{6}", []int{}},
+		voip:                     numberDesc{"41\\d{7}", []int{}},
+	},
+	"UA": {
+		countryCode:              380,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[89]\\d{9}|[3-9]\\d{8}", []int{9, 10}},
+		fixedLine:                numberDesc{"(?:3[1-8]|4[13-8]|5[1-7]|6[12459])\\d{7}", []int{9}},
+		mobile:                   numberDesc{"(?:50|6[36-8]|7[1-3]|9[1-9])\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"800[1-8]\\d{5,6}", []int{}},
+		premiumRate:              numberDesc{"900[239]\\d{5,6}", []int{}},
+		voip:                     numberDesc{"89[1-579]\\d{6}", []int{9}},
+	},
+	"UG": {
+		countryCode:              256,
+		internationalPrefix:      "00[057]",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"800\\d{6}|(?:[29]0|[347]\\d)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"(?:20(?:(?:(?:[0147]\\d|5[0-4])\\d|2(?:40|[5-9]\\d)|3(?:0[67]|2[0-4])|810)\\d|6(?:00[0-2]|[15-9]\\d\\d|30[0-4]))|[34]\\d{5})\\d{3}", []int{}},
+		mobile:                   numberDesc{"7260\\d{5}|7(?:[0157-9]\\d|20|4[0-4])\\d{6}", []int{}},
+		tollFree:                 numberDesc{"800[1-3]\\d{5}", []int{}},
+		premiumRate:              numberDesc{"90[1-3]\\d{6}", []int{}},
+	},
+	"US": {
+		countryCode:                   1,
+		internationalPrefix:           "011",
+		nationalPrefixForParsing:      "1",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"[2-9]\\d{9}", []int{10}},
+		fixedLine:                     numberDesc{"(?:2(?:0[1-35-9]|1[02-9]|2[03-589]|3[149]|4[08]|5[1-46]|6[0279]|7[0269]|8[13])|3(?:0[1-57-9]|1[02-9]|2[0135]|3[0-24679]|4[167]|5[12]|6[014]|8[056])|4(?:0[124-9]|1[02-579]|2[3-5]|3[0245]|4[0235]|58|6[39]|7[0589]|8[04])|5(?:0[1-57-9]|1[0235-8]|20|3[0149]|4[01]|5[19]|6[1-47]|7[013-5]|8[056])|6(?:0[1-35-9]|1[024-9]|2[03689]|[34][016]|5[017]|6[0-279]|78|8[0-29])|7(?:0[1-46-8]|1[2-9]|2[04-7]|3[1247]|4[037]|5[47]|6[02359]|7[02-59]|8[156])|8(?:0[1-68]|1[02-8]|2[08]|3[0-28]|4[3578]|5[046-9]|6[02-5]|7[028])|9(?:0[1346-9]|1[02-9]|2[0589]|3[0146-8]|4[0179]|5[12469]|7[0-389]|8[04-69]))[2-9]\\d{6}", []int{}},
+		mobile:                        numberDesc{"(?:2(?:0[1-35-9]|1[02-9]|2[03-589]|3[149]|4[08]|5[1-46]|6[0279]|7[0269]|8[13])|3(?:0[1-57-9]|1[02-9]|2[0135]|3[0-24679]|4[167]|5[12]|6[014]|8[056])|4(?:0[124-9]|1[02-579]|2[3-5]|3[0245]|4[0235]|58|6[39]|7[0589]|8[04])|5(?:0[1-57-9]|1[0235-8]|20|3[0149]|4[01]|5[19]|6[1-47]|7[013-5]|8[056])|6(?:0[1-35-9]|1[024-9]|2[03689]|[34][016]|5[017]|6[0-279]|78|8[0-29])|7(?:0[1-46-8]|1[2-9]|2[04-7]|3[1247]|4[037]|5[47]|6[02359]|7[02-59]|8[156])|8(?:0[1-68]|1[02-8]|2[08]|3[0-28]|4[3578]|5[046-9]|6[02-5]|7[028])|9(?:0[1346-9]|1[02-9]|2[0589]|3[0146-8]|4[0179]|5[12469]|7[0-389]|8[04-69]))[2-9]\\d{6}", []int{}},
+		tollFree:                      numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:                numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+		uan:                           numberDesc{"710[2-9]\\d{6}", []int{}},
+	},
+	"UY": {
+		countryCode:              598,
+		internationalPrefix:      "0(?:0|1[3-9]\\d)",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:[249]\\d\\d|80)\\d{5}|9\\d{6}", []int{7, 8}},
+		fixedLine:                numberDesc{"(?:2\\d|4[2-7])\\d{6}", []int{8}},
+		mobile:                   numberDesc{"9[1-9]\\d{6}", []int{8}},
+		tollFree:                 numberDesc{"80[05]\\d{4}", []int{7}},
+		premiumRate:              numberDesc{"90[0-8]\\d{4}", []int{7}},
+	},
+	"UZ": {
+		countryCode:              998,
+		internationalPrefix:      "810",
+		nationalPrefixForParsing: "8",
+		general:                  numberDesc{"[679]\\d{8}", []int{9}},
+		fixedLine:                numberDesc{"78(?:1(?:13|2[02]|50)|2(?:10|2[139]|98)|77[01])\\d{4}|(?:6(?:1(?:22|3[124]|4[1-4]|5[1-3578]|64)|2(?:22|3[0-57-9]|41)|5(?:22|3[3-7]|5[024-8])|6\\d\\d|7(?:[23]\\d|7[69])|9(?:22|4[1-8]|6[135]))|7(?:0(?:5[4-9]|6[0146]|7[124-6]|9[135-8])|1[12]\\d|2(?:22|3[13-57-9]|4[1-3579]|5[14])|3(?:2\\d|3[1578]|4[1-35-7]|5[1-57]|61)|4(?:2\\d|3[1-579]|7[1-79])|5(?:22|5[1-9]|6[1457])|6(?:22|3[12457]|4[13-8])|9(?:22|5[1-9])))\\d{5}", []int{}},
+		mobile:                   numberDesc{"(?:6(?:1(?:2(?:2[01]|98)|35[0-4]|50\\d|61[23]|7(?:[01][017]|4\\d|55|9[5-9]))|2(?:(?:11|7\\d)\\d|2(?:[12]1|9[01379])|5(?:[126]\\d|3[0-4]))|5(?:19[01]|2(?:27|9[26])|(?:30|59|7\\d)\\d)|6(?:2(?:1[5-9]|2[0367]|38|41|52|60)|(?:3[79]|9[0-3])\\d|4(?:56|83)|7(?:[07]\\d|1[017]|3[07]|4[047]|5[057]|67|8[0178]|9[79]))|7(?:2(?:24|3[237]|4[5-9]|7[15-8])|5(?:7[12]|8[0589])|7(?:0\\d|[39][07])|9(?:0\\d|7[079]))|9(?:2(?:1[1267]|3[01]|5\\d|7[0-4])|(?:5[67]|7\\d)\\d|6(?:2[0-26]|8\\d)))|7(?:0\\d{3}|1(?:13[01]|6(?:0[47]|1[67]|66)|71[3-69]|98\\d)|2(?:2(?:2[79]|95)|3(?:2[5-9]|6[0-6])|57\\d|7(?:0\\d|1[17]|2[27]|3[37]|44|5[057]|66|88))|3(?:2(?:1[0-6]|21|3[469]|7[159])|(?:33|9[4-6])\\d|5(?:0[0-4]|5[579]|9\\d)|7(?:[0-3579]\\d|4[0467]|6[67]|8[078]))|4(?:2(?:29|5[0257]|6[0-7]|7[1-57])|5(?:1[0-4]|8\\d|9[5-9])|7(?:0\\d|1[024589]|2[0-27]|3[0137]|[46][07]|5[01]|7[5-9]|9[079])|9(?:7[015-9]|[89]\\d))|5(?:112|2(?:0\\d|2[29]|[49]4)|3[1568]\\d|52[6-9]|7(?:0[01578]|1[017]|[23]7|4[047]|[5-7]\\d|8[78]|9[079]))|6(?:2(?:2[1245]|4[2-4])|39\\d|41[179]|5(?:[349]\\d|5[0-2])|7(?:0[017]|[13]\\d|22|44|55|67|88))|9(?:22[128]|3(?:2[0-4]|7\\d)|57[02569]|7(?:2[05-9]|3[37]|4\\d|60|7[2579]|87|9[07])))|9[0-57-9]\\d{3})\\d{4}", []int{}},
+	},
+	"VA": {
+		countryCode:         39,
+		internationalPrefix: "00",
+		leadingDigits:       "06698",
+		general:             numberDesc{"0\\d{5,10}|3[0-8]\\d{7,10}|55\\d{8}|8\\d{5}(?:\\d{2,4})?|(?:1\\d|39)\\d{7,8}", []int{6, 7, 8, 9, 10, 11, 12}},
+		fixedLine:           numberDesc{"06698\\d{1,6}", []int{6, 7, 8, 9, 10, 11}},
+		mobile:              numberDesc{"3[1-9]\\d{8}|3[2-9]\\d{7}", []int{9, 10}},
+		tollFree:            numberDesc{"80(?:0\\d{3}|3)\\d{3}", []int{6, 9}},
+		premiumRate:         numberDesc{"(?:0878\\d\\d|89(?:2|4[5-9]\\d))\\d{3}|89[45][0-4]\\d\\d|(?:1(?:44|6[346])|89(?:5[5-9]|9))\\d{6}", []int{6, 8, 9, 10}},
+		sharedCost:          numberDesc{"84(?:[08]\\d{3}|[17])\\d{3}", []int{6, 9}},
+		voip:                numberDesc{"55\\d{8}", []int{10}},
+		personalNumber:      numberDesc{"1(?:78\\d|99)\\d{6}", []int{9, 10}},
+		voicemail:           numberDesc{"3[2-8]\\d{9,10}", []int{11, 12}},
+	},
+	"VC": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-7]\\d{6})$",
+		nationalPrefixTransformRule: "784$1",
+		leadingDigits:               "784",
+		general:                     numberDesc{"(?:[58]\\d\\d|784|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"784(?:266|3(?:6[6-9]|7\\d|8[0-24-6])|4(?:38|5[0-36-8]|8[0-8])|5(?:55|7[0-2]|93)|638|784)\\d{4}", []int{}},
+		mobile:                      numberDesc{"784(?:4(?:3[0-5]|5[45]|89|9[0-8])|5(?:2[6-9]|3[0-4]))\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"VE": {
+		countryCode:              58,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[89]00\\d{7}|(?:[24]\\d|50)\\d{8}", []int{10}},
+		fixedLine:                numberDesc{"(?:2(?:12|3[457-9]|[467]\\d|[58][1-9]|9[1-6])|50[01])\\d{7}", []int{}},
+		mobile:                   numberDesc{"4(?:1[24-8]|2[46])\\d{7}", []int{}},
+		tollFree:                 numberDesc{"800\\d{7}", []int{}},
+		premiumRate:              numberDesc{"900\\d{7}", []int{}},
+	},
+	"VG": {
+		countryCode:                 1,
+		internationalPrefix:         "011",
+		nationalPrefixForParsing:    "1|([2-578]\\d{6})$",
+		nationalPrefixTransformRule: "284$1",
+		leadingDigits:               "284",
+		general:                     numberDesc{"(?:284|[58]\\d\\d|900)\\d{7}", []int{10}},
+		fixedLine:                   numberDesc{"284496[0-5]\\d{3}|284(?:229|4(?:22|9[45])|774|8(?:52|6[459]))\\d{4}", []int{}},
+		mobile:                      numberDesc{"284496[6-9]\\d{3}|284(?:3(?:0[0-3]|4[0-7]|68|9[34])|4(?:4[0-6]|68|99)|54[0-57])\\d{4}", []int{}},
+		tollFree:                    numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                 numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:              numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"VI": {
+		countryCode:                   1,
+		internationalPrefix:           "011",
+		nationalPrefixForParsing:      "1|([2-9]\\d{6})$",
+		nationalPrefixTransformRule:   "340$1",
+		leadingDigits:                 "340",
+		sameMobileAndFixedLinePattern: true,
+		general:                       numberDesc{"[58]\\d{9}|(?:34|90)0\\d{7}", []int{10}},
+		fixedLine:                     numberDesc{"340(?:2(?:0[12]|2[06-8]|4[49]|77)|3(?:32|44)|4(?:22|7[34]|89)|5(?:1[34]|55)|6(?:2[56]|4[23]|77|9[023])|7(?:1[2-57-9]|27|7\\d)|884|998)\\d{4}", []int{}},
+		mobile:                        numberDesc{"340(?:2(?:0[12]|2[06-8]|4[49]|77)|3(?:32|44)|4(?:22|7[34]|89)|5(?:1[34]|55)|6(?:2[56]|4[23]|77|9[023])|7(?:1[2-57-9]|27|7\\d)|884|998)\\d{4}", []int{}},
+		tollFree:                      numberDesc{"8(?:00|33|44|55|66|77|88)[2-9]\\d{6}", []int{}},
+		premiumRate:                   numberDesc{"900[2-9]\\d{6}", []int{}},
+		personalNumber:                numberDesc{"5(?:00|2[12]|33|44|66|77|88)[2-9]\\d{6}", []int{}},
+	},
+	"VN": {
+		countryCode:              84,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[12]\\d{9}|[135-9]\\d{8}|[16]\\d{7}|[16-8]\\d{6}", []int{7, 8, 9, 10}},
+		fixedLine:                numberDesc{"2(?:0[3-9]|1[0-689]|2[0-25-9]|3[2-9]|4[2-8]|5[124-9]|6[0-39]|7[0-7]|8[2-79]|9[0-4679])\\d{7}", []int{10}},
+		mobile:                   numberDesc{"(?:52[238]|8(?:79|9[689])|99[013-9])\\d{6}|(?:3\\d|5[689]|7[06-9]|8[1-68]|9[0-8])\\d{7}", []int{9}},
+		tollFree:                 numberDesc{"1800\\d{4,6}|12(?:03|28)\\d{4}", []int{8, 9, 10}},
+		premiumRate:              numberDesc{"1900\\d{4,6}", []int{8, 9, 10}},
+		voip:                     numberDesc{"672\\d{6}", []int{9}},
+		uan:                      numberDesc{"(?:[17]99|80\\d)\\d{4}|69\\d{5,6}", []int{7, 8}},
+	},
+	"VU": {
+		countryCode:         678,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[23]\\d|[48]8)\\d{3}|(?:[57]\\d|90)\\d{5}", []int{5, 7}},
+		fixedLine:           numberDesc{"(?:38[0-8]|48[4-9])\\d\\d|(?:2[02-9]|3[4-7]|88)\\d{3}", []int{5}},
+		mobile:              numberDesc{"57[2-5]\\d{4}|(?:5[0-689]|7[013-7])\\d{5}", []int{7}},
+		voip:                numberDesc{"90[1-9]\\d{4}", []int{7}},
+		uan:                 numberDesc{"(?:3[03]|900\\d)\\d{3}", []int{}},
+	},
+	"WF": {
+		countryCode:         681,
+		internationalPrefix: "00",
+		general:             numberDesc{"(?:[45]0|68|72|8\\d)\\d{4}", []int{6}},
+		fixedLine:           numberDesc{"(?:50|68|72)\\d{4}", []int{}},
+		mobile:              numberDesc{"(?:50|68|72|8[23])\\d{4}", []int{}},
+		voicemail:           numberDesc{"[48]0\\d{4}", []int{}},
+	},
+	"WS": {
+		countryCode:         685,
+		internationalPrefix: "0",
+		general:             numberDesc{"[2-6]\\d{4}|8\\d{5}(?:\\d{4})?|[78]\\d{6}", []int{5, 6, 7, 10}},
+		fixedLine:           numberDesc{"(?:[2-5]\\d|6[1-9])\\d{3}", []int{5}},
+		mobile:              numberDesc{"(?:7[25-7]|8(?:[3-7]|9\\d{3}))\\d{5}", []int{7, 10}},
+		tollFree:            numberDesc{"800\\d{3}", []int{6}},
+	},
+	"XK": {
+		countryCode:              383,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[23]\\d{7,8}|(?:4\\d\\d|[89]00)\\d{5}", []int{8, 9}},
+		fixedLine:                numberDesc{"(?:2[89]|39)0\\d{6}|[23][89]\\d{6}", []int{}},
+		mobile:                   numberDesc{"4[3-9]\\d{6}", []int{8}},
+		tollFree:                 numberDesc{"800\\d{5}", []int{8}},
+		premiumRate:              numberDesc{"900\\d{5}", []int{8}},
+	},
+	"YE": {
+		countryCode:              967,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:1|7\\d)\\d{7}|[1-7]\\d{6}", []int{7, 8, 9}},
+		fixedLine:                numberDesc{"17\\d{6}|(?:[12][2-68]|3[2358]|4[2-58]|5[2-6]|6[3-58]|7[24-68])\\d{5}", []int{7, 8}},
+		mobile:                   numberDesc{"7[0137]\\d{7}", []int{9}},
+	},
+	"YT": {
+		countryCode:              262,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		leadingDigits:            "269|63",
+		general:                  numberDesc{"80\\d{7}|(?:26|63)9\\d{6}", []int{9}},
+		fixedLine:                numberDesc{"269(?:0[67]|5[0-2]|6\\d|[78]0)\\d{4}", []int{}},
+		mobile:                   numberDesc{"639(?:0[0-79]|1[019]|[267]\\d|3[09]|[45]0|9[04-79])\\d{4}", []int{}},
+		tollFree:                 numberDesc{"80\\d{7}", []int{}},
+	},
+	"ZA": {
+		countryCode:              27,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"[1-9]\\d{8}|8\\d{4,7}", []int{5, 6, 7, 8, 9}},
+		fixedLine:                numberDesc{"(?:1[0-8]|2[1-378]|3[1-69]|4\\d|5[1346-8])\\d{7}", []int{9}},
+		mobile:                   numberDesc{"(?:1(?:3492[0-25]|4495[0235]|549(?:20|5[01]))|4[34]492[01])\\d{3}|8[1-4]\\d{3,7}|(?:2[27]|47|54)4950\\d{3}|(?:1(?:049[2-4]|9[12]\\d\\d)|(?:6\\d|7[0-46-9])\\d{3}|8(?:5\\d{3}|7(?:08[67]|158|28[5-9]|310)))\\d{4}|(?:1[6-8]|28|3[2-69]|4[025689]|5[36-8])4920\\d{3}|(?:12|[2-5]1)492\\d{4}", []int{}},
+		tollFree:                 numberDesc{"80\\d{7}", []int{9}},
+		premiumRate:              numberDesc{"(?:86[2-9]|9[0-2]\\d)\\d{6}", []int{9}},
+		sharedCost:               numberDesc{"860\\d{6}", []int{9}},
+		voip:                     numberDesc{"87(?:08[0-589]|15[0-79]|28[0-4]|31[1-9])\\d{4}|87(?:[02][0-79]|1[0-46-9]|3[02-9]|[4-9]\\d)\\d{5}", []int{9}},
+		uan:                      numberDesc{"861\\d{6}", []int{9}},
+	},
+	"ZM": {
+		countryCode:              260,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"(?:63|80)0\\d{6}|(?:21|[79]\\d)\\d{7}", []int{9}},
+		fixedLine:                numberDesc{"21[1-8]\\d{6}", []int{}},
+		mobile:                   numberDesc{"(?:7[67]|9[5-8])\\d{7}", []int{}},
+		tollFree:                 numberDesc{"800\\d{6}", []int{}},
+		voip:                     numberDesc{"630\\d{6}", []int{}},
+	},
+	"ZW": {
+		countryCode:              263,
+		internationalPrefix:      "00",
+		nationalPrefixForParsing: "0",
+		general:                  numberDesc{"2(?:[0-57-9]\\d{6,8}|6[0-24-9]\\d{6,7})|[38]\\d{9}|[35-8]\\d{8}|[3-6]\\d{7}|[1-689]\\d{6}|[1-3569]\\d{5}|[1356]\\d{4}", []int{5, 6, 7, 8, 9, 10}},
+		fixedLine:                numberDesc{"(?:1(?:(?:3\\d|9)\\d|[4-8])|2(?:(?:(?:0(?:2[014]|5)|(?:2[0157]|31|84|9)\\d\\d|[56](?:[14]\\d\\d|20)|7(?:[089]|2[03]|[35]\\d\\d))\\d|4(?:2\\d\\d|8))\\d|1(?:2|[39]\\d{4}))|3(?:(?:123|(?:29\\d|92)\\d)\\d\\d|7(?:[19]|[56]\\d))|5(?:0|1[2-478]|26|[37]2|4(?:2\\d{3}|83)|5(?:25\\d\\d|[78])|[689]\\d)|6(?:(?:[16-8]21|28|52[013])\\d\\d|[39])|8(?:[1349]28|523)\\d\\d)\\d{3}|(?:4\\d\\d|9[2-9])\\d{4,5}|(?:(?:2(?:(?:(?:0|8[146])\\d|7[1-7])\\d|2(?:[278]\\d|92)|58(?:2\\d|3))|3(?:[26]|9\\d{3})|5(?:4\\d|5)\\d\\d)\\d|6(?:(?:(?:[0-246]|[78]\\d)\\d|37)\\d|5[2-8]))\\d\\d|(?:2(?:[569]\\d|8[2-57-9])|3(?:[013-59]\\d|8[37])|6[89]8)\\d{3}", []int{}},
+		mobile:                   numberDesc{"7(?:[17]\\d|[38][1-9])\\d{6}", []int{9}},
+		tollFree:                 numberDesc{"80(?:[01]\\d|20|8[0-8])\\d{3}", []int{7}},
+		voip:                     numberDesc{"86(?:1[12]|22|30|44|55|77|8[368])\\d{6}", []int{10}},
+	},
+}
+
+var countryCodeRegions = map[int][]string{
+	1:   {"US", "AG", "AI", "AS", "BB", "BM", "BS", "CA", "DM", "DO", "GD", "GU", "JM", "KN", "KY", "LC", "MP", "MS", "PR", "SX", "TC", "TT", "VC", "VG", "VI"},
+	7:   {"RU", "KZ"},
+	20:  {"EG"},
+	27:  {"ZA"},
+	30:  {"GR"},
+	31:  {"NL"},
+	32:  {"BE"},
+	33:  {"FR"},
+	34:  {"ES"},
+	36:  {"HU"},
+	39:  {"IT", "VA"},
+	40:  {"RO"},
+	41:  {"CH"},
+	43:  {"AT"},
+	44:  {"GB", "GG", "IM", "JE"},
+	45:  {"DK"},
+	46:  {"SE"},
+	47:  {"NO", "SJ"},
+	48:  {"PL"},
+	49:  {"DE"},
+	51:  {"PE"},
+	52:  {"MX"},
+	53:  {"CU"},
+	54:  {"AR"},
+	55:  {"BR"},
+	56:  {"CL"},
+	57:  {"CO"},
+	58:  {"VE"},
+	60:  {"MY"},
+	61:  {"AU", "CC", "CX"},
+	62:  {"ID"},
+	63:  {"PH"},
+	64:  {"NZ"},
+	65:  {"SG"},
+	66:  {"TH"},
+	81:  {"JP"},
+	82:  {"KR"},
+	84:  {"VN"},
+	86:  {"CN"},
+	90:  {"TR"},
+	91:  {"IN"},
+	92:  {"PK"},
+	93:  {"AF"},
+	94:  {"LK"},
+	95:  {"MM"},
+	98:  {"IR"},
+	211: {"SS"},
+	212: {"MA", "EH"},
+	213: {"DZ"},
+	216: {"TN"},
+	218: {"LY"},
+	220: {"GM"},
+	221: {"SN"},
+	222: {"MR"},
+	223: {"ML"},
+	224: {"GN"},
+	225: {"CI"},
+	226: {"BF"},
+	227: {"NE"},
+	228: {"TG"},
+	229: {"BJ"},
+	230: {"MU"},
+	231: {"LR"},
+	232: {"SL"},
+	233: {"GH"},
+	234: {"NG"},
+	235: {"TD"},
+	236: {"CF"},
+	237: {"CM"},
+	238: {"CV"},
+	239: {"ST"},
+	240: {"GQ"},
+	241: {"GA"},
+	242: {"CG"},
+	243: {"CD"},
+	244: {"AO"},
+	245: {"GW"},
+	246: {"IO"},
+	247: {"AC"},
+	248: {"SC"},
+	249: {"SD"},
+	250: {"RW"},
+	251: {"ET"},
+	252: {"SO"},
+	253: {"DJ"},
+	254: {"KE"},
+	255: {"TZ"},
+	256: {"UG"},
+	257: {"BI"},
+	258: {"MZ"},
+	260: {"ZM"},
+	261: {"MG"},
+	262: {"RE", "YT"},
+	263: {"ZW"},
+	264: {"NA"},
+	265: {"MW"},
+	266: {"LS"},
+	267: {"BW"},
+	268: {"SZ"},
+	269: {"KM"},
+	290: {"SH", "TA"},
+	291: {"ER"},
+	297: {"AW"},
+	298: {"FO"},
+	299: {"GL"},
+	350: {"GI"},
+	351: {"PT"},
+	352: {"LU"},
+	353: {"IE"},
+	354: {"IS"},
+	355: {"AL"},
+	356: {"MT"},
+	357: {"CY"},
+	358: {"FI", "AX"},
+	359: {"BG"},
+	370: {"LT"},
+	371: {"LV"},
+	372: {"EE"},
+	373: {"MD"},
+	374: {"AM"},
+	375: {"BY"},
+	376: {"AD"},
+	377: {"MC"},
+	378: {"SM"},
+	379: {"VA"},
+	380: {"UA"},
+	381: {"RS"},
+	382: {"ME"},
+	383: {"XK"},
+	385: {"HR"},
+	386: {"SI"},
+	387: {"BA"},
+	389: {"MK"},
+	420: {"CZ"},
+	421: {"SK"},
+	423: {"LI"},
+	500: {"FK"},
+	501: {"BZ"},
+	502: {"GT"},
+	503: {"SV"},
+	504: {"HN"},
+	505: {"NI"},
+	506: {"CR"},
+	507: {"PA"},
+	508: {"PM"},
+	509: {"HT"},
+	590: {"GP", "BL", "MF"},
+	591: {"BO"},
+	592: {"GY"},
+	593: {"EC"},
+	594: {"GF"},
+	595: {"PY"},
+	596: {"MQ"},
+	597: {"SR"},
+	598: {"UY"},
+	599: {"CW", "BQ"},
+	670: {"TL"},
+	672: {"NF"},
+	673: {"BN"},
+	674: {"NR"},
+	675: {"PG"},
+	676: {"TO"},
+	677: {"SB"},
+	678: {"VU"},
+	679: {"FJ"},
+	680: {"PW"},
+	681: {"WF"},
+	682: {"CK"},
+	683: {"NU"},
+	685: {"WS"},
+	686: {"KI"},
+	687: {"NC"},
+	688: {"TV"},
+	689: {"PF"},
+	690: {"TK"},
+	691: {"FM"},
+	692: {"MH"},
+	850: {"KP"},
+	852: {"HK"},
+	853: {"MO"},
+	855: {"KH"},
+	856: {"LA"},
+	880: {"BD"},
+	886: {"TW"},
+	960: {"MV"},
+	961: {"LB"},
+	962: {"JO"},
+	963: {"SY"},
+	964: {"IQ"},
+	965: {"KW"},
+	966: {"SA"},
+	967: {"YE"},
+	968: {"OM"},
+	970: {"PS"},
+	971: {"AE"},
+	972: {"IL"},
+	973: {"BH"},
+	974: {"QA"},
+	975: {"BT"},
+	976: {"MN"},
+	977: {"NP"},
+	992: {"TJ"},
+	993: {"TM"},
+	994: {"AZ"},
+	995: {"GE"},
+	996: {"KG"},
+	998: {"UZ"},
+}
@@ -0,0 +1,354 @@
+package phonenumbers
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//Type is the kind of line a phone number belongs to
+type Type string
+
+//Types of phone numbers
+const (
+	Unknown           Type = "unknown"
+	FixedLine         Type = "fixedline"
+	Mobile            Type = "mobile"
+	FixedLineOrMobile Type = "fixedlineormobile"
+	TollFree          Type = "tollfree"
+	PremiumRate       Type = "premiumrate"
+	SharedCost        Type = "sharedcost"
+	VoIP              Type = "voip"
+	PersonalNumber    Type = "personalnumber"
+	Pager             Type = "pager"
+	UAN               Type = "uan"
+	Voicemail         Type = "voicemail"
+)
+
+const (
+	minLengthForNSN     = 2
+	maxLengthForNSN     = 17
+	maxLengthInput      = 250
+	maxCountryCodeDigit = 3
+)
+
+var (
+	//ErrNotANumber is returned when the input does not look like a phone number
+	ErrNotANumber = errors.New("The string supplied is not a phone number")
+	//ErrInvalidCountryCode is returned when the country calling code is unknown or
+	// when a national number is given without a region to interpret it in
+	ErrInvalidCountryCode = errors.New("The country calling code is unknown or missing")
+	//ErrTooShort is returned when the national number is too short
+	ErrTooShort = errors.New("The phone number is too short")
+	//ErrTooLong is returned when the national number is too long
+	ErrTooLong = errors.New("The phone number is too long")
+	//ErrInvalidNumber is returned when the number does not match the numbering plan of its country
+	ErrInvalidNumber = errors.New("The phone number does not exist in the numbering plan of its country")
+)
+
+//Number is a parsed phone number
+type Number struct {
+	CountryCode int
+	//NationalNumber is the national significant number, without national prefix
+	NationalNumber string
+	//Region is the ISO 3166-1 alpha-2 code of the region the number belongs to,
+	// empty if the number is not valid in any region
+	Region string
+	Type   Type
+}
+
+type numberDesc struct {
+	pattern string
+	lengths []int
+}
+
+//metadata are the numbering plan rules of a region
+type metadata struct {
+	countryCode                   int
+	internationalPrefix           string
+	nationalPrefixForParsing      string
+	nationalPrefixTransformRule   string
+	leadingDigits                 string
+	sameMobileAndFixedLinePattern bool
+
+	general, fixedLine, mobile, tollFree, premiumRate, sharedCost,
+	voip, personalNumber, pager, uan, voicemail numberDesc
+}
+
+var (
+	regexCache     = map[string]*regexp.Regexp{}
+	regexCacheLock sync.Mutex
+)
+
+//compile returns the compiled expression from the cache, the metadata patterns are compiled on first use
+func compile(expression string) *regexp.Regexp {
+	regexCacheLock.Lock()
+	defer regexCacheLock.Unlock()
+	re, found := regexCache[expression]
+	if !found {
+		re = regexp.MustCompile(expression)
+		regexCache[expression] = re
+	}
+	return re
+}
+
+//matches checks if the national number has one of the possible lengths and matches the pattern completely
+func (d numberDesc) matches(nationalNumber string) bool {
+	if d.pattern == "" {
+		return false
+	}
+	if len(d.lengths) > 0 {
+		possible := false
+		for _, l := range d.lengths {
+			possible = possible || l == len(nationalNumber)
+		}
+		if !possible {
+			return false
+		}
+	}
+	return compile("^(?:" + d.pattern + ")$").MatchString(nationalNumber)
+}
+
+func (d numberDesc) minLength() int {
+	min := minLengthForNSN
+	for i, l := range d.lengths {
+		if i == 0 || l < min {
+			min = l
+		}
+	}
+	return min
+}
+
+func (d numberDesc) maxLength() int {
+	max := maxLengthForNSN
+	for i, l := range d.lengths {
+		if i == 0 || l > max {
+			max = l
+		}
+	}
+	return max
+}
+
+//IsRegion checks if region is a supported ISO 3166-1 alpha-2 region code
+func IsRegion(region string) bool {
+	_, found := regionMetadata[strings.ToUpper(region)]
+	return found
+}
+
+//Parse parses a phone number in international format, or in the national format of defaultRegion.
+// Spaces, dashes, dots, slashes and parentheses are ignored. The region may be empty if the number
+// starts with a '+'. Parse only fails if the number can not be split in a country calling code
+// and a national number, use IsValid to check it against the numbering plan of its country.
+func Parse(number string, defaultRegion string) (n *Number, err error) {
+	if len(number) > maxLengthInput {
+		err = ErrTooLong
+		return
+	}
+	digits, international, err := stripFormatting(number)
+	if err != nil {
+		return
+	}
+	region := regionMetadata[strings.ToUpper(defaultRegion)]
+
+	var countryCode int
+	var nationalNumber string
+	if !international && region != nil && region.internationalPrefix != "" {
+		if loc := compile("^(?:" + region.internationalPrefix + ")").FindStringIndex(digits); loc != nil && loc[1] < len(digits) && digits[loc[1]] != '0' {
+			digits = digits[loc[1]:]
+			international = true
+		}
+	}
+	if international {
+		if len(digits) <= minLengthForNSN {
+			err = ErrTooShort
+			return
+		}
+		if countryCode, nationalNumber = extractCountryCode(digits); countryCode == 0 {
+			err = ErrInvalidCountryCode
+			return
+		}
+	} else if region == nil {
+		err = ErrInvalidCountryCode
+		return
+	} else {
+		countryCode, nationalNumber = region.countryCode, digits
+		//A number of the default region that is dialed with the country calling code but without the '+'
+		if prefix := strconv.Itoa(region.countryCode); strings.HasPrefix(digits, prefix) {
+			withoutCountryCode := region.stripNationalPrefix(digits[len(prefix):])
+			if (!region.general.matches(digits) && region.general.matches(withoutCountryCode)) || len(digits) > region.general.maxLength() {
+				nationalNumber = withoutCountryCode
+			}
+		}
+	}
+	if len(nationalNumber) < minLengthForNSN {
+		err = ErrTooShort
+		return
+	}
+	//The national prefix is stripped from international numbers as well, since +32 0475... is a common mistake
+	if main := regionMetadata[countryCodeRegions[countryCode][0]]; main != nil {
+		if stripped := main.stripNationalPrefix(nationalNumber); len(stripped) >= main.general.minLength() {
+			nationalNumber = stripped
+		}
+	}
+	if len(nationalNumber) < minLengthForNSN {
+		err = ErrTooShort
+		return
+	}
+	if len(nationalNumber) > maxLengthForNSN {
+		err = ErrTooLong
+		return
+	}
+	n = &Number{CountryCode: countryCode, NationalNumber: nationalNumber, Type: Unknown}
+	for _, r := range countryCodeRegions[countryCode] {
+		m := regionMetadata[r]
+		if m.leadingDigits != "" {
+			if !compile("^(?:" + m.leadingDigits + ")").MatchString(nationalNumber) {
+				continue
+			}
+		} else if m.numberType(nationalNumber) == Unknown {
+			continue
+		}
+		n.Region = r
+		n.Type = m.numberType(nationalNumber)
+		break
+	}
+	return
+}
+
+//stripFormatting returns the digits of a number and if it starts with a '+'
+func stripFormatting(number string) (digits string, international bool, err error) {
+	number = strings.TrimSpace(number)
+	if strings.HasPrefix(number, "+") || strings.HasPrefix(number, "＋") {
+		international = true
+		number = strings.TrimLeft(number, "+＋")
+	}
+	buf := make([]byte, 0, len(number))
+	for _, c := range number {
+		switch {
+		case c >= '0' && c <= '9':
+			buf = append(buf, byte(c))
+		case c >= '０' && c <= '９':
+			buf = append(buf, byte('0'+c-'０'))
+		case strings.ContainsRune("  -‐‑‒–—―−./()[]~", c):
+		default:
+			err = ErrNotANumber
+			return
+		}
+	}
+	if len(buf) < minLengthForNSN {
+		err = ErrNotANumber
+		return
+	}
+	digits = string(buf)
+	return
+}
+
+//extractCountryCode splits a number without international prefix in a country calling code and the national number
+func extractCountryCode(digits string) (countryCode int, nationalNumber string) {
+	if digits[0] == '0' {
+		return
+	}
+	for i := 1; i <= maxCountryCodeDigit && i <= len(digits); i++ {
+		code, _ := strconv.Atoi(digits[:i])
+		if _, found := countryCodeRegions[code]; found {
+			return code, digits[i:]
+		}
+	}
+	return
+}
+
+//stripNationalPrefix removes the national (trunk) prefix, applying the transform rule of the region if it has one.
+// The number is left untouched if it was valid in the numbering plan and stripping the prefix would make it invalid.
+func (m *metadata) stripNationalPrefix(number string) string {
+	if m.nationalPrefixForParsing == "" {
+		return number
+	}
+	prefix := compile("^(?:" + m.nationalPrefixForParsing + ")")
+	groups := prefix.FindStringSubmatchIndex(number)
+	if groups == nil {
+		return number
+	}
+	viable := m.general.matches(number)
+	var result string
+	lastGroup := len(groups)/2 - 1
+	if m.nationalPrefixTransformRule == "" || groups[lastGroup*2] < 0 {
+		result = number[groups[1]:]
+	} else {
+		result = prefix.ReplaceAllString(number, m.nationalPrefixTransformRule)
+	}
+	if viable && !m.general.matches(result) {
+		return number
+	}
+	return result
+}
+
+//numberType determines the type of a national number according to the numbering plan of the region
+func (m *metadata) numberType(nationalNumber string) Type {
+	if !m.general.matches(nationalNumber) {
+		return Unknown
+	}
+	for _, t := range []struct {
+		desc       numberDesc
+		numberType Type
+	}{
+		{m.premiumRate, PremiumRate},
+		{m.tollFree, TollFree},
+		{m.sharedCost, SharedCost},
+		{m.voip, VoIP},
+		{m.personalNumber, PersonalNumber},
+		{m.pager, Pager},
+		{m.uan, UAN},
+		{m.voicemail, Voicemail},
+	} {
+		if t.desc.matches(nationalNumber) {
+			return t.numberType
+		}
+	}
+	if m.fixedLine.matches(nationalNumber) {
+		if m.sameMobileAndFixedLinePattern || m.mobile.matches(nationalNumber) {
+			return FixedLineOrMobile
+		}
+		return FixedLine
+	}
+	if !m.sameMobileAndFixedLinePattern && m.mobile.matches(nationalNumber) {
+		return Mobile
+	}
+	return Unknown
+}
+
+//IsValid checks if the number exists in the numbering plan of its country
+func (n *Number) IsValid() bool {
+	return n.Region != "" && n.Type != Unknown
+}
+
+//CanReceiveSMS checks if the number can receive text messages,
+// numbers of which the line type can not be determined are given the benefit of the doubt
+func (n *Number) CanReceiveSMS() bool {
+	switch n.Type {
+	case FixedLine, TollFree, PremiumRate, SharedCost, UAN, Voicemail:
+		return false
+	}
+	return true
+}
+
+//E164 formats the number in E.164 format: a '+', the country calling code and the national significant number
+func (n *Number) E164() string {
+	return "+" + strconv.Itoa(n.CountryCode) + n.NationalNumber
+}
+
+//Normalize parses a phone number and returns it in E.164 format if it is valid.
+// National numbers are interpreted in defaultRegion.
+func Normalize(number string, defaultRegion string) (normalized string, err error) {
+	n, err := Parse(number, defaultRegion)
+	if err != nil {
+		return
+	}
+	if !n.IsValid() {
+		err = ErrInvalidNumber
+		return
+	}
+	normalized = n.E164()
+	return
+}
@@ -0,0 +1,88 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	type testcase struct {
+		number     string
+		region     string
+		normalized string
+		valid      bool
+	}
+	testcases := []testcase{
+		{number: "+32475123456", region: "", normalized: "+32475123456", valid: true},
+		{number: "+32 475 12 34 56", region: "US", normalized: "+32475123456", valid: true},
+		{number: "0475/12.34.56", region: "be", normalized: "+32475123456", valid: true},
+		{number: "0032475123456", region: "BE", normalized: "+32475123456", valid: true},
+		{number: "+32 (0)475 12 34 56", region: "", normalized: "+32475123456", valid: true},
+		{number: "32475123456", region: "BE", normalized: "+32475123456", valid: true},
+		{number: "+1 (201) 555-0123", region: "", normalized: "+12015550123", valid: true},
+		{number: "1-201-555-0123", region: "US", normalized: "+12015550123", valid: true},
+		{number: "011 44 7400 123456", region: "US", normalized: "+447400123456", valid: true},
+		{number: "+39 06 1234 5678", region: "", normalized: "+390612345678", valid: true},
+		{number: "＋３２４７５１２３４５６", region: "", normalized: "+32475123456", valid: true},
+		{number: "+3247512345", region: "", valid: false},
+		{number: "+32175123456789", region: "", valid: false},
+		{number: "0475123456", region: "", valid: false},
+		{number: "+999123456", region: "", valid: false},
+		{number: "+32 475 CALL ME", region: "", valid: false},
+		{number: "", region: "BE", valid: false},
+	}
+	for _, test := range testcases {
+		normalized, err := Normalize(test.number, test.region)
+		if test.valid {
+			assert.NoError(t, err, test.number)
+			assert.Equal(t, test.normalized, normalized, test.number)
+		} else {
+			assert.Error(t, err, test.number)
+		}
+	}
+}
+
+func TestNumberType(t *testing.T) {
+	type testcase struct {
+		number        string
+		region        string
+		numberType    Type
+		canReceiveSMS bool
+	}
+	testcases := []testcase{
+		{number: "+32475123456", region: "BE", numberType: Mobile, canReceiveSMS: true},
+		{number: "+3292123456", region: "BE", numberType: FixedLine, canReceiveSMS: false},
+		{number: "+3280012345", region: "BE", numberType: TollFree, canReceiveSMS: false},
+		{number: "+447400123456", region: "GB", numberType: Mobile, canReceiveSMS: true},
+		{number: "+441212345678", region: "GB", numberType: FixedLine, canReceiveSMS: false},
+		{number: "+12015550123", region: "US", numberType: FixedLineOrMobile, canReceiveSMS: true},
+		{number: "+14165550123", region: "CA", numberType: FixedLineOrMobile, canReceiveSMS: true},
+	}
+	for _, test := range testcases {
+		n, err := Parse(test.number, "")
+		if !assert.NoError(t, err, test.number) {
+			continue
+		}
+		assert.True(t, n.IsValid(), test.number)
+		assert.Equal(t, test.region, n.Region, test.number)
+		assert.Equal(t, test.numberType, n.Type, test.number)
+		assert.Equal(t, test.canReceiveSMS, n.CanReceiveSMS(), test.number)
+	}
+}
+
+func TestIsRegion(t *testing.T) {
+	assert.True(t, IsRegion("BE"))
+	assert.True(t, IsRegion("be"))
+	assert.False(t, IsRegion("XX"))
+	assert.False(t, IsRegion(""))
+}
+
+func TestMetadata(t *testing.T) {
+	for region, m := range regionMetadata {
+		for _, expression := range []string{m.internationalPrefix, m.nationalPrefixForParsing, m.leadingDigits, m.general.pattern, m.fixedLine.pattern, m.mobile.pattern} {
+			assert.NotPanics(t, func() { compile(expression) }, region)
+		}
+		assert.Contains(t, countryCodeRegions[m.countryCode], region)
+	}
+}
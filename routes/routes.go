@@ -8,12 +8,15 @@ import (
 
 	"github.com/itsyouonline/identityserver/db"
 	"github.com/itsyouonline/identityserver/identityservice"
+	"github.com/itsyouonline/identityserver/mailqueue"
 	"github.com/itsyouonline/identityserver/oauthservice"
+	"github.com/itsyouonline/identityserver/scimservice"
 	"github.com/itsyouonline/identityserver/siteservice"
+	"github.com/itsyouonline/identityserver/smsoutbox"
 )
 
 //GetRouter contructs the router hierarchy and registers all handlers and middleware
-func GetRouter(sc *siteservice.Service, is *identityservice.Service, oauthsc *oauthservice.Service) http.Handler {
+func GetRouter(sc *siteservice.Service, is *identityservice.Service, oauthsc *oauthservice.Service, scimsc *scimservice.Service, outbox *smsoutbox.Outbox, mailQueue *mailqueue.Queue) http.Handler {
 	r := mux.NewRouter().StrictSlash(true)
 
 	sc.AddRoutes(r)
@@ -22,6 +25,9 @@ func GetRouter(sc *siteservice.Service, is *identityservice.Service, oauthsc *oa
 	apiRouter := r.PathPrefix("/api").Subrouter()
 	is.AddRoutes(apiRouter)
 	oauthsc.AddRoutes(r)
+	scimsc.AddRoutes(r)
+	outbox.AddRoutes(r)
+	mailQueue.AddRoutes(r)
 
 	// Add middlewares
 	router := NewRouter(r)
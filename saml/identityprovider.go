@@ -0,0 +1,281 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const (
+	//AssertionValidity is how long a service provider may take to consume an assertion
+	AssertionValidity = 5 * time.Minute
+
+	passwordProtectedTransport = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport"
+	basicAttributeNameFormat   = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+)
+
+var (
+	//ErrNoServiceProvider is returned when the metadata does not describe a service provider
+	ErrNoServiceProvider = errors.New("The metadata does not contain a service provider descriptor")
+	//ErrNoAssertionConsumerService is returned when the service provider does not support the HTTP-POST binding
+	ErrNoAssertionConsumerService = errors.New("The service provider has no assertion consumer service with the HTTP-POST binding")
+	//ErrInvalidRequest is returned when a SAML authentication request can not be parsed
+	ErrInvalidRequest = errors.New("Invalid SAML authentication request")
+)
+
+//IdentityProvider issues signed assertions about the users of itsyou.online
+type IdentityProvider struct {
+	EntityID        string
+	SingleSignOnURL string
+	//KeyStore holds the RSA key and certificate the assertions are signed with
+	KeyStore dsig.X509KeyStore
+	//Now returns the current time, it defaults to time.Now
+	Now func() time.Time
+}
+
+//ServiceProviderMetadata is the information about a service provider that is needed to send it assertions
+type ServiceProviderMetadata struct {
+	EntityID                    string
+	AssertionConsumerServiceURL string
+}
+
+//AuthnRequest is an authentication request a service provider sent
+type AuthnRequest struct {
+	ID     string
+	Issuer string
+	//AssertionConsumerServiceURL is empty if the service provider wants the registered one to be used
+	AssertionConsumerServiceURL string
+	//ForceAuthn asks to authenticate the user again, even if there already is a session
+	ForceAuthn bool
+}
+
+func (idp *IdentityProvider) now() time.Time {
+	if idp.Now != nil {
+		return idp.Now()
+	}
+	return time.Now()
+}
+
+type xmlServiceProviderSSODescriptor struct {
+	AssertionConsumerServices []xmlEndpoint `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type xmlServiceProviderEntityDescriptor struct {
+	XMLName          xml.Name                          `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID         string                            `xml:"entityID,attr"`
+	SPSSODescriptors []xmlServiceProviderSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+}
+
+//ParseServiceProviderMetadata extracts the entity id and the HTTP-POST assertion consumer service from
+// the metadata a service provider publishes
+func ParseServiceProviderMetadata(metadata []byte) (sp *ServiceProviderMetadata, err error) {
+	entity := xmlServiceProviderEntityDescriptor{}
+	if err = xml.Unmarshal(metadata, &entity); err != nil || entity.EntityID == "" {
+		return nil, ErrInvalidMetadata
+	}
+	if len(entity.SPSSODescriptors) == 0 {
+		return nil, ErrNoServiceProvider
+	}
+	for _, service := range entity.SPSSODescriptors[0].AssertionConsumerServices {
+		if service.Binding == HTTPPostBinding && isHTTPURL(service.Location) {
+			return &ServiceProviderMetadata{EntityID: entity.EntityID, AssertionConsumerServiceURL: service.Location}, nil
+		}
+	}
+	return nil, ErrNoAssertionConsumerService
+}
+
+type xmlIDPSSODescriptorOutput struct {
+	WantAuthnRequestsSigned    bool                   `xml:"WantAuthnRequestsSigned,attr"`
+	ProtocolSupportEnumeration string                 `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptor              xmlKeyDescriptorOutput `xml:"md:KeyDescriptor"`
+	NameIDFormats              []string               `xml:"md:NameIDFormat"`
+	SingleSignOnServices       []xmlEndpoint          `xml:"md:SingleSignOnService"`
+}
+
+type xmlKeyDescriptorOutput struct {
+	Use         string `xml:"use,attr"`
+	Certificate string `xml:"ds:KeyInfo>ds:X509Data>ds:X509Certificate"`
+}
+
+type xmlIDPEntityDescriptor struct {
+	XMLName            xml.Name                  `xml:"md:EntityDescriptor"`
+	Namespace          string                    `xml:"xmlns:md,attr"`
+	SignatureNamespace string                    `xml:"xmlns:ds,attr"`
+	EntityID           string                    `xml:"entityID,attr"`
+	IDPSSODescriptor   xmlIDPSSODescriptorOutput `xml:"md:IDPSSODescriptor"`
+}
+
+//Metadata returns the metadata a service provider needs to trust this identity provider
+func (idp *IdentityProvider) Metadata() ([]byte, error) {
+	_, certificate, err := idp.KeyStore.GetKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	descriptor := xmlIDPEntityDescriptor{
+		Namespace:          metadataNamespace,
+		SignatureNamespace: signatureNamespace,
+		EntityID:           idp.EntityID,
+		IDPSSODescriptor: xmlIDPSSODescriptorOutput{
+			ProtocolSupportEnumeration: protocolNamespace,
+			KeyDescriptor: xmlKeyDescriptorOutput{
+				Use:         "signing",
+				Certificate: base64.StdEncoding.EncodeToString(certificate),
+			},
+			NameIDFormats: []string{NameIDFormatUnspecified},
+			SingleSignOnServices: []xmlEndpoint{
+				{Binding: HTTPRedirectBinding, Location: idp.SingleSignOnURL},
+				{Binding: HTTPPostBinding, Location: idp.SingleSignOnURL},
+			},
+		},
+	}
+	output, err := xml.MarshalIndent(&descriptor, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+type xmlAuthnRequestInput struct {
+	XMLName                     xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	ForceAuthn                  bool     `xml:"ForceAuthn,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+}
+
+//ParseAuthnRequest decodes the SAMLRequest parameter of an authentication request,
+// requests sent with the HTTP-Redirect binding are deflated before they are encoded.
+// The request is not required to be signed, the response is only sent to a registered assertion consumer service.
+func ParseAuthnRequest(samlRequest string, deflated bool) (*AuthnRequest, error) {
+	raw, err := base64.StdEncoding.DecodeString(removeWhitespace(samlRequest))
+	if err != nil {
+		return nil, ErrInvalidRequest
+	}
+	if deflated {
+		reader := flate.NewReader(bytes.NewReader(raw))
+		defer reader.Close()
+		//An authentication request is small, don't let a crafted one inflate without bounds
+		if raw, err = ioutil.ReadAll(io.LimitReader(reader, 1<<20)); err != nil {
+			return nil, ErrInvalidRequest
+		}
+	}
+	request := xmlAuthnRequestInput{}
+	if err = xml.Unmarshal(raw, &request); err != nil {
+		return nil, ErrInvalidRequest
+	}
+	if request.Version != "2.0" || request.ID == "" || request.Issuer == "" ||
+		(request.ProtocolBinding != "" && request.ProtocolBinding != HTTPPostBinding) {
+		return nil, ErrInvalidRequest
+	}
+	return &AuthnRequest{
+		ID:                          request.ID,
+		Issuer:                      request.Issuer,
+		AssertionConsumerServiceURL: request.AssertionConsumerServiceURL,
+		ForceAuthn:                  request.ForceAuthn,
+	}, nil
+}
+
+//NewResponse creates a base64 encoded response with a signed assertion about the user for the HTTP-POST binding.
+// inResponseTo is empty for a response the identity provider sends on its own initiative.
+func (idp *IdentityProvider) NewResponse(sp *ServiceProviderMetadata, inResponseTo string, nameID string, authnInstant time.Time, attributes map[string][]string) (string, error) {
+	responseID, err := NewID()
+	if err != nil {
+		return "", err
+	}
+	assertionID, err := NewID()
+	if err != nil {
+		return "", err
+	}
+	now := idp.now().UTC()
+	issueInstant := now.Format(time.RFC3339)
+	notOnOrAfter := now.Add(AssertionValidity).Format(time.RFC3339)
+
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", assertionNamespace)
+	assertion.CreateAttr("ID", assertionID)
+	assertion.CreateAttr("Version", "2.0")
+	assertion.CreateAttr("IssueInstant", issueInstant)
+	assertion.CreateElement("saml:Issuer").SetText(idp.EntityID)
+
+	subject := assertion.CreateElement("saml:Subject")
+	nameIDElement := subject.CreateElement("saml:NameID")
+	nameIDElement.CreateAttr("Format", NameIDFormatUnspecified)
+	nameIDElement.SetText(nameID)
+	confirmation := subject.CreateElement("saml:SubjectConfirmation")
+	confirmation.CreateAttr("Method", bearerMethod)
+	confirmationData := confirmation.CreateElement("saml:SubjectConfirmationData")
+	if inResponseTo != "" {
+		confirmationData.CreateAttr("InResponseTo", inResponseTo)
+	}
+	confirmationData.CreateAttr("NotOnOrAfter", notOnOrAfter)
+	confirmationData.CreateAttr("Recipient", sp.AssertionConsumerServiceURL)
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", issueInstant)
+	conditions.CreateAttr("NotOnOrAfter", notOnOrAfter)
+	conditions.CreateElement("saml:AudienceRestriction").CreateElement("saml:Audience").SetText(sp.EntityID)
+
+	authnStatement := assertion.CreateElement("saml:AuthnStatement")
+	authnStatement.CreateAttr("AuthnInstant", authnInstant.UTC().Format(time.RFC3339))
+	authnStatement.CreateAttr("SessionIndex", assertionID)
+	authnStatement.CreateElement("saml:AuthnContext").CreateElement("saml:AuthnContextClassRef").SetText(passwordProtectedTransport)
+
+	if len(attributes) > 0 {
+		names := make([]string, 0, len(attributes))
+		for name := range attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		statement := assertion.CreateElement("saml:AttributeStatement")
+		for _, name := range names {
+			attribute := statement.CreateElement("saml:Attribute")
+			attribute.CreateAttr("Name", name)
+			attribute.CreateAttr("NameFormat", basicAttributeNameFormat)
+			for _, value := range attributes[name] {
+				attribute.CreateElement("saml:AttributeValue").SetText(value)
+			}
+		}
+	}
+
+	signingContext := dsig.NewDefaultSigningContext(idp.KeyStore)
+	signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signature, err := signingContext.ConstructSignature(assertion, true)
+	if err != nil {
+		return "", err
+	}
+	//The schema requires the signature to follow the issuer
+	assertion.InsertChildAt(1, signature)
+
+	response := etree.NewElement("samlp:Response")
+	response.CreateAttr("xmlns:samlp", protocolNamespace)
+	response.CreateAttr("xmlns:saml", assertionNamespace)
+	response.CreateAttr("ID", responseID)
+	response.CreateAttr("Version", "2.0")
+	response.CreateAttr("IssueInstant", issueInstant)
+	response.CreateAttr("Destination", sp.AssertionConsumerServiceURL)
+	if inResponseTo != "" {
+		response.CreateAttr("InResponseTo", inResponseTo)
+	}
+	response.CreateElement("saml:Issuer").SetText(idp.EntityID)
+	response.CreateElement("samlp:Status").CreateElement("samlp:StatusCode").CreateAttr("Value", successStatus)
+	response.AddChild(assertion)
+
+	doc := etree.NewDocument()
+	doc.SetRoot(response)
+	raw, err := doc.WriteToBytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,107 @@
+package saml
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIdentityProvider() *IdentityProvider {
+	return &IdentityProvider{
+		EntityID:        "https://itsyou.online/samlidp/acme/metadata",
+		SingleSignOnURL: "https://itsyou.online/samlidp/acme/sso",
+		KeyStore:        dsig.RandomKeyStoreForTest(),
+	}
+}
+
+//newTestServiceProviderFor creates a service provider that trusts the identity provider
+func newTestServiceProviderFor(idp *IdentityProvider) *ServiceProvider {
+	metadata, _ := idp.Metadata()
+	idpMetadata, _ := ParseIdentityProviderMetadata(metadata)
+	return &ServiceProvider{
+		EntityID:                    "https://app.acme.com/metadata",
+		AssertionConsumerServiceURL: "https://app.acme.com/acs",
+		IdentityProvider:            idpMetadata,
+	}
+}
+
+func TestIdentityProviderMetadata(t *testing.T) {
+	idp := newTestIdentityProvider()
+	metadata, err := idp.Metadata()
+	assert.NoError(t, err)
+	parsed, err := ParseIdentityProviderMetadata(metadata)
+	assert.NoError(t, err)
+	_, certificate, _ := idp.KeyStore.GetKeyPair()
+	assert.Equal(t, idp.EntityID, parsed.EntityID)
+	assert.Equal(t, idp.SingleSignOnURL, parsed.SingleSignOnURL)
+	assert.Equal(t, []string{base64.StdEncoding.EncodeToString(certificate)}, parsed.Certificates)
+}
+
+func TestParseServiceProviderMetadata(t *testing.T) {
+	sp := newTestServiceProvider(dsig.RandomKeyStoreForTest())
+	metadata, _ := sp.Metadata()
+	parsed, err := ParseServiceProviderMetadata(metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, sp.EntityID, parsed.EntityID)
+	assert.Equal(t, sp.AssertionConsumerServiceURL, parsed.AssertionConsumerServiceURL)
+
+	_, err = ParseServiceProviderMetadata([]byte(testIdentityProviderMetadata))
+	assert.Equal(t, ErrNoServiceProvider, err)
+	_, err = ParseServiceProviderMetadata([]byte("<html></html>"))
+	assert.Equal(t, ErrInvalidMetadata, err)
+}
+
+func TestParseAuthnRequest(t *testing.T) {
+	sp := newTestServiceProviderFor(newTestIdentityProvider())
+	requestID, redirectURL, err := sp.AuthnRequestURL("state")
+	assert.NoError(t, err)
+	u, _ := url.Parse(redirectURL)
+
+	request, err := ParseAuthnRequest(u.Query().Get("SAMLRequest"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, requestID, request.ID)
+	assert.Equal(t, sp.EntityID, request.Issuer)
+	assert.Equal(t, sp.AssertionConsumerServiceURL, request.AssertionConsumerServiceURL)
+	assert.False(t, request.ForceAuthn)
+
+	_, err = ParseAuthnRequest(u.Query().Get("SAMLRequest"), false)
+	assert.Equal(t, ErrInvalidRequest, err)
+	_, err = ParseAuthnRequest("bm90YXJlcXVlc3Q=", false)
+	assert.Equal(t, ErrInvalidRequest, err)
+}
+
+func TestNewResponse(t *testing.T) {
+	idp := newTestIdentityProvider()
+	sp := newTestServiceProviderFor(idp)
+	spMetadata := &ServiceProviderMetadata{EntityID: sp.EntityID, AssertionConsumerServiceURL: sp.AssertionConsumerServiceURL}
+	attributes := map[string][]string{
+		"email":    {"jane@acme.com"},
+		"memberof": {"acme", "acme.engineering"},
+	}
+
+	response, err := idp.NewResponse(spMetadata, "request1", "jane", time.Now(), attributes)
+	assert.NoError(t, err)
+	assertion, err := sp.ParseResponse(response, "request1")
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", assertion.NameID)
+	assert.Equal(t, NameIDFormatUnspecified, assertion.NameIDFormat)
+	assert.Equal(t, attributes, assertion.Attributes)
+
+	//The response is only valid for the request it answers and the service provider it is meant for
+	_, err = sp.ParseResponse(response, "request2")
+	assert.Equal(t, ErrInvalidResponse, err)
+	other := newTestServiceProviderFor(idp)
+	other.EntityID = "https://other.acme.com/metadata"
+	_, err = other.ParseResponse(response, "request1")
+	assert.Equal(t, ErrInvalidResponse, err)
+	//An assertion signed by another identity provider is rejected
+	impostor := newTestIdentityProvider()
+	response, err = impostor.NewResponse(spMetadata, "request1", "jane", time.Now(), attributes)
+	assert.NoError(t, err)
+	_, err = sp.ParseResponse(response, "request1")
+	assert.Equal(t, ErrInvalidSignature, err)
+}
@@ -0,0 +1,189 @@
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	metadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	signatureNamespace = "http://www.w3.org/2000/09/xmldsig#"
+
+	//HTTPRedirectBinding is the binding used to send authentication requests to the identity provider
+	HTTPRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	//HTTPPostBinding is the binding used to send responses to the assertion consumer service
+	HTTPPostBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	//NameIDFormatPersistent is an opaque identifier that stays the same for a user
+	NameIDFormatPersistent = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	//NameIDFormatEmailAddress is the email address of the user
+	NameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	//NameIDFormatUnspecified leaves the format to the identity provider
+	NameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+)
+
+var (
+	//ErrInvalidMetadata is returned when the metadata can not be parsed
+	ErrInvalidMetadata = errors.New("The metadata is not a valid SAML 2.0 entity descriptor")
+	//ErrNoIdentityProvider is returned when the metadata does not describe an identity provider
+	ErrNoIdentityProvider = errors.New("The metadata does not contain an identity provider descriptor")
+	//ErrNoSingleSignOnService is returned when the identity provider does not support the HTTP-Redirect binding
+	ErrNoSingleSignOnService = errors.New("The identity provider has no single sign on service with the HTTP-Redirect binding")
+	//ErrNoSigningCertificate is returned when the identity provider does not publish a valid signing certificate
+	ErrNoSigningCertificate = errors.New("The identity provider has no valid signing certificate")
+)
+
+//IdentityProviderMetadata is the information about an identity provider that is needed
+// to send it authentication requests and to validate the responses it sends back
+type IdentityProviderMetadata struct {
+	EntityID        string
+	SingleSignOnURL string
+	//Certificates are the base64 encoded DER certificates the identity provider signs with
+	Certificates []string
+}
+
+type xmlKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+}
+
+type xmlEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type xmlIDPSSODescriptor struct {
+	KeyDescriptors       []xmlKeyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleSignOnServices []xmlEndpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+type xmlEntityDescriptor struct {
+	XMLName           xml.Name              `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID          string                `xml:"entityID,attr"`
+	IDPSSODescriptors []xmlIDPSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+type xmlEntitiesDescriptor struct {
+	XMLName           xml.Name              `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntitiesDescriptor"`
+	EntityDescriptors []xmlEntityDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+}
+
+//ParseIdentityProviderMetadata extracts the entity id, the HTTP-Redirect single sign on url and the
+// signing certificates from the metadata an identity provider publishes.
+// If the metadata contains multiple entities, the first identity provider is used.
+func ParseIdentityProviderMetadata(metadata []byte) (idp *IdentityProviderMetadata, err error) {
+	var entities []xmlEntityDescriptor
+	entity := xmlEntityDescriptor{}
+	if err = xml.Unmarshal(metadata, &entity); err == nil {
+		entities = append(entities, entity)
+	} else {
+		group := xmlEntitiesDescriptor{}
+		if err = xml.Unmarshal(metadata, &group); err != nil {
+			return nil, ErrInvalidMetadata
+		}
+		entities = group.EntityDescriptors
+	}
+	for _, entity := range entities {
+		if len(entity.IDPSSODescriptors) == 0 {
+			continue
+		}
+		if entity.EntityID == "" {
+			return nil, ErrInvalidMetadata
+		}
+		idp = &IdentityProviderMetadata{EntityID: entity.EntityID}
+		descriptor := entity.IDPSSODescriptors[0]
+		for _, service := range descriptor.SingleSignOnServices {
+			if service.Binding == HTTPRedirectBinding && isHTTPURL(service.Location) {
+				idp.SingleSignOnURL = service.Location
+				break
+			}
+		}
+		if idp.SingleSignOnURL == "" {
+			return nil, ErrNoSingleSignOnService
+		}
+		for _, key := range descriptor.KeyDescriptors {
+			//Keys without a use can be used for both signing and encryption
+			if key.Use != "" && key.Use != "signing" {
+				continue
+			}
+			for _, certificate := range key.Certificates {
+				certificate = removeWhitespace(certificate)
+				if _, err = ParseCertificate(certificate); err == nil {
+					idp.Certificates = append(idp.Certificates, certificate)
+				}
+			}
+		}
+		if len(idp.Certificates) == 0 {
+			return nil, ErrNoSigningCertificate
+		}
+		return idp, nil
+	}
+	return nil, ErrNoIdentityProvider
+}
+
+//ParseCertificate parses a base64 encoded DER certificate as it is used in SAML metadata
+func ParseCertificate(certificate string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(removeWhitespace(certificate))
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func isHTTPURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+func removeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+type xmlSPSSODescriptor struct {
+	XMLName                    xml.Name             `xml:"md:SPSSODescriptor"`
+	AuthnRequestsSigned        bool                 `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                 `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string               `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormats              []string             `xml:"md:NameIDFormat"`
+	AssertionConsumerServices  []xmlIndexedEndpoint `xml:"md:AssertionConsumerService"`
+}
+
+type xmlIndexedEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+	Index    int    `xml:"index,attr"`
+}
+
+type xmlSPEntityDescriptor struct {
+	XMLName         xml.Name           `xml:"md:EntityDescriptor"`
+	Namespace       string             `xml:"xmlns:md,attr"`
+	EntityID        string             `xml:"entityID,attr"`
+	SPSSODescriptor xmlSPSSODescriptor `xml:"md:SPSSODescriptor"`
+}
+
+//Metadata returns the metadata an identity provider needs to register this service provider
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	descriptor := xmlSPEntityDescriptor{
+		Namespace: metadataNamespace,
+		EntityID:  sp.EntityID,
+		SPSSODescriptor: xmlSPSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: protocolNamespace,
+			NameIDFormats:              []string{NameIDFormatPersistent, NameIDFormatEmailAddress, NameIDFormatUnspecified},
+			AssertionConsumerServices: []xmlIndexedEndpoint{
+				{Binding: HTTPPostBinding, Location: sp.AssertionConsumerServiceURL, Index: 0},
+			},
+		},
+	}
+	output, err := xml.MarshalIndent(&descriptor, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), output...), nil
+}
@@ -0,0 +1,363 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	//MaxClockSkew is the tolerated difference between the clocks of the identity provider and this server
+	MaxClockSkew = 3 * time.Minute
+
+	successStatus = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	bearerMethod  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+var (
+	//ErrInvalidResponse is returned when the SAML response is malformed or does not pass validation
+	ErrInvalidResponse = errors.New("Invalid SAML response")
+	//ErrAuthenticationFailed is returned when the identity provider reports that the authentication failed
+	ErrAuthenticationFailed = errors.New("The identity provider did not authenticate the user")
+	//ErrInvalidSignature is returned when neither the response nor the assertion carries a valid signature
+	ErrInvalidSignature = errors.New("The SAML response is not signed by the identity provider")
+	//ErrExpired is returned when the assertion is not valid at this time
+	ErrExpired = errors.New("The SAML assertion is expired or not yet valid")
+)
+
+//ServiceProvider sends authentication requests to a single identity provider and validates its responses
+type ServiceProvider struct {
+	EntityID                    string
+	AssertionConsumerServiceURL string
+	IdentityProvider            *IdentityProviderMetadata
+	//Now returns the current time, it defaults to time.Now
+	Now func() time.Time
+}
+
+//Assertion holds the validated information about the user the identity provider authenticated
+type Assertion struct {
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	Attributes   map[string][]string
+}
+
+//Attribute returns the first value of the first of the named attributes that is present
+func (a *Assertion) Attribute(names ...string) string {
+	for _, name := range names {
+		if values := a.Attributes[name]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func (sp *ServiceProvider) now() time.Time {
+	if sp.Now != nil {
+		return sp.Now()
+	}
+	return time.Now()
+}
+
+//NewID creates a random identifier for a request or an assertion, it starts with a letter as required for an xml ID
+func NewID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "id" + hex.EncodeToString(b), nil
+}
+
+type xmlAuthnRequest struct {
+	XMLName                     xml.Name `xml:"samlp:AuthnRequest"`
+	ProtocolNamespace           string   `xml:"xmlns:samlp,attr"`
+	AssertionNamespace          string   `xml:"xmlns:saml,attr"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	Destination                 string   `xml:"Destination,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      string   `xml:"saml:Issuer"`
+	NameIDPolicy                struct {
+		Format      string `xml:"Format,attr"`
+		AllowCreate bool   `xml:"AllowCreate,attr"`
+	} `xml:"samlp:NameIDPolicy"`
+}
+
+//AuthnRequestURL creates an authentication request and returns its id and the url that sends it
+// to the identity provider using the HTTP-Redirect binding.
+// The id needs to be kept to validate the response.
+func (sp *ServiceProvider) AuthnRequestURL(relayState string) (requestID string, redirectURL string, err error) {
+	requestID, err = NewID()
+	if err != nil {
+		return
+	}
+	request := xmlAuthnRequest{
+		ProtocolNamespace:           protocolNamespace,
+		AssertionNamespace:          assertionNamespace,
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                sp.now().UTC().Format(time.RFC3339),
+		Destination:                 sp.IdentityProvider.SingleSignOnURL,
+		ProtocolBinding:             HTTPPostBinding,
+		AssertionConsumerServiceURL: sp.AssertionConsumerServiceURL,
+		Issuer:                      sp.EntityID,
+	}
+	request.NameIDPolicy.Format = NameIDFormatUnspecified
+	request.NameIDPolicy.AllowCreate = true
+	output, err := xml.Marshal(&request)
+	if err != nil {
+		return
+	}
+	//The HTTP-Redirect binding deflates the request before encoding it
+	deflated := &bytes.Buffer{}
+	writer, err := flate.NewWriter(deflated, flate.BestCompression)
+	if err != nil {
+		return
+	}
+	writer.Write(output)
+	writer.Close()
+
+	u, err := url.Parse(sp.IdentityProvider.SingleSignOnURL)
+	if err != nil {
+		return
+	}
+	query := u.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	u.RawQuery = query.Encode()
+	redirectURL = u.String()
+	return
+}
+
+type xmlResponse struct {
+	XMLName      xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	ID           string   `xml:"ID,attr"`
+	InResponseTo string   `xml:"InResponseTo,attr"`
+	Destination  string   `xml:"Destination,attr"`
+	Version      string   `xml:"Version,attr"`
+	Issuer       string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	StatusCode   struct {
+		Value string `xml:"Value,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:protocol Status>StatusCode"`
+}
+
+type xmlSubjectConfirmation struct {
+	Method string `xml:"Method,attr"`
+	Data   struct {
+		NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+		Recipient    string `xml:"Recipient,attr"`
+		InResponseTo string `xml:"InResponseTo,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+}
+
+type xmlAttribute struct {
+	Name   string   `xml:"Name,attr"`
+	Values []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+}
+
+type xmlAssertion struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	Version string   `xml:"Version,attr"`
+	Issuer  string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject struct {
+		NameID struct {
+			Format string `xml:"Format,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		SubjectConfirmations []xmlSubjectConfirmation `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions *struct {
+		NotBefore            string `xml:"NotBefore,attr"`
+		NotOnOrAfter         string `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AuthnStatements []struct {
+		SessionIndex string `xml:"SessionIndex,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AuthnStatement"`
+	Attributes []xmlAttribute `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement>Attribute"`
+}
+
+//ParseResponse decodes and validates a SAML response that was posted to the assertion consumer service.
+// The response must answer the authentication request with the given id and either the response or the
+// assertion must be signed with one of the certificates of the identity provider.
+// Only the signed parts of the response are used.
+func (sp *ServiceProvider) ParseResponse(samlResponse string, requestID string) (*Assertion, error) {
+	raw, err := base64.StdEncoding.DecodeString(removeWhitespace(samlResponse))
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(raw); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	responseElement := doc.Root()
+	if responseElement == nil || responseElement.Tag != "Response" || responseElement.NamespaceURI() != protocolNamespace {
+		return nil, ErrInvalidResponse
+	}
+	validationContext, err := sp.validationContext()
+	if err != nil {
+		return nil, err
+	}
+
+	responseSigned := false
+	if signature, _ := etreeutils.NSFindOneChild(responseElement, signatureNamespace, "Signature"); signature != nil {
+		if responseElement, err = validationContext.Validate(responseElement); err != nil {
+			return nil, ErrInvalidSignature
+		}
+		responseSigned = true
+	}
+	response := xmlResponse{}
+	if err = etreeutils.NSUnmarshalElement(etreeutils.DefaultNSContext, responseElement, &response); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	if response.Version != "2.0" || requestID == "" || response.InResponseTo != requestID ||
+		(response.Destination != "" && response.Destination != sp.AssertionConsumerServiceURL) ||
+		(response.Issuer != "" && response.Issuer != sp.IdentityProvider.EntityID) {
+		return nil, ErrInvalidResponse
+	}
+	if response.StatusCode.Value != successStatus {
+		return nil, ErrAuthenticationFailed
+	}
+
+	//Only a single, unencrypted assertion is supported
+	type unverifiedElement struct {
+		context etreeutils.NSContext
+		element *etree.Element
+	}
+	var assertions []unverifiedElement
+	rootContext, err := etreeutils.DefaultNSContext.SubContext(responseElement)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	err = etreeutils.NSIterateChildren(rootContext, responseElement, func(ctx etreeutils.NSContext, el *etree.Element) error {
+		namespace, err := ctx.LookupPrefix(el.Space)
+		if err != nil {
+			return err
+		}
+		if namespace == assertionNamespace && (el.Tag == "Assertion" || el.Tag == "EncryptedAssertion") {
+			assertions = append(assertions, unverifiedElement{context: ctx, element: el})
+		}
+		return nil
+	})
+	if err != nil || len(assertions) != 1 || assertions[0].element.Tag != "Assertion" {
+		return nil, ErrInvalidResponse
+	}
+	assertionElement, err := etreeutils.NSDetatch(assertions[0].context, assertions[0].element)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	if signature, _ := etreeutils.NSFindOneChild(assertionElement, signatureNamespace, "Signature"); signature != nil {
+		if assertionElement, err = validationContext.Validate(assertionElement); err != nil {
+			return nil, ErrInvalidSignature
+		}
+	} else if !responseSigned {
+		return nil, ErrInvalidSignature
+	}
+	assertion := xmlAssertion{}
+	if err = etreeutils.NSUnmarshalElement(etreeutils.DefaultNSContext, assertionElement, &assertion); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	return sp.validateAssertion(&assertion, requestID)
+}
+
+func (sp *ServiceProvider) validationContext() (*dsig.ValidationContext, error) {
+	certificateStore := &dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{}}
+	for _, certificate := range sp.IdentityProvider.Certificates {
+		parsed, err := ParseCertificate(certificate)
+		if err != nil {
+			return nil, err
+		}
+		certificateStore.Roots = append(certificateStore.Roots, parsed)
+	}
+	validationContext := dsig.NewDefaultValidationContext(certificateStore)
+	validationContext.Clock = dsig.NewFakeClockAt(sp.now())
+	return validationContext, nil
+}
+
+func (sp *ServiceProvider) validateAssertion(assertion *xmlAssertion, requestID string) (*Assertion, error) {
+	now := sp.now()
+	if assertion.Version != "2.0" || assertion.Issuer != sp.IdentityProvider.EntityID || strings.TrimSpace(assertion.Subject.NameID.Value) == "" {
+		return nil, ErrInvalidResponse
+	}
+	//A bearer confirmation for this service provider is required
+	confirmed := false
+	for _, confirmation := range assertion.Subject.SubjectConfirmations {
+		if confirmation.Method != bearerMethod || confirmation.Data.Recipient != sp.AssertionConsumerServiceURL {
+			continue
+		}
+		if confirmation.Data.InResponseTo != "" && confirmation.Data.InResponseTo != requestID {
+			continue
+		}
+		notOnOrAfter, err := time.Parse(time.RFC3339, confirmation.Data.NotOnOrAfter)
+		if err != nil || !now.Add(-MaxClockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		confirmed = true
+		break
+	}
+	if !confirmed {
+		return nil, ErrExpired
+	}
+	if conditions := assertion.Conditions; conditions != nil {
+		if conditions.NotBefore != "" {
+			notBefore, err := time.Parse(time.RFC3339, conditions.NotBefore)
+			if err != nil {
+				return nil, ErrInvalidResponse
+			}
+			if now.Add(MaxClockSkew).Before(notBefore) {
+				return nil, ErrExpired
+			}
+		}
+		if conditions.NotOnOrAfter != "" {
+			notOnOrAfter, err := time.Parse(time.RFC3339, conditions.NotOnOrAfter)
+			if err != nil {
+				return nil, ErrInvalidResponse
+			}
+			if !now.Add(-MaxClockSkew).Before(notOnOrAfter) {
+				return nil, ErrExpired
+			}
+		}
+		//Every audience restriction needs to include this service provider
+		for _, restriction := range conditions.AudienceRestrictions {
+			allowed := false
+			for _, audience := range restriction.Audiences {
+				if audience == sp.EntityID {
+					allowed = true
+				}
+			}
+			if !allowed {
+				return nil, ErrInvalidResponse
+			}
+		}
+	}
+	result := &Assertion{
+		NameID:       strings.TrimSpace(assertion.Subject.NameID.Value),
+		NameIDFormat: assertion.Subject.NameID.Format,
+		Attributes:   make(map[string][]string),
+	}
+	if len(assertion.AuthnStatements) > 0 {
+		result.SessionIndex = assertion.AuthnStatements[0].SessionIndex
+	}
+	for _, attribute := range assertion.Attributes {
+		result.Attributes[attribute.Name] = append(result.Attributes[attribute.Name], attribute.Values...)
+	}
+	return result, nil
+}
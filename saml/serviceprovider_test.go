@@ -0,0 +1,166 @@
+package saml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIdentityProviderMetadata = `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>bm90YWNlcnRpZmljYXRl</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+    <md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+      %s
+    </ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+
+const testAssertion = `<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="assertion1" Version="2.0" IssueInstant="%[1]s">
+  <saml:Issuer>https://idp.example.com/metadata</saml:Issuer>
+  <saml:Subject>
+    <saml:NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">employee-42</saml:NameID>
+    <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+      <saml:SubjectConfirmationData InResponseTo="request1" NotOnOrAfter="%[2]s" Recipient="https://itsyou.online/saml/acme/acs"/>
+    </saml:SubjectConfirmation>
+  </saml:Subject>
+  <saml:Conditions NotBefore="%[1]s" NotOnOrAfter="%[2]s">
+    <saml:AudienceRestriction><saml:Audience>%[3]s</saml:Audience></saml:AudienceRestriction>
+  </saml:Conditions>
+  <saml:AuthnStatement AuthnInstant="%[1]s" SessionIndex="session1"/>
+  <saml:AttributeStatement>
+    <saml:Attribute Name="email"><saml:AttributeValue>jane@acme.com</saml:AttributeValue></saml:Attribute>
+  </saml:AttributeStatement>
+</saml:Assertion>`
+
+const testResponse = `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="response1" Version="2.0" InResponseTo="request1" Destination="https://itsyou.online/saml/acme/acs">
+  <saml:Issuer>https://idp.example.com/metadata</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+</samlp:Response>`
+
+func newTestServiceProvider(keyStore dsig.X509KeyStore) *ServiceProvider {
+	_, certificate, _ := keyStore.GetKeyPair()
+	return &ServiceProvider{
+		EntityID:                    "https://itsyou.online/saml/acme/metadata",
+		AssertionConsumerServiceURL: "https://itsyou.online/saml/acme/acs",
+		IdentityProvider: &IdentityProviderMetadata{
+			EntityID:        "https://idp.example.com/metadata",
+			SingleSignOnURL: "https://idp.example.com/sso/redirect",
+			Certificates:    []string{base64.StdEncoding.EncodeToString(certificate)},
+		},
+	}
+}
+
+//createResponse builds a response with an assertion signed by the key store, tamper can modify the assertion after signing
+func createResponse(t *testing.T, keyStore dsig.X509KeyStore, audience string, sign bool, tamper func(*etree.Element)) string {
+	now := time.Now().UTC()
+	assertionDoc := etree.NewDocument()
+	err := assertionDoc.ReadFromString(fmt.Sprintf(testAssertion, now.Format(time.RFC3339), now.Add(5*time.Minute).Format(time.RFC3339), audience))
+	assert.NoError(t, err)
+	assertion := assertionDoc.Root()
+	if sign {
+		signingContext := dsig.NewDefaultSigningContext(keyStore)
+		signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+		assertion, err = signingContext.SignEnveloped(assertion)
+		assert.NoError(t, err)
+	}
+	if tamper != nil {
+		tamper(assertion)
+	}
+	responseDoc := etree.NewDocument()
+	err = responseDoc.ReadFromString(testResponse)
+	assert.NoError(t, err)
+	responseDoc.Root().AddChild(assertion)
+	raw, err := responseDoc.WriteToBytes()
+	assert.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestParseIdentityProviderMetadata(t *testing.T) {
+	keyStore := dsig.RandomKeyStoreForTest()
+	_, certificate, _ := keyStore.GetKeyPair()
+	encoded := base64.StdEncoding.EncodeToString(certificate)
+
+	idp, err := ParseIdentityProviderMetadata([]byte(fmt.Sprintf(testIdentityProviderMetadata, encoded)))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/metadata", idp.EntityID)
+	assert.Equal(t, "https://idp.example.com/sso/redirect", idp.SingleSignOnURL)
+	assert.Equal(t, []string{encoded}, idp.Certificates)
+
+	_, err = ParseIdentityProviderMetadata([]byte(fmt.Sprintf(testIdentityProviderMetadata, "bm90YWNlcnRpZmljYXRl")))
+	assert.Equal(t, ErrNoSigningCertificate, err)
+	_, err = ParseIdentityProviderMetadata([]byte("<html></html>"))
+	assert.Equal(t, ErrInvalidMetadata, err)
+}
+
+func TestServiceProviderMetadata(t *testing.T) {
+	sp := newTestServiceProvider(dsig.RandomKeyStoreForTest())
+	metadata, err := sp.Metadata()
+	assert.NoError(t, err)
+	doc := etree.NewDocument()
+	assert.NoError(t, doc.ReadFromBytes(metadata))
+	assert.Equal(t, "https://itsyou.online/saml/acme/metadata", doc.Root().SelectAttrValue("entityID", ""))
+	acs := doc.FindElement("//AssertionConsumerService")
+	assert.Equal(t, "https://itsyou.online/saml/acme/acs", acs.SelectAttrValue("Location", ""))
+	assert.Equal(t, HTTPPostBinding, acs.SelectAttrValue("Binding", ""))
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	sp := newTestServiceProvider(dsig.RandomKeyStoreForTest())
+	requestID, redirectURL, err := sp.AuthnRequestURL("state")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, requestID)
+	assert.True(t, strings.HasPrefix(redirectURL, "https://idp.example.com/sso/redirect?"))
+	assert.Contains(t, redirectURL, "RelayState=state")
+	assert.Contains(t, redirectURL, "SAMLRequest=")
+}
+
+func TestParseResponse(t *testing.T) {
+	keyStore := dsig.RandomKeyStoreForTest()
+	otherKeyStore := dsig.RandomKeyStoreForTest()
+	sp := newTestServiceProvider(keyStore)
+
+	assertion, err := sp.ParseResponse(createResponse(t, keyStore, sp.EntityID, true, nil), "request1")
+	assert.NoError(t, err)
+	assert.Equal(t, "employee-42", assertion.NameID)
+	assert.Equal(t, NameIDFormatPersistent, assertion.NameIDFormat)
+	assert.Equal(t, "session1", assertion.SessionIndex)
+	assert.Equal(t, "jane@acme.com", assertion.Attribute("mail", "email"))
+
+	type testcase struct {
+		name      string
+		response  string
+		requestID string
+		now       time.Time
+		err       error
+	}
+	testcases := []testcase{
+		{name: "unsolicited", response: createResponse(t, keyStore, sp.EntityID, true, nil), requestID: "", err: ErrInvalidResponse},
+		{name: "other request", response: createResponse(t, keyStore, sp.EntityID, true, nil), requestID: "request2", err: ErrInvalidResponse},
+		{name: "unsigned", response: createResponse(t, keyStore, sp.EntityID, false, nil), requestID: "request1", err: ErrInvalidSignature},
+		{name: "other key", response: createResponse(t, otherKeyStore, sp.EntityID, true, nil), requestID: "request1", err: ErrInvalidSignature},
+		{name: "other audience", response: createResponse(t, keyStore, "https://other.example.com", true, nil), requestID: "request1", err: ErrInvalidResponse},
+		{name: "expired", response: createResponse(t, keyStore, sp.EntityID, true, nil), requestID: "request1", now: time.Now().Add(10 * time.Minute), err: ErrExpired},
+		{name: "tampered", requestID: "request1", err: ErrInvalidSignature, response: createResponse(t, keyStore, sp.EntityID, true, func(assertion *etree.Element) {
+			assertion.FindElement("./Subject/NameID").SetText("admin")
+		})},
+		{name: "garbage", response: "bm90eG1s", requestID: "request1", err: ErrInvalidResponse},
+	}
+	for _, test := range testcases {
+		sp.Now = nil
+		if !test.now.IsZero() {
+			now := test.now
+			sp.Now = func() time.Time { return now }
+		}
+		_, err := sp.ParseResponse(test.response, test.requestID)
+		assert.Equal(t, test.err, err, test.name)
+	}
+}
@@ -0,0 +1,411 @@
+package scimservice
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//ErrInvalidFilter is returned when a filter can not be parsed
+var ErrInvalidFilter = errors.New("invalid filter")
+
+//filter matches resources in their generic json representation
+type filter interface {
+	matches(resource map[string]interface{}) bool
+}
+
+type andFilter struct{ left, right filter }
+
+func (f andFilter) matches(resource map[string]interface{}) bool {
+	return f.left.matches(resource) && f.right.matches(resource)
+}
+
+type orFilter struct{ left, right filter }
+
+func (f orFilter) matches(resource map[string]interface{}) bool {
+	return f.left.matches(resource) || f.right.matches(resource)
+}
+
+type notFilter struct{ inner filter }
+
+func (f notFilter) matches(resource map[string]interface{}) bool {
+	return !f.inner.matches(resource)
+}
+
+//compareFilter compares an attribute with a value, the value is nil for the pr operator
+type compareFilter struct {
+	path     string
+	operator string
+	value    interface{}
+}
+
+func (f compareFilter) matches(resource map[string]interface{}) bool {
+	values := attributeValues(resource, f.path)
+	switch f.operator {
+	case "pr":
+		for _, value := range values {
+			if value != nil && value != "" {
+				return true
+			}
+		}
+		return false
+	case "ne":
+		return !(compareFilter{path: f.path, operator: "eq", value: f.value}).matches(resource)
+	}
+	for _, value := range values {
+		if compare(value, f.operator, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+//valuePathFilter matches if an element of a multi-valued attribute matches the inner filter
+type valuePathFilter struct {
+	path  string
+	inner filter
+}
+
+func (f valuePathFilter) matches(resource map[string]interface{}) bool {
+	for _, element := range elements(resource, f.path) {
+		if f.inner.matches(element) {
+			return true
+		}
+	}
+	return false
+}
+
+//compare compares an attribute value with a filter value, strings are compared case insensitive
+func compare(value interface{}, operator string, filterValue interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		fv, ok := filterValue.(string)
+		if !ok {
+			return false
+		}
+		v, fv = strings.ToLower(v), strings.ToLower(fv)
+		switch operator {
+		case "eq":
+			return v == fv
+		case "co":
+			return strings.Contains(v, fv)
+		case "sw":
+			return strings.HasPrefix(v, fv)
+		case "ew":
+			return strings.HasSuffix(v, fv)
+		case "gt":
+			return v > fv
+		case "ge":
+			return v >= fv
+		case "lt":
+			return v < fv
+		case "le":
+			return v <= fv
+		}
+	case float64:
+		fv, ok := filterValue.(float64)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "eq":
+			return v == fv
+		case "gt":
+			return v > fv
+		case "ge":
+			return v >= fv
+		case "lt":
+			return v < fv
+		case "le":
+			return v <= fv
+		}
+	default:
+		return operator == "eq" && value == filterValue
+	}
+	return false
+}
+
+//lookup gets an attribute of a resource, attribute names are case insensitive
+func lookup(resource map[string]interface{}, name string) (key string, value interface{}, found bool) {
+	for key, value = range resource {
+		if strings.EqualFold(key, name) {
+			found = true
+			return
+		}
+	}
+	key = name
+	value = nil
+	return
+}
+
+//attributeValues gets the values of an attribute path, multi-valued attributes are flattened.
+// A complex multi-valued attribute without sub-attribute stands for its value sub-attributes.
+func attributeValues(resource map[string]interface{}, path string) (values []interface{}) {
+	current := []interface{}{resource}
+	for _, name := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, item := range current {
+			if complex, ok := item.(map[string]interface{}); ok {
+				_, value, _ := lookup(complex, name)
+				if multi, ok := value.([]interface{}); ok {
+					next = append(next, multi...)
+				} else if value != nil {
+					next = append(next, value)
+				}
+			}
+		}
+		current = next
+	}
+	for _, item := range current {
+		if complex, ok := item.(map[string]interface{}); ok {
+			_, value, _ := lookup(complex, "value")
+			values = append(values, value)
+		} else {
+			values = append(values, item)
+		}
+	}
+	return
+}
+
+//elements gets the elements of a complex multi-valued attribute
+func elements(resource map[string]interface{}, path string) (result []map[string]interface{}) {
+	_, value, _ := lookup(resource, path)
+	multi, _ := value.([]interface{})
+	for _, item := range multi {
+		if element, ok := item.(map[string]interface{}); ok {
+			result = append(result, element)
+		}
+	}
+	return
+}
+
+//parseFilter parses a SCIM filter expression
+func parseFilter(expression string) (f filter, err error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return
+	}
+	p := &filterParser{tokens: tokens}
+	if f, err = p.parseOr(); err != nil {
+		return
+	}
+	if p.position != len(p.tokens) {
+		err = ErrInvalidFilter
+	}
+	return
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+//tokenize splits a filter in words, json strings, parentheses and brackets
+func tokenize(expression string) (tokens []token, err error) {
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == '[' || r == ']':
+			tokens = append(tokens, token{text: string(r)})
+			i++
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, ErrInvalidFilter
+			}
+			var value string
+			if err = json.Unmarshal([]byte(string(runes[i:j+1])), &value); err != nil {
+				return nil, ErrInvalidFilter
+			}
+			tokens = append(tokens, token{text: value, quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("()[]\"", runes[j]); j++ {
+			}
+			tokens = append(tokens, token{text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return
+}
+
+type filterParser struct {
+	tokens   []token
+	position int
+}
+
+func (p *filterParser) peek() (t token, ok bool) {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position], true
+	}
+	return
+}
+
+func (p *filterParser) next() (t token, ok bool) {
+	t, ok = p.peek()
+	if ok {
+		p.position++
+	}
+	return
+}
+
+//isKeyword checks if the next token is an unquoted keyword
+func (p *filterParser) isKeyword(keyword string) bool {
+	t, ok := p.peek()
+	return ok && !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) expect(text string) error {
+	if t, ok := p.next(); !ok || t.quoted || t.text != text {
+		return ErrInvalidFilter
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (f filter, err error) {
+	if f, err = p.parseAnd(); err != nil {
+		return
+	}
+	for p.isKeyword("or") {
+		p.position++
+		var right filter
+		if right, err = p.parseAnd(); err != nil {
+			return
+		}
+		f = orFilter{left: f, right: right}
+	}
+	return
+}
+
+func (p *filterParser) parseAnd() (f filter, err error) {
+	if f, err = p.parseNot(); err != nil {
+		return
+	}
+	for p.isKeyword("and") {
+		p.position++
+		var right filter
+		if right, err = p.parseNot(); err != nil {
+			return
+		}
+		f = andFilter{left: f, right: right}
+	}
+	return
+}
+
+func (p *filterParser) parseNot() (f filter, err error) {
+	if p.isKeyword("not") {
+		p.position++
+		if f, err = p.parseGroup(); err != nil {
+			return
+		}
+		return notFilter{inner: f}, nil
+	}
+	if p.isKeyword("(") {
+		return p.parseGroup()
+	}
+	return p.parseAttributeExpression()
+}
+
+func (p *filterParser) parseGroup() (f filter, err error) {
+	if err = p.expect("("); err != nil {
+		return
+	}
+	if f, err = p.parseOr(); err != nil {
+		return
+	}
+	err = p.expect(")")
+	return
+}
+
+func (p *filterParser) parseAttributeExpression() (f filter, err error) {
+	t, ok := p.next()
+	if !ok || t.quoted || !isAttributePath(t.text) {
+		return nil, ErrInvalidFilter
+	}
+	path := stripSchema(t.text)
+	if p.isKeyword("[") {
+		p.position++
+		var inner filter
+		if inner, err = p.parseOr(); err != nil {
+			return
+		}
+		if err = p.expect("]"); err != nil {
+			return
+		}
+		return valuePathFilter{path: path, inner: inner}, nil
+	}
+	operator, ok := p.next()
+	if !ok || operator.quoted {
+		return nil, ErrInvalidFilter
+	}
+	op := strings.ToLower(operator.text)
+	if op == "pr" {
+		return compareFilter{path: path, operator: op}, nil
+	}
+	switch op {
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, ErrInvalidFilter
+	}
+	valueToken, ok := p.next()
+	if !ok {
+		return nil, ErrInvalidFilter
+	}
+	value, err := parseValue(valueToken)
+	if err != nil {
+		return
+	}
+	return compareFilter{path: path, operator: op, value: value}, nil
+}
+
+//parseValue converts a comparison value to the type json decoding gives it
+func parseValue(t token) (value interface{}, err error) {
+	if t.quoted {
+		return t.text, nil
+	}
+	switch t.text {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, ErrInvalidFilter
+	}
+	return number, nil
+}
+
+func isAttributePath(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(".:_-$", r) {
+			return false
+		}
+	}
+	return true
+}
+
+//stripSchema removes the core schema urn from a fully qualified attribute path
+func stripSchema(path string) string {
+	for _, schema := range []string{userSchema, groupSchema} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+			return path[len(schema)+1:]
+		}
+	}
+	return path
+}
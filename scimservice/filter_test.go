@@ -0,0 +1,53 @@
+package scimservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itsyouonline/identityserver/db/user"
+)
+
+func newTestUser() map[string]interface{} {
+	u := &user.User{
+		Username:       "jane",
+		Firstname:      "Jane",
+		Lastname:       "Doe",
+		EmailAddresses: []user.EmailAddress{{Label: "work", EmailAddress: "Jane@acme.com"}, {Label: "home", EmailAddress: "jane@home.com"}},
+		Phonenumbers:   []user.Phonenumber{{Label: "main", Phonenumber: "+3212345678"}},
+	}
+	return toMap(newUser(u, "E123", []string{"acme"}, "https://itsyou.online/scim/v2/Users/jane"))
+}
+
+func TestFilter(t *testing.T) {
+	resource := newTestUser()
+	cases := map[string]bool{
+		`userName eq "jane"`: true,
+		`USERNAME eq "JANE"`: true,
+		`userName eq "john"`: false,
+		`userName ne "john"`: true,
+		`name.familyName sw "D" and name.givenName ew "ne"`:                true,
+		`name.familyName co "x" or externalId eq "E123"`:                   true,
+		`not (externalId eq "E123")`:                                       false,
+		`emails co "@acme.com"`:                                            true,
+		`emails.value eq "jane@home.com"`:                                  true,
+		`emails[type eq "work" and value co "acme"]`:                       true,
+		`emails[type eq "home" and value co "acme"]`:                       false,
+		`phoneNumbers pr`:                                                  true,
+		`addresses pr`:                                                     false,
+		`active eq true`:                                                   true,
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jane"`:    true,
+		`groups.value eq "acme" and (userName gt "a" and userName lt "k")`: true,
+	}
+	for expression, expected := range cases {
+		f, err := parseFilter(expression)
+		if assert.NoError(t, err, expression) {
+			assert.Equal(t, expected, f.matches(resource), expression)
+		}
+	}
+
+	for _, expression := range []string{``, `userName`, `userName eq`, `userName xx "jane"`, `(userName eq "jane"`, `userName eq "jane`, `userName eq jane`, `emails[type eq "work"`} {
+		_, err := parseFilter(expression)
+		assert.Equal(t, ErrInvalidFilter, err, expression)
+	}
+}
@@ -0,0 +1,191 @@
+package scimservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+)
+
+//ListGroups is the handler for GET /scim/v2/Groups
+// Lists the organization and its suborganizations
+func (service *Service) ListGroups(w http.ResponseWriter, r *http.Request) {
+	tree, err := getTree(r)
+	if handleServerError(w, "getting the organization tree", err) {
+		return
+	}
+	resources := make([]interface{}, 0, len(tree))
+	for i := range tree {
+		resources = append(resources, newGroup(&tree[i], location(r, "Groups", tree[i].Globalid)))
+	}
+	writeList(w, r, resources)
+}
+
+//GetGroup is the handler for GET /scim/v2/Groups/{id}
+func (service *Service) GetGroup(w http.ResponseWriter, r *http.Request) {
+	_, org, ok := loadGroup(w, r)
+	if !ok {
+		return
+	}
+	group := newGroup(org, location(r, "Groups", org.Globalid))
+	writeResource(w, r, http.StatusOK, group, group.Meta)
+}
+
+//ReplaceGroup is the handler for PUT /scim/v2/Groups/{id}
+func (service *Service) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	tree, org, ok := loadGroupForUpdate(w, r)
+	if !ok {
+		return
+	}
+	resource := &Group{}
+	if err := decodeResource(r, resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", "Invalid group")
+		return
+	}
+	updateGroup(w, r, tree, org, resource)
+}
+
+//PatchGroup is the handler for PATCH /scim/v2/Groups/{id}
+func (service *Service) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	tree, org, ok := loadGroupForUpdate(w, r)
+	if !ok {
+		return
+	}
+	patch := &PatchRequest{}
+	if err := decodeResource(r, patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", "Invalid patch request")
+		return
+	}
+	m := toMap(newGroup(org, ""))
+	if err := applyPatch(m, patch.Operations); err != nil {
+		writePatchError(w, err)
+		return
+	}
+	resource := &Group{}
+	if err := fromMap(m, resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", "Invalid group")
+		return
+	}
+	updateGroup(w, r, tree, org, resource)
+}
+
+//loadGroup gets the organization of the request if it is part of the tree
+func loadGroup(w http.ResponseWriter, r *http.Request) (tree []organization.Organization, org *organization.Organization, ok bool) {
+	tree, err := getTree(r)
+	if handleServerError(w, "getting the organization tree", err) {
+		return
+	}
+	globalid := mux.Vars(r)["id"]
+	for i := range tree {
+		if tree[i].Globalid == globalid {
+			return tree, &tree[i], true
+		}
+	}
+	writeError(w, http.StatusNotFound, "", "Group not found")
+	return
+}
+
+//loadGroupForUpdate gets the organization of the request and checks the If-Match header
+func loadGroupForUpdate(w http.ResponseWriter, r *http.Request) (tree []organization.Organization, org *organization.Organization, ok bool) {
+	if tree, org, ok = loadGroup(w, r); !ok {
+		return
+	}
+	ok = checkPrecondition(w, r, newGroup(org, "").Meta.Version)
+	return
+}
+
+//updateGroup makes the members of the organization match the members of the group.
+// Users that are not in the tree yet get an invitation instead of being added directly.
+func updateGroup(w http.ResponseWriter, r *http.Request, tree []organization.Organization, org *organization.Organization, resource *Group) {
+	if resource.DisplayName != "" && resource.DisplayName != org.Globalid {
+		writeError(w, http.StatusBadRequest, "mutability", "The displayName can not be changed")
+		return
+	}
+	wanted := map[string]bool{}
+	for _, member := range resource.Members {
+		wanted[member.Value] = true
+	}
+	current := map[string]bool{}
+	for _, username := range groupMembers(org) {
+		current[username] = true
+	}
+	owners := 0
+	for _, owner := range org.Owners {
+		if wanted[owner] {
+			owners++
+		}
+	}
+	if owners == 0 && len(org.Owners) > 0 {
+		writeError(w, http.StatusBadRequest, "mutability", "The last owner of "+org.Globalid+" can not be removed")
+		return
+	}
+
+	userMgr := user.NewManager(r)
+	for _, member := range resource.Members {
+		if current[member.Value] {
+			continue
+		}
+		exists, err := userMgr.Exists(member.Value)
+		if handleServerError(w, "checking if the user exists", err) {
+			return
+		}
+		if !exists {
+			writeError(w, http.StatusBadRequest, "invalidValue", "Unknown user: "+member.Value)
+			return
+		}
+	}
+
+	orgMgr := organization.NewManager(r)
+	invitationMgr := invitations.NewInvitationManager(r)
+	inTree := memberships(tree)
+	for _, member := range resource.Members {
+		username := member.Value
+		if current[username] {
+			continue
+		}
+		current[username] = true
+		if len(inTree[username]) > 0 {
+			if handleServerError(w, "adding the member", orgMgr.SaveMember(org, username)) {
+				return
+			}
+			org.Members = append(org.Members, username)
+			continue
+		}
+		invite := &invitations.JoinOrganizationInvitation{
+			Organization: org.Globalid,
+			Role:         invitations.RoleMember,
+			User:         username,
+			Status:       invitations.RequestPending,
+			Created:      db.DateTime(time.Now()),
+		}
+		if handleServerError(w, "inviting the member", invitationMgr.Save(invite)) {
+			return
+		}
+	}
+	for _, username := range groupMembers(org) {
+		if wanted[username] {
+			continue
+		}
+		if handleServerError(w, "removing the member", orgMgr.RemoveUser(org.Globalid, username)) {
+			return
+		}
+		org.Members = removeString(org.Members, username)
+		org.Owners = removeString(org.Owners, username)
+	}
+	group := newGroup(org, location(r, "Groups", org.Globalid))
+	writeResource(w, r, http.StatusOK, group, group.Meta)
+}
+
+func removeString(values []string, value string) (result []string) {
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return
+}
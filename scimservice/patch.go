@@ -0,0 +1,252 @@
+package scimservice
+
+import (
+	"strconv"
+	"strings"
+)
+
+//patchError is returned when a patch operation can not be applied, the scimType tells what is wrong
+type patchError struct {
+	scimType string
+	detail   string
+}
+
+func (e *patchError) Error() string {
+	return e.detail
+}
+
+//patchPath is a parsed PATCH path: attribute[filter].subAttribute
+type patchPath struct {
+	attribute    string
+	filter       filter
+	rawFilter    string
+	subAttribute string
+}
+
+func parsePatchPath(path string) (p *patchPath, err error) {
+	path = stripSchema(strings.TrimSpace(path))
+	p = &patchPath{}
+	if open := strings.Index(path, "["); open >= 0 {
+		close := strings.LastIndex(path, "]")
+		if close < open {
+			return nil, &patchError{scimType: "invalidPath", detail: "Invalid path: " + path}
+		}
+		p.rawFilter = path[open+1 : close]
+		if p.filter, err = parseFilter(p.rawFilter); err != nil {
+			return nil, &patchError{scimType: "invalidFilter", detail: "Invalid filter in path: " + path}
+		}
+		rest := path[close+1:]
+		if rest != "" {
+			if !strings.HasPrefix(rest, ".") {
+				return nil, &patchError{scimType: "invalidPath", detail: "Invalid path: " + path}
+			}
+			p.subAttribute = rest[1:]
+		}
+		path = path[:open]
+	} else if dot := strings.Index(path, "."); dot >= 0 {
+		p.subAttribute = path[dot+1:]
+		path = path[:dot]
+	}
+	if !isAttributePath(path) || strings.Contains(p.subAttribute, ".") {
+		return nil, &patchError{scimType: "invalidPath", detail: "Invalid path: " + path}
+	}
+	p.attribute = path
+	return
+}
+
+//applyPatch applies the operations of a PATCH request to the generic json representation of a resource
+func applyPatch(resource map[string]interface{}, operations []PatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if operation.Path == "" {
+			values, ok := operation.Value.(map[string]interface{})
+			if op == "remove" || !ok {
+				return &patchError{scimType: "noTarget", detail: "A path is required"}
+			}
+			for name, value := range values {
+				path, err := parsePatchPath(name)
+				if err != nil {
+					return err
+				}
+				if err = applyOperation(resource, op, path, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		path, err := parsePatchPath(operation.Path)
+		if err != nil {
+			return err
+		}
+		if err = applyOperation(resource, op, path, operation.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]interface{}, op string, path *patchPath, value interface{}) error {
+	if op != "add" && op != "replace" && op != "remove" {
+		return &patchError{scimType: "invalidSyntax", detail: "Unsupported operation: " + op}
+	}
+	value = normalizeBoolean(path.attribute, value)
+	key, current, _ := lookup(resource, path.attribute)
+
+	if path.filter != nil {
+		return applyFilteredOperation(resource, key, op, path, value)
+	}
+	if path.subAttribute != "" {
+		complex, _ := current.(map[string]interface{})
+		if complex == nil {
+			if op == "remove" {
+				return nil
+			}
+			complex = map[string]interface{}{}
+			resource[key] = complex
+		}
+		subKey, _, _ := lookup(complex, path.subAttribute)
+		if op == "remove" {
+			delete(complex, subKey)
+		} else {
+			complex[subKey] = value
+		}
+		return nil
+	}
+
+	switch op {
+	case "remove":
+		if multi, isMulti := current.([]interface{}); isMulti && value != nil {
+			//Some clients list the values to remove instead of using a filter
+			resource[key] = removeValues(multi, value)
+			return nil
+		}
+		delete(resource, key)
+	case "add":
+		if multi, isMulti := current.([]interface{}); isMulti {
+			if values, ok := value.([]interface{}); ok {
+				resource[key] = append(multi, values...)
+			} else {
+				resource[key] = append(multi, value)
+			}
+			return nil
+		}
+		if complex, isComplex := current.(map[string]interface{}); isComplex {
+			if values, ok := value.(map[string]interface{}); ok {
+				for name, v := range values {
+					subKey, _, _ := lookup(complex, name)
+					complex[subKey] = v
+				}
+				return nil
+			}
+		}
+		resource[key] = value
+	case "replace":
+		resource[key] = value
+	}
+	return nil
+}
+
+//applyFilteredOperation applies an operation to the elements of a multi-valued attribute that match the filter of the path
+func applyFilteredOperation(resource map[string]interface{}, key string, op string, path *patchPath, value interface{}) error {
+	_, current, _ := lookup(resource, key)
+	multi, _ := current.([]interface{})
+	var kept []interface{}
+	matched := false
+	for _, item := range multi {
+		element, ok := item.(map[string]interface{})
+		if !ok || !path.filter.matches(element) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+		switch {
+		case op == "remove" && path.subAttribute == "":
+			continue
+		case op == "remove":
+			subKey, _, _ := lookup(element, path.subAttribute)
+			delete(element, subKey)
+		case path.subAttribute != "":
+			subKey, _, _ := lookup(element, path.subAttribute)
+			element[subKey] = value
+		default:
+			values, ok := value.(map[string]interface{})
+			if !ok {
+				return &patchError{scimType: "invalidValue", detail: "A complex value is required"}
+			}
+			for name, v := range values {
+				subKey, _, _ := lookup(element, name)
+				element[subKey] = v
+			}
+		}
+		kept = append(kept, element)
+	}
+	if !matched && op != "remove" {
+		//Setting a value for a type that is not there yet adds it, like emails[type eq "work"].value
+		element, ok := newElementFor(path.filter)
+		if !ok {
+			return &patchError{scimType: "noTarget", detail: "No values match the filter " + path.rawFilter}
+		}
+		if path.subAttribute != "" {
+			element[path.subAttribute] = value
+		} else if values, ok := value.(map[string]interface{}); ok {
+			for name, v := range values {
+				element[name] = v
+			}
+		}
+		kept = append(kept, element)
+	}
+	if kept == nil {
+		delete(resource, key)
+	} else {
+		resource[key] = kept
+	}
+	return nil
+}
+
+//newElementFor creates the element a simple equality filter would match
+func newElementFor(f filter) (element map[string]interface{}, ok bool) {
+	compare, ok := f.(compareFilter)
+	if !ok || compare.operator != "eq" || strings.Contains(compare.path, ".") {
+		return nil, false
+	}
+	return map[string]interface{}{compare.path: compare.value}, true
+}
+
+//normalizeBoolean converts the "True" and "False" strings some provisioning clients send for the active attribute
+func normalizeBoolean(attribute string, value interface{}) interface{} {
+	if s, ok := value.(string); ok && strings.EqualFold(attribute, "active") {
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+//removeValues removes the elements with the same value sub-attribute as the given values
+func removeValues(multi []interface{}, value interface{}) (kept []interface{}) {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	remove := map[string]bool{}
+	for _, v := range values {
+		if complex, ok := v.(map[string]interface{}); ok {
+			_, v, _ = lookup(complex, "value")
+		}
+		if s, ok := v.(string); ok {
+			remove[s] = true
+		}
+	}
+	kept = []interface{}{}
+	for _, item := range multi {
+		v := item
+		if complex, ok := item.(map[string]interface{}); ok {
+			_, v, _ = lookup(complex, "value")
+		}
+		if s, ok := v.(string); ok && remove[s] {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return
+}
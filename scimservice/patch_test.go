@@ -0,0 +1,145 @@
+package scimservice
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/user"
+)
+
+func patchUser(t *testing.T, operations string) (*User, error) {
+	request := &PatchRequest{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"Operations":`+operations+`}`), request))
+	resource := newTestUser()
+	if err := applyPatch(resource, request.Operations); err != nil {
+		return nil, err
+	}
+	patched := &User{}
+	assert.NoError(t, fromMap(resource, patched))
+	return patched, nil
+}
+
+func TestPatchUser(t *testing.T) {
+	patched, err := patchUser(t, `[
+		{"op": "replace", "path": "name.givenName", "value": "Janet"},
+		{"op": "Replace", "path": "emails[type eq \"work\"].value", "value": "janet@acme.com"},
+		{"op": "add", "path": "phoneNumbers", "value": [{"value": "+3287654321", "type": "mobile"}]},
+		{"op": "remove", "path": "emails[type eq \"home\"]"},
+		{"op": "replace", "value": {"externalId": "E456", "name.familyName": "Smith"}}
+	]`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Janet", patched.Name.GivenName)
+	assert.Equal(t, "Smith", patched.Name.FamilyName)
+	assert.Equal(t, "E456", patched.ExternalID)
+	assert.Equal(t, []MultiValued{{Value: "janet@acme.com", Type: "work", Primary: true}}, patched.Emails)
+	assert.Len(t, patched.PhoneNumbers, 2)
+
+	//A value for a type that is not there yet is added
+	patched, err = patchUser(t, `[{"op": "add", "path": "addresses[type eq \"work\"].locality", "value": "Ghent"}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Address{{Type: "work", Locality: "Ghent"}}, patched.Addresses)
+
+	patched, err = patchUser(t, `[{"op": "replace", "path": "active", "value": "False"}]`)
+	assert.NoError(t, err)
+	assert.False(t, *patched.Active)
+
+	_, err = patchUser(t, `[{"op": "remove"}]`)
+	assert.Equal(t, "noTarget", err.(*patchError).scimType)
+	_, err = patchUser(t, `[{"op": "move", "path": "userName"}]`)
+	assert.Equal(t, "invalidSyntax", err.(*patchError).scimType)
+	_, err = patchUser(t, `[{"op": "replace", "path": "emails[type xx \"work\"].value", "value": "x"}]`)
+	assert.Equal(t, "invalidFilter", err.(*patchError).scimType)
+	_, err = patchUser(t, `[{"op": "replace", "path": "emails[type co \"w\"].value", "value": "x"}, {"op": "replace", "path": "emails[type co \"x\"].value", "value": "x"}]`)
+	assert.Equal(t, "noTarget", err.(*patchError).scimType)
+}
+
+func TestPatchGroupMembers(t *testing.T) {
+	org := &organization.Organization{Globalid: "acme", Owners: []string{"root"}, Members: []string{"jane", "john"}}
+	resource := toMap(newGroup(org, ""))
+	request := &PatchRequest{}
+	json.Unmarshal([]byte(`{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "bob"}]},
+		{"op": "remove", "path": "members[value eq \"jane\"]"},
+		{"op": "remove", "path": "members", "value": [{"value": "john"}]}
+	]}`), request)
+	assert.NoError(t, applyPatch(resource, request.Operations))
+	group := &Group{}
+	assert.NoError(t, fromMap(resource, group))
+	var members []string
+	for _, member := range group.Members {
+		members = append(members, member.Value)
+	}
+	assert.Equal(t, []string{"root", "bob"}, members)
+}
+
+func TestApplyUser(t *testing.T) {
+	active := true
+	resource := &User{
+		UserName: "jane",
+		Name:     &Name{GivenName: "Jane", FamilyName: "Doe"},
+		Active:   &active,
+		Emails: []MultiValued{
+			{Value: "jane@home.com", Type: "home"},
+			{Value: "jane@acme.com", Type: "work", Primary: true},
+			{Value: "doe@acme.com", Type: "work"},
+		},
+		PhoneNumbers: []MultiValued{{Value: "+32 (9) 223-45.67"}},
+		Addresses:    []Address{{StreetAddress: "Main street 1", Locality: "Ghent", Country: "Belgium"}},
+	}
+	u := &user.User{Username: "jane"}
+	assert.NoError(t, applyUser(resource, u))
+	assert.Equal(t, "Jane", u.Firstname)
+	assert.Equal(t, []user.EmailAddress{
+		{Label: "work", EmailAddress: "jane@acme.com"},
+		{Label: "home", EmailAddress: "jane@home.com"},
+		{Label: "work2", EmailAddress: "doe@acme.com"},
+	}, u.EmailAddresses)
+	assert.Equal(t, []user.Phonenumber{{Label: "main", Phonenumber: "+3292234567"}}, u.Phonenumbers)
+	assert.Equal(t, []user.Address{{Label: "main", Street: "Main street 1", City: "Ghent", Country: "Belgium"}}, u.Addresses)
+
+	//The version changes with the attributes
+	before := newUser(u, "", []string{"acme"}, "").Meta.Version
+	u.Lastname = "Smith"
+	assert.NotEqual(t, before, newUser(u, "", []string{"acme"}, "").Meta.Version)
+
+	resource.PhoneNumbers = []MultiValued{{Value: "0912345"}}
+	assert.Error(t, applyUser(resource, u))
+}
+
+func TestVisibleProfile(t *testing.T) {
+	u := &user.User{
+		Username:       "alice",
+		Firstname:      "Alice",
+		EmailAddresses: []user.EmailAddress{{Label: "home", EmailAddress: "alice@example.com"}},
+		Phonenumbers:   []user.Phonenumber{{Label: "mobile", Phonenumber: "+32123456789"}},
+	}
+	//Users the organization provisioned are shown completely
+	resource := newUser(visibleProfile(u, true, nil), "", []string{"acme"}, "")
+	assert.Equal(t, "Alice", resource.Name.GivenName)
+	assert.Len(t, resource.Emails, 1)
+	assert.Len(t, resource.PhoneNumbers, 1)
+
+	resource = newUser(visibleProfile(u, false, nil), "", []string{"acme"}, "")
+	assert.Nil(t, resource.Name)
+	assert.Empty(t, resource.Emails)
+	assert.Empty(t, resource.PhoneNumbers)
+
+	authorization := &user.Authorization{EmailAddresses: []user.AuthorizationMap{{RealLabel: "home", RequestedLabel: "main"}}}
+	resource = newUser(visibleProfile(u, false, authorization), "", []string{"acme"}, "")
+	assert.Nil(t, resource.Name)
+	assert.Equal(t, []MultiValued{{Value: "alice@example.com", Type: "main", Primary: true}}, resource.Emails)
+	assert.Empty(t, resource.PhoneNumbers)
+}
+
+func TestNewTree(t *testing.T) {
+	root := &organization.Organization{Globalid: "acm.x"}
+	tree := newTree(root, []organization.Organization{{Globalid: "acm.x.sales"}, {Globalid: "acmex.dev"}, {Globalid: "acm.x.dev"}})
+	globalids := []string{}
+	for _, org := range tree {
+		globalids = append(globalids, org.Globalid)
+	}
+	assert.Equal(t, []string{"acm.x", "acm.x.dev", "acm.x.sales"}, globalids)
+}
@@ -0,0 +1,302 @@
+package scimservice
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	"github.com/itsyouonline/identityserver/db/user"
+)
+
+const (
+	userSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	errorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+//Meta is the resource metadata, the Version is the weak ETag of the resource
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+//Name is the name of a user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+//MultiValued is an element of a multi-valued attribute like emails, phoneNumbers, groups or members
+type MultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+//Address is a physical mailing address of a user
+type Address struct {
+	Type          string `json:"type,omitempty"`
+	StreetAddress string `json:"streetAddress,omitempty"`
+	Locality      string `json:"locality,omitempty"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty"`
+	Country       string `json:"country,omitempty"`
+	Primary       bool   `json:"primary,omitempty"`
+}
+
+//User is the SCIM representation of an itsyou.online user, the id is the username
+type User struct {
+	Schemas      []string      `json:"schemas"`
+	ID           string        `json:"id"`
+	ExternalID   string        `json:"externalId,omitempty"`
+	UserName     string        `json:"userName"`
+	Name         *Name         `json:"name,omitempty"`
+	DisplayName  string        `json:"displayName,omitempty"`
+	Active       *bool         `json:"active,omitempty"`
+	Emails       []MultiValued `json:"emails,omitempty"`
+	PhoneNumbers []MultiValued `json:"phoneNumbers,omitempty"`
+	Addresses    []Address     `json:"addresses,omitempty"`
+	Groups       []MultiValued `json:"groups,omitempty"`
+	Meta         *Meta         `json:"meta,omitempty"`
+}
+
+//Group is the SCIM representation of an organization, the id is the globalid
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id"`
+	DisplayName string        `json:"displayName"`
+	Members     []MultiValued `json:"members"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+//ListResponse is a page of query results
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+//Error is the SCIM error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+//PatchOperation is a single operation of a PATCH request
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+//PatchRequest is the body of a PATCH request
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+//newUser converts a user, the groups are the organizations of the tree the user is member or owner of
+func newUser(u *user.User, externalID string, groups []string, location string) *User {
+	active := len(groups) > 0
+	resource := &User{
+		Schemas:    []string{userSchema},
+		ID:         u.Username,
+		ExternalID: externalID,
+		UserName:   u.Username,
+		Active:     &active,
+	}
+	if u.Firstname != "" || u.Lastname != "" {
+		resource.Name = &Name{
+			Formatted:  strings.TrimSpace(u.Firstname + " " + u.Lastname),
+			GivenName:  u.Firstname,
+			FamilyName: u.Lastname,
+		}
+		resource.DisplayName = resource.Name.Formatted
+	}
+	for i, email := range u.EmailAddresses {
+		resource.Emails = append(resource.Emails, MultiValued{Value: email.EmailAddress, Type: email.Label, Primary: i == 0})
+	}
+	for i, phonenumber := range u.Phonenumbers {
+		resource.PhoneNumbers = append(resource.PhoneNumbers, MultiValued{Value: phonenumber.Phonenumber, Type: phonenumber.Label, Primary: i == 0})
+	}
+	for i, address := range u.Addresses {
+		resource.Addresses = append(resource.Addresses, Address{
+			Type:          address.Label,
+			StreetAddress: strings.TrimSpace(address.Street + " " + address.Nr),
+			Locality:      address.City,
+			Region:        address.Other,
+			PostalCode:    address.Postalcode,
+			Country:       address.Country,
+			Primary:       i == 0,
+		})
+	}
+	for _, globalid := range groups {
+		resource.Groups = append(resource.Groups, MultiValued{Value: globalid, Display: globalid})
+	}
+	resource.Meta = &Meta{ResourceType: "User", Location: location, Version: version(resource)}
+	return resource
+}
+
+//newGroup converts an organization, the owners are members of the group as well
+func newGroup(org *organization.Organization, location string) *Group {
+	resource := &Group{
+		Schemas:     []string{groupSchema},
+		ID:          org.Globalid,
+		DisplayName: org.Globalid,
+		Members:     []MultiValued{},
+	}
+	for _, username := range groupMembers(org) {
+		resource.Members = append(resource.Members, MultiValued{Value: username, Type: "User"})
+	}
+	resource.Meta = &Meta{ResourceType: "Group", Location: location, Version: version(resource)}
+	return resource
+}
+
+//groupMembers lists the owners and members of an organization once
+func groupMembers(org *organization.Organization) (usernames []string) {
+	seen := map[string]bool{}
+	for _, username := range append(append([]string{}, org.Owners...), org.Members...) {
+		if !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	return
+}
+
+//version calculates the weak ETag of a resource from its json representation without metadata
+func version(resource interface{}) string {
+	content, _ := json.Marshal(resource)
+	hash := sha256.Sum256(content)
+	return fmt.Sprintf(`W/"%s"`, hex.EncodeToString(hash[:8]))
+}
+
+//toMap converts a resource to its generic json representation for filtering and patching
+func toMap(resource interface{}) (m map[string]interface{}) {
+	content, _ := json.Marshal(resource)
+	json.Unmarshal(content, &m)
+	return
+}
+
+//fromMap converts a generic json representation back to a resource
+func fromMap(m map[string]interface{}, resource interface{}) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, resource)
+}
+
+//labels turns the types of multi-valued attributes into unique itsyou.online labels
+func labels(types []string) (result []string) {
+	used := map[string]bool{}
+	for _, t := range types {
+		base := strings.TrimSpace(t)
+		if len(base) < 2 {
+			base = "main"
+		}
+		if len(base) > 40 {
+			base = base[:40]
+		}
+		label := base
+		for i := 2; used[label]; i++ {
+			label = fmt.Sprintf("%s%d", base, i)
+		}
+		used[label] = true
+		result = append(result, label)
+	}
+	return
+}
+
+//invalidValueError is returned when a resource can not be stored
+type invalidValueError string
+
+func (e invalidValueError) Error() string {
+	return string(e)
+}
+
+//applyUser copies the attributes of a SCIM user to an itsyou.online user, primary values come first
+func applyUser(resource *User, u *user.User) error {
+	if resource.Name != nil {
+		u.Firstname = resource.Name.GivenName
+		u.Lastname = resource.Name.FamilyName
+	} else {
+		u.Firstname, u.Lastname = "", ""
+	}
+
+	emails := primaryFirst(resource.Emails)
+	types := make([]string, len(emails))
+	for i, email := range emails {
+		types[i] = email.Type
+	}
+	u.EmailAddresses = []user.EmailAddress{}
+	for i, label := range labels(types) {
+		if !strings.Contains(emails[i].Value, "@") {
+			return invalidValueError("Invalid email address: " + emails[i].Value)
+		}
+		u.EmailAddresses = append(u.EmailAddresses, user.EmailAddress{Label: label, EmailAddress: strings.TrimSpace(emails[i].Value)})
+	}
+
+	phonenumbers := primaryFirst(resource.PhoneNumbers)
+	types = make([]string, len(phonenumbers))
+	for i, phonenumber := range phonenumbers {
+		types[i] = phonenumber.Type
+	}
+	u.Phonenumbers = []user.Phonenumber{}
+	for i, label := range labels(types) {
+		phonenumber := user.Phonenumber{Label: label, Phonenumber: phonenumbers[i].Value}
+		phonenumber.Normalize("")
+		if !phonenumber.IsValid() {
+			return invalidValueError("Invalid phone number, the international format is required: " + phonenumbers[i].Value)
+		}
+		u.Phonenumbers = append(u.Phonenumbers, phonenumber)
+	}
+
+	addresses := append([]Address{}, resource.Addresses...)
+	for i, address := range addresses {
+		if address.Primary && i > 0 {
+			addresses[0], addresses[i] = addresses[i], addresses[0]
+			break
+		}
+	}
+	types = make([]string, len(addresses))
+	for i, address := range addresses {
+		types[i] = address.Type
+	}
+	u.Addresses = []user.Address{}
+	for i, label := range labels(types) {
+		u.Addresses = append(u.Addresses, user.Address{
+			Label:      label,
+			Street:     addresses[i].StreetAddress,
+			City:       addresses[i].Locality,
+			Other:      addresses[i].Region,
+			Postalcode: addresses[i].PostalCode,
+			Country:    addresses[i].Country,
+		})
+	}
+	return nil
+}
+
+//primaryFirst moves the primary value to the front
+func primaryFirst(values []MultiValued) []MultiValued {
+	values = append([]MultiValued{}, values...)
+	for i, value := range values {
+		if value.Primary && i > 0 {
+			values[0], values[i] = values[i], values[0]
+			break
+		}
+	}
+	return values
+}
@@ -0,0 +1,314 @@
+package scimservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/context"
+	"github.com/gorilla/mux"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	scimdb "github.com/itsyouonline/identityserver/db/scim"
+	"github.com/itsyouonline/identityserver/oauthservice"
+)
+
+const (
+	//basePath is where the SCIM endpoints are served
+	basePath = "/scim/v2"
+	//defaultCount is the page size if the client does not ask for one
+	defaultCount = 100
+	//maxCount limits the page size
+	maxCount = 1000
+)
+
+//Service is the SCIM 2.0 provisioning http service
+type Service struct {
+}
+
+//NewService creates and initializes a Service
+func NewService() *Service {
+	return &Service{}
+}
+
+//AddRoutes adds the routes and handlerfunctions to the router
+func (service *Service) AddRoutes(router *mux.Router) {
+	router.Handle(basePath+"/ServiceProviderConfig", service.authenticate(service.GetServiceProviderConfig)).Methods("GET")
+	router.Handle(basePath+"/ResourceTypes", service.authenticate(service.GetResourceTypes)).Methods("GET")
+
+	router.Handle(basePath+"/Users", service.authenticate(service.ListUsers)).Methods("GET")
+	router.Handle(basePath+"/Users", service.authenticate(service.CreateUser)).Methods("POST")
+	router.Handle(basePath+"/Users/{id}", service.authenticate(service.GetUser)).Methods("GET")
+	router.Handle(basePath+"/Users/{id}", service.authenticate(service.ReplaceUser)).Methods("PUT")
+	router.Handle(basePath+"/Users/{id}", service.authenticate(service.PatchUser)).Methods("PATCH")
+	router.Handle(basePath+"/Users/{id}", service.authenticate(service.DeleteUser)).Methods("DELETE")
+
+	router.Handle(basePath+"/Groups", service.authenticate(service.ListGroups)).Methods("GET")
+	router.Handle(basePath+"/Groups/{id}", service.authenticate(service.GetGroup)).Methods("GET")
+	router.Handle(basePath+"/Groups/{id}", service.authenticate(service.ReplaceGroup)).Methods("PUT")
+	router.Handle(basePath+"/Groups/{id}", service.authenticate(service.PatchGroup)).Methods("PATCH")
+
+	scimdb.InitModels()
+}
+
+//authenticate only allows access tokens an organization got with its client credentials,
+// the organization and its suborganizations are the tree that is provisioned
+func (service *Service) authenticate(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+		if len(authorization) != 2 || (!strings.EqualFold(authorization[0], "bearer") && authorization[0] != "token") {
+			writeError(w, http.StatusUnauthorized, "", "An organization access token is required")
+			return
+		}
+		at, err := oauthservice.NewManager(r).GetAccessToken(strings.TrimSpace(authorization[1]))
+		if err != nil {
+			log.Error("Error getting the access token: ", err)
+			writeError(w, http.StatusInternalServerError, "", "")
+			return
+		}
+		if at == nil {
+			writeError(w, http.StatusUnauthorized, "", "Invalid or expired access token")
+			return
+		}
+		if at.Username != "" || at.GlobalID == "" || !hasScope(at.Scope, "organization:owner") {
+			writeError(w, http.StatusForbidden, "", "Only organization client credentials can be used for provisioning")
+			return
+		}
+		context.Set(r, "scimorganization", at.GlobalID)
+		next(w, r)
+	})
+}
+
+func hasScope(scopes string, scope string) bool {
+	for _, s := range strings.FieldsFunc(scopes, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+//getOrganization returns the organization the access token was granted to
+func getOrganization(r *http.Request) string {
+	globalid, _ := context.Get(r, "scimorganization").(string)
+	return globalid
+}
+
+//getTree gets the organization and its suborganizations, sorted by globalid
+func getTree(r *http.Request) (tree []organization.Organization, err error) {
+	globalid := getOrganization(r)
+	orgMgr := organization.NewManager(r)
+	root, err := orgMgr.GetByName(globalid)
+	if err != nil {
+		return
+	}
+	suborganizations, err := orgMgr.GetSubOrganizations(globalid)
+	if err != nil {
+		return
+	}
+	tree = newTree(root, suborganizations)
+	return
+}
+
+//newTree puts an organization and the suborganizations that are really below it in a tree sorted by globalid
+func newTree(root *organization.Organization, suborganizations []organization.Organization) (tree []organization.Organization) {
+	tree = []organization.Organization{*root}
+	for _, suborganization := range suborganizations {
+		if organization.IsDescendant(suborganization.Globalid, root.Globalid) {
+			tree = append(tree, suborganization)
+		}
+	}
+	sort.Sort(byGlobalID(tree))
+	return
+}
+
+//byGlobalID implements sort.Interface for []Organization based on the Globalid field
+type byGlobalID []organization.Organization
+
+func (a byGlobalID) Len() int           { return len(a) }
+func (a byGlobalID) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a byGlobalID) Less(i, j int) bool { return a[i].Globalid < a[j].Globalid }
+
+//memberships maps the usernames in the tree to the organizations they are member or owner of
+func memberships(tree []organization.Organization) map[string][]string {
+	result := map[string][]string{}
+	for i := range tree {
+		for _, username := range groupMembers(&tree[i]) {
+			result[username] = append(result[username], tree[i].Globalid)
+		}
+	}
+	return result
+}
+
+func location(r *http.Request, resourceType string, id string) string {
+	return "https://" + r.Host + basePath + "/" + resourceType + "/" + id
+}
+
+//GetServiceProviderConfig is the handler for GET /scim/v2/ServiceProviderConfig
+func (service *Service) GetServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxCount},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": true},
+		"authenticationSchemes": []map[string]string{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Access token of an organization obtained with the client credentials grant",
+		}},
+	})
+}
+
+//GetResourceTypes is the handler for GET /scim/v2/ResourceTypes
+func (service *Service) GetResourceTypes(w http.ResponseWriter, r *http.Request) {
+	resourceTypes := []interface{}{
+		map[string]interface{}{
+			"schemas":  []string{"urn:ietf:params:scim:schemas:core:2.0:ResourceType"},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   userSchema,
+		},
+		map[string]interface{}{
+			"schemas":  []string{"urn:ietf:params:scim:schemas:core:2.0:ResourceType"},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   groupSchema,
+		},
+	}
+	writeJSON(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
+
+//writeList filters the resources and writes the requested page
+func writeList(w http.ResponseWriter, r *http.Request, resources []interface{}) {
+	query := r.URL.Query()
+	if expression := query.Get("filter"); expression != "" {
+		f, err := parseFilter(expression)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalidFilter", "Invalid filter: "+expression)
+			return
+		}
+		var filtered []interface{}
+		for _, resource := range resources {
+			if f.matches(toMap(resource)) {
+				filtered = append(filtered, resource)
+			}
+		}
+		resources = filtered
+	}
+	startIndex, err := strconv.Atoi(query.Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil {
+		count = defaultCount
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > maxCount {
+		count = maxCount
+	}
+	page := []interface{}{}
+	for i := startIndex - 1; i < len(resources) && len(page) < count; i++ {
+		page = append(page, resources[i])
+	}
+	writeJSON(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	})
+}
+
+//checkPrecondition compares the If-Match header with the current version of a resource
+func checkPrecondition(w http.ResponseWriter, r *http.Request, currentVersion string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+	for _, etag := range strings.Split(ifMatch, ",") {
+		if strings.TrimSpace(etag) == currentVersion {
+			return true
+		}
+	}
+	writeError(w, http.StatusPreconditionFailed, "", "The resource was modified")
+	return false
+}
+
+//writeResource writes a resource with its version as ETag, a GET with a matching If-None-Match gets a 304
+func writeResource(w http.ResponseWriter, r *http.Request, status int, resource interface{}, meta *Meta) {
+	w.Header().Set("ETag", meta.Version)
+	if status == http.StatusCreated {
+		w.Header().Set("Location", meta.Location)
+	}
+	if r.Method == "GET" && r.Header.Get("If-None-Match") == meta.Version {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, status, resource)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, scimType string, detail string) {
+	if detail == "" {
+		detail = http.StatusText(status)
+	}
+	writeJSON(w, status, &Error{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+//handleServerError logs the error and writes a 500 response if there is an error
+func handleServerError(w http.ResponseWriter, actionText string, err error) bool {
+	if err != nil {
+		log.Error("Error while "+actionText, " - ", err)
+		writeError(w, http.StatusInternalServerError, "", "")
+		return true
+	}
+	return false
+}
+
+//decodeResource decodes a request body into a resource, the active attribute is also accepted as a string
+func decodeResource(r *http.Request, resource interface{}) error {
+	m := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		return err
+	}
+	if key, value, found := lookup(m, "active"); found {
+		m[key] = normalizeBoolean("active", value)
+	}
+	return fromMap(m, resource)
+}
+
+//writePatchError writes the response for a failed patch
+func writePatchError(w http.ResponseWriter, err error) {
+	if patchErr, ok := err.(*patchError); ok {
+		writeError(w, http.StatusBadRequest, patchErr.scimType, patchErr.detail)
+		return
+	}
+	writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+}
@@ -0,0 +1,302 @@
+package scimservice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	scimdb "github.com/itsyouonline/identityserver/db/scim"
+	"github.com/itsyouonline/identityserver/db/user"
+)
+
+//provisionedUser holds a user in the tree together with what the organization knows about it
+type provisionedUser struct {
+	user          *user.User
+	groups        []string
+	provisioned   *scimdb.ProvisionedUser
+	authorization *user.Authorization
+	resource      *User
+}
+
+//profile returns the part of the user the organization can see
+func (pu *provisionedUser) profile() *user.User {
+	return visibleProfile(pu.user, pu.provisioned != nil, pu.authorization)
+}
+
+//visibleProfile returns the full profile of a user the organization provisioned itself,
+// of other users only what they authorized the organization to see
+func visibleProfile(u *user.User, provisioned bool, authorization *user.Authorization) *user.User {
+	if provisioned {
+		return u
+	}
+	return authorization.FilterUser(u)
+}
+
+//loadUser gets a user of the tree, nil is returned if the user is not member or owner of one of its organizations
+func loadUser(r *http.Request, tree []organization.Organization, username string) (pu *provisionedUser, err error) {
+	groups := memberships(tree)[username]
+	if len(groups) == 0 {
+		return
+	}
+	userMgr := user.NewManager(r)
+	u, err := userMgr.GetByName(username)
+	if err == mgo.ErrNotFound {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	provisioned, err := scimdb.NewManager(r).GetProvisionedUser(getOrganization(r), username)
+	if err != nil && err != mgo.ErrNotFound {
+		return
+	}
+	authorization, err := userMgr.GetAuthorization(username, getOrganization(r))
+	if err != nil {
+		return
+	}
+	pu = &provisionedUser{user: u, groups: groups, provisioned: provisioned, authorization: authorization}
+	externalID := ""
+	if provisioned != nil {
+		externalID = provisioned.ExternalID
+	}
+	pu.resource = newUser(pu.profile(), externalID, groups, location(r, "Users", username))
+	return
+}
+
+//ListUsers is the handler for GET /scim/v2/Users
+// Lists the members and owners of the organization and its suborganizations
+func (service *Service) ListUsers(w http.ResponseWriter, r *http.Request) {
+	tree, err := getTree(r)
+	if handleServerError(w, "getting the organization tree", err) {
+		return
+	}
+	userGroups := memberships(tree)
+	usernames := make([]string, 0, len(userGroups))
+	for username := range userGroups {
+		usernames = append(usernames, username)
+	}
+	userMgr := user.NewManager(r)
+	users, err := userMgr.GetByNames(usernames)
+	if handleServerError(w, "getting the users", err) {
+		return
+	}
+	provisionedUsers, err := scimdb.NewManager(r).GetProvisionedUsers(getOrganization(r))
+	if handleServerError(w, "getting the provisioned users", err) {
+		return
+	}
+	authorizationList, err := userMgr.GetAuthorizationsGrantedTo(getOrganization(r), usernames)
+	if handleServerError(w, "getting the authorizations", err) {
+		return
+	}
+	externalIDs := map[string]string{}
+	provisioned := map[string]bool{}
+	for _, record := range provisionedUsers {
+		externalIDs[record.Username] = record.ExternalID
+		provisioned[record.Username] = true
+	}
+	authorizations := make(map[string]*user.Authorization, len(authorizationList))
+	for i := range authorizationList {
+		authorizations[authorizationList[i].Username] = &authorizationList[i]
+	}
+	resources := make([]interface{}, 0, len(users))
+	for i := range users {
+		username := users[i].Username
+		profile := visibleProfile(&users[i], provisioned[username], authorizations[username])
+		resources = append(resources, newUser(profile, externalIDs[username], userGroups[username], location(r, "Users", username)))
+	}
+	writeList(w, r, resources)
+}
+
+//GetUser is the handler for GET /scim/v2/Users/{id}
+func (service *Service) GetUser(w http.ResponseWriter, r *http.Request) {
+	tree, err := getTree(r)
+	if handleServerError(w, "getting the organization tree", err) {
+		return
+	}
+	pu, err := loadUser(r, tree, mux.Vars(r)["id"])
+	if handleServerError(w, "getting the user", err) {
+		return
+	}
+	if pu == nil {
+		writeError(w, http.StatusNotFound, "", "User not found")
+		return
+	}
+	writeResource(w, r, http.StatusOK, pu.resource, pu.resource.Meta)
+}
+
+//CreateUser is the handler for POST /scim/v2/Users
+// Creates an itsyou.online user and adds it as member of the organization
+func (service *Service) CreateUser(w http.ResponseWriter, r *http.Request) {
+	resource := &User{}
+	if err := decodeResource(r, resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", "Invalid user")
+		return
+	}
+	if !user.ValidateUsername(resource.UserName) {
+		writeError(w, http.StatusBadRequest, "invalidValue", "The userName can only contain lowercase letters, digits, - and _")
+		return
+	}
+	userMgr := user.NewManager(r)
+	exists, err := userMgr.Exists(resource.UserName)
+	if handleServerError(w, "checking if the user exists", err) {
+		return
+	}
+	if exists {
+		writeError(w, http.StatusConflict, "uniqueness", "The userName is already taken")
+		return
+	}
+	newuser := &user.User{Username: resource.UserName}
+	if err = applyUser(resource, newuser); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	err = userMgr.Save(newuser)
+	if db.IsDup(err) {
+		writeError(w, http.StatusConflict, "uniqueness", "The userName is already taken")
+		return
+	}
+	if handleServerError(w, "creating the user", err) {
+		return
+	}
+	globalid := getOrganization(r)
+	provisioned := &scimdb.ProvisionedUser{
+		Globalid:   globalid,
+		Username:   newuser.Username,
+		ExternalID: resource.ExternalID,
+		Created:    db.DateTime(time.Now()),
+	}
+	if handleServerError(w, "saving the provisioned user", scimdb.NewManager(r).SaveProvisionedUser(provisioned)) {
+		return
+	}
+	org := &organization.Organization{Globalid: globalid}
+	if handleServerError(w, "adding the user to the organization", organization.NewManager(r).SaveMember(org, newuser.Username)) {
+		return
+	}
+	created := newUser(newuser, resource.ExternalID, []string{globalid}, location(r, "Users", newuser.Username))
+	writeResource(w, r, http.StatusCreated, created, created.Meta)
+}
+
+//ReplaceUser is the handler for PUT /scim/v2/Users/{id}
+func (service *Service) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	tree, pu, ok := loadUserForUpdate(w, r)
+	if !ok {
+		return
+	}
+	resource := &User{}
+	if err := decodeResource(r, resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", "Invalid user")
+		return
+	}
+	updateUser(w, r, tree, pu, resource)
+}
+
+//PatchUser is the handler for PATCH /scim/v2/Users/{id}
+func (service *Service) PatchUser(w http.ResponseWriter, r *http.Request) {
+	tree, pu, ok := loadUserForUpdate(w, r)
+	if !ok {
+		return
+	}
+	patch := &PatchRequest{}
+	if err := decodeResource(r, patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", "Invalid patch request")
+		return
+	}
+	m := toMap(pu.resource)
+	if err := applyPatch(m, patch.Operations); err != nil {
+		writePatchError(w, err)
+		return
+	}
+	resource := &User{}
+	if err := fromMap(m, resource); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", "Invalid user")
+		return
+	}
+	updateUser(w, r, tree, pu, resource)
+}
+
+//DeleteUser is the handler for DELETE /scim/v2/Users/{id}
+// Removes the user from the organization and its suborganizations, the itsyou.online account is kept
+func (service *Service) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	tree, pu, ok := loadUserForUpdate(w, r)
+	if !ok {
+		return
+	}
+	if deprovision(w, r, tree, pu) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+//loadUserForUpdate gets the user of the request and checks the If-Match header
+func loadUserForUpdate(w http.ResponseWriter, r *http.Request) (tree []organization.Organization, pu *provisionedUser, ok bool) {
+	tree, err := getTree(r)
+	if handleServerError(w, "getting the organization tree", err) {
+		return
+	}
+	pu, err = loadUser(r, tree, mux.Vars(r)["id"])
+	if handleServerError(w, "getting the user", err) {
+		return
+	}
+	if pu == nil {
+		writeError(w, http.StatusNotFound, "", "User not found")
+		return
+	}
+	ok = checkPrecondition(w, r, pu.resource.Meta.Version)
+	return
+}
+
+//updateUser stores the new attributes of a user.
+// The profile of users the organization did not provision is read-only and left untouched.
+func updateUser(w http.ResponseWriter, r *http.Request, tree []organization.Organization, pu *provisionedUser, resource *User) {
+	if resource.UserName != "" && resource.UserName != pu.user.Username {
+		writeError(w, http.StatusBadRequest, "mutability", "The userName can not be changed")
+		return
+	}
+	if resource.Active != nil && !*resource.Active {
+		if deprovision(w, r, tree, pu) {
+			pu.provisioned = nil
+			pu.resource = newUser(pu.profile(), "", nil, pu.resource.Meta.Location)
+			writeResource(w, r, http.StatusOK, pu.resource, pu.resource.Meta)
+		}
+		return
+	}
+	externalID := ""
+	if pu.provisioned != nil {
+		if err := applyUser(resource, pu.user); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+		if handleServerError(w, "saving the user", user.NewManager(r).Save(pu.user)) {
+			return
+		}
+		pu.provisioned.ExternalID = resource.ExternalID
+		if handleServerError(w, "saving the provisioned user", scimdb.NewManager(r).SaveProvisionedUser(pu.provisioned)) {
+			return
+		}
+		externalID = resource.ExternalID
+	}
+	pu.resource = newUser(pu.profile(), externalID, pu.groups, pu.resource.Meta.Location)
+	writeResource(w, r, http.StatusOK, pu.resource, pu.resource.Meta)
+}
+
+//deprovision removes a user from all organizations of the tree, unless the user is the last owner of one of them
+func deprovision(w http.ResponseWriter, r *http.Request, tree []organization.Organization, pu *provisionedUser) bool {
+	username := pu.user.Username
+	for _, org := range tree {
+		if len(org.Owners) == 1 && org.Owners[0] == username {
+			writeError(w, http.StatusBadRequest, "mutability", "The user is the last owner of "+org.Globalid)
+			return false
+		}
+	}
+	orgMgr := organization.NewManager(r)
+	for _, globalid := range pu.groups {
+		if handleServerError(w, "removing the user from "+globalid, orgMgr.RemoveUser(globalid, username)) {
+			return false
+		}
+	}
+	return !handleServerError(w, "removing the provisioned user", scimdb.NewManager(r).RemoveProvisionedUser(getOrganization(r), username))
+}
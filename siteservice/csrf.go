@@ -0,0 +1,79 @@
+package siteservice
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+const (
+	//csrfCookieName is the name of the cookie the angular frontend reads the token from
+	csrfCookieName = "XSRF-TOKEN"
+	//csrfHeaderName is the header the angular frontend puts the token in
+	csrfHeaderName = "X-XSRF-TOKEN"
+	//csrfFormField can be used by plain html forms to pass the token
+	csrfFormField = "csrftoken"
+)
+
+//getCSRFToken returns the csrf token of the browser session, a new token is created if there is none yet.
+// The token is also set in a cookie readable by javascript so it can be sent back in the X-XSRF-TOKEN header.
+func (service *Service) getCSRFToken(w http.ResponseWriter, request *http.Request) (token string, err error) {
+	csrfSession, err := service.GetSession(request, SessionCSRF, "csrf")
+	if err != nil {
+		return
+	}
+	token, _ = csrfSession.Values["token"].(string)
+	if token == "" {
+		if token, err = tools.GenerateRandomString(); err != nil {
+			return
+		}
+		csrfSession.Values["token"] = token
+		if err = csrfSession.Save(request, w); err != nil {
+			return
+		}
+	}
+	cookie := &http.Cookie{
+		Name:   csrfCookieName,
+		Path:   "/",
+		Value:  token,
+		Secure: true,
+	}
+	http.SetCookie(w, cookie)
+	return
+}
+
+//validCSRFToken checks the token passed in the request against the one in the browser session
+func (service *Service) validCSRFToken(request *http.Request) bool {
+	csrfSession, err := service.GetSession(request, SessionCSRF, "csrf")
+	if err != nil {
+		return false
+	}
+	expected, _ := csrfSession.Values["token"].(string)
+	if expected == "" {
+		return false
+	}
+	token := request.Header.Get(csrfHeaderName)
+	if token == "" {
+		token = request.FormValue(csrfFormField)
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+//CSRFMiddleware rejects state changing requests that do not carry the csrf token of the browser session
+func (service *Service) CSRFMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case "GET", "HEAD", "OPTIONS":
+		default:
+			if !service.validCSRFToken(request) {
+				log.Debug("Invalid or missing csrf token for ", request.Method, " ", request.URL.Path)
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, request)
+	})
+}
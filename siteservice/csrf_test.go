@@ -0,0 +1,102 @@
+package siteservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+//newBrowserRequest creates a request carrying the cookies a browser received in an earlier response
+func newBrowserRequest(method, path string, previous *httptest.ResponseRecorder) *http.Request {
+	request, _ := http.NewRequest(method, path, strings.NewReader("{}"))
+	request.Header.Set("Content-Type", "application/json")
+	if previous != nil {
+		for _, cookie := range (&http.Response{Header: previous.Header()}).Cookies() {
+			request.AddCookie(cookie)
+		}
+	}
+	return request
+}
+
+func TestCSRFProtectedRoutesWithoutToken(t *testing.T) {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+	router := mux.NewRouter()
+	siteService.AddRoutes(router)
+
+	paths := []string{
+		"/login",
+		"/login/totpconfirmation",
+		"/login/smsconfirmation",
+		"/login/forgotpassword",
+		"/login/resetpassword",
+		"/register",
+		"/register/smsconfirmation",
+		"/register/resendsms",
+	}
+	for _, path := range paths {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newBrowserRequest("POST", path, nil))
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+	}
+}
+
+func TestCSRFLoginAndRegistrationFlow(t *testing.T) {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+
+	//The frontend receives the token when loading the configuration
+	configResponse := httptest.NewRecorder()
+	token, err := siteService.getCSRFToken(configResponse, newBrowserRequest("GET", "/config", nil))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Contains(t, configResponse.Header()["Set-Cookie"][len(configResponse.Header()["Set-Cookie"])-1], csrfCookieName+"=")
+
+	type testcase struct {
+		path   string
+		token  string
+		status int
+	}
+	testcases := []testcase{
+		{path: "/login", token: token, status: http.StatusOK},
+		{path: "/login", token: "", status: http.StatusForbidden},
+		{path: "/login", token: "forged", status: http.StatusForbidden},
+		{path: "/register", token: token, status: http.StatusOK},
+		{path: "/register", token: "forged", status: http.StatusForbidden},
+	}
+	for _, test := range testcases {
+		handler := siteService.CSRFMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		request := newBrowserRequest("POST", test.path, configResponse)
+		request.Header.Set(csrfHeaderName, test.token)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, request)
+		assert.Equal(t, test.status, w.Code, test.path+" "+test.token)
+	}
+
+	//Requesting the token again in the same browser session returns the same token
+	request := newBrowserRequest("GET", "/config", configResponse)
+	sameToken, err := siteService.getCSRFToken(httptest.NewRecorder(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, token, sameToken)
+
+	//A token of another browser session is refused
+	otherResponse := httptest.NewRecorder()
+	otherToken, err := siteService.getCSRFToken(otherResponse, newBrowserRequest("GET", "/config", nil))
+	assert.NoError(t, err)
+	request = newBrowserRequest("POST", "/login", configResponse)
+	request.Header.Set(csrfHeaderName, otherToken)
+	assert.False(t, siteService.validCSRFToken(request))
+}
+
+func TestCSRFTokenInForm(t *testing.T) {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+	configResponse := httptest.NewRecorder()
+	token, err := siteService.getCSRFToken(configResponse, newBrowserRequest("GET", "/config", nil))
+	assert.NoError(t, err)
+
+	request := newBrowserRequest("GET", "/logout?csrftoken="+url.QueryEscape(token), configResponse)
+	assert.True(t, siteService.validCSRFToken(request))
+}
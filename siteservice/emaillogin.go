@@ -0,0 +1,185 @@
+package siteservice
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/sessions"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/db"
+	validationdb "github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+	"github.com/itsyouonline/identityserver/tools"
+)
+
+const (
+	mongoLoginLinkCollectionName = "loginlinks"
+	//loginLinkValidity is kept equal to the lifetime of the login session the link is bound to
+	loginLinkValidity = time.Minute * 5
+)
+
+//initEmailLoginModels initialize models in mongo
+func (service *Service) initEmailLoginModels() {
+	index := mgo.Index{
+		Key:      []string{"key"},
+		Unique:   true,
+		DropDups: false,
+	}
+	db.EnsureIndex(mongoLoginLinkCollectionName, index)
+
+	automaticExpiration := mgo.Index{
+		Key:         []string{"createdat"},
+		ExpireAfter: loginLinkValidity,
+		Background:  true,
+	}
+	db.EnsureIndex(mongoLoginLinkCollectionName, automaticExpiration)
+}
+
+//loginLinkInformation is a pending passwordless login.
+// The key is stored in the login session of the browser that requested the link, the secret is sent in the email.
+type loginLinkInformation struct {
+	Key         string
+	Secret      string
+	Username    string
+	QueryString string
+	CreatedAt   time.Time
+}
+
+func newLoginLinkInformation(username string, queryString string) (info *loginLinkInformation, err error) {
+	info = &loginLinkInformation{CreatedAt: time.Now(), Username: username, QueryString: queryString}
+	if info.Key, err = tools.GenerateRandomString(); err != nil {
+		return
+	}
+	info.Secret, err = tools.GenerateRandomString()
+	return
+}
+
+//isExpired checks if the link is older than the loginLinkValidity,
+// mongo only removes expired documents periodically
+func (info *loginLinkInformation) isExpired() bool {
+	return info.CreatedAt.Add(loginLinkValidity).Before(time.Now())
+}
+
+//RequestLoginLink handler for POST /login/emaillink
+// It sends an email with a login link to a validated email address.
+// The response is the same whether the address is known or not to avoid leaking which addresses are registered.
+func (service *Service) RequestLoginLink(w http.ResponseWriter, request *http.Request) {
+	values := struct {
+		Email string `json:"email"`
+	}{}
+	if err := json.NewDecoder(request.Body).Decode(&values); err != nil {
+		log.Debug("Error decoding the login link request:", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	email := strings.TrimSpace(values.Email)
+	if email == "" {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	valMgr := validationdb.NewManager(request)
+	validatedemail, err := valMgr.GetByEmailAddress(email)
+	if err == mgo.ErrNotFound {
+		log.Debug("Login link requested for an unvalidated email address")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		log.Error("Failed to search for the validated email address: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	info, err := newLoginLinkInformation(validatedemail.Username, request.URL.RawQuery)
+	if err != nil {
+		log.Error("Error creating login link information: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	mgoCollection := db.GetCollection(db.GetDBSession(request), mongoLoginLinkCollectionName)
+	if err = mgoCollection.Insert(info); err != nil {
+		log.Error("Error saving login link information: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	//Bind the link to this browser
+	loginSession.Values["loginlinkkey"] = info.Key
+
+	loginurl := fmt.Sprintf("https://%s/login/emaillink?c=%s", request.Host, url.QueryEscape(info.Secret))
+	if err = service.emailaddressValidationService.SendLoginLink(request, validatedemail.Username, validatedemail.EmailAddress, loginurl, request.URL.Query().Get("client_id")); err != nil {
+		log.Error("Error sending login link: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	sessions.Save(request, w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//ProcessLoginLink handler for GET /login/emaillink
+// The login link is only accepted in the browser that requested it and can only be used once.
+// Just like a password login, it is followed by a 2 factor authentication unless the
+// organization's 2FA validity period has not passed yet.
+func (service *Service) ProcessLoginLink(w http.ResponseWriter, request *http.Request) {
+	secret := request.URL.Query().Get("c")
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	key, _ := loginSession.Values["loginlinkkey"].(string)
+	if key == "" || secret == "" {
+		log.Debug("Login link used without a matching login session")
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired login link, please request a new one from the same browser you open it in.")
+		return
+	}
+	delete(loginSession.Values, "loginlinkkey")
+
+	info, err := service.consumeLoginLink(request, key, secret)
+	if err != nil {
+		log.Error("Error loading login link information: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if info == nil {
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired login link, please request a new one from the same browser you open it in.")
+		return
+	}
+
+	service.setAuthenticationMethods(request, security.AMREmail)
+	service.continueLogin(w, request, loginSession, info.Username, info.QueryString)
+}
+
+//consumeLoginLink removes the login link with the given key if the secret matches and returns it.
+// nil is returned if no valid link exists.
+func (service *Service) consumeLoginLink(request *http.Request, key string, secret string) (info *loginLinkInformation, err error) {
+	mgoCollection := db.GetCollection(db.GetDBSession(request), mongoLoginLinkCollectionName)
+	info = &loginLinkInformation{}
+	_, err = mgoCollection.Find(bson.M{"key": key}).Apply(mgo.Change{Remove: true}, info)
+	if err == mgo.ErrNotFound {
+		info, err = nil, nil
+		return
+	}
+	if err != nil {
+		info = nil
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(info.Secret), []byte(secret)) != 1 || info.isExpired() {
+		info = nil
+	}
+	return
+}
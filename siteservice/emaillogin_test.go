@@ -0,0 +1,37 @@
+package siteservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoginLinkInformation(t *testing.T) {
+	info, err := newLoginLinkInformation("john", "client_id=example")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, info.Key)
+	assert.NotEmpty(t, info.Secret)
+	assert.NotEqual(t, info.Key, info.Secret)
+	assert.Equal(t, "john", info.Username)
+	assert.Equal(t, "client_id=example", info.QueryString)
+	assert.False(t, info.isExpired())
+
+	info.CreatedAt = time.Now().Add(-loginLinkValidity - time.Second)
+	assert.True(t, info.isExpired())
+}
+
+func TestProcessLoginLinkFromOtherBrowser(t *testing.T) {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+
+	//A browser without the login session that requested the link is not logged in
+	request, _ := http.NewRequest("GET", "/login/emaillink?c=secret", nil)
+	w := httptest.NewRecorder()
+	siteService.ProcessLoginLink(w, request)
+	assert.Equal(t, http.StatusOK, w.Code)
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		assert.NotEqual(t, "authenticatedsession", cookie.Name)
+	}
+}
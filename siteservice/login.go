@@ -20,10 +20,14 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/itsyouonline/identityserver/credentials/password"
 	"github.com/itsyouonline/identityserver/credentials/totp"
+	"github.com/itsyouonline/identityserver/db/device"
 	"github.com/itsyouonline/identityserver/db/user"
 	organizationdb "github.com/itsyouonline/identityserver/db/organization"
 	validationdb "github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/db/websession"
 	"github.com/itsyouonline/identityserver/identityservice/organization"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+	"github.com/itsyouonline/identityserver/messages"
 	"github.com/itsyouonline/identityserver/tools"
 	"github.com/itsyouonline/identityserver/validation"
 	"gopkg.in/mgo.v2/bson"
@@ -57,6 +61,8 @@ type loginSessionInformation struct {
 	SMSCode    string
 	Confirmed  bool
 	CreatedAt  time.Time
+	//Calls is how many times the code was read out in a voice call
+	Calls int
 }
 
 func newLoginSessionInformation() (sessionInformation *loginSessionInformation, err error) {
@@ -97,7 +103,6 @@ func (service *Service) ShowLoginForm(w http.ResponseWriter, request *http.Reque
 
 //ProcessLoginForm logs a user in if the credentials are valid
 func (service *Service) ProcessLoginForm(w http.ResponseWriter, request *http.Request) {
-	//TODO: validate csrf token
 	//TODO: limit the number of failed/concurrent requests
 
 	err := request.ParseForm()
@@ -158,35 +163,48 @@ func (service *Service) ProcessLoginForm(w http.ResponseWriter, request *http.Re
 		return
 	}
 	loginSession.Values["username"] = u.Username
+	service.setAuthenticationMethods(request, security.AMRPassword)
 	//check if 2fa validity has passed
-	if client != "" {
-		l2faMgr := organizationdb.NewLast2FAManager(request)
-		if l2faMgr.Exists(client, u.Username) {
-			timestamp, err := l2faMgr.GetLast2FA(client, u.Username)
-			if err != nil {
-				log.Error(err)
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-			mgr := organizationdb.NewManager(request)
-			seconds, err := mgr.GetValidity(client)
-			if err != nil {
-				log.Error(err)
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-			timeconverted := time.Time(timestamp)
-			if timeconverted.Add(time.Second * time.Duration(seconds)).After(time.Now()) {
-				service.loginUser(w, request, u.Username)
-				return
-			}
-		}
+	last2FAValid, err := service.isLast2FAStillValid(request, client, u.Username)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if last2FAValid {
+		service.loginUser(w, request, u.Username)
+		return
 	}
 
 	sessions.Save(request, w)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+//isLast2FAStillValid checks if the user did a 2 factor authentication for the client
+// within the validity period the organization configured.
+// It is never valid when the client explicitly requests a multi factor authentication.
+func (service *Service) isLast2FAStillValid(request *http.Request, client string, username string) (valid bool, err error) {
+	if client == "" || security.RequestedACR(request.URL.Query().Get("acr_values")) == security.ACRMultiFactor {
+		return
+	}
+	l2faMgr := organizationdb.NewLast2FAManager(request)
+	if !l2faMgr.Exists(client, username) {
+		return
+	}
+	timestamp, err := l2faMgr.GetLast2FA(client, username)
+	if err != nil {
+		return
+	}
+	mgr := organizationdb.NewManager(request)
+	seconds, err := mgr.GetValidity(client)
+	if err != nil {
+		return
+	}
+	timeconverted := time.Time(timestamp)
+	valid = timeconverted.Add(time.Second * time.Duration(seconds)).After(time.Now())
+	return
+}
+
 // GetTwoFactorAuthenticationMethods returns the possible two factor authentication methods the user can use to login with.
 func (service *Service) GetTwoFactorAuthenticationMethods(w http.ResponseWriter, request *http.Request) {
 	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
@@ -211,7 +229,8 @@ func (service *Service) GetTwoFactorAuthenticationMethods(w http.ResponseWriter,
 	response := struct {
 		Totp bool              `json:"totp"`
 		Sms  map[string]string `json:"sms"`
-	}{Sms: make(map[string]string)}
+		Push map[string]string `json:"push"`
+	}{Sms: make(map[string]string), Push: make(map[string]string)}
 	totpMgr := totp.NewManager(request)
 	response.Totp, err = totpMgr.HasTOTP(username)
 	if err != nil {
@@ -228,11 +247,22 @@ func (service *Service) GetTwoFactorAuthenticationMethods(w http.ResponseWriter,
 	}
 	for _, validatedPhoneNumber := range verifiedPhones {
 		for _, number := range userFromDB.Phonenumbers {
-			if number.Phonenumber == string(validatedPhoneNumber.Phonenumber) {
+			if number.Phonenumber == string(validatedPhoneNumber.Phonenumber) && number.CanReceiveSMS() {
 				response.Sms[number.Label] = string(validatedPhoneNumber.Phonenumber)
 			}
 		}
 	}
+	devices, err := device.NewManager(request).GetByUser(username)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	for _, pairedDevice := range devices {
+		if pairedDevice.PushToken != "" {
+			response.Push[pairedDevice.ID.Hex()] = pairedDevice.Label
+		}
+	}
 	json.NewEncoder(w).Encode(response)
 	return
 }
@@ -298,6 +328,11 @@ func (service *Service) GetSmsCode(w http.ResponseWriter, request *http.Request)
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
+	if !phoneNumber.CanReceiveSMS() {
+		log.Debug("Login sms refused, the phone number can not receive sms: ", phoneNumber.Phonenumber)
+		w.WriteHeader(422)
+		return
+	}
 	loginSession.Values["sessionkey"] = sessionInfo.SessionKey
 	authClientId := loginSession.Values["auth_client_id"]
 	authenticatingOrganization := ""
@@ -306,15 +341,86 @@ func (service *Service) GetSmsCode(w http.ResponseWriter, request *http.Request)
 	}
 	mgoCollection := db.GetCollection(db.GetDBSession(request), mongoLoginCollectionName)
 	mgoCollection.Insert(sessionInfo)
-	organizationText := ""
-	if authenticatingOrganization != "" {
-		split := strings.Split(authenticatingOrganization, ".")
-		organizationText = fmt.Sprintf("to authorize the organization %s, ", split[len(split)-1])
+	smsmessage, err := messages.NewContext(request, username, authenticatingOrganization).RenderSMS(messages.LoginCode, &messages.Data{
+		Username: username,
+		Code:     sessionInfo.SMSCode,
+		URL:      fmt.Sprintf("https://%s/sc?c=%s&k=%s", request.Host, sessionInfo.SMSCode, url.QueryEscape(sessionInfo.SessionKey)),
+	})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	sessions.Save(request, w)
+	if err = service.smsService.Send(phoneNumber.Phonenumber, smsmessage); err != nil {
+		log.Error("Error sending login sms: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//CallLoginCode calls a phone of the user logging in and reads out the login code.
+// The code of the sms that was sent before is reused, so the code of either one can be entered.
+func (service *Service) CallLoginCode(w http.ResponseWriter, request *http.Request) {
+	phoneLabel := mux.Vars(request)["phoneLabel"]
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error("Error getting login session", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	username, ok := loginSession.Values["username"].(string)
+	if username == "" || !ok {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	userFromDB, err := user.NewManager(request).GetByName(username)
+	if err != nil {
+		log.Error("Error getting user", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	phoneNumber, err := userFromDB.GetPhonenumberByLabel(phoneLabel)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	mgoCollection := db.GetCollection(db.GetDBSession(request), mongoLoginCollectionName)
+	sessionInfo := &loginSessionInformation{}
+	sessionKey, _ := loginSession.Values["sessionkey"].(string)
+	_, err = mgoCollection.Find(bson.M{"sessionkey": sessionKey, "confirmed": false}).Apply(mgo.Change{Update: bson.M{"$inc": bson.M{"calls": 1}}, ReturnNew: true}, sessionInfo)
+	if err == mgo.ErrNotFound {
+		if sessionInfo, err = newLoginSessionInformation(); err == nil {
+			sessionInfo.Calls = 1
+			err = mgoCollection.Insert(sessionInfo)
+		}
+		loginSession.Values["sessionkey"] = sessionInfo.SessionKey
+	}
+	if err != nil {
+		log.Error("Error storing the login session information: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if sessionInfo.Calls > validation.MaxCallsPerCode {
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+	authenticatingOrganization, _ := loginSession.Values["auth_client_id"].(string)
+	message, language, err := messages.NewContext(request, username, authenticatingOrganization).RenderVoice(messages.LoginCode, &messages.Data{
+		Username: username,
+		Code:     sessionInfo.SMSCode,
+	})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
 	}
-	smsmessage := fmt.Sprintf("To continue signing in at itsyou.online %senter the code %s in the form or use this link: https://%s/sc?c=%s&k=%s",
-		organizationText, sessionInfo.SMSCode, request.Host, sessionInfo.SMSCode, url.QueryEscape(sessionInfo.SessionKey))
 	sessions.Save(request, w)
-	go service.smsService.Send(phoneNumber.Phonenumber, smsmessage)
+	if err = service.voiceService.Call(phoneNumber.Phonenumber, message, language); err != nil {
+		log.Error("Error calling with the login code: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -349,6 +455,7 @@ func (service *Service) ProcessTOTPConfirmation(w http.ResponseWriter, request *
 		w.WriteHeader(422)
 		return
 	}
+	service.addAuthenticationMethod(request, security.AMROTP)
 
 	//add last 2fa date if logging in with oauth2
 	service.storeLast2FALogin(request, username)
@@ -495,6 +602,7 @@ func (service *Service) Process2FASMSConfirmation(w http.ResponseWriter, request
 	}
 	userMgr := user.NewManager(request)
 	userMgr.RemoveExpireDate(username)
+	service.addAuthenticationMethod(request, security.AMRSMS)
 
 	//add last 2fa date if logging in with oauth2
 	service.storeLast2FALogin(request, username)
@@ -515,6 +623,39 @@ func (service *Service) storeLast2FALogin(request *http.Request, username string
 	}
 }
 
+//continueLogin continues a login that was started outside the login form, like an email link or an upstream provider,
+// with the parameters of the original login request.
+// Just like a password login, it is followed by a 2 factor authentication unless the
+// organization's 2FA validity period has not passed yet.
+func (service *Service) continueLogin(w http.ResponseWriter, request *http.Request, loginSession *sessions.Session, username string, queryString string) {
+	request.URL.RawQuery = queryString
+	queryValues := request.URL.Query()
+	loginSession.Values["username"] = username
+
+	last2FAValid, err := service.isLast2FAStillValid(request, queryValues.Get("client_id"), username)
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !last2FAValid {
+		sessions.Save(request, w)
+		http.Redirect(w, request, "/login?"+queryString+"#/2fa", http.StatusFound)
+		return
+	}
+
+	userMgr := user.NewManager(request)
+	userMgr.RemoveExpireDate(username)
+	if err = service.SetLoggedInUser(w, request, username); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	log.Debugf("Successfull login by '%s'", username)
+	redirectURL := service.getLoginRedirectURL(request)
+	sessions.Save(request, w)
+	http.Redirect(w, request, redirectURL, http.StatusFound)
+}
+
 func (service *Service) loginUser(w http.ResponseWriter, request *http.Request, username string) {
 	if err := service.SetLoggedInUser(w, request, username); err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -524,9 +665,25 @@ func (service *Service) loginUser(w http.ResponseWriter, request *http.Request,
 
 	log.Debugf("Successfull login by '%s'", username)
 
-	redirectURL := "/"
+	redirectURL := service.getLoginRedirectURL(request)
+
+	sessions.Save(request, w)
+	response := struct {
+		Redirecturl string `json:"redirecturl"`
+	}{}
+	response.Redirecturl = redirectURL
+	log.Debug("Redirecting to:", redirectURL)
+	json.NewEncoder(w).Encode(response)
+}
+
+//getLoginRedirectURL returns the url the user should be sent to after a successful login
+func (service *Service) getLoginRedirectURL(request *http.Request) (redirectURL string) {
+	redirectURL = "/"
 	queryValues := request.URL.Query()
 	endpoint := queryValues.Get("endpoint")
+	//The user just authenticated, asking for it again when returning to the endpoint would loop
+	queryValues.Del("prompt")
+	queryValues.Del("max_age")
 	if endpoint != "" {
 		queryValues.Del("endpoint")
 		redirectURL = endpoint + "?" + queryValues.Encode()
@@ -545,18 +702,13 @@ func (service *Service) loginUser(w http.ResponseWriter, request *http.Request,
 				}
 				endpoint, _ = url.QueryUnescape(queryValues.Get("endpoint"))
 				queryValues.Del("endpoint")
+				queryValues.Del("prompt")
+				queryValues.Del("max_age")
 				redirectURL = endpoint + "?" + queryValues.Encode()
 			}
 		}
 	}
-
-	sessions.Save(request, w)
-	response := struct {
-		Redirecturl string `json:"redirecturl"`
-	}{}
-	response.Redirecturl = redirectURL
-	log.Debug("Redirecting to:", redirectURL)
-	json.NewEncoder(w).Encode(response)
+	return
 }
 
 //ForgotPassword handler for POST /login/forgotpassword
@@ -642,14 +794,34 @@ func (service *Service) ResetPassword(w http.ResponseWriter, request *http.Reque
 		return
 
 	}
+	//Whoever knew the old password should not stay logged in
+	if err = websession.NewManager(request).DeleteByUser(token.Username, ""); err != nil {
+		log.Error("Failed to revoke the sessions after a password reset: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 	return
 }
 
+//LoginCallPhonenumberConfirmation reads out the phone number confirmation code in a voice call, for users that do not receive the sms
+func (service *Service) LoginCallPhonenumberConfirmation(w http.ResponseWriter, request *http.Request) {
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	username, _ := loginSession.Values["username"].(string)
+	validationkey, _ := loginSession.Values["phonenumbervalidationkey"].(string)
+	service.callValidationCode(w, request, username, validationkey)
+}
+
 //LoginResendPhonenumberConfirmation resend the phone number confirmation after logging in to a possibly new phone number
 func (service *Service) LoginResendPhonenumberConfirmation(w http.ResponseWriter, request *http.Request) {
 	values := struct {
 		PhoneNumber string `json:"phonenumber"`
+		Country     string `json:"country"`
 	}{}
 
 	response := struct {
@@ -681,6 +853,7 @@ func (service *Service) LoginResendPhonenumberConfirmation(w http.ResponseWriter
 	_ = service.phonenumberValidationService.ExpireValidation(request, validationkey)
 
 	phonenumber := user.Phonenumber{Label: "main", Phonenumber: values.PhoneNumber}
+	phonenumber.Normalize(values.Country)
 	if !phonenumber.IsValid() {
 		log.Debug("Invalid phone number")
 		w.WriteHeader(422)
@@ -688,6 +861,13 @@ func (service *Service) LoginResendPhonenumberConfirmation(w http.ResponseWriter
 		json.NewEncoder(w).Encode(&response)
 		return
 	}
+	if !phonenumber.CanReceiveSMS() {
+		log.Debug("Phone number can not receive sms: ", phonenumber.Phonenumber)
+		w.WriteHeader(422)
+		response.Error = "phonenumber_cannot_receive_sms"
+		json.NewEncoder(w).Encode(&response)
+		return
+	}
 
 	uMgr := user.NewManager(request)
 	err = uMgr.SavePhone(username, phonenumber)
@@ -1,166 +1,265 @@
 package siteservice
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/upstreamaccount"
 	"github.com/itsyouonline/identityserver/db/user"
-	"github.com/itsyouonline/identityserver/identityservice"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+)
+
+const (
+	upstreamIntentLink  = "link"
+	upstreamIntentLogin = "login"
 )
 
-func (service *Service) FacebookCallback(w http.ResponseWriter, request *http.Request) {
-	var code = request.URL.Query().Get("code")
-	var redirectUri = "https://" + request.Host + "/facebook_callback"
-	clientId, err := identityservice.GetOauthClientID("facebook")
+//GetUpstreamProviders handler for GET /login/upstreamproviders
+// Lists the providers that can be used to login with a linked account
+func (service *Service) GetUpstreamProviders(w http.ResponseWriter, request *http.Request) {
+	providers, err := service.getUpstreamProviders()
 	if err != nil {
+		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	clientSecret, err := identityservice.GetOauthSecret("facebook")
+	type upstreamProvider struct {
+		Name        string `json:"name"`
+		DisplayName string `json:"displayname"`
+	}
+	response := []upstreamProvider{}
+	for _, provider := range providers {
+		response = append(response, upstreamProvider{Name: provider.Name(), DisplayName: provider.DisplayName()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&response)
+}
+
+//UpstreamLink handler for GET /upstream/{provider}/link
+// Sends the logged in user to the provider to link the account there to the itsyou.online account
+func (service *Service) UpstreamLink(w http.ResponseWriter, request *http.Request) {
+	username, err := service.GetLoggedInUser(request, w)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	var oauthUrl = fmt.Sprintf("https://graph.facebook.com/v2.6/oauth/access_token?client_id=%s&client_secret=%s&code=%s&redirect_uri=%s",
-		clientId, clientSecret, code, redirectUri)
-	var fbInfo user.FBInfo
-	httpClient := &http.Client{}
-	req, _ := http.NewRequest("POST", oauthUrl, nil)
-	// Get access token from Github
-	response, _ := httpClient.Do(req)
-	facebookResponse := struct {
-		Access_token string
-		Token_type   string
-		Expires_in   int
-		Error        user.FacebookError
-	}{}
-	if err := json.NewDecoder(response.Body).Decode(&facebookResponse); err != nil {
-		log.Error(err)
+	ac, err := service.GetAuthenticationContext(request)
+	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	if facebookResponse.Error.Message != "" {
-		log.Error(facebookResponse.Error)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	//Linking adds a way to login, make sure it is the user and not someone using a forgotten session
+	if username == "" || ac == nil || !ac.IsAuthenticatedWithin(security.RecentAuthenticationMaxAge) {
+		http.Redirect(w, request, "/login?"+url.Values{"endpoint": {request.URL.Path}}.Encode(), http.StatusFound)
 		return
 	}
+	service.redirectToUpstreamProvider(w, request, upstreamIntentLink, username)
+}
 
-	// Get Facebook user info using the Graph API.
-	var fields = "id,picture,link,name"
-	var apiUrl = fmt.Sprintf("https://graph.facebook.com/v2.6/me/?access_token=%s&fields=%s", facebookResponse.Access_token, fields)
-	req, _ = http.NewRequest("GET", apiUrl, nil)
-	response, _ = httpClient.Do(req)
+//UpstreamLogin handler for GET /upstream/{provider}/login
+// Sends the user to the provider to login with a linked account, the query parameters of the login page are kept
+func (service *Service) UpstreamLogin(w http.ResponseWriter, request *http.Request) {
+	service.redirectToUpstreamProvider(w, request, upstreamIntentLogin, "")
+}
 
-	if err := json.NewDecoder(response.Body).Decode(&fbInfo); err != nil {
+//redirectToUpstreamProvider stores a state and PKCE verifier in a session bound to this browser and sends the user to the provider
+func (service *Service) redirectToUpstreamProvider(w http.ResponseWriter, request *http.Request, intent string, username string) {
+	provider, err := service.getUpstreamProvider(mux.Vars(request)["provider"])
+	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	// Save facebook info in database.
-	userMgr := user.NewManager(request)
-	var loggedInUser, e = service.GetLoggedInUser(request, w)
-	if e != nil {
-		log.Error(e)
+	if provider == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	state, err := newPKCEVerifier()
+	if err != nil {
+		log.Error("Error creating upstream state: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	codeVerifier, err := newPKCEVerifier()
+	if err != nil {
+		log.Error("Error creating upstream code verifier: ", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	var fbAccount user.FacebookAccount
-	fbAccount.Id = fbInfo.Id
-	fbAccount.Name = fbInfo.Name
-	fbAccount.Picture = fbInfo.Picture.Data.Url
-	fbAccount.Link = fbInfo.Link
-	if err := userMgr.UpdateFacebookAccount(loggedInUser, fbAccount); err != nil {
+	upstreamSession, err := service.GetSession(request, SessionLogin, "upstreamsession")
+	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
+	upstreamSession.Values["provider"] = provider.Name()
+	upstreamSession.Values["state"] = state
+	upstreamSession.Values["codeverifier"] = codeVerifier
+	upstreamSession.Values["intent"] = intent
+	upstreamSession.Values["username"] = username
+	upstreamSession.Values["querystring"] = request.URL.RawQuery
+	sessions.Save(request, w)
+
+	redirectURI := "https://" + request.Host + provider.RedirectPath()
+	http.Redirect(w, request, provider.AuthorizationURL(state, codeVerifier, redirectURI), http.StatusFound)
+}
 
-	http.Redirect(w, request, "/", http.StatusTemporaryRedirect)
+//UpstreamCallback handler for GET /upstream/{provider}/callback
+func (service *Service) UpstreamCallback(w http.ResponseWriter, request *http.Request) {
+	service.processUpstreamCallback(w, request, mux.Vars(request)["provider"])
+}
 
+//LegacyUpstreamCallback returns the handler for the callback urls that were registered at github and facebook
+// before the generic upstream providers existed
+func (service *Service) LegacyUpstreamCallback(providerName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, request *http.Request) {
+		service.processUpstreamCallback(w, request, providerName)
+	}
 }
 
-func (service *Service) GithubCallback(w http.ResponseWriter, request *http.Request) {
-	var code = request.URL.Query().Get("code")
-	// Get GitHub access token
-	clientId, err := identityservice.GetOauthClientID("github")
-	log.Info("clientId")
-	log.Info(clientId)
+func (service *Service) processUpstreamCallback(w http.ResponseWriter, request *http.Request, providerName string) {
+	upstreamSession, err := service.GetSession(request, SessionLogin, "upstreamsession")
 	if err != nil {
+		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	clientSecret, err := identityservice.GetOauthSecret("github")
-	if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	expectedProvider, _ := upstreamSession.Values["provider"].(string)
+	state, _ := upstreamSession.Values["state"].(string)
+	codeVerifier, _ := upstreamSession.Values["codeverifier"].(string)
+	intent, _ := upstreamSession.Values["intent"].(string)
+	linkingUsername, _ := upstreamSession.Values["username"].(string)
+	queryString, _ := upstreamSession.Values["querystring"].(string)
+	//The state can only be used once
+	upstreamSession.Options.MaxAge = -1
+
+	receivedState := request.URL.Query().Get("state")
+	if state == "" || expectedProvider != providerName || subtle.ConstantTimeCompare([]byte(state), []byte(receivedState)) != 1 {
+		log.Debug("Upstream callback with an invalid state")
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired request, please try again.")
 		return
 	}
-	redirect_uri := "https://" + request.Host + "/github_callback"
-	var oauthUrl = fmt.Sprintf("https://github.com/login/oauth/access_token?&client_id=%s&client_secret=%s&code=%s&redirect_uri=%s",
-		clientId, clientSecret, code, redirect_uri)
-	var githubUserInfo user.GithubAccount
-	httpClient := &http.Client{}
-	req, _ := http.NewRequest("POST", oauthUrl, nil)
-	req.Header.Add("Accept", "application/json")
-	// Get access token from Github
-	response, err := httpClient.Do(req)
-	if err != nil || response.StatusCode != 200 {
-		log.Error(response.Status)
+	provider, err := service.getUpstreamProvider(providerName)
+	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	githubResponse := struct {
-		Access_token      string
-		Scope             string
-		Token_type        string
-		Error             string
-		Error_description string
-		Error_uri         string
-	}{}
-	if err := json.NewDecoder(response.Body).Decode(&githubResponse); err != nil {
-		log.Error(err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	if provider == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	displayName := template.HTMLEscapeString(provider.DisplayName())
+	code := request.URL.Query().Get("code")
+	if code == "" {
+		log.Debug("Upstream provider ", providerName, " returned an error: ", request.URL.Query().Get("error"))
+		service.renderEmailConfirmationPage(w, request, fmt.Sprintf("Authentication at %s was cancelled.", displayName))
 		return
 	}
-	if githubResponse.Error != "" {
-		log.Error(githubResponse)
+	redirectURI := "https://" + request.Host + provider.RedirectPath()
+	userinfo, err := provider.Exchange(code, codeVerifier, redirectURI)
+	if err == ErrUpstreamExchangeFailed {
+		service.renderEmailConfirmationPage(w, request, fmt.Sprintf("Authentication at %s failed, please try again.", displayName))
+		return
+	}
+	if err != nil {
+		log.Error("Error exchanging the upstream authorization code: ", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	// Get user info from github
-	var apiUrl = fmt.Sprintf("https://api.github.com/user?access_token=%s", githubResponse.Access_token)
-	req, _ = http.NewRequest("GET", apiUrl, nil)
-	// Get GitHub profile info from this user
-	response, err = httpClient.Do(req)
+	if intent == upstreamIntentLink {
+		service.linkUpstreamAccount(w, request, provider, linkingUsername, userinfo)
+		return
+	}
 
-	if err != nil || response.StatusCode != 200 {
-		log.Error(response.Status)
-		log.Error(err)
+	account, err := upstreamaccount.NewManager(request).GetBySubject(provider.Name(), userinfo.Subject)
+	if err == mgo.ErrNotFound {
+		service.renderEmailConfirmationPage(w, request, fmt.Sprintf("This %s account is not linked to an itsyou.online account. Login with your password and link it on your profile page first.", displayName))
+		return
+	}
+	if err != nil {
+		log.Error("Error loading upstream account: ", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	if err := json.NewDecoder(response.Body).Decode(&githubUserInfo); err != nil {
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	userMgr := user.NewManager(request)
-	// Save Github user info in db
-	var loggedInUser, e = service.GetLoggedInUser(request, w)
-	if e != nil {
-		log.Error(e)
+	service.setAuthenticationMethods(request, security.AMRFederated)
+	service.continueLogin(w, request, loginSession, account.Username, queryString)
+}
+
+//linkUpstreamAccount links the upstream account to the user that started the linking in this browser
+func (service *Service) linkUpstreamAccount(w http.ResponseWriter, request *http.Request, provider UpstreamProvider, username string, userinfo *UpstreamUserInfo) {
+	loggedInUser, err := service.GetLoggedInUser(request, w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if loggedInUser == "" || loggedInUser != username {
+		http.Redirect(w, request, "/login", http.StatusFound)
+		return
+	}
+	account := &upstreamaccount.UpstreamAccount{
+		Provider: provider.Name(),
+		Subject:  userinfo.Subject,
+		Username: username,
+		Login:    userinfo.Login,
+		Name:     userinfo.Name,
+		Email:    userinfo.Email,
+		Picture:  userinfo.Picture,
+		Profile:  userinfo.Profile,
+	}
+	err = upstreamaccount.NewManager(request).Link(account)
+	if err == db.ErrDuplicate {
+		service.renderEmailConfirmationPage(w, request, fmt.Sprintf("This %s account is already linked to another itsyou.online account.", template.HTMLEscapeString(provider.DisplayName())))
+		return
+	}
+	if err != nil {
+		log.Error("Error linking upstream account: ", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-	if err := userMgr.UpdateGithubAccount(loggedInUser, githubUserInfo); err != nil {
+	//The github and facebook information is also kept on the user for the user:github and user:facebook scopes
+	userMgr := user.NewManager(request)
+	switch provider.Name() {
+	case "github":
+		id, _ := strconv.Atoi(userinfo.Subject)
+		err = userMgr.UpdateGithubAccount(username, user.GithubAccount{
+			Login:      userinfo.Login,
+			Id:         id,
+			Avatar_url: userinfo.Picture,
+			Html_url:   userinfo.Profile,
+			Name:       userinfo.Name,
+		})
+	case "facebook":
+		err = userMgr.UpdateFacebookAccount(username, user.FacebookAccount{
+			Id:      userinfo.Subject,
+			Picture: userinfo.Picture,
+			Link:    userinfo.Profile,
+			Name:    userinfo.Name,
+		})
+	}
+	if err != nil {
 		log.Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-
-	http.Redirect(w, request, "/", http.StatusTemporaryRedirect)
-
+	sessions.Save(request, w)
+	http.Redirect(w, request, "/", http.StatusFound)
 }
@@ -0,0 +1,173 @@
+package siteservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db/device"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+)
+
+//pushDeviceStore keeps the paired devices and the login challenges sent to them
+type pushDeviceStore interface {
+	Get(username string, id string) (*device.Device, error)
+	NewChallenge(username string, deviceID string) (*device.Challenge, error)
+	GetChallenge(key string) (*device.Challenge, error)
+	RemoveChallenge(key string) error
+}
+
+func (service *Service) getPushDeviceStore(request *http.Request) pushDeviceStore {
+	if service.pushDevices != nil {
+		return service.pushDevices
+	}
+	return device.NewManager(request)
+}
+
+//SendPushChallenge handler for POST /login/pushchallenge/{deviceID}
+// It creates a challenge for the user logging in and sends it to the paired device.
+// The challenge key is stored in the login session so only this browser can complete the login.
+func (service *Service) SendPushChallenge(w http.ResponseWriter, request *http.Request) {
+	deviceID := mux.Vars(request)["deviceID"]
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error("Error getting login session", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	username, ok := loginSession.Values["username"].(string)
+	if username == "" || !ok {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	deviceMgr := service.getPushDeviceStore(request)
+	pairedDevice, err := deviceMgr.Get(username, deviceID)
+	if err == mgo.ErrNotFound || (err == nil && pairedDevice.PushToken == "") {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error getting device", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	challenge, err := deviceMgr.NewChallenge(username, deviceID)
+	if err != nil {
+		log.Error("Error creating push challenge", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	loginSession.Values["pushchallengekey"] = challenge.Key
+
+	authenticatingOrganization, _ := loginSession.Values["auth_client_id"].(string)
+	organizationText := ""
+	if authenticatingOrganization != "" {
+		split := strings.Split(authenticatingOrganization, ".")
+		organizationText = fmt.Sprintf(" to authorize the organization %s", split[len(split)-1])
+	}
+	message := fmt.Sprintf("Approve signing in at itsyou.online as %s%s?", username, organizationText)
+	data := map[string]string{
+		"challenge":    challenge.Key,
+		"username":     username,
+		"device":       deviceID,
+		"organization": authenticatingOrganization,
+	}
+	sessions.Save(request, w)
+	go func() {
+		if err := service.pushService.Send(pairedDevice.PushToken, message, data); err != nil {
+			log.Error("Error sending push challenge: ", err)
+		}
+	}()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//getPushChallenge returns the push challenge of the user logging in, nil is returned if there is none
+func (service *Service) getPushChallenge(request *http.Request) (challenge *device.Challenge, err error) {
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	key, _ := loginSession.Values["pushchallengekey"].(string)
+	username, _ := loginSession.Values["username"].(string)
+	if key == "" || username == "" {
+		return
+	}
+	challenge, err = service.getPushDeviceStore(request).GetChallenge(key)
+	if err != nil {
+		log.Error("Error getting push challenge: ", err)
+		return
+	}
+	if challenge != nil && challenge.Username != username {
+		challenge = nil
+	}
+	return
+}
+
+//CheckPushConfirmation is called by the push form to check if the device already answered the challenge
+func (service *Service) CheckPushConfirmation(w http.ResponseWriter, request *http.Request) {
+	challenge, err := service.getPushChallenge(request)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	response := map[string]device.ChallengeStatus{}
+	if challenge == nil {
+		//An expired challenge can not be approved anymore
+		response["status"] = device.ChallengeDenied
+	} else {
+		response["status"] = challenge.Status
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+//ProcessPushConfirmation finishes the login once the challenge is approved on the paired device
+func (service *Service) ProcessPushConfirmation(w http.ResponseWriter, request *http.Request) {
+	username, err := service.getUserLoggingIn(request)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if username == "" {
+		sessions.Save(request, w)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	challenge, err := service.getPushChallenge(request)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if challenge == nil || challenge.Status != device.ChallengeApproved {
+		w.WriteHeader(422)
+		return
+	}
+	//A challenge can only be used for a single login
+	if err = service.getPushDeviceStore(request).RemoveChallenge(challenge.Key); err != nil {
+		log.Error("Error removing push challenge: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	delete(loginSession.Values, "pushchallengekey")
+
+	//Devices can only be paired after logging in, so there is no registration expiry left to remove
+	service.addAuthenticationMethod(request, security.AMRSoftwareKey)
+
+	//add last 2fa date if logging in with oauth2
+	service.storeLast2FALogin(request, username)
+
+	service.loginUser(w, request, username)
+}
@@ -0,0 +1,156 @@
+package siteservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/itsyouonline/identityserver/communication"
+	"github.com/itsyouonline/identityserver/db/device"
+	"github.com/stretchr/testify/assert"
+)
+
+//memoryDeviceStore keeps paired devices and push challenges in memory
+type memoryDeviceStore struct {
+	devices    []device.Device
+	challenges map[string]*device.Challenge
+}
+
+func (s *memoryDeviceStore) Get(username string, id string) (*device.Device, error) {
+	for i := range s.devices {
+		if s.devices[i].Username == username && s.devices[i].ID.Hex() == id {
+			return &s.devices[i], nil
+		}
+	}
+	return nil, mgo.ErrNotFound
+}
+
+func (s *memoryDeviceStore) NewChallenge(username string, deviceID string) (*device.Challenge, error) {
+	challenge := &device.Challenge{Key: bson.NewObjectId().Hex(), Username: username, DeviceID: deviceID, Status: device.ChallengePending}
+	s.challenges[challenge.Key] = challenge
+	return challenge, nil
+}
+
+func (s *memoryDeviceStore) GetChallenge(key string) (*device.Challenge, error) {
+	return s.challenges[key], nil
+}
+
+func (s *memoryDeviceStore) RemoveChallenge(key string) error {
+	delete(s.challenges, key)
+	return nil
+}
+
+//pushLoginBrowser replays the cookies of earlier responses like a browser does
+type pushLoginBrowser struct {
+	router  *mux.Router
+	cookies map[string]*http.Cookie
+}
+
+func (b *pushLoginBrowser) do(method string, path string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(method, path, nil)
+	for _, cookie := range b.cookies {
+		request.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	b.router.ServeHTTP(w, request)
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		b.cookies[cookie.Name] = cookie
+	}
+	return w
+}
+
+func newPushLoginTest() (service *Service, store *memoryDeviceStore, browser *pushLoginBrowser, deviceID string) {
+	service = NewService("MyCookieSecret", nil, nil, &communication.DevPushService{}, nil)
+	//Keep the authenticated session in a cookie, there is no database in the tests
+	service.Sessions[SessionInteractive] = initializeSessionStore("MyCookieSecret", 10*60)
+	pairedDevice := device.Device{ID: bson.NewObjectId(), Username: "alice", Label: "phone", PushToken: "pushtoken"}
+	store = &memoryDeviceStore{devices: []device.Device{pairedDevice}, challenges: map[string]*device.Challenge{}}
+	service.pushDevices = store
+
+	router := mux.NewRouter()
+	//Start a login session as if alice entered a correct password
+	router.Methods("GET").Path("/test/password").HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		loginSession, _ := service.GetSession(request, SessionLogin, "loginsession")
+		loginSession.Values["username"] = "alice"
+		sessions.Save(request, w)
+	})
+	router.Methods("POST").Path("/login/pushchallenge/{deviceID}").HandlerFunc(service.SendPushChallenge)
+	router.Methods("GET").Path("/login/pushconfirmed").HandlerFunc(service.CheckPushConfirmation)
+	router.Methods("POST").Path("/login/pushconfirmation").HandlerFunc(service.ProcessPushConfirmation)
+	browser = &pushLoginBrowser{router: router, cookies: map[string]*http.Cookie{}}
+	browser.do("GET", "/test/password")
+	deviceID = pairedDevice.ID.Hex()
+	return
+}
+
+func sentChallenge(t *testing.T, store *memoryDeviceStore) *device.Challenge {
+	assert.Len(t, store.challenges, 1)
+	for _, challenge := range store.challenges {
+		return challenge
+	}
+	return nil
+}
+
+func pushConfirmationStatus(browser *pushLoginBrowser) device.ChallengeStatus {
+	response := map[string]device.ChallengeStatus{}
+	json.NewDecoder(browser.do("GET", "/login/pushconfirmed").Body).Decode(&response)
+	return response["status"]
+}
+
+func TestPushLoginApproved(t *testing.T) {
+	_, store, browser, deviceID := newPushLoginTest()
+
+	assert.Equal(t, http.StatusNoContent, browser.do("POST", "/login/pushchallenge/"+deviceID).Code)
+	challenge := sentChallenge(t, store)
+	assert.Equal(t, "alice", challenge.Username)
+	assert.Equal(t, device.ChallengePending, pushConfirmationStatus(browser))
+
+	//Not approved yet
+	assert.Equal(t, 422, browser.do("POST", "/login/pushconfirmation").Code)
+	_, loggedIn := browser.cookies["authenticatedsession"]
+	assert.False(t, loggedIn)
+
+	challenge.Status = device.ChallengeApproved
+	assert.Equal(t, device.ChallengeApproved, pushConfirmationStatus(browser))
+	w := browser.do("POST", "/login/pushconfirmation")
+	assert.Equal(t, http.StatusOK, w.Code)
+	response := struct {
+		Redirecturl string `json:"redirecturl"`
+	}{}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "/", response.Redirecturl)
+	_, loggedIn = browser.cookies["authenticatedsession"]
+	assert.True(t, loggedIn)
+
+	//The challenge can only be used once
+	assert.Empty(t, store.challenges)
+}
+
+func TestPushLoginDenied(t *testing.T) {
+	_, store, browser, deviceID := newPushLoginTest()
+
+	assert.Equal(t, http.StatusNoContent, browser.do("POST", "/login/pushchallenge/"+deviceID).Code)
+	sentChallenge(t, store).Status = device.ChallengeDenied
+	assert.Equal(t, device.ChallengeDenied, pushConfirmationStatus(browser))
+
+	assert.Equal(t, 422, browser.do("POST", "/login/pushconfirmation").Code)
+	_, loggedIn := browser.cookies["authenticatedsession"]
+	assert.False(t, loggedIn)
+}
+
+func TestPushChallengeUnknownDevice(t *testing.T) {
+	_, store, browser, _ := newPushLoginTest()
+
+	assert.Equal(t, http.StatusNotFound, browser.do("POST", "/login/pushchallenge/"+bson.NewObjectId().Hex()).Code)
+	assert.Empty(t, store.challenges)
+
+	//Without a login session there is nobody to send a challenge for
+	delete(browser.cookies, "loginsession")
+	assert.Equal(t, http.StatusUnauthorized, browser.do("POST", "/login/pushchallenge/"+store.devices[0].ID.Hex()).Code)
+}
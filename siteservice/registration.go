@@ -14,6 +14,7 @@ import (
 	"github.com/itsyouonline/identityserver/credentials/totp"
 	"github.com/itsyouonline/identityserver/db"
 	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/identityservice/security"
 	"github.com/itsyouonline/identityserver/siteservice/website/packaged/html"
 	"github.com/itsyouonline/identityserver/validation"
 )
@@ -140,6 +141,7 @@ func (service *Service) ProcessPhonenumberConfirmationForm(w http.ResponseWriter
 	if isConfirmed, _ := service.phonenumberValidationService.IsConfirmed(request, validationkey); isConfirmed {
 		userMgr := user.NewManager(request)
 		userMgr.RemoveExpireDate(username)
+		service.setAuthenticationMethods(request, security.AMRPassword, security.AMRSMS)
 		service.loginUser(w, request, username)
 		return
 	}
@@ -169,6 +171,7 @@ func (service *Service) ProcessPhonenumberConfirmationForm(w http.ResponseWriter
 	}
 	userMgr := user.NewManager(request)
 	userMgr.RemoveExpireDate(username)
+	service.setAuthenticationMethods(request, security.AMRPassword, security.AMRSMS)
 	service.loginUser(w, request, username)
 }
 
@@ -176,6 +179,7 @@ func (service *Service) ProcessPhonenumberConfirmationForm(w http.ResponseWriter
 func (service *Service) ResendPhonenumberConfirmation(w http.ResponseWriter, request *http.Request) {
 	values := struct {
 		PhoneNumber string `json:"phonenumber"`
+		Country     string `json:"country"`
 	}{}
 
 	response := struct {
@@ -208,6 +212,7 @@ func (service *Service) ResendPhonenumberConfirmation(w http.ResponseWriter, req
 	_ = service.phonenumberValidationService.ExpireValidation(request, validationkey)
 
 	phonenumber := user.Phonenumber{Label: "main", Phonenumber: values.PhoneNumber}
+	phonenumber.Normalize(values.Country)
 	if !phonenumber.IsValid() {
 		log.Debug("Invalid phone number")
 		w.WriteHeader(422)
@@ -215,6 +220,13 @@ func (service *Service) ResendPhonenumberConfirmation(w http.ResponseWriter, req
 		json.NewEncoder(w).Encode(&response)
 		return
 	}
+	if !phonenumber.CanReceiveSMS() {
+		log.Debug("Phone number can not receive sms: ", phonenumber.Phonenumber)
+		w.WriteHeader(422)
+		response.Error = "phonenumber_cannot_receive_sms"
+		json.NewEncoder(w).Encode(&response)
+		return
+	}
 
 	uMgr := user.NewManager(request)
 	err = uMgr.SavePhone(username, phonenumber)
@@ -236,6 +248,19 @@ func (service *Service) ResendPhonenumberConfirmation(w http.ResponseWriter, req
 	json.NewEncoder(w).Encode(&response)
 }
 
+//CallPhonenumberConfirmation reads out the phonenumber confirmation code in a voice call, for users that do not receive the sms
+func (service *Service) CallPhonenumberConfirmation(w http.ResponseWriter, request *http.Request) {
+	registrationSession, err := service.GetSession(request, SessionForRegistration, "registrationdetails")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	username, _ := registrationSession.Values["username"].(string)
+	validationkey, _ := registrationSession.Values["phonenumbervalidationkey"].(string)
+	service.callValidationCode(w, request, username, validationkey)
+}
+
 //ProcessRegistrationForm processes the user registration form
 func (service *Service) ProcessRegistrationForm(w http.ResponseWriter, request *http.Request) {
 	response := struct {
@@ -247,6 +272,7 @@ func (service *Service) ProcessRegistrationForm(w http.ResponseWriter, request *
 		Login          string `json:"login"`
 		Email          string `json:"email"`
 		Phonenumber    string `json:"phonenumber"`
+		Country        string `json:"country"`
 		TotpCode       string `json:"totpcode"`
 		Password       string `json:"password"`
 		RedirectParams string `json:"redirectparams"`
@@ -324,6 +350,7 @@ func (service *Service) ProcessRegistrationForm(w http.ResponseWriter, request *
 
 	if twoFAMethod == "sms" {
 		phonenumber = user.Phonenumber{Label: "main", Phonenumber: values.Phonenumber}
+		phonenumber.Normalize(values.Country)
 		if !phonenumber.IsValid() {
 			log.Debug("Invalid phone number")
 			w.WriteHeader(422)
@@ -331,6 +358,13 @@ func (service *Service) ProcessRegistrationForm(w http.ResponseWriter, request *
 			json.NewEncoder(w).Encode(&response)
 			return
 		}
+		if !phonenumber.CanReceiveSMS() {
+			log.Debug("Phone number can not receive sms: ", phonenumber.Phonenumber)
+			w.WriteHeader(422)
+			response.Error = "phonenumber_cannot_receive_sms"
+			json.NewEncoder(w).Encode(&response)
+			return
+		}
 		newuser.Phonenumbers = []user.Phonenumber{phonenumber}
 		// Remove account after 3 days if it still doesn't have a verified phone by then
 		duration := time.Duration(time.Hour * 24 * 3)
@@ -384,6 +418,7 @@ func (service *Service) ProcessRegistrationForm(w http.ResponseWriter, request *
 	totpMgr := totp.NewManager(request)
 	totpMgr.Save(newuser.Username, totpsecret)
 	log.Debugf("Registered %s", newuser.Username)
+	service.setAuthenticationMethods(request, security.AMRPassword, security.AMROTP)
 	service.loginUser(w, request, newuser.Username)
 }
 
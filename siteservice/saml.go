@@ -0,0 +1,345 @@
+package siteservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/organization"
+	samldb "github.com/itsyouonline/identityserver/db/saml"
+	"github.com/itsyouonline/identityserver/db/upstreamaccount"
+	"github.com/itsyouonline/identityserver/identityservice/security"
+	"github.com/itsyouonline/identityserver/saml"
+)
+
+//samlLinkForm asks the user to confirm linking the account at the identity provider of an organization
+var samlLinkForm = template.Must(template.New("samllink").Parse(`<!DOCTYPE html>
+<html>
+<body>
+<form method="post" action="{{.URL}}">
+<p>Link <strong>{{.NameID}}</strong> at the identity provider of <strong>{{.Globalid}}</strong> to your itsyou.online account <strong>{{.Username}}</strong>?</p>
+<p>Anyone that can log in as {{.NameID}} at {{.Globalid}} can then log in as {{.Username}}, and you become a member of {{.Globalid}}.</p>
+<input type="hidden" name="csrftoken" value="{{.CSRFToken}}"/>
+<input type="submit" value="Link"/>
+</form>
+</body>
+</html>`))
+
+//getSAMLIdentityProvider returns the identity provider configured for an organization, nil if there is none
+func (service *Service) getSAMLIdentityProvider(request *http.Request, globalid string) (*saml.IdentityProviderMetadata, error) {
+	if service.samlIdentityProviders != nil {
+		return service.samlIdentityProviders[globalid], nil
+	}
+	idp, err := samldb.NewManager(request).GetIdentityProvider(globalid)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saml.IdentityProviderMetadata{
+		EntityID:        idp.EntityID,
+		SingleSignOnURL: idp.SingleSignOnURL,
+		Certificates:    idp.Certificates,
+	}, nil
+}
+
+//getSAMLServiceProvider returns the service provider for the organization in the request path, nil if it has no identity provider
+func (service *Service) getSAMLServiceProvider(request *http.Request) (sp *saml.ServiceProvider, err error) {
+	globalid := mux.Vars(request)["globalid"]
+	idp, err := service.getSAMLIdentityProvider(request, globalid)
+	if err != nil || idp == nil {
+		return
+	}
+	baseURL := "https://" + request.Host + "/saml/" + globalid
+	sp = &saml.ServiceProvider{
+		EntityID:                    baseURL + "/metadata",
+		AssertionConsumerServiceURL: baseURL + "/acs",
+		IdentityProvider:            idp,
+	}
+	return
+}
+
+//GetSAMLProvider handler for GET /login/samlprovider
+// Tells the login page if the organization in the client_id lets its members login through its own identity provider
+func (service *Service) GetSAMLProvider(w http.ResponseWriter, request *http.Request) {
+	globalid := request.URL.Query().Get("client_id")
+	idp, err := service.getSAMLIdentityProvider(request, globalid)
+	if err != nil {
+		log.Error("Error loading the SAML identity provider: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if globalid == "" || idp == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"globalid": globalid})
+}
+
+//SAMLMetadata handler for GET /saml/{globalid}/metadata
+// Publishes the service provider metadata the identity provider of the organization needs
+func (service *Service) SAMLMetadata(w http.ResponseWriter, request *http.Request) {
+	sp, err := service.getSAMLServiceProvider(request)
+	if err != nil {
+		log.Error("Error loading the SAML identity provider: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if sp == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	metadata, err := sp.Metadata()
+	if err != nil {
+		log.Error("Error creating the SAML metadata: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(metadata)
+}
+
+//SAMLLogin handler for GET /saml/{globalid}/login
+// Sends the user to the identity provider of the organization, the query parameters of the login page are kept
+func (service *Service) SAMLLogin(w http.ResponseWriter, request *http.Request) {
+	sp, err := service.getSAMLServiceProvider(request)
+	if err != nil {
+		log.Error("Error loading the SAML identity provider: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if sp == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	requestID, redirectURL, err := sp.AuthnRequestURL("")
+	if err != nil {
+		log.Error("Error creating the SAML authentication request: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	samlSession, err := service.GetSession(request, SessionLogin, "samlsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	globalid := mux.Vars(request)["globalid"]
+	samlSession.Values = map[interface{}]interface{}{
+		"globalid":    globalid,
+		"requestid":   requestID,
+		"querystring": samlLoginQuery(request.URL.Query(), globalid),
+	}
+	sessions.Save(request, w)
+	http.Redirect(w, request, redirectURL, http.StatusFound)
+}
+
+//samlLoginQuery returns the query string of the login page to continue with after the identity provider of an organization
+// authenticated the user. The client_id is always the organization, another client could skip its own two factor authentication.
+func samlLoginQuery(query url.Values, globalid string) string {
+	query.Set("client_id", globalid)
+	return query.Encode()
+}
+
+//SAMLAssertionConsumerService handler for POST /saml/{globalid}/acs
+// The response is only accepted in the browser that sent the authentication request it answers,
+// so this cross site post does not need a csrf token.
+func (service *Service) SAMLAssertionConsumerService(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	samlSession, err := service.GetSession(request, SessionLogin, "samlsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	expectedGlobalid, _ := samlSession.Values["globalid"].(string)
+	requestID, _ := samlSession.Values["requestid"].(string)
+	queryString, _ := samlSession.Values["querystring"].(string)
+	//A response can only be used once
+	maxAge := samlSession.Options.MaxAge
+	samlSession.Options.MaxAge = -1
+
+	if requestID == "" || expectedGlobalid != globalid {
+		log.Debug("SAML response without a pending authentication request")
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired request, please try again.")
+		return
+	}
+	sp, err := service.getSAMLServiceProvider(request)
+	if err != nil {
+		log.Error("Error loading the SAML identity provider: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if sp == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	assertion, err := sp.ParseResponse(request.PostFormValue("SAMLResponse"), requestID)
+	if err == saml.ErrAuthenticationFailed {
+		service.renderEmailConfirmationPage(w, request, "Authentication at your organization failed, please try again.")
+		return
+	}
+	if err != nil {
+		log.Debug("Invalid SAML response for ", globalid, ": ", err)
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired request, please try again.")
+		return
+	}
+
+	account, err := upstreamaccount.NewManager(request).GetBySubject(upstreamaccount.SAMLProvider(globalid), assertion.NameID)
+	if err == mgo.ErrNotFound {
+		//Link just in time, the user proves the itsyou.online account is theirs by logging in
+		samlSession.Options.MaxAge = maxAge
+		samlSession.Values = map[interface{}]interface{}{
+			"linkglobalid": globalid,
+			"linknameid":   assertion.NameID,
+			"linkemail":    assertion.Attribute("email", "mail", "urn:oid:0.9.2342.19200300.100.1.3"),
+			"linkname":     assertion.Attribute("displayName", "name", "urn:oid:2.16.840.1.113730.3.1.241"),
+			"querystring":  queryString,
+		}
+		loginURL := "/login?" + url.Values{"endpoint": {"/saml/" + globalid + "/link"}, "client_id": {globalid}}.Encode()
+		service.renderEmailConfirmationPage(w, request, fmt.Sprintf(
+			"Your %s account is not linked to an itsyou.online account yet. <a href=\"%s\">Login</a> with your itsyou.online account to link it.",
+			template.HTMLEscapeString(globalid), template.HTMLEscapeString(loginURL)))
+		return
+	}
+	if err != nil {
+		log.Error("Error loading the linked SAML account: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	service.setAuthenticationMethods(request, security.AMRFederated)
+	service.continueLogin(w, request, loginSession, account.Username, queryString)
+}
+
+//SAMLLink handler for GET /saml/{globalid}/link
+// Asks the user that just logged in to confirm linking the account at the identity provider of the organization
+func (service *Service) SAMLLink(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	_, nameID, username, ok := service.getPendingSAMLLink(w, request)
+	if !ok {
+		return
+	}
+	csrfToken, err := service.getCSRFToken(w, request)
+	if err != nil {
+		log.Error("Error creating csrf token: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	sessions.Save(request, w)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	samlLinkForm.Execute(w, map[string]string{
+		"URL":       request.URL.Path,
+		"Globalid":  globalid,
+		"NameID":    nameID,
+		"Username":  username,
+		"CSRFToken": csrfToken,
+	})
+}
+
+//SAMLConfirmLink handler for POST /saml/{globalid}/link
+// Links the account at the identity provider of the organization to the user that confirmed it
+// and makes the user a member of the organization
+func (service *Service) SAMLConfirmLink(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	samlSession, nameID, username, ok := service.getPendingSAMLLink(w, request)
+	if !ok {
+		return
+	}
+	samlSession.Options.MaxAge = -1
+
+	account := &upstreamaccount.UpstreamAccount{
+		Provider: upstreamaccount.SAMLProvider(globalid),
+		Subject:  nameID,
+		Username: username,
+		Login:    nameID,
+	}
+	account.Email, _ = samlSession.Values["linkemail"].(string)
+	account.Name, _ = samlSession.Values["linkname"].(string)
+	err := upstreamaccount.NewManager(request).Link(account)
+	if err == db.ErrDuplicate {
+		service.renderEmailConfirmationPage(w, request, "This account is already linked to another itsyou.online account.")
+		return
+	}
+	if err != nil {
+		log.Error("Error linking the SAML account: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if err = addSAMLMember(request, globalid, username); err != nil {
+		log.Error("Error adding the SAML user to the organization: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	queryString, _ := samlSession.Values["querystring"].(string)
+	request.URL.RawQuery = queryString
+	redirectURL := service.getLoginRedirectURL(request)
+	sessions.Save(request, w)
+	http.Redirect(w, request, redirectURL, http.StatusFound)
+}
+
+//getPendingSAMLLink returns the NameID the identity provider of the organization in the path authenticated in this browser
+// and the user that recently logged in to link it to. If there is none, the response is written and ok is false.
+func (service *Service) getPendingSAMLLink(w http.ResponseWriter, request *http.Request) (samlSession *sessions.Session, nameID string, username string, ok bool) {
+	globalid := mux.Vars(request)["globalid"]
+	samlSession, err := service.GetSession(request, SessionLogin, "samlsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	linkGlobalid, _ := samlSession.Values["linkglobalid"].(string)
+	nameID, _ = samlSession.Values["linknameid"].(string)
+	if nameID == "" || linkGlobalid != globalid {
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired request, please try again.")
+		return
+	}
+	username, err = service.GetLoggedInUser(request, w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	ac, err := service.GetAuthenticationContext(request)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if username == "" || ac == nil || !ac.IsAuthenticatedWithin(security.RecentAuthenticationMaxAge) {
+		http.Redirect(w, request, "/login?"+url.Values{"endpoint": {request.URL.Path}, "client_id": {globalid}}.Encode(), http.StatusFound)
+		return
+	}
+	ok = true
+	return
+}
+
+//addSAMLMember adds the user to the members of the organization, unless the user is already an owner
+func addSAMLMember(request *http.Request, globalid string, username string) error {
+	orgMgr := organization.NewManager(request)
+	org, err := orgMgr.GetByName(globalid)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	isOwner, err := orgMgr.IsOwner(globalid, username)
+	if err != nil || isOwner {
+		return err
+	}
+	return orgMgr.SaveMember(org, username)
+}
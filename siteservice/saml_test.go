@@ -0,0 +1,111 @@
+package siteservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itsyouonline/identityserver/saml"
+)
+
+func newSAMLTestRouter() *mux.Router {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+	siteService.samlIdentityProviders = map[string]*saml.IdentityProviderMetadata{
+		"acme": {
+			EntityID:        "https://idp.acme.com/metadata",
+			SingleSignOnURL: "https://idp.acme.com/sso",
+		},
+	}
+	router := mux.NewRouter()
+	siteService.AddRoutes(router)
+	return router
+}
+
+func TestSAMLMetadata(t *testing.T) {
+	router := newSAMLTestRouter()
+
+	w := httptest.NewRecorder()
+	request := newBrowserRequest("GET", "/saml/acme/metadata", nil)
+	request.Host = "itsyou.online"
+	router.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `entityID="https://itsyou.online/saml/acme/metadata"`)
+	assert.Contains(t, w.Body.String(), `Location="https://itsyou.online/saml/acme/acs"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/saml/other/metadata", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/login/samlprovider?client_id=acme", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/login/samlprovider?client_id=other", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSAMLResponseIsBoundToTheRequest(t *testing.T) {
+	router := newSAMLTestRouter()
+
+	loginW := httptest.NewRecorder()
+	router.ServeHTTP(loginW, newBrowserRequest("GET", "/saml/acme/login?client_id=acme", nil))
+	assert.Equal(t, http.StatusFound, loginW.Code)
+	assert.True(t, strings.HasPrefix(loginW.Header().Get("Location"), "https://idp.acme.com/sso?SAMLRequest="))
+
+	postResponse := func(globalid string, previous *httptest.ResponseRecorder) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		request := newBrowserRequest("POST", "/saml/"+globalid+"/acs", previous)
+		request.Body = nil
+		form := url.Values{"SAMLResponse": {"bm90YXJlc3BvbnNl"}}
+		request.PostForm = form
+		router.ServeHTTP(w, request)
+		return w
+	}
+
+	//Without a pending authentication request in this browser, a response is never accepted
+	w := postResponse("acme", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid or expired request")
+	w = postResponse("other", loginW)
+	assert.Contains(t, w.Body.String(), "Invalid or expired request")
+
+	//The pending request is consumed by the first response
+	w = postResponse("acme", loginW)
+	assert.Contains(t, w.Body.String(), "Invalid or expired request")
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == "samlsession" {
+			assert.True(t, cookie.MaxAge < 0)
+		}
+	}
+}
+
+func TestSAMLLoginQuery(t *testing.T) {
+	query, _ := url.ParseQuery("client_id=other&response_type=code&redirect_uri=https%3A%2F%2Fexample.com")
+	parsed, _ := url.ParseQuery(samlLoginQuery(query, "acme"))
+	assert.Equal(t, "acme", parsed.Get("client_id"))
+	assert.Equal(t, "code", parsed.Get("response_type"))
+	assert.Equal(t, "https://example.com", parsed.Get("redirect_uri"))
+
+	parsed, _ = url.ParseQuery(samlLoginQuery(url.Values{}, "acme"))
+	assert.Equal(t, "acme", parsed.Get("client_id"))
+}
+
+func TestSAMLLinkNeedsConfirmation(t *testing.T) {
+	router := newSAMLTestRouter()
+
+	//Linking is a post with the csrf token of the confirmation page
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("POST", "/saml/acme/link", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	//Without an account authenticated at the identity provider there is nothing to confirm
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/saml/acme/link", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid or expired request")
+}
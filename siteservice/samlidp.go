@@ -0,0 +1,376 @@
+package siteservice
+
+import (
+	"crypto/tls"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/itsyouonline/identityserver/db/organization"
+	samldb "github.com/itsyouonline/identityserver/db/saml"
+	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/saml"
+)
+
+//samlPostForm sends the SAML response to the assertion consumer service of the service provider
+var samlPostForm = template.Must(template.New("samlpost").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.URL}}">
+<input type="hidden" name="SAMLResponse" value="{{.SAMLResponse}}"/>
+{{if .RelayState}}<input type="hidden" name="RelayState" value="{{.RelayState}}"/>{{end}}
+<noscript><input type="submit" value="Continue"/></noscript>
+</form>
+</body>
+</html>`))
+
+//SetSAMLSigningCertificate sets the key and certificate the SAML assertions are signed with,
+// the SAML identity provider endpoints are disabled until it is set
+func (service *Service) SetSAMLSigningCertificate(certificate tls.Certificate) {
+	service.samlKeyStore = dsig.TLSCertKeyStore(certificate)
+}
+
+//getSAMLIssuer returns the identity provider that signs the assertions for the service providers of an organization
+func (service *Service) getSAMLIssuer(request *http.Request, globalid string) *saml.IdentityProvider {
+	baseURL := "https://" + request.Host + "/samlidp/" + globalid
+	return &saml.IdentityProvider{
+		EntityID:        baseURL + "/metadata",
+		SingleSignOnURL: baseURL + "/sso",
+		KeyStore:        service.samlKeyStore,
+	}
+}
+
+//getSAMLServiceProviders returns the service providers an organization registered
+func (service *Service) getSAMLServiceProviders(request *http.Request, globalid string) ([]samldb.ServiceProvider, error) {
+	if service.samlServiceProviders != nil {
+		return service.samlServiceProviders[globalid], nil
+	}
+	return samldb.NewManager(request).GetServiceProviders(globalid)
+}
+
+//findSAMLServiceProvider returns the first service provider of the organization that matches, nil if there is none
+func (service *Service) findSAMLServiceProvider(request *http.Request, globalid string, match func(*samldb.ServiceProvider) bool) (*samldb.ServiceProvider, error) {
+	sps, err := service.getSAMLServiceProviders(request, globalid)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sps {
+		if match(&sps[i]) {
+			return &sps[i], nil
+		}
+	}
+	return nil, nil
+}
+
+//SAMLIdPMetadata handler for GET /samlidp/{globalid}/metadata
+// Publishes the identity provider metadata the service providers of the organization need
+func (service *Service) SAMLIdPMetadata(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	if service.samlKeyStore == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	sps, err := service.getSAMLServiceProviders(request, globalid)
+	if err != nil {
+		log.Error("Error loading the SAML service providers: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if len(sps) == 0 {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	metadata, err := service.getSAMLIssuer(request, globalid).Metadata()
+	if err != nil {
+		log.Error("Error creating the SAML metadata: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(metadata)
+}
+
+//SAMLIdPSingleSignOn handler for GET and POST /samlidp/{globalid}/sso
+// Accepts an authentication request of a registered service provider with the HTTP-Redirect or the HTTP-POST binding
+func (service *Service) SAMLIdPSingleSignOn(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	if service.samlKeyStore == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	var authnRequest *saml.AuthnRequest
+	var relayState string
+	var err error
+	if request.Method == "POST" {
+		authnRequest, err = saml.ParseAuthnRequest(request.PostFormValue("SAMLRequest"), false)
+		relayState = request.PostFormValue("RelayState")
+	} else {
+		authnRequest, err = saml.ParseAuthnRequest(request.URL.Query().Get("SAMLRequest"), true)
+		relayState = request.URL.Query().Get("RelayState")
+	}
+	if err != nil {
+		log.Debug("Invalid SAML authentication request for ", globalid, ": ", err)
+		service.renderEmailConfirmationPage(w, request, "Invalid authentication request.")
+		return
+	}
+	sp, err := service.findSAMLServiceProvider(request, globalid, func(sp *samldb.ServiceProvider) bool {
+		return sp.EntityID == authnRequest.Issuer
+	})
+	if err != nil {
+		log.Error("Error loading the SAML service providers: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	//Assertions are only ever sent to the assertion consumer service the organization registered
+	if sp == nil || (authnRequest.AssertionConsumerServiceURL != "" && authnRequest.AssertionConsumerServiceURL != sp.AssertionConsumerServiceURL) {
+		log.Debug("SAML authentication request from an unknown service provider: ", authnRequest.Issuer)
+		service.renderEmailConfirmationPage(w, request, "This application is not registered with the organization.")
+		return
+	}
+	service.startSAMLIdPRequest(w, request, globalid, sp.Label, authnRequest.ID, relayState, authnRequest.ForceAuthn)
+}
+
+//SAMLIdPInitiated handler for GET /samlidp/{globalid}/sso/{label}
+// Logs the user in to a service provider without an authentication request of the service provider
+func (service *Service) SAMLIdPInitiated(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	label := mux.Vars(request)["label"]
+	if service.samlKeyStore == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	sp, err := service.findSAMLServiceProvider(request, globalid, func(sp *samldb.ServiceProvider) bool {
+		return sp.Label == label
+	})
+	if err != nil {
+		log.Error("Error loading the SAML service providers: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if sp == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	service.startSAMLIdPRequest(w, request, globalid, label, "", request.URL.Query().Get("RelayState"), false)
+}
+
+//startSAMLIdPRequest keeps the pending request in the browser while the user logs in and authorizes the organization
+func (service *Service) startSAMLIdPRequest(w http.ResponseWriter, request *http.Request, globalid, label, requestID, relayState string, forceAuthn bool) {
+	idpSession, err := service.GetSession(request, SessionLogin, "samlidpsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	idpSession.Values = map[interface{}]interface{}{
+		"globalid":   globalid,
+		"label":      label,
+		"requestid":  requestID,
+		"relaystate": relayState,
+		"forceauthn": forceAuthn,
+		"requested":  time.Now().Unix(),
+	}
+	sessions.Save(request, w)
+	http.Redirect(w, request, "/samlidp/"+globalid+"/continue", http.StatusFound)
+}
+
+//SAMLIdPContinue handler for GET /samlidp/{globalid}/continue
+// Sends the signed assertion about the logged in user to the service provider once the user
+// authorized the organization to share the attributes the service provider asks for
+func (service *Service) SAMLIdPContinue(w http.ResponseWriter, request *http.Request) {
+	globalid := mux.Vars(request)["globalid"]
+	idpSession, err := service.GetSession(request, SessionLogin, "samlidpsession")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	expectedGlobalid, _ := idpSession.Values["globalid"].(string)
+	label, _ := idpSession.Values["label"].(string)
+	if service.samlKeyStore == nil || label == "" || expectedGlobalid != globalid {
+		service.renderEmailConfirmationPage(w, request, "Invalid or expired request, please try again.")
+		return
+	}
+	loginURL := "/login?" + url.Values{"endpoint": {request.URL.Path}, "client_id": {globalid}}.Encode()
+	username, err := service.GetLoggedInUser(request, w)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	ac, err := service.GetAuthenticationContext(request)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	requested, _ := idpSession.Values["requested"].(int64)
+	forceAuthn, _ := idpSession.Values["forceauthn"].(bool)
+	if username == "" || ac == nil || (forceAuthn && ac.AuthTime.Before(time.Unix(requested, 0))) {
+		http.Redirect(w, request, loginURL, http.StatusFound)
+		return
+	}
+	sp, err := service.findSAMLServiceProvider(request, globalid, func(sp *samldb.ServiceProvider) bool {
+		return sp.Label == label
+	})
+	if err != nil {
+		log.Error("Error loading the SAML service providers: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if sp == nil {
+		service.renderEmailConfirmationPage(w, request, "This application is not registered with the organization.")
+		return
+	}
+
+	possibleScopes, err := filterPossibleSAMLScopes(request, username, sp.Scopes)
+	if err != nil {
+		log.Error("Error filtering the SAML scopes: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	userMgr := user.NewManager(request)
+	authorization, err := userMgr.GetAuthorization(username, globalid)
+	if err != nil {
+		log.Error("Error loading the authorization: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	var authorizedScopes []string
+	if authorization != nil {
+		authorizedScopes = authorization.FilterAuthorizedScopes(possibleScopes)
+	}
+	//Ask the user to authorize the organization, coming back from the authorize page the user may have refused some scopes
+	if needsSAMLConsent(authorization, authorizedScopes, possibleScopes) {
+		if !askedSAMLConsent(idpSession, possibleScopes) {
+			idpSession.Values["consentscopes"] = strings.Join(possibleScopes, ",")
+			sessions.Save(request, w)
+			queryValues := url.Values{
+				"client_id": {globalid},
+				"scope":     {strings.Join(possibleScopes, ",")},
+				"endpoint":  {request.URL.Path},
+			}
+			http.Redirect(w, request, "/authorize?"+queryValues.Encode(), http.StatusFound)
+			return
+		}
+		//The user refused to authorize the organization, nothing is sent to the service provider
+		if !isAuthorized(authorization) {
+			idpSession.Options.MaxAge = -1
+			service.renderEmailConfirmationPage(w, request, "You did not authorize the organization, you are not logged in to the application.")
+			return
+		}
+	}
+	userobj, err := userMgr.GetByName(username)
+	if err != nil {
+		log.Error("Error loading the user: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	spMetadata := &saml.ServiceProviderMetadata{EntityID: sp.EntityID, AssertionConsumerServiceURL: sp.AssertionConsumerServiceURL}
+	requestID, _ := idpSession.Values["requestid"].(string)
+	relayState, _ := idpSession.Values["relaystate"].(string)
+	attributes := samlAttributes(userobj, authorization, authorizedScopes)
+	samlResponse, err := service.getSAMLIssuer(request, globalid).NewResponse(spMetadata, requestID, username, ac.AuthTime, attributes)
+	if err != nil {
+		log.Error("Error creating the SAML response: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	//A pending request is answered only once
+	idpSession.Options.MaxAge = -1
+	sessions.Save(request, w)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	samlPostForm.Execute(w, map[string]string{
+		"URL":          sp.AssertionConsumerServiceURL,
+		"SAMLResponse": samlResponse,
+		"RelayState":   relayState,
+	})
+}
+
+//askedSAMLConsent checks if the user was already sent to the authorize page for these scopes during the pending request.
+// The pending request remembers it since nothing in the request coming back from the authorize page can be trusted to tell.
+func askedSAMLConsent(idpSession *sessions.Session, scopes []string) bool {
+	asked, ok := idpSession.Values["consentscopes"].(string)
+	return ok && asked == strings.Join(scopes, ",")
+}
+
+//needsSAMLConsent checks if the user still has to authorize the organization before an assertion is sent,
+// even without scopes the user has to authorize the organization once
+func needsSAMLConsent(authorization *user.Authorization, authorizedScopes []string, possibleScopes []string) bool {
+	return !isAuthorized(authorization) || len(authorizedScopes) < len(possibleScopes)
+}
+
+//isAuthorized checks if the user stored an authorization for the organization
+func isAuthorized(authorization *user.Authorization) bool {
+	return authorization != nil && authorization.Username != ""
+}
+
+//filterPossibleSAMLScopes drops the user:memberof scopes of organizations the user is not a member or an owner of
+// and the ones of roles the user does not have
+func filterPossibleSAMLScopes(request *http.Request, username string, scopes []string) (possibleScopes []string, err error) {
+	possibleScopes = make([]string, 0, len(scopes))
+	orgMgr := organization.NewManager(request)
+	for _, scope := range scopes {
+		if strings.HasPrefix(scope, "user:memberof:") {
+			globalid, role := organization.SplitMembership(strings.TrimPrefix(scope, "user:memberof:"))
+			if role != "" {
+				var hasRole bool
+				if hasRole, err = orgMgr.HasRole(globalid, username, role); err != nil {
+					return
+				}
+				if hasRole {
+					possibleScopes = append(possibleScopes, scope)
+				}
+				continue
+			}
+			var isMember, isOwner bool
+			if isMember, err = orgMgr.IsMember(globalid, username); err != nil {
+				return
+			}
+			if !isMember {
+				if isOwner, err = orgMgr.IsOwner(globalid, username); err != nil {
+					return
+				}
+			}
+			if !isMember && !isOwner {
+				continue
+			}
+		}
+		possibleScopes = append(possibleScopes, scope)
+	}
+	return
+}
+
+//samlAttributes builds the attributes of an assertion from the authorized scopes
+func samlAttributes(userobj *user.User, authorization *user.Authorization, authorizedScopes []string) map[string][]string {
+	attributes := make(map[string][]string)
+	for _, scope := range authorizedScopes {
+		switch {
+		case scope == "user:name":
+			attributes["givenName"] = []string{userobj.Firstname}
+			attributes["sn"] = []string{userobj.Lastname}
+			attributes["displayName"] = []string{strings.TrimSpace(userobj.Firstname + " " + userobj.Lastname)}
+		case scope == "user:email" || strings.HasPrefix(scope, "user:email:"):
+			requestedLabel := strings.TrimPrefix(strings.TrimPrefix(scope, "user:email"), ":")
+			for _, emailmap := range authorization.EmailAddresses {
+				//Without a label, the first authorized email address is used
+				if requestedLabel != "" && emailmap.RequestedLabel != requestedLabel {
+					continue
+				}
+				if email, err := userobj.GetEmailAddressByLabel(emailmap.RealLabel); err == nil {
+					attributes["email"] = append(attributes["email"], email.EmailAddress)
+				}
+				break
+			}
+		case strings.HasPrefix(scope, "user:memberof:"):
+			attributes["memberof"] = append(attributes["memberof"], strings.TrimPrefix(scope, "user:memberof:"))
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,127 @@
+package siteservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+
+	samldb "github.com/itsyouonline/identityserver/db/saml"
+	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/saml"
+)
+
+func newSAMLIdPTestRouter() *mux.Router {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+	siteService.samlKeyStore = dsig.RandomKeyStoreForTest()
+	siteService.samlServiceProviders = map[string][]samldb.ServiceProvider{
+		"acme": {{
+			Globalid:                    "acme",
+			Label:                       "wiki",
+			EntityID:                    "https://wiki.acme.com/metadata",
+			AssertionConsumerServiceURL: "https://wiki.acme.com/acs",
+			Scopes:                      []string{"user:name", "user:memberof:acme"},
+		}},
+	}
+	router := mux.NewRouter()
+	siteService.AddRoutes(router)
+	return router
+}
+
+func TestSAMLIdPMetadata(t *testing.T) {
+	router := newSAMLIdPTestRouter()
+
+	w := httptest.NewRecorder()
+	request := newBrowserRequest("GET", "/samlidp/acme/metadata", nil)
+	request.Host = "itsyou.online"
+	router.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusOK, w.Code)
+	idp, err := saml.ParseIdentityProviderMetadata(w.Body.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://itsyou.online/samlidp/acme/metadata", idp.EntityID)
+	assert.Equal(t, "https://itsyou.online/samlidp/acme/sso", idp.SingleSignOnURL)
+
+	//Organizations without service providers are no identity provider
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/samlidp/other/metadata", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSAMLIdPOnlyAnswersRegisteredServiceProviders(t *testing.T) {
+	router := newSAMLIdPTestRouter()
+	authnRequest := func(entityID string, acs string) string {
+		sp := &saml.ServiceProvider{
+			EntityID:                    entityID,
+			AssertionConsumerServiceURL: acs,
+			IdentityProvider:            &saml.IdentityProviderMetadata{SingleSignOnURL: "https://itsyou.online/samlidp/acme/sso"},
+		}
+		_, redirectURL, err := sp.AuthnRequestURL("state")
+		assert.NoError(t, err)
+		u, _ := url.Parse(redirectURL)
+		return u.Path + "?" + u.RawQuery
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", authnRequest("https://other.com/metadata", "https://other.com/acs"), nil))
+	assert.Contains(t, w.Body.String(), "This application is not registered")
+	//A registered service provider can not have the assertion sent elsewhere
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", authnRequest("https://wiki.acme.com/metadata", "https://evil.com/acs"), nil))
+	assert.Contains(t, w.Body.String(), "This application is not registered")
+
+	ssoW := httptest.NewRecorder()
+	router.ServeHTTP(ssoW, newBrowserRequest("GET", authnRequest("https://wiki.acme.com/metadata", "https://wiki.acme.com/acs"), nil))
+	assert.Equal(t, http.StatusFound, ssoW.Code)
+	assert.Equal(t, "/samlidp/acme/continue", ssoW.Header().Get("Location"))
+
+	//The user needs to login before the assertion is sent
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/samlidp/acme/continue", ssoW))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Location"), "/login?"))
+	assert.Contains(t, w.Header().Get("Location"), "client_id=acme")
+
+	//Without a pending request, nothing is sent
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/samlidp/acme/continue", nil))
+	assert.Contains(t, w.Body.String(), "Invalid or expired request")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/samlidp/acme/sso/wiki?RelayState=home", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/samlidp/acme/sso/other", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAskedSAMLConsent(t *testing.T) {
+	idpSession := sessions.NewSession(nil, "samlidpsession")
+	scopes := []string{"user:name", "user:memberof:acme"}
+	assert.False(t, askedSAMLConsent(idpSession, scopes))
+
+	idpSession.Values["consentscopes"] = "user:name,user:memberof:acme"
+	assert.True(t, askedSAMLConsent(idpSession, scopes))
+	//The user becoming member of another organization in the meantime needs a new consent
+	assert.False(t, askedSAMLConsent(idpSession, append(scopes, "user:memberof:acme.hr")))
+
+	//Service providers that ask for no attributes get the consent asked as well
+	assert.False(t, askedSAMLConsent(idpSession, []string{}))
+	idpSession.Values["consentscopes"] = ""
+	assert.True(t, askedSAMLConsent(idpSession, []string{}))
+}
+
+func TestNeedsSAMLConsent(t *testing.T) {
+	scopes := []string{"user:name"}
+	assert.True(t, needsSAMLConsent(nil, nil, nil))
+	assert.True(t, needsSAMLConsent(&user.Authorization{}, nil, nil), "no authorization was stored")
+	authorization := &user.Authorization{Username: "alice", GrantedTo: "acme"}
+	assert.False(t, needsSAMLConsent(authorization, nil, nil))
+	assert.True(t, needsSAMLConsent(authorization, nil, scopes))
+	assert.False(t, needsSAMLConsent(authorization, scopes, scopes))
+}
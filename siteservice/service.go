@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/sessions"
+	"github.com/justinas/alice"
 	"github.com/itsyouonline/identityserver/communication"
 	"github.com/itsyouonline/identityserver/siteservice/apiconsole"
 	"github.com/itsyouonline/identityserver/siteservice/website/packaged/assets"
@@ -16,24 +17,40 @@ import (
 
 	"encoding/json"
 	log "github.com/Sirupsen/logrus"
+	dsig "github.com/russellhaering/goxmldsig"
 	"github.com/itsyouonline/identityserver/credentials/totp"
-	"github.com/itsyouonline/identityserver/identityservice"
+	samldb "github.com/itsyouonline/identityserver/db/saml"
+	"github.com/itsyouonline/identityserver/db/upstreamaccount"
+	"github.com/itsyouonline/identityserver/db/websession"
+	"github.com/itsyouonline/identityserver/saml"
 	"github.com/itsyouonline/identityserver/tools/assetfs"
 )
 
 //Service is the identityserver http service
 type Service struct {
-	Sessions                      map[SessionType]*sessions.CookieStore
+	Sessions                      map[SessionType]sessions.Store
 	smsService                    communication.SMSService
 	phonenumberValidationService  *validation.IYOPhonenumberValidationService
 	EmailService                  communication.EmailService
 	emailaddressValidationService *validation.IYOEmailAddressValidationService
+	pushService                   communication.PushService
+	voiceService                  communication.VoiceService
+	//upstreamProviders overrides the providers configured in the globalconfig when set
+	upstreamProviders map[string]UpstreamProvider
+	//samlIdentityProviders overrides the identity providers the organizations configured when set
+	samlIdentityProviders map[string]*saml.IdentityProviderMetadata
+	//samlKeyStore signs the assertions of the SAML identity provider
+	samlKeyStore dsig.X509KeyStore
+	//samlServiceProviders overrides the service providers the organizations registered when set
+	samlServiceProviders map[string][]samldb.ServiceProvider
+	//pushDevices overrides the paired devices and push challenges stored in the database when set
+	pushDevices pushDeviceStore
 }
 
 //NewService creates and initializes a Service
-func NewService(cookieSecret string, smsService communication.SMSService, emailService communication.EmailService) (service *Service) {
-	service = &Service{smsService: smsService}
-	p := &validation.IYOPhonenumberValidationService{SMSService: smsService}
+func NewService(cookieSecret string, smsService communication.SMSService, emailService communication.EmailService, pushService communication.PushService, voiceService communication.VoiceService) (service *Service) {
+	service = &Service{smsService: smsService, pushService: pushService, voiceService: voiceService}
+	p := &validation.IYOPhonenumberValidationService{SMSService: smsService, VoiceService: voiceService}
 	service.phonenumberValidationService = p
 	e := &validation.IYOEmailAddressValidationService{EmailService: emailService}
 	service.emailaddressValidationService = e
@@ -45,38 +62,67 @@ func NewService(cookieSecret string, smsService communication.SMSService, emailS
 func (service *Service) InitModels() {
 	service.initLoginModels()
 	service.initRegistrationModels()
+	service.initEmailLoginModels()
+	websession.InitModels()
+	upstreamaccount.InitModels()
 }
 
 //AddRoutes registers the http routes with the router
 func (service *Service) AddRoutes(router *mux.Router) {
+	//State changing requests need to pass the csrf token
+	csrfProtected := alice.New(service.CSRFMiddleware)
+
 	router.Methods("GET").Path("/").HandlerFunc(service.HomePage)
 	//Registration form
 	router.Methods("GET").Path("/validateusername").HandlerFunc(service.ValidateUsername)
 	router.Methods("GET").Path("/register").HandlerFunc(service.ShowRegistrationForm)
-	router.Methods("POST").Path("/register").HandlerFunc(service.ProcessRegistrationForm)
+	router.Methods("POST").Path("/register").Handler(csrfProtected.ThenFunc(service.ProcessRegistrationForm))
 	router.Methods("GET").Path("/phonevalidation").HandlerFunc(service.PhonenumberValidation)
 	router.Methods("GET").Path("/emailvalidation").HandlerFunc(service.EmailValidation)
-	router.Methods("POST").Path("/register/resendsms").HandlerFunc(service.ResendPhonenumberConfirmation)
+	router.Methods("POST").Path("/register/resendsms").Handler(csrfProtected.ThenFunc(service.ResendPhonenumberConfirmation))
+	router.Methods("POST").Path("/register/callme").Handler(csrfProtected.ThenFunc(service.CallPhonenumberConfirmation))
 	router.Methods("GET").Path("/register/smsconfirmed").HandlerFunc(service.CheckRegistrationSMSConfirmation)
-	router.Methods("POST").Path("/register/smsconfirmation").HandlerFunc(service.ProcessPhonenumberConfirmationForm)
+	router.Methods("POST").Path("/register/smsconfirmation").Handler(csrfProtected.ThenFunc(service.ProcessPhonenumberConfirmationForm))
 	//Login forms
 	router.Methods("GET").Path("/login").HandlerFunc(service.ShowLoginForm)
-	router.Methods("POST").Path("/login").HandlerFunc(service.ProcessLoginForm)
+	router.Methods("POST").Path("/login").Handler(csrfProtected.ThenFunc(service.ProcessLoginForm))
 	router.Methods("GET").Path("/login/twofamethods").HandlerFunc(service.GetTwoFactorAuthenticationMethods)
-	router.Methods("POST").Path("/login/totpconfirmation").HandlerFunc(service.ProcessTOTPConfirmation)
-	router.Methods("POST").Path("/login/smscode/{phoneLabel}").HandlerFunc(service.GetSmsCode)
-	router.Methods("POST").Path("/login/smsconfirmation").HandlerFunc(service.Process2FASMSConfirmation)
-	router.Methods("POST").Path("/login/resendsms").HandlerFunc(service.LoginResendPhonenumberConfirmation)
+	router.Methods("POST").Path("/login/totpconfirmation").Handler(csrfProtected.ThenFunc(service.ProcessTOTPConfirmation))
+	router.Methods("POST").Path("/login/smscode/{phoneLabel}").Handler(csrfProtected.ThenFunc(service.GetSmsCode))
+	router.Methods("POST").Path("/login/voicecode/{phoneLabel}").Handler(csrfProtected.ThenFunc(service.CallLoginCode))
+	router.Methods("POST").Path("/login/smsconfirmation").Handler(csrfProtected.ThenFunc(service.Process2FASMSConfirmation))
+	router.Methods("POST").Path("/login/resendsms").Handler(csrfProtected.ThenFunc(service.LoginResendPhonenumberConfirmation))
+	router.Methods("POST").Path("/login/callme").Handler(csrfProtected.ThenFunc(service.LoginCallPhonenumberConfirmation))
 	router.Methods("GET").Path("/sc").HandlerFunc(service.MobileSMSConfirmation)
 	router.Methods("GET").Path("/login/smsconfirmed").HandlerFunc(service.Check2FASMSConfirmation)
-	router.Methods("POST").Path("/login/forgotpassword").HandlerFunc(service.ForgotPassword)
-	router.Methods("POST").Path("/login/resetpassword").HandlerFunc(service.ResetPassword)
+	router.Methods("POST").Path("/login/pushchallenge/{deviceID}").Handler(csrfProtected.ThenFunc(service.SendPushChallenge))
+	router.Methods("GET").Path("/login/pushconfirmed").HandlerFunc(service.CheckPushConfirmation)
+	router.Methods("POST").Path("/login/pushconfirmation").Handler(csrfProtected.ThenFunc(service.ProcessPushConfirmation))
+	router.Methods("POST").Path("/login/forgotpassword").Handler(csrfProtected.ThenFunc(service.ForgotPassword))
+	router.Methods("POST").Path("/login/resetpassword").Handler(csrfProtected.ThenFunc(service.ResetPassword))
+	router.Methods("POST").Path("/login/emaillink").Handler(csrfProtected.ThenFunc(service.RequestLoginLink))
+	router.Methods("GET").Path("/login/emaillink").HandlerFunc(service.ProcessLoginLink)
 	//Authorize form
 	router.Methods("GET").Path("/authorize").HandlerFunc(service.ShowAuthorizeForm)
-	//Facebook callback
-	router.Methods("GET").Path("/facebook_callback").HandlerFunc(service.FacebookCallback)
-	//Github callback
-	router.Methods("GET").Path("/github_callback").HandlerFunc(service.GithubCallback)
+	//Upstream identity providers
+	router.Methods("GET").Path("/login/upstreamproviders").HandlerFunc(service.GetUpstreamProviders)
+	router.Methods("GET").Path("/upstream/{provider}/link").HandlerFunc(service.UpstreamLink)
+	router.Methods("GET").Path("/upstream/{provider}/login").HandlerFunc(service.UpstreamLogin)
+	router.Methods("GET").Path("/upstream/{provider}/callback").HandlerFunc(service.UpstreamCallback)
+	router.Methods("GET").Path("/facebook_callback").HandlerFunc(service.LegacyUpstreamCallback("facebook"))
+	router.Methods("GET").Path("/github_callback").HandlerFunc(service.LegacyUpstreamCallback("github"))
+	//SAML identity providers of organizations
+	router.Methods("GET").Path("/login/samlprovider").HandlerFunc(service.GetSAMLProvider)
+	router.Methods("GET").Path("/saml/{globalid}/metadata").HandlerFunc(service.SAMLMetadata)
+	router.Methods("GET").Path("/saml/{globalid}/login").HandlerFunc(service.SAMLLogin)
+	router.Methods("POST").Path("/saml/{globalid}/acs").HandlerFunc(service.SAMLAssertionConsumerService)
+	router.Methods("GET").Path("/saml/{globalid}/link").HandlerFunc(service.SAMLLink)
+	router.Methods("POST").Path("/saml/{globalid}/link").Handler(csrfProtected.ThenFunc(service.SAMLConfirmLink))
+	//SAML identity provider for the service providers of an organization
+	router.Methods("GET").Path("/samlidp/{globalid}/metadata").HandlerFunc(service.SAMLIdPMetadata)
+	router.Methods("GET", "POST").Path("/samlidp/{globalid}/sso").HandlerFunc(service.SAMLIdPSingleSignOn)
+	router.Methods("GET").Path("/samlidp/{globalid}/sso/{label}").HandlerFunc(service.SAMLIdPInitiated)
+	router.Methods("GET").Path("/samlidp/{globalid}/continue").HandlerFunc(service.SAMLIdPContinue)
 	//Logout link
 	router.Methods("GET").Path("/logout").HandlerFunc(service.Logout)
 	//Error page
@@ -148,14 +194,25 @@ func (service *Service) HomePage(w http.ResponseWriter, request *http.Request) {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
+	//The signout link needs the csrf token
+	if _, err = service.getCSRFToken(w, request); err != nil {
+		log.Error("Error creating csrf token: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
 	sessions.Save(request, w)
 	w.Write(htmlData)
 }
 
 //Logout logs out the user and redirect to the homepage
-//TODO: csrf protection, really important here!
+// Since this is a plain link, the csrf token is passed as a query parameter
 func (service *Service) Logout(w http.ResponseWriter, request *http.Request) {
-	service.SetLoggedInUser(w, request, "")
+	if !service.validCSRFToken(request) {
+		log.Debug("Logout request without a valid csrf token")
+		http.Redirect(w, request, "", http.StatusFound)
+		return
+	}
+	service.ClearLoggedInUser(w, request)
 	sessions.Save(request, w)
 	http.Redirect(w, request, "", http.StatusFound)
 }
@@ -213,14 +270,18 @@ func (service *Service) GetConfig(w http.ResponseWriter, request *http.Request)
 		return
 	}
 	totpsession.Values["secret"] = token.Secret
+	csrfToken, err := service.getCSRFToken(w, request)
+	if err != nil {
+		log.Error("Error creating csrf token: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
 	sessions.Save(request, w)
 	data := struct {
-		TotpSecret       string `json:"totpsecret"`
-		GithubClientId   string `json:"githubclientid"`
-		FacebookClientId string `json:"facebookclientid"`
+		TotpSecret string `json:"totpsecret"`
+		CSRFToken  string `json:"csrftoken"`
 	}{}
 	data.TotpSecret = token.Secret
-	data.GithubClientId, _ = identityservice.GetOauthClientID("github")
-	data.FacebookClientId, _ = identityservice.GetOauthClientID("facebook")
+	data.CSRFToken = csrfToken
 	json.NewEncoder(w).Encode(&data)
 }
@@ -2,11 +2,13 @@ package siteservice
 
 import (
 	"net/http"
+	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/sessions"
 
 	"github.com/gorilla/context"
+	"github.com/itsyouonline/identityserver/identityservice/security"
 	"time"
 )
 
@@ -20,6 +22,8 @@ const (
 	SessionInteractive SessionType = iota
 	//SessionLogin is the session during the login flow
 	SessionLogin SessionType = iota
+	//SessionCSRF holds the csrf token for as long as the browser is open
+	SessionCSRF SessionType = iota
 )
 
 //initializeSessionStore creates a cookieStore
@@ -34,11 +38,13 @@ func initializeSessionStore(cookieSecret string, maxAge int) (sessionStore *sess
 }
 
 func (service *Service) initializeSessions(cookieSecret string) {
-	service.Sessions = make(map[SessionType]*sessions.CookieStore)
+	service.Sessions = make(map[SessionType]sessions.Store)
 
 	service.Sessions[SessionForRegistration] = initializeSessionStore(cookieSecret, 10*60)
-	service.Sessions[SessionInteractive] = initializeSessionStore(cookieSecret, 10*60)
+	//Authenticated sessions are kept server side so they can be revoked
+	service.Sessions[SessionInteractive] = NewMongoStore(cookieSecret, 10*60)
 	service.Sessions[SessionLogin] = initializeSessionStore(cookieSecret, 5*60)
+	service.Sessions[SessionCSRF] = initializeSessionStore(cookieSecret, 0)
 
 }
 
@@ -47,14 +53,57 @@ func (service *Service) GetSession(request *http.Request, kind SessionType, name
 	return service.Sessions[kind].Get(request, name)
 }
 
-//SetLoggedInUser creates a session for an authenticated user and clears the login session
+//setAuthenticationMethods replaces the authentication methods recorded in the login session,
+// it is used when a first factor is validated
+func (service *Service) setAuthenticationMethods(request *http.Request, methods ...string) (err error) {
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	loginSession.Values["amr"] = strings.Join(methods, " ")
+	return
+}
+
+//addAuthenticationMethod records an additional authentication method in the login session
+func (service *Service) addAuthenticationMethod(request *http.Request, method string) (err error) {
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	amr, _ := loginSession.Values["amr"].(string)
+	methods := strings.Fields(amr)
+	for _, recorded := range methods {
+		if recorded == method {
+			return
+		}
+	}
+	loginSession.Values["amr"] = strings.Join(append(methods, method), " ")
+	return
+}
+
+//SetLoggedInUser creates a session for an authenticated user and clears the login session.
+// The authentication methods recorded in the login session are kept together with the time of the login.
 func (service *Service) SetLoggedInUser(w http.ResponseWriter, request *http.Request, username string) (err error) {
 	authenticatedSession, err := service.GetSession(request, SessionInteractive, "authenticatedsession")
 	if err != nil {
 		log.Error(err)
 		return
 	}
+	loginSession, err := service.GetSession(request, SessionLogin, "loginsession")
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	//Never reuse the session key of before the login, it could have been planted by someone else
+	if err = regenerateSession(request, authenticatedSession); err != nil {
+		log.Error(err)
+		return
+	}
 	authenticatedSession.Values["username"] = username
+	authenticatedSession.Values["amr"], _ = loginSession.Values["amr"].(string)
+	authenticatedSession.Values["auth_time"] = time.Now().Unix()
 
 	//TODO: rework this, is not really secure I think
 	// Set user cookie after successful login
@@ -110,11 +159,61 @@ func (service *Service) GetLoggedInUser(request *http.Request, w http.ResponseWr
 	return
 }
 
+//GetAuthenticationContext returns how the authenticated user logged in, nil if there is no authenticated user
+func (service *Service) GetAuthenticationContext(request *http.Request) (ac *security.AuthenticationContext, err error) {
+	authenticatedSession, err := service.GetSession(request, SessionInteractive, "authenticatedsession")
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if username, _ := authenticatedSession.Values["username"].(string); username == "" {
+		return
+	}
+	amr, _ := authenticatedSession.Values["amr"].(string)
+	ac = &security.AuthenticationContext{Methods: strings.Fields(amr)}
+	//Sessions created before the authentication time was recorded are treated as not recently authenticated
+	if authTime, ok := authenticatedSession.Values["auth_time"].(int64); ok {
+		ac.AuthTime = time.Unix(authTime, 0)
+	}
+	return
+}
+
+//ClearLoggedInUser removes the authenticated session from the server and the browser
+func (service *Service) ClearLoggedInUser(w http.ResponseWriter, request *http.Request) (err error) {
+	authenticatedSession, err := service.GetSession(request, SessionInteractive, "authenticatedsession")
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	authenticatedSession.Options.MaxAge = -1
+	err = authenticatedSession.Save(request, w)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	// Clear user cookie
+	cookie := &http.Cookie{
+		Name:    "itsyou.online.user",
+		Path:    "/",
+		Value:   "",
+		Expires: time.Unix(1, 0),
+	}
+	http.SetCookie(w, cookie)
+	return
+}
+
 //SetWebUserMiddleWare puthe the authenticated user on the context
+// The key of the authenticated session is also put on the context so the api can recognize the current session
 func (service *Service) SetWebUserMiddleWare(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
 		if username, err := service.GetLoggedInUser(request, w); err == nil {
 			context.Set(request, "webuser", username)
+			if ac, err := service.GetAuthenticationContext(request); err == nil {
+				security.SetAuthenticationContext(request, ac)
+			}
+			if authenticatedSession, err := service.GetSession(request, SessionInteractive, "authenticatedsession"); err == nil {
+				context.Set(request, "websession", authenticatedSession.ID)
+			}
 		}
 
 		next.ServeHTTP(w, request)
@@ -2,14 +2,17 @@ package siteservice
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/itsyouonline/identityserver/identityservice/security"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestAvailableSessions(t *testing.T) {
 
-	siteService := NewService("MyCookieSecret", nil, nil)
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
 	request := &http.Request{}
 
 	session, err := siteService.GetSession(request, SessionForRegistration, "akey")
@@ -21,3 +24,24 @@ func TestAvailableSessions(t *testing.T) {
 	assert.NotNil(t, session)
 
 }
+
+func TestAuthenticationMethodsAreRecordedOnLogin(t *testing.T) {
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+	request, _ := http.NewRequest("POST", "/login/totpconfirmation", nil)
+	w := httptest.NewRecorder()
+
+	ac, err := siteService.GetAuthenticationContext(request)
+	assert.NoError(t, err)
+	assert.Nil(t, ac)
+
+	assert.NoError(t, siteService.setAuthenticationMethods(request, security.AMRPassword))
+	assert.NoError(t, siteService.addAuthenticationMethod(request, security.AMRPassword))
+	assert.NoError(t, siteService.addAuthenticationMethod(request, security.AMROTP))
+	assert.NoError(t, siteService.SetLoggedInUser(w, request, "john"))
+
+	ac, err = siteService.GetAuthenticationContext(request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{security.AMRPassword, security.AMROTP}, ac.Methods)
+	assert.Equal(t, security.ACRMultiFactor, ac.ACR())
+	assert.True(t, ac.IsAuthenticatedWithin(time.Minute))
+}
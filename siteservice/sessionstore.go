@@ -0,0 +1,157 @@
+package siteservice
+
+import (
+	"encoding/base32"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/websession"
+)
+
+//MongoStore is a sessions.Store that keeps the session values in mongo.
+// The cookie only contains a signed opaque key so sessions can be listed and revoked on the server.
+type MongoStore struct {
+	Codecs  []securecookie.Codec
+	Options *sessions.Options
+}
+
+//NewMongoStore creates a MongoStore
+// mageAge is the maximum age in seconds
+func NewMongoStore(cookieSecret string, maxAge int) *MongoStore {
+	store := &MongoStore{
+		Codecs: securecookie.CodecsFromPairs([]byte(cookieSecret)),
+		Options: &sessions.Options{
+			Path:     "/",
+			MaxAge:   maxAge,
+			HttpOnly: true,
+			Secure:   true,
+		},
+	}
+	for _, codec := range store.Codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(maxAge)
+		}
+	}
+	return store
+}
+
+//Get returns a session for the given name after adding it to the registry.
+func (s *MongoStore) Get(request *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(request).Get(s, name)
+}
+
+//New returns a session for the given name without adding it to the registry.
+// If the session was revoked or expired on the server, a new empty session is returned.
+func (s *MongoStore) New(request *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	opts := *s.Options
+	session.Options = &opts
+	session.IsNew = true
+
+	cookie, err := request.Cookie(name)
+	if err != nil || db.GetDBSession(request) == nil {
+		return session, nil
+	}
+	var key string
+	if err = securecookie.DecodeMulti(name, cookie.Value, &key, s.Codecs...); err != nil {
+		log.Debug("Invalid session cookie: ", err)
+		return session, nil
+	}
+	stored, err := websession.NewManager(request).GetByKey(key)
+	if err != nil || stored == nil {
+		return session, err
+	}
+	if s.isExpired(stored) {
+		return session, nil
+	}
+	if err = securecookie.DecodeMulti(name, stored.Values, &session.Values, s.Codecs...); err != nil {
+		log.Debug("Invalid stored session: ", err)
+		return session, nil
+	}
+	session.ID = key
+	session.IsNew = false
+	return session, nil
+}
+
+//Save persists the session and writes the cookie holding the session key.
+// Setting session.Options.MaxAge to a negative value removes the session from the server.
+func (s *MongoStore) Save(request *http.Request, w http.ResponseWriter, session *sessions.Session) (err error) {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			err = websession.NewManager(request).DeleteByKey(session.ID)
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return
+	}
+	//Don't clutter the database with anonymous sessions
+	if session.ID == "" && len(session.Values) == 0 {
+		return
+	}
+	if session.ID == "" {
+		session.ID = generateSessionKey()
+	}
+	values, err := securecookie.EncodeMulti(session.Name(), session.Values, s.Codecs...)
+	if err != nil {
+		return
+	}
+	username, _ := session.Values["username"].(string)
+	now := db.DateTime(time.Now())
+	stored := &websession.Session{
+		Key:          session.ID,
+		Name:         session.Name(),
+		Username:     username,
+		Values:       values,
+		UserAgent:    request.UserAgent(),
+		IP:           remoteIP(request),
+		CreatedAt:    now,
+		LastActivity: now,
+	}
+	if err = websession.NewManager(request).Save(stored); err != nil {
+		return
+	}
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.Codecs...)
+	if err != nil {
+		return
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+	return
+}
+
+//regenerateSession drops the server side session behind a session key and clears the values,
+// the next save issues a new key. Used on login so a key that was set or known before the login can not be used afterwards.
+func regenerateSession(request *http.Request, session *sessions.Session) (err error) {
+	if session.ID != "" && db.GetDBSession(request) != nil {
+		if err = websession.NewManager(request).DeleteByKey(session.ID); err != nil {
+			return
+		}
+	}
+	session.ID = ""
+	session.IsNew = true
+	session.Values = make(map[interface{}]interface{})
+	return
+}
+
+func (s *MongoStore) isExpired(stored *websession.Session) bool {
+	maxAge := time.Duration(s.Options.MaxAge) * time.Second
+	return time.Time(stored.LastActivity).Add(maxAge).Before(time.Now())
+}
+
+func generateSessionKey() string {
+	return strings.TrimRight(base32.StdEncoding.EncodeToString(securecookie.GenerateRandomKey(32)), "=")
+}
+
+//remoteIP returns the ip address of the client without the port
+func remoteIP(request *http.Request) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return request.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,76 @@
+package siteservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/websession"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoStoreAnonymousSession(t *testing.T) {
+	store := NewMongoStore("MyCookieSecret", 10*60)
+	request := &http.Request{Header: http.Header{}}
+
+	session, err := store.Get(request, "authenticatedsession")
+	assert.NoError(t, err)
+	assert.True(t, session.IsNew)
+	assert.Equal(t, "", session.ID)
+
+	//Empty sessions are not persisted and no cookie is set
+	w := httptest.NewRecorder()
+	assert.NoError(t, store.Save(request, w, session))
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+}
+
+func TestMongoStoreExpiration(t *testing.T) {
+	store := NewMongoStore("MyCookieSecret", 10*60)
+
+	type testcase struct {
+		lastActivity time.Time
+		expired      bool
+	}
+	testcases := []testcase{
+		{lastActivity: time.Now(), expired: false},
+		{lastActivity: time.Now().Add(-5 * time.Minute), expired: false},
+		{lastActivity: time.Now().Add(-11 * time.Minute), expired: true},
+	}
+	for _, test := range testcases {
+		stored := &websession.Session{LastActivity: db.DateTime(test.lastActivity)}
+		assert.Equal(t, test.expired, store.isExpired(stored), test.lastActivity.String())
+	}
+}
+
+func TestRemoteIP(t *testing.T) {
+	type testcase struct {
+		remoteAddr string
+		ip         string
+	}
+	testcases := []testcase{
+		{remoteAddr: "10.0.0.1:5432", ip: "10.0.0.1"},
+		{remoteAddr: "[::1]:5432", ip: "::1"},
+		{remoteAddr: "10.0.0.1", ip: "10.0.0.1"},
+	}
+	for _, test := range testcases {
+		assert.Equal(t, test.ip, remoteIP(&http.Request{RemoteAddr: test.remoteAddr}), test.remoteAddr)
+	}
+}
+
+func TestRegenerateSession(t *testing.T) {
+	store := NewMongoStore("MyCookieSecret", 10*60)
+	request := &http.Request{Header: http.Header{}}
+
+	session, err := store.Get(request, "authenticatedsession")
+	assert.NoError(t, err)
+	session.ID = "plantedkey"
+	session.IsNew = false
+	session.Values["username"] = "mallory"
+
+	assert.NoError(t, regenerateSession(request, session))
+	assert.Equal(t, "", session.ID)
+	assert.True(t, session.IsNew)
+	assert.Empty(t, session.Values)
+}
@@ -0,0 +1,389 @@
+package siteservice
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/globalconfig"
+	"github.com/itsyouonline/identityserver/identityservice"
+)
+
+//upstreamProviderConfigPrefix is the prefix of the globalconfig keys holding the json configuration of an upstream provider,
+// the rest of the key is the name of the provider
+const upstreamProviderConfigPrefix = "upstreamprovider-"
+
+//ErrUpstreamExchangeFailed is returned when an upstream provider does not give a valid token or user information
+var ErrUpstreamExchangeFailed = errors.New("Upstream exchange failed")
+
+//UpstreamUserInfo is the identity of a user at an upstream provider
+type UpstreamUserInfo struct {
+	//Subject is the identifier of the user at the provider, it never changes
+	Subject string
+	Login   string
+	Name    string
+	Email   string
+	Picture string
+	Profile string
+	//Claims holds all information the provider returned
+	Claims map[string]interface{}
+}
+
+//UpstreamProvider is an identity provider users can link their account to and login with
+type UpstreamProvider interface {
+	//Name identifies the provider in urls and linked accounts
+	Name() string
+	//DisplayName is shown to the user
+	DisplayName() string
+	//RedirectPath is the path on itsyou.online the provider redirects back to
+	RedirectPath() string
+	//AuthorizationURL is the url the user is sent to for authenticating at the provider
+	AuthorizationURL(state string, codeVerifier string, redirectURI string) string
+	//Exchange converts the authorization code to the user information
+	Exchange(code string, codeVerifier string, redirectURI string) (userinfo *UpstreamUserInfo, err error)
+}
+
+//UpstreamProviderConfig configures a generic OAuth2 or OpenID Connect provider
+type UpstreamProviderConfig struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayname"`
+	//Preset fills in the endpoints and claim mapping of a well known provider
+	Preset           string   `json:"preset"`
+	ClientID         string   `json:"clientid"`
+	ClientSecret     string   `json:"clientsecret"`
+	AuthorizationURL string   `json:"authorizationurl"`
+	TokenURL         string   `json:"tokenurl"`
+	UserInfoURL      string   `json:"userinfourl"`
+	Scopes           []string `json:"scopes"`
+	//PKCE enables RFC 7636 proof key for code exchange, providers that do not support it ignore the extra parameters
+	PKCE bool `json:"pkce"`
+	//ClaimMapping maps the fields of UpstreamUserInfo (subject, login, name, email, picture, profile)
+	// to the claims returned by the userinfo endpoint, nested claims are separated by a dot
+	ClaimMapping map[string]string `json:"claimmapping"`
+	RedirectPath string            `json:"redirectpath"`
+}
+
+//upstreamPresets are the well known providers, they keep the callback urls that were registered before the generic providers existed
+var upstreamPresets = map[string]UpstreamProviderConfig{
+	"github": {
+		DisplayName:      "GitHub",
+		AuthorizationURL: "https://github.com/login/oauth/authorize",
+		TokenURL:         "https://github.com/login/oauth/access_token",
+		UserInfoURL:      "https://api.github.com/user",
+		ClaimMapping: map[string]string{
+			"subject": "id",
+			"login":   "login",
+			"name":    "name",
+			"email":   "email",
+			"picture": "avatar_url",
+			"profile": "html_url",
+		},
+		RedirectPath: "/github_callback",
+	},
+	"facebook": {
+		DisplayName:      "Facebook",
+		AuthorizationURL: "https://www.facebook.com/v2.6/dialog/oauth",
+		TokenURL:         "https://graph.facebook.com/v2.6/oauth/access_token",
+		UserInfoURL:      "https://graph.facebook.com/v2.6/me?fields=id,picture,link,name",
+		ClaimMapping: map[string]string{
+			"subject": "id",
+			"name":    "name",
+			"picture": "picture.data.url",
+			"profile": "link",
+		},
+		RedirectPath: "/facebook_callback",
+	},
+	"oidc": {
+		Scopes: []string{"openid", "profile", "email"},
+		PKCE:   true,
+		ClaimMapping: map[string]string{
+			"subject": "sub",
+			"login":   "preferred_username",
+			"name":    "name",
+			"email":   "email",
+			"picture": "picture",
+			"profile": "profile",
+		},
+	},
+}
+
+//applyPreset fills in the empty settings from the preset
+func (config *UpstreamProviderConfig) applyPreset() {
+	preset, found := upstreamPresets[config.Preset]
+	if !found {
+		return
+	}
+	if config.DisplayName == "" {
+		config.DisplayName = preset.DisplayName
+	}
+	if config.AuthorizationURL == "" {
+		config.AuthorizationURL = preset.AuthorizationURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = preset.TokenURL
+	}
+	if config.UserInfoURL == "" {
+		config.UserInfoURL = preset.UserInfoURL
+	}
+	if config.Scopes == nil {
+		config.Scopes = preset.Scopes
+	}
+	config.PKCE = config.PKCE || preset.PKCE
+	if config.ClaimMapping == nil {
+		config.ClaimMapping = preset.ClaimMapping
+	}
+	if config.RedirectPath == "" {
+		config.RedirectPath = preset.RedirectPath
+	}
+}
+
+//OAuth2UpstreamProvider is an UpstreamProvider for the OAuth2 authorization code flow,
+// the user information is fetched from a userinfo endpoint like the one of OpenID Connect
+type OAuth2UpstreamProvider struct {
+	Config     UpstreamProviderConfig
+	HTTPClient *http.Client
+}
+
+//NewOAuth2UpstreamProvider creates an UpstreamProvider from its configuration
+func NewOAuth2UpstreamProvider(config UpstreamProviderConfig) *OAuth2UpstreamProvider {
+	config.applyPreset()
+	if config.DisplayName == "" {
+		config.DisplayName = config.Name
+	}
+	if config.RedirectPath == "" {
+		config.RedirectPath = "/upstream/" + config.Name + "/callback"
+	}
+	return &OAuth2UpstreamProvider{Config: config, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+//Name identifies the provider in urls and linked accounts
+func (p *OAuth2UpstreamProvider) Name() string {
+	return p.Config.Name
+}
+
+//DisplayName is shown to the user
+func (p *OAuth2UpstreamProvider) DisplayName() string {
+	return p.Config.DisplayName
+}
+
+//RedirectPath is the path on itsyou.online the provider redirects back to
+func (p *OAuth2UpstreamProvider) RedirectPath() string {
+	return p.Config.RedirectPath
+}
+
+//AuthorizationURL is the url the user is sent to for authenticating at the provider
+func (p *OAuth2UpstreamProvider) AuthorizationURL(state string, codeVerifier string, redirectURI string) string {
+	parameters := url.Values{}
+	parameters.Set("response_type", "code")
+	parameters.Set("client_id", p.Config.ClientID)
+	parameters.Set("redirect_uri", redirectURI)
+	parameters.Set("state", state)
+	if len(p.Config.Scopes) > 0 {
+		parameters.Set("scope", strings.Join(p.Config.Scopes, " "))
+	}
+	if p.Config.PKCE {
+		parameters.Set("code_challenge", pkceChallenge(codeVerifier))
+		parameters.Set("code_challenge_method", "S256")
+	}
+	separator := "?"
+	if strings.Contains(p.Config.AuthorizationURL, "?") {
+		separator = "&"
+	}
+	return p.Config.AuthorizationURL + separator + parameters.Encode()
+}
+
+//Exchange converts the authorization code to an access token and uses it to get the user information
+func (p *OAuth2UpstreamProvider) Exchange(code string, codeVerifier string, redirectURI string) (userinfo *UpstreamUserInfo, err error) {
+	parameters := url.Values{}
+	parameters.Set("grant_type", "authorization_code")
+	parameters.Set("code", code)
+	parameters.Set("redirect_uri", redirectURI)
+	parameters.Set("client_id", p.Config.ClientID)
+	parameters.Set("client_secret", p.Config.ClientSecret)
+	if p.Config.PKCE {
+		parameters.Set("code_verifier", codeVerifier)
+	}
+	req, err := http.NewRequest("POST", p.Config.TokenURL, strings.NewReader(parameters.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	tokenResponse := struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}{}
+	if err = p.doJSON(req, &tokenResponse); err != nil {
+		return
+	}
+	if tokenResponse.AccessToken == "" {
+		log.Error("No access token received from upstream provider ", p.Name(), ": ", tokenResponse.Error)
+		err = ErrUpstreamExchangeFailed
+		return
+	}
+
+	req, err = http.NewRequest("GET", p.Config.UserInfoURL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+tokenResponse.AccessToken)
+	req.Header.Set("Accept", "application/json")
+	claims := map[string]interface{}{}
+	if err = p.doJSON(req, &claims); err != nil {
+		return
+	}
+	userinfo = p.mapClaims(claims)
+	if userinfo.Subject == "" {
+		log.Error("No subject in the user information of upstream provider ", p.Name())
+		userinfo = nil
+		err = ErrUpstreamExchangeFailed
+	}
+	return
+}
+
+func (p *OAuth2UpstreamProvider) doJSON(req *http.Request, target interface{}) (err error) {
+	response, err := p.HTTPClient.Do(req)
+	if err != nil {
+		log.Error("Error calling upstream provider ", p.Name(), ": ", err)
+		return
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		log.Error("Upstream provider ", p.Name(), " returned ", response.Status, " for ", req.URL.Path)
+		err = ErrUpstreamExchangeFailed
+		return
+	}
+	if err = json.NewDecoder(response.Body).Decode(target); err != nil {
+		log.Error("Invalid response from upstream provider ", p.Name(), ": ", err)
+		err = ErrUpstreamExchangeFailed
+	}
+	return
+}
+
+//mapClaims converts the claims of the userinfo endpoint using the claim mapping
+func (p *OAuth2UpstreamProvider) mapClaims(claims map[string]interface{}) *UpstreamUserInfo {
+	claim := func(field string) string {
+		path, mapped := p.Config.ClaimMapping[field]
+		if !mapped {
+			path = field
+		}
+		return claimString(claims, path)
+	}
+	return &UpstreamUserInfo{
+		Subject: claim("subject"),
+		Login:   claim("login"),
+		Name:    claim("name"),
+		Email:   claim("email"),
+		Picture: claim("picture"),
+		Profile: claim("profile"),
+		Claims:  claims,
+	}
+}
+
+//claimString returns the value of a dot separated path in the claims as a string, numeric identifiers are formatted without exponent
+func claimString(claims map[string]interface{}, path string) string {
+	var value interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = nested[part]
+	}
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typedValue)
+	}
+	return ""
+}
+
+//newPKCEVerifier generates a code verifier, it is also used as state since both need to be unguessable
+func newPKCEVerifier() (verifier string, err error) {
+	randombytes := make([]byte, 32)
+	if _, err = rand.Read(randombytes); err != nil {
+		return
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(randombytes)
+	return
+}
+
+//pkceChallenge returns the S256 code challenge of a verifier
+func pkceChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+//loadUpstreamProviders loads the configured providers from the globalconfig.
+// Github and facebook credentials stored under the old "<provider>-clientid" and "<provider>-secret" keys are still used.
+func loadUpstreamProviders() (providers map[string]UpstreamProvider, err error) {
+	providers = make(map[string]UpstreamProvider)
+	configMgr := globalconfig.NewManager()
+	configs, err := configMgr.ListByPrefix(upstreamProviderConfigPrefix)
+	if err != nil {
+		return
+	}
+	for _, storedConfig := range configs {
+		config := UpstreamProviderConfig{}
+		if e := json.Unmarshal([]byte(storedConfig.Value), &config); e != nil {
+			log.Error("Invalid upstream provider configuration for ", storedConfig.Key, ": ", e)
+			continue
+		}
+		config.Name = strings.TrimPrefix(storedConfig.Key, upstreamProviderConfigPrefix)
+		providers[config.Name] = NewOAuth2UpstreamProvider(config)
+	}
+	for _, name := range []string{"github", "facebook"} {
+		if _, configured := providers[name]; configured {
+			continue
+		}
+		if exists, _ := configMgr.Exists(name + "-clientid"); !exists {
+			continue
+		}
+		config := UpstreamProviderConfig{Name: name, Preset: name}
+		if config.ClientID, err = identityservice.GetOauthClientID(name); err != nil {
+			return
+		}
+		if config.ClientSecret, err = identityservice.GetOauthSecret(name); err != nil {
+			return
+		}
+		providers[name] = NewOAuth2UpstreamProvider(config)
+	}
+	return
+}
+
+//getUpstreamProvider returns a configured provider, nil if it does not exist
+func (service *Service) getUpstreamProvider(name string) (provider UpstreamProvider, err error) {
+	providers, err := service.getUpstreamProviders()
+	if err != nil {
+		return
+	}
+	provider = providers[name]
+	return
+}
+
+//getUpstreamProviders returns the configured providers, they are loaded from the globalconfig for every request
+// so they can be changed without restarting
+func (service *Service) getUpstreamProviders() (providers map[string]UpstreamProvider, err error) {
+	if service.upstreamProviders != nil {
+		providers = service.upstreamProviders
+		return
+	}
+	providers, err = loadUpstreamProviders()
+	if err != nil {
+		err = fmt.Errorf("Error loading the upstream providers: %s", err)
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package siteservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+//newStandInProvider starts a local OAuth2 provider that only accepts the given code together with the
+// code verifier matching the challenge it received in the authorization request
+func newStandInProvider(code string, challenge *string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != code ||
+			r.Form.Get("client_id") != "itsyouonline" || r.Form.Get("client_secret") != "secret" ||
+			pkceChallenge(r.Form.Get("code_verifier")) != *challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "upstreamtoken", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer upstreamtoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"uid": 12345678, "username": "jdoe", "name": "John Doe", "avatar": {"data": {"url": "https://example.com/jdoe.png"}}}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func newStandInProviderConfig(server *httptest.Server) UpstreamProviderConfig {
+	return UpstreamProviderConfig{
+		Name:             "standin",
+		ClientID:         "itsyouonline",
+		ClientSecret:     "secret",
+		AuthorizationURL: server.URL + "/authorize",
+		TokenURL:         server.URL + "/token",
+		UserInfoURL:      server.URL + "/userinfo",
+		Scopes:           []string{"openid", "profile"},
+		PKCE:             true,
+		ClaimMapping: map[string]string{
+			"subject": "uid",
+			"login":   "username",
+			"picture": "avatar.data.url",
+		},
+	}
+}
+
+func TestUpstreamProviderExchange(t *testing.T) {
+	var challenge string
+	server := newStandInProvider("validcode", &challenge)
+	defer server.Close()
+	provider := NewOAuth2UpstreamProvider(newStandInProviderConfig(server))
+
+	verifier, err := newPKCEVerifier()
+	assert.NoError(t, err)
+	authorizationURL, err := url.Parse(provider.AuthorizationURL("thestate", verifier, "https://itsyou.online/upstream/standin/callback"))
+	assert.NoError(t, err)
+	parameters := authorizationURL.Query()
+	assert.Equal(t, "/authorize", authorizationURL.Path)
+	assert.Equal(t, "code", parameters.Get("response_type"))
+	assert.Equal(t, "itsyouonline", parameters.Get("client_id"))
+	assert.Equal(t, "thestate", parameters.Get("state"))
+	assert.Equal(t, "openid profile", parameters.Get("scope"))
+	assert.Equal(t, "https://itsyou.online/upstream/standin/callback", parameters.Get("redirect_uri"))
+	assert.Equal(t, "S256", parameters.Get("code_challenge_method"))
+	challenge = parameters.Get("code_challenge")
+
+	userinfo, err := provider.Exchange("validcode", verifier, "https://itsyou.online/upstream/standin/callback")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678", userinfo.Subject)
+	assert.Equal(t, "jdoe", userinfo.Login)
+	assert.Equal(t, "John Doe", userinfo.Name)
+	assert.Equal(t, "https://example.com/jdoe.png", userinfo.Picture)
+	assert.Equal(t, "", userinfo.Email)
+
+	//An intercepted code is useless without the verifier
+	otherVerifier, _ := newPKCEVerifier()
+	_, err = provider.Exchange("validcode", otherVerifier, "https://itsyou.online/upstream/standin/callback")
+	assert.Equal(t, ErrUpstreamExchangeFailed, err)
+	_, err = provider.Exchange("invalidcode", verifier, "https://itsyou.online/upstream/standin/callback")
+	assert.Equal(t, ErrUpstreamExchangeFailed, err)
+}
+
+func TestUpstreamProviderPresets(t *testing.T) {
+	provider := NewOAuth2UpstreamProvider(UpstreamProviderConfig{Name: "github", Preset: "github", ClientID: "id"})
+	assert.Equal(t, "GitHub", provider.DisplayName())
+	assert.Equal(t, "/github_callback", provider.RedirectPath())
+	assert.Equal(t, "https://github.com/login/oauth/access_token", provider.Config.TokenURL)
+	assert.False(t, provider.Config.PKCE)
+
+	provider = NewOAuth2UpstreamProvider(UpstreamProviderConfig{Name: "corporate", Preset: "oidc", AuthorizationURL: "https://sso.example.com/authorize"})
+	assert.Equal(t, "corporate", provider.DisplayName())
+	assert.Equal(t, "/upstream/corporate/callback", provider.RedirectPath())
+	assert.Equal(t, "https://sso.example.com/authorize", provider.Config.AuthorizationURL)
+	assert.Equal(t, "sub", provider.Config.ClaimMapping["subject"])
+	assert.True(t, provider.Config.PKCE)
+}
+
+func TestUpstreamCallbackState(t *testing.T) {
+	var challenge string
+	server := newStandInProvider("validcode", &challenge)
+	defer server.Close()
+	siteService := NewService("MyCookieSecret", nil, nil, nil, nil)
+	siteService.upstreamProviders = map[string]UpstreamProvider{
+		"standin": NewOAuth2UpstreamProvider(newStandInProviderConfig(server)),
+	}
+	router := mux.NewRouter()
+	siteService.AddRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newBrowserRequest("GET", "/upstream/standin/login?client_id=example", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	authorizationURL, _ := url.Parse(w.Header().Get("Location"))
+	state := authorizationURL.Query().Get("state")
+	assert.NotEmpty(t, state)
+
+	//A callback with a different state, or from a browser that did not start the login, is rejected
+	callbackW := httptest.NewRecorder()
+	router.ServeHTTP(callbackW, newBrowserRequest("GET", "/upstream/standin/callback?code=validcode&state=forged", w))
+	assert.Equal(t, http.StatusOK, callbackW.Code)
+	assert.Contains(t, callbackW.Body.String(), "Invalid or expired request")
+
+	callbackW = httptest.NewRecorder()
+	router.ServeHTTP(callbackW, newBrowserRequest("GET", "/upstream/standin/callback?code=validcode&state="+url.QueryEscape(state), nil))
+	assert.Contains(t, callbackW.Body.String(), "Invalid or expired request")
+
+	//With the right state the code is exchanged, a wrong code verifier makes the exchange fail
+	challenge = "notthechallenge"
+	callbackW = httptest.NewRecorder()
+	router.ServeHTTP(callbackW, newBrowserRequest("GET", "/upstream/standin/callback?code=validcode&state="+url.QueryEscape(state), w))
+	assert.Contains(t, callbackW.Body.String(), "failed")
+
+	unknownW := httptest.NewRecorder()
+	router.ServeHTTP(unknownW, newBrowserRequest("GET", "/upstream/unknown/login", nil))
+	assert.Equal(t, http.StatusNotFound, unknownW.Code)
+}
@@ -35,6 +35,25 @@ func (service *Service) PhonenumberValidation(w http.ResponseWriter, request *ht
 	service.renderSMSConfirmationPage(w, request, "Your phonenumber is confirmed")
 }
 
+//callValidationCode reads out the code of a pending phonenumber validation in a voice call
+func (service *Service) callValidationCode(w http.ResponseWriter, request *http.Request, username string, key string) {
+	err := service.phonenumberValidationService.CallValidationCode(request, username, key)
+	if err == validation.ErrInvalidOrExpiredKey {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	if err == validation.ErrTooManyCalls {
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		log.Error("Error calling with the phonenumber validation code: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (service *Service) EmailValidation(w http.ResponseWriter, request *http.Request) {
 
 	err := request.ParseForm()
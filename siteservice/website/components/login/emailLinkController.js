@@ -0,0 +1,21 @@
+(function () {
+    'use strict';
+    angular.module('loginApp')
+        .controller('emailLinkController', ['$http', '$window', emailLinkController]);
+
+    function emailLinkController($http, $window) {
+        var vm = this;
+        vm.submit = submit;
+        vm.emailSend = false;
+        function submit() {
+            var data = {
+                email: vm.email
+            };
+            $http.post('/login/emaillink' + $window.location.search, data).then(
+                function () {
+                    vm.emailSend = true;
+                }
+            );
+        }
+    }
+})();
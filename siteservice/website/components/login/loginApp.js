@@ -72,6 +72,11 @@
                 controller: 'forgotPasswordController',
                 controllerAs: 'vm'
             })
+            .when('/emaillink', {
+                templateUrl: 'components/login/views/emaillink.html',
+                controller: 'emailLinkController',
+                controllerAs: 'vm'
+            })
             .when('/resetpassword/:code', {
                 templateUrl: 'components/login/views/resetpassword.html',
                 controller: 'resetPasswordController',
@@ -18,6 +18,9 @@
         vm.basicInfoValid = basicInfoValid;
         vm.signupInfoValid = signupInfoValid;
         vm.moveOn = moveOn;
+        vm.upstreamLoginUrl = upstreamLoginUrl;
+        vm.upstreamProviders = [];
+        vm.samlLoginUrl = "";
         vm.externalSite = URI($window.location.href).search(true).client_id;
         $rootScope.registrationUrl = '/register' + $window.location.search;
         vm.logo = "";
@@ -46,9 +49,19 @@
                         renderLogo();
                     }
                 );
+                LoginService.getSAMLProvider(vm.externalSite).then(
+                    function (data) {
+                        vm.samlLoginUrl = '/saml/' + encodeURIComponent(data.globalid) + '/login' + $window.location.search;
+                    }
+                );
                 window.addEventListener('resize', resizeLogo, false);
                 window.addEventListener('orientationchange', resizeLogo, false);
             }
+            LoginService.getUpstreamProviders().then(
+                function (data) {
+                    vm.upstreamProviders = data;
+                }
+            );
             autoFillListener();
             $scope.$on('$destroy', function() {
                   // Make sure that the interval is destroyed too
@@ -56,6 +69,10 @@
             });
         }
 
+        function upstreamLoginUrl(provider) {
+            return '/upstream/' + encodeURIComponent(provider.name) + '/login' + $window.location.search;
+        }
+
         function renderLogo() {
             if (vm.logo !== "") {
                 var img = new Image();
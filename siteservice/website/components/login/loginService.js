@@ -14,9 +14,15 @@
         return {
             getTwoFactorAuthenticationMethods: getTwoFactorAuthenticationMethods,
             sendSmsCode: sendSmsCode,
+            callCode: callCode,
             submitTotpCode: submitTotpCode,
             submitSmsCode: submitSmsCode,
             checkSmsConfirmation: checkSmsConfirmation,
+            sendPushChallenge: sendPushChallenge,
+            checkPushConfirmation: checkPushConfirmation,
+            submitPushConfirmation: submitPushConfirmation,
+            getUpstreamProviders: getUpstreamProviders,
+            getSAMLProvider: getSAMLProvider,
             getLogo: getLogo
         };
 
@@ -55,6 +61,11 @@
             return genericHttpCall($http.post, url);
         }
 
+        function callCode(phoneLabel) {
+            var url = apiURL + '/voicecode/' + encodeURIComponent(phoneLabel);
+            return genericHttpCall($http.post, url);
+        }
+
         function submitTotpCode(code, queryString) {
             var url = apiURL + '/totpconfirmation' + queryString;
             var data = {
@@ -76,6 +87,31 @@
             return genericHttpCall($http.get, url);
         }
 
+        function sendPushChallenge(deviceID) {
+            var url = apiURL + '/pushchallenge/' + encodeURIComponent(deviceID);
+            return genericHttpCall($http.post, url);
+        }
+
+        function checkPushConfirmation() {
+            var url = apiURL + '/pushconfirmed';
+            return genericHttpCall($http.get, url);
+        }
+
+        function submitPushConfirmation(code, queryString) {
+            var url = apiURL + '/pushconfirmation' + queryString;
+            return genericHttpCall($http.post, url, {});
+        }
+
+        function getUpstreamProviders() {
+            var url = apiURL + '/upstreamproviders';
+            return genericHttpCall($http.get, url);
+        }
+
+        function getSAMLProvider(globalid) {
+            var url = apiURL + '/samlprovider?client_id=' + encodeURIComponent(globalid);
+            return genericHttpCall($http.get, url);
+        }
+
         function getLogo(globalid) {
             var url = '/api/organizations/' + encodeURIComponent(globalid) + '/logo';
             return genericHttpCall($http.get, url)
@@ -10,8 +10,10 @@
         vm.resetValidation = resetValidation;
 
         function submit() {
+            var language = ($window.navigator.language || '').split('-');
             var data = {
-                phonenumber: vm.phonenumber.replace(' ', '')
+                phonenumber: vm.phonenumber.replace(' ', ''),
+                country: language.length > 1 ? language[language.length - 1].toUpperCase() : ''
             };
             $http
                 .post('/login/resendsms', data)
@@ -20,8 +22,8 @@
                 }, function (response) {
                     switch (response.status) {
                         case 422:
-                            if (response.data.error === 'invalid_phonenumber') {
-                                $scope.phoneconfirmationform.phonenumber.$setValidity("invalid_phonenumber", false);
+                            if (response.data.error === 'invalid_phonenumber' || response.data.error === 'phonenumber_cannot_receive_sms') {
+                                $scope.phoneconfirmationform.phonenumber.$setValidity(response.data.error, false);
                             }
                             break;
                     }
@@ -30,6 +32,7 @@
 
         function resetValidation() {
             $scope.phoneconfirmationform.phonenumber.$setValidity("invalid_phonenumber", true);
+            $scope.phoneconfirmationform.phonenumber.$setValidity("phonenumber_cannot_receive_sms", true);
         }
     }
 })();
\ No newline at end of file
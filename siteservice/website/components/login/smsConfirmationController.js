@@ -7,6 +7,7 @@
     function smsConfirmationController($http, $timeout, $window, $scope) {
         var vm = this;
         vm.submit = submit;
+        vm.callMe = callMe;
         vm.smsconfirmation = {confirmed: false};
 
         $timeout(checkconfirmation, 1000);
@@ -27,6 +28,15 @@
             );
         }
 
+        function callMe() {
+            vm.callStatus = 'calling';
+            $http
+                .post('login/callme')
+                .then(angular.noop, function () {
+                    vm.callStatus = 'failed';
+                });
+        }
+
         function submit() {
             var data = {
                 smscode: vm.smscode
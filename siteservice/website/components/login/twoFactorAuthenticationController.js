@@ -11,6 +11,10 @@
         vm.resetValidation = resetValidation;
         vm.shouldShowSendButton = shouldShowSendButton;
         vm.sendSmsCode = sendSmsCode;
+        vm.callCode = callCode;
+        vm.sendPushChallenge = sendPushChallenge;
+        vm.isPushMethod = isPushMethod;
+        vm.pushDenied = false;
         vm.login = login;
         vm.getHelpText = getHelpText;
         vm.nextStep = nextStep;
@@ -35,6 +39,11 @@
                             vm.possibleTwoFaMethods['sms-' + label] = 'SMS - ' + sms + ' (' + label + ')';
                         });
                     }
+                    if (data['push'] && Object.keys(data['push']).length) {
+                        angular.forEach(data['push'], function (label, deviceID) {
+                            vm.possibleTwoFaMethods['push-' + deviceID] = 'Approve on device - ' + label;
+                        });
+                    }
                     var methods = Object.keys(vm.possibleTwoFaMethods);
                     if (!methods.length) {
                         // Redirect to resend sms page
@@ -61,6 +70,13 @@
             if (vm.step === STEP_CODE && vm.selectedTwoFaMethod.indexOf('sms-') === 0) {
                 sendSmsCode();
             }
+            if (vm.step === STEP_CODE && isPushMethod()) {
+                sendPushChallenge();
+            }
+        }
+
+        function isPushMethod() {
+            return !!vm.selectedTwoFaMethod && vm.selectedTwoFaMethod.indexOf('push-') === 0;
         }
 
         function getHelpText() {
@@ -72,12 +88,15 @@
                 if (vm.selectedTwoFaMethod === 'totp') {
                     text = 'Fill in the 6 digit code from the authenticator application on your phone.';
                 }
+                if (isPushMethod()) {
+                    text = 'Approve the login request sent to your device to continue.';
+                }
             }
             return text;
         }
 
         function shouldShowSendButton() {
-            return vm.selectedTwoFaMethod && vm.selectedTwoFaMethod.indexOf('sms-') === 0 && vm.step === STEP_CODE;
+            return vm.selectedTwoFaMethod && (vm.selectedTwoFaMethod.indexOf('sms-') === 0 || isPushMethod()) && vm.step === STEP_CODE;
         }
 
         function resetValidation() {
@@ -96,12 +115,32 @@
                 });
         }
 
+        function callCode() {
+            var phoneLabel = vm.selectedTwoFaMethod.replace('sms-', '');
+            LoginService.callCode(phoneLabel);
+        }
+
+        function sendPushChallenge() {
+            if (interval) {
+                $interval.cancel(interval);
+            }
+            vm.pushDenied = false;
+            var deviceID = vm.selectedTwoFaMethod.replace('push-', '');
+            LoginService
+                .sendPushChallenge(deviceID)
+                .then(function () {
+                    interval = $interval(checkPushConfirmation, 1000);
+                });
+        }
+
         function login() {
             var method;
             if (vm.selectedTwoFaMethod === 'totp') {
                 method = LoginService.submitTotpCode;
             } else if (vm.selectedTwoFaMethod.indexOf('sms-') === 0) {
                 method = LoginService.submitSmsCode;
+            } else if (isPushMethod()) {
+                method = LoginService.submitPushConfirmation;
             }
             method(vm.code, queryString)
                 .then(
@@ -115,7 +154,11 @@
                     function (response) {
                         switch (response.status) {
                             case 422:
-                                $scope.twoFaForm.code.$setValidity("invalid_code", false);
+                                if (isPushMethod()) {
+                                    vm.pushDenied = true;
+                                } else {
+                                    $scope.twoFaForm.code.$setValidity("invalid_code", false);
+                                }
                                 break;
                         }
                     });
@@ -130,6 +173,18 @@
                 });
         }
 
+        function checkPushConfirmation() {
+            LoginService.checkPushConfirmation()
+                .then(function (data) {
+                    if (data.status === 'approved') {
+                        login();
+                    } else if (data.status === 'denied') {
+                        $interval.cancel(interval);
+                        vm.pushDenied = true;
+                    }
+                });
+        }
+
         function goToPage(url) {
             if (interval) {
                 $interval.cancel(interval);
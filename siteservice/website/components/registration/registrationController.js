@@ -45,6 +45,7 @@
                             var err = response.data.error;
                             switch (err) {
                                 case 'invalid_phonenumber':
+                                case 'phonenumber_cannot_receive_sms':
                                     $scope.signupform.phonenumber.$setValidity(err, false);
                                     break;
                                 case 'invalid_totpcode':
@@ -71,6 +72,7 @@
             switch (prop) {
                 case 'phonenumber':
                     $scope.signupform[prop].$setValidity("invalid_phonenumber", true);
+                    $scope.signupform[prop].$setValidity("phonenumber_cannot_receive_sms", true);
                     break;
                 case 'totpcode':
                     $scope.signupform[prop].$setValidity("invalid_totpcode", true);
@@ -78,6 +80,7 @@
                 case 'twoFAMethod':
                     $scope.signupform.totpcode.$setValidity("totpcode", true);
                     $scope.signupform.phonenumber.$setValidity("invalid_phonenumber", true);
+                    $scope.signupform.phonenumber.$setValidity("phonenumber_cannot_receive_sms", true);
                     $scope.signupform.phonenumber.$setValidity("pattern", true);
                     break;
             }
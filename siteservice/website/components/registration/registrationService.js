@@ -3,9 +3,9 @@
 
     angular
         .module("itsyouonline.registration")
-        .service("registrationService", ['$http', RegistrationService]);
+        .service("registrationService", ['$http', '$window', RegistrationService]);
 
-    function RegistrationService($http) {
+    function RegistrationService($http, $window) {
         return {
             validateUsername: validateUsername,
             register: register
@@ -29,9 +29,16 @@
                 password: password,
                 totpcode: totpcode,
                 phonenumber: sms,
+                country: browserCountry(),
                 redirectparams: redirectparams
             };
             return $http.post(url, data);
         }
+
+        // The region of the browser language is used to interpret phone numbers without country code
+        function browserCountry() {
+            var language = ($window.navigator.language || '').split('-');
+            return language.length > 1 ? language[language.length - 1].toUpperCase() : '';
+        }
     }
 })();
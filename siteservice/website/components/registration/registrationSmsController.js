@@ -7,6 +7,7 @@
     function smsController($http, $timeout, $window, $scope, $cookies) {
         var vm = this;
         vm.submit = submit;
+        vm.callMe = callMe;
         vm.smsconfirmation = {confirmed: false};
 
         $timeout(checkconfirmation, 1000);
@@ -26,6 +27,15 @@
             );
         }
 
+        function callMe() {
+            vm.callStatus = 'calling';
+            $http
+                .post('register/callme')
+                .then(angular.noop, function () {
+                    vm.callStatus = 'failed';
+                });
+        }
+
         function submit() {
             var data = {
                 smscode: vm.smscode
@@ -10,11 +10,17 @@
                     scope.header_login = attr.register !== undefined;
                     scope.showCookieWarning = !localStorage.getItem('cookiewarning-dismissed');
                     scope.hideCookieWarning  = hideCookieWarning;
+                    scope.csrftoken = encodeURIComponent(getCSRFToken());
 
                     function hideCookieWarning(){
                         localStorage.setItem('cookiewarning-dismissed', true);
                         scope.showCookieWarning = false;
                     }
+
+                    function getCSRFToken() {
+                        var match = document.cookie.match(/(?:^|;\s*)XSRF-TOKEN=([^;]*)/);
+                        return match ? decodeURIComponent(match[1]) : '';
+                    }
                 }
             };
         });
@@ -6,9 +6,9 @@
 
     angular
         .module('itsyouonline.user', [])
-        .factory('UserDialogService', ['$window', '$q', '$interval', '$mdMedia', '$mdDialog', 'UserService', 'configService', UserDialogService]);
+        .factory('UserDialogService', ['$window', '$q', '$interval', '$mdMedia', '$mdDialog', 'UserService', UserDialogService]);
 
-    function UserDialogService($window, $q, $interval, $mdMedia, $mdDialog, UserService, configService) {
+    function UserDialogService($window, $q, $interval, $mdMedia, $mdDialog, UserService) {
         var vm;
         var genericDetailControllerParams = ['$scope', '$mdDialog', 'user', 'data',
             'createFunction', 'updateFunction', 'deleteFunction', GenericDetailDialogController];
@@ -333,13 +333,7 @@
         }
 
         function addFacebook() {
-            configService.getConfig(function (config) {
-                $window.location.href = 'https://www.facebook.com/dialog/oauth?client_id='
-                    + config.facebookclientid
-                    + '&response_type=code&redirect_uri='
-                    + $window.location.origin
-                    + '/facebook_callback';
-            });
+            $window.location.href = '/upstream/facebook/link';
         }
 
         function facebook(ev) {
@@ -383,9 +377,7 @@
         }
 
         function addGithub() {
-            configService.getConfig(function (config) {
-                $window.location.href = 'https://github.com/login/oauth/authorize/?client_id=' + config.githubclientid;
-            });
+            $window.location.href = '/upstream/github/link';
         }
 
         /**
@@ -114,7 +114,8 @@
                         $scope.authorizations.name = true;
                     }
                     else if (scope.startsWith('user:memberof:')) {
-                        $scope.requested.organizations[permissionLabel] = true;
+                        // user:memberof:globalid or user:memberof:globalid:role
+                        $scope.requested.organizations[scope.substr('user:memberof:'.length)] = true;
                     }
                     else if (scope.startsWith('user:digitalwalletaddress:')) {
                         auth.reallabel = vm.user.digitalwallet.length ? vm.user.digitalwallet[0].label : '';
@@ -0,0 +1,105 @@
+package smsoutbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"gopkg.in/mgo.v2"
+
+	smsdb "github.com/itsyouonline/identityserver/db/sms"
+	"github.com/itsyouonline/identityserver/oauthservice"
+)
+
+//AddRoutes adds the delivery report callback and the admin view of the failures to the router
+func (o *Outbox) AddRoutes(router *mux.Router) {
+	router.HandleFunc("/sms/status/{id}", o.DeliveryReport).Methods("POST")
+	router.HandleFunc("/api/sms/failures", o.ListFailures).Methods("GET")
+
+	smsdb.InitModels()
+}
+
+//DeliveryReport is the handler for POST /sms/status/{id}, the delivery report of a provider.
+// Twilio posts a MessageStatus form value, the http gateway a status form value or json property.
+// The random message id in the url is the only authentication so it is never shown to users.
+func (o *Outbox) DeliveryReport(w http.ResponseWriter, r *http.Request) {
+	var reported string
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		body := struct {
+			Status string `json:"status"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		reported = body.Status
+	} else {
+		reported = r.FormValue("MessageStatus")
+		if reported == "" {
+			reported = r.FormValue("status")
+		}
+	}
+	status, final := deliveryStatus(reported)
+	if !final {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	err := smsdb.NewManager(r).SetDeliveryStatus(mux.Vars(r)["id"], status)
+	if err == mgo.ErrNotFound {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error("Error saving the sms delivery status: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//deliveryStatus maps a status reported by a provider to the status of the message,
+// final is false for the intermediate statuses like queued or sending
+func deliveryStatus(reported string) (status string, final bool) {
+	switch strings.ToLower(strings.TrimSpace(reported)) {
+	case "delivered":
+		return smsdb.StatusDelivered, true
+	case "undelivered", "failed", "rejected", "expired":
+		return smsdb.StatusUndelivered, true
+	}
+	return "", false
+}
+
+//ListFailures is the handler for GET /api/sms/failures, it lists the messages of the last days
+// (1 by default, at most 7) that failed, were not delivered or needed another provider.
+// Only the owners of the admin organization, or the admin organization itself, have access.
+func (o *Outbox) ListFailures(w http.ResponseWriter, r *http.Request) {
+	allowed, err := oauthservice.IsAdmin(r, o.adminOrganization)
+	if err != nil {
+		log.Error("Error checking the sms admin access: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil || days < 1 {
+		days = 1
+	}
+	if days > 7 {
+		days = 7
+	}
+	failures, err := smsdb.NewManager(r).GetFailures(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Error("Error listing the sms failures: ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&failures)
+}
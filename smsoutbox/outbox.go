@@ -0,0 +1,158 @@
+package smsoutbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/itsyouonline/identityserver/communication"
+	"github.com/itsyouonline/identityserver/db"
+	smsdb "github.com/itsyouonline/identityserver/db/sms"
+)
+
+const (
+	//maxAttempts is the number of times the providers are tried before a message fails
+	maxAttempts = 6
+	//firstRetryDelay is the time before the first retry, it doubles with every attempt
+	firstRetryDelay = 15 * time.Second
+	//maxAge is how long a message is useful, the login and validation codes in it expire after 10 minutes
+	maxAge = 10 * time.Minute
+	//attemptLease keeps other instances from retrying a message while it is being sent
+	attemptLease = 2 * time.Minute
+)
+
+//Outbox is an SMSService that stores every message before handing it to the providers of a registry.
+// Messages none of the providers accepted are retried in the background until they are too old to be useful.
+type Outbox struct {
+	registry          *communication.SMSProviderRegistry
+	statusCallbackURL string
+	adminOrganization string
+}
+
+//NewOutbox creates an Outbox that sends the messages through registry.
+// If statusCallbackURL, the public url of this server, is set, the providers are asked to report the delivery of the messages.
+// The owners of the adminOrganization can list the messages that failed.
+func NewOutbox(registry *communication.SMSProviderRegistry, statusCallbackURL string, adminOrganization string) *Outbox {
+	return &Outbox{registry: registry, statusCallbackURL: statusCallbackURL, adminOrganization: adminOrganization}
+}
+
+//Send stores an SMS and sends it in the background, an error is only returned if it could not be stored
+func (o *Outbox) Send(phonenumber string, message string) (err error) {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		log.Warn("No database connection, sending sms without the outbox")
+		return o.registry.Send(phonenumber, message)
+	}
+	id, err := newMessageID()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	sms := &smsdb.Message{
+		ID:          id,
+		Phonenumber: phonenumber,
+		Text:        message,
+		Status:      smsdb.StatusPending,
+		Failures:    []smsdb.Failure{},
+		Created:     db.DateTime(now),
+		Updated:     db.DateTime(now),
+		NextAttempt: db.DateTime(now.Add(attemptLease)),
+	}
+	if err = smsdb.NewManager(r).Save(sms); err != nil {
+		log.Error("Error storing sms in the outbox: ", err)
+		return
+	}
+	go func() {
+		r, done := db.NewBackgroundRequest()
+		defer done()
+		o.deliver(r, sms)
+	}()
+	return
+}
+
+//deliver hands a message to the providers and records the outcome
+func (o *Outbox) deliver(r *http.Request, sms *smsdb.Message) {
+	mgr := smsdb.NewManager(r)
+	now := time.Now()
+	if now.Sub(time.Time(sms.Created)) > maxAge {
+		log.Errorf("SMS %s to %s expired before a provider accepted it", sms.ID, sms.Phonenumber)
+		failure := smsdb.Failure{Error: "Expired before a provider accepted it", Time: db.DateTime(now)}
+		if err := mgr.MarkFailed(sms.ID, []smsdb.Failure{failure}); err != nil {
+			log.Error("Error updating sms in the outbox: ", err)
+		}
+		return
+	}
+	outgoing := &communication.OutgoingSMS{Phonenumber: sms.Phonenumber, Message: sms.Text}
+	if o.statusCallbackURL != "" {
+		outgoing.StatusCallback = o.statusCallbackURL + "/sms/status/" + sms.ID
+	}
+	provider, messageID, providerErrors := o.registry.Deliver(outgoing)
+	failures := make([]smsdb.Failure, 0, len(providerErrors))
+	for _, providerError := range providerErrors {
+		failures = append(failures, smsdb.Failure{Provider: providerError.Provider, Error: providerError.Err.Error(), Time: db.DateTime(time.Now())})
+	}
+	attempts := sms.Attempts + 1
+	var err error
+	switch {
+	case provider != "":
+		if len(failures) > 0 {
+			log.Warnf("SMS %s was sent by %s after other providers failed: %v", sms.ID, provider, &communication.SMSDeliveryError{Failures: providerErrors})
+		}
+		err = mgr.MarkSent(sms.ID, provider, messageID, failures)
+	case attempts >= maxAttempts:
+		log.Errorf("SMS %s to %s failed after %d attempts: %v", sms.ID, sms.Phonenumber, attempts, &communication.SMSDeliveryError{Failures: providerErrors})
+		err = mgr.MarkFailed(sms.ID, failures)
+	default:
+		log.Warnf("SMS %s to %s is retried: %v", sms.ID, sms.Phonenumber, &communication.SMSDeliveryError{Failures: providerErrors})
+		err = mgr.MarkRetry(sms.ID, failures, time.Now().Add(retryDelay(attempts)))
+	}
+	if err != nil {
+		log.Error("Error updating sms in the outbox: ", err)
+	}
+}
+
+//retryDelay is the time to wait after a number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	return firstRetryDelay << uint(attempts-1)
+}
+
+//Run retries the pending messages every interval, it never returns
+func (o *Outbox) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		o.retryPending()
+	}
+}
+
+func (o *Outbox) retryPending() {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		return
+	}
+	mgr := smsdb.NewManager(r)
+	for {
+		now := time.Now()
+		sms, err := mgr.Claim(now, now.Add(attemptLease))
+		if err != nil {
+			log.Error("Error getting pending sms from the outbox: ", err)
+			return
+		}
+		if sms == nil {
+			return
+		}
+		o.deliver(r, sms)
+	}
+}
+
+func newMessageID() (id string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	id = hex.EncodeToString(b)
+	return
+}
@@ -0,0 +1,45 @@
+package smsoutbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	smsdb "github.com/itsyouonline/identityserver/db/sms"
+)
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 15*time.Second, retryDelay(1))
+	assert.Equal(t, 30*time.Second, retryDelay(2))
+	assert.Equal(t, 2*time.Minute, retryDelay(4))
+
+	//All attempts are made before the codes in the message expire
+	var total time.Duration
+	for attempts := 1; attempts < maxAttempts; attempts++ {
+		total += retryDelay(attempts)
+	}
+	assert.True(t, total < maxAge, "the retries take %v", total)
+}
+
+func TestDeliveryStatus(t *testing.T) {
+	cases := []struct {
+		reported string
+		status   string
+		final    bool
+	}{
+		{"delivered", smsdb.StatusDelivered, true},
+		{"DELIVERED", smsdb.StatusDelivered, true},
+		{"undelivered", smsdb.StatusUndelivered, true},
+		{"failed", smsdb.StatusUndelivered, true},
+		{"rejected", smsdb.StatusUndelivered, true},
+		{"queued", "", false},
+		{"sent", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		status, final := deliveryStatus(c.reported)
+		assert.Equal(t, c.status, status, c.reported)
+		assert.Equal(t, c.final, final, c.reported)
+	}
+}
@@ -1,8 +1,25 @@
-// Code generated by go-bindata.
+// Code generated for package templates by go-bindata DO NOT EDIT. (@generated)
 // sources:
-// templates/emailwithbutton.html
-// DO NOT EDIT!
-
+// templates/templates/emailwithbutton.html
+// templates/templates/emailwithbutton.txt
+// templates/templates/messages/en/common.tmpl
+// templates/templates/messages/en/emailvalidation.tmpl
+// templates/templates/messages/en/invitationaccepted.tmpl
+// templates/templates/messages/en/invitationrejected.tmpl
+// templates/templates/messages/en/logincode.tmpl
+// templates/templates/messages/en/loginlink.tmpl
+// templates/templates/messages/en/organizationinvitation.tmpl
+// templates/templates/messages/en/passwordreset.tmpl
+// templates/templates/messages/en/phonenumbervalidation.tmpl
+// templates/templates/messages/nl/common.tmpl
+// templates/templates/messages/nl/emailvalidation.tmpl
+// templates/templates/messages/nl/invitationaccepted.tmpl
+// templates/templates/messages/nl/invitationrejected.tmpl
+// templates/templates/messages/nl/logincode.tmpl
+// templates/templates/messages/nl/loginlink.tmpl
+// templates/templates/messages/nl/organizationinvitation.tmpl
+// templates/templates/messages/nl/passwordreset.tmpl
+// templates/templates/messages/nl/phonenumbervalidation.tmpl
 package templates
 
 import (
@@ -49,26 +66,37 @@ type bindataFileInfo struct {
 	modTime time.Time
 }
 
+// Name return file name
 func (fi bindataFileInfo) Name() string {
 	return fi.name
 }
+
+// Size return file size
 func (fi bindataFileInfo) Size() int64 {
 	return fi.size
 }
+
+// Mode return file mode
 func (fi bindataFileInfo) Mode() os.FileMode {
 	return fi.mode
 }
+
+// Mode return file modify time
 func (fi bindataFileInfo) ModTime() time.Time {
 	return fi.modTime
 }
+
+// IsDir return file whether a directory
 func (fi bindataFileInfo) IsDir() bool {
-	return false
+	return fi.mode&os.ModeDir != 0
 }
+
+// Sys return file is sys mode
 func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _emailwithbuttonHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xdc\x56\x51\x6f\xdb\x36\x10\x7e\xef\xaf\xb8\x28\x18\xba\x01\x91\x25\xd9\x4e\x63\xcb\x76\x8a\x35\x69\xd0\x02\xdd\x1a\x14\x0e\xba\x3e\xd2\x12\x6d\x11\xa6\x48\x8d\xa4\xe3\x78\x85\xff\xfb\x8e\x94\x6c\xcb\xb6\x12\x77\x4f\x1b\x46\x03\x89\x2c\xdf\x1d\xef\xfb\xbe\xbb\x23\x87\x67\xb7\x9f\x6f\xc6\xdf\xee\xdf\x43\x66\x72\x0e\xf7\x0f\xef\x3e\x7d\xbc\x01\xcf\x0f\x82\xaf\x9d\x9b\x20\xb8\x1d\xdf\xc2\x1f\x1f\xc6\xbf\x7d\x82\xa8\x15\xc2\x58\x11\xa1\x99\x61\x52\x10\x1e\x04\xef\x7f\xf7\x5e\x41\xb5\xbc\xcc\x98\x22\x0e\x82\xe5\x72\xd9\x5a\x76\x5a\x52\xcd\x82\xf1\x97\xe0\xc9\xc6\x8c\x6c\x90\xea\xd1\x37\xb5\x08\xad\xd4\xa4\xde\xf5\xab\xa1\xdb\xf8\x29\xe7\x42\x8f\x1a\xc2\x44\xfd\x7e\xbf\xf4\x76\xb6\x94\xa4\xd7\x6e\xd7\x61\x4e\x0d\x01\x6b\xef\xd3\x3f\x17\xec\x71\xe4\xdd\x48\x61\xa8\x30\xfe\x78\x55\x50\x0f\x92\xf2\xdb\xc8\x33\xf4\xc9\x04\xd6\x7f\x00\x49\x46\x94\xa6\x66\xf4\x30\xbe\xf3\x7b\x5e\x50\x05\x32\xcc\x70\x7a\xfd\xfd\x7b\x6b\x6c\x1f\xd6\xeb\x61\x50\xbe\x29\x7f\xe5\x4c\xcc\x21\x53\x74\x5a\x26\xa7\x31\xbb\x29\x86\xd6\xad\x99\x94\x33\x4e\x49\xc1\x74\x2b\x91\x79\x90\x68\xfd\x76\x4a\x72\xc6\x57\xa3\xaf\x94\xf3\x29\xa7\xd4\xc4\xdd\x30\xf4\x40\x51\x3e\xf2\xb4\x59\x71\xaa\x33\x7c\xe9\x81\xc1\x04\xab\xbc\xd0\xcb\xc2\x0a\x4a\x5c\xc3\x89\x4c\x57\xe0\x4c\x47\x5e\x4e\xd4\x8c\x89\x38\x1c\x40\x41\xd2\x94\x89\x99\x7d\x5c\xb2\xd4\x64\x71\x14\x86\x3f\x0d\xac\x5f\xca\x1e\x8f\xcc\x77\xd6\x35\x63\x9b\xb2\x5f\xa6\x17\xbf\xfe\x55\x31\xc2\x5f\x5f\x68\x94\xc2\xd7\x54\xb1\xa9\x8d\xe5\xc0\xd6\xe2\xd5\x9c\x27\x24\x99\xcf\x94\x5c\x88\x34\x56\xb3\x09\xf9\xb9\xdb\xbb\x88\xba\x97\x17\x51\xef\xea\x22\x6c\xf5\xdb\xbf\x0c\x32\xca\x66\x99\x89\x7b\x61\xf1\x34\x28\x64\xa9\x6f\x8c\xb0\x89\x61\x8f\x74\x13\xbc\x79\x83\x37\xa1\x75\xaa\x99\x34\x9b\xc1\x65\xd7\xda\xc1\x96\x0b\x08\xa1\xd3\xe0\x79\xe8\xbd\x35\x6f\xa3\x31\xfa\xe0\xa7\xc9\xc5\xb9\x91\x03\x99\x99\xd1\x2b\xb9\x68\x49\x81\x25\x40\xbd\x46\x1f\x5c\xd5\x46\x56\x4d\x3f\xa5\x89\x54\xc4\xa1\x17\x52\xd0\xc1\x94\x4b\x62\x62\x4e\xa7\xa6\x14\x40\xb3\xbf\x68\xec\xd2\x4e\x24\x97\x2a\x3e\xbf\xbb\xbb\xdb\x93\x66\x5b\x39\x75\x71\x9c\xc1\xb2\xa4\x78\x22\x79\xfa\x1c\x00\xbb\x3e\x1a\xfd\x0d\x73\xfe\xec\x72\x1e\x06\xa4\x81\x9d\x00\xe9\x39\xa0\x7b\xff\x55\xed\x6b\xfd\xf1\xb8\xd6\x9c\x02\x40\x16\x46\xc2\xb6\x34\xe1\x48\xd0\x63\x31\x2b\x2d\xf7\xb5\x71\xb1\x8e\xc4\x19\x16\x1b\xd7\x92\x31\x38\x6f\xbb\x35\x80\x3a\x6d\xb0\x29\x69\xa8\xd1\x06\x3b\xca\x21\x7a\x63\x37\xac\x13\x09\x42\xaa\x9c\xe0\x4c\xb0\x44\xf9\x55\x01\x43\xbb\x6b\xed\x36\xf0\x6c\xb9\x44\xfb\xa9\x22\x4e\xa7\x34\xe1\x6c\x86\x16\x4e\xda\x06\x39\x3e\xa0\x8e\x12\x70\xa4\x3c\x60\x2e\x82\xe4\x38\x55\xce\x0e\x28\x2f\xfe\x17\x40\xed\xd4\x44\xb3\xf5\xfa\x04\xba\x17\x5a\xd2\xf5\xc3\xde\x66\x09\xce\x6d\xaa\x1a\x5b\x7b\xd3\xa3\x96\x5a\xc5\xd7\xeb\xc6\xb6\xac\x36\xaa\x8d\x2d\x38\xef\x46\xbd\x4e\xd2\x1d\x4c\xa4\x4a\xa9\xf2\x15\x49\xd9\x42\xc7\x9d\x5d\x27\x4e\xdd\x1a\xa4\x4c\x17\x9c\xac\x62\xe6\x1a\xc8\x9f\x70\x99\xcc\x4f\x0d\xcf\x1d\xfd\xd1\x15\x06\x3c\x6a\x57\x4e\x0d\xc2\xf1\x75\x41\x12\x8b\xbb\xd2\x63\x33\xaf\x5d\xff\x6c\x87\x76\xd4\x46\x4e\x9c\x38\x35\x42\x2a\x3e\x1a\x47\x4c\xd9\x51\x36\xc6\x19\xcb\x0b\xa9\x0c\x11\x8d\x42\x55\x62\xbd\x5b\x18\x23\x45\x29\xd9\xd1\x78\x68\x1a\x0d\x99\x6a\xa4\x33\xed\xdb\x4f\x45\x67\x99\x49\x45\x64\xf5\x4b\xce\xc4\xa6\xd8\x22\x44\x53\xa1\x8d\x4a\xc9\xdb\x6e\x44\x04\xa7\x3a\xe0\xca\xad\x7f\x40\x7f\xfb\x80\xfe\x8a\xea\x7a\xe5\x47\xfd\x5d\x36\xdb\xb2\xdf\x1d\x99\x35\xd6\x9f\x2b\xf9\x92\x42\x6c\x2b\x03\x4b\xa9\xe6\xe8\xf7\x16\xee\x89\x36\x14\x4c\x46\xb1\xf5\xb0\xf3\x97\xf8\x12\xdc\xc5\x81\x09\x1c\x8e\x78\x8a\x28\x98\x28\xb9\xc4\x84\xe3\x67\x30\xff\x9b\x20\x11\x46\xea\x4f\x14\x25\xf3\xd8\xfd\xc5\xdf\xf8\x8f\x75\xdf\xa1\x62\x55\x97\x1d\x56\x2a\x60\xd1\x50\x65\x33\xc4\xb0\x1b\xe7\x86\xf2\x3b\x39\x13\xff\x9b\x15\x81\x88\xbe\x50\xa2\xa5\xb0\xa0\x5e\x98\x7c\x9b\x71\x7b\xf9\xe2\x5d\x64\x87\xfa\xc7\x66\xa2\x5d\x7b\xc7\x7e\xc3\x91\x7f\x98\xd4\xa9\x03\x7f\xfb\xcf\x5e\x48\xdd\xfd\x14\xef\xcf\xd7\x7f\x07\x00\x00\xff\xff\x79\xc2\x06\xcc\x2c\x0c\x00\x00")
+var _emailwithbuttonHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x6f\x6f\x23\x39\x6f\x7f\xbf\x9f\x42\xcf\x2c\x8a\xe7\xcd\x84\x16\x49\xfd\xb5\x9d\x14\x7d\x72\xcf\xf5\x0a\x38\xd7\xc3\x21\x8b\x6b\x5f\x7a\xe3\x49\x62\xec\xc4\xe3\xda\x93\x6c\xb6\x81\xbf\x7b\x41\x8d\xc6\x1e\x27\xd9\x6d\xfb\xaa\xb8\xc2\xf1\xe1\xd6\x3f\x91\xa2\x48\x8a\xa4\x64\x81\xd3\xbf\xfc\xf4\xaf\x97\xd7\xff\xfe\xdb\xdf\xd5\x7d\xfb\x50\xab\xdf\x3e\xfd\x6d\xf6\x2f\x97\xaa\x38\x1b\x8d\xfe\xe0\xcb\xd1\xe8\xa7\xeb\x9f\xd4\xbf\xfd\x72\x7d\x35\x53\x08\x5a\x5d\x6f\xe6\xab\xed\xb2\x5d\x36\xab\x79\x3d\x1a\xfd\xfd\xd7\xe2\x83\xca\x7f\xc5\x7d\xdb\xae\xc7\xa3\xd1\xd7\xaf\x5f\xe1\x2b\x43\xb3\xb9\x1b\x5d\xff\x3e\x7a\x16\x99\x28\x42\xf2\xd7\xb3\x76\x20\x01\x16\xed\xa2\xb8\xf8\x30\x15\x8a\x7a\x7e\xa8\x57\xdb\xf3\x77\xc4\x60\x8c\xb1\x9b\x9d\x78\xab\xf9\xe2\x22\xad\x3a\x7d\xa8\xda\xb9\x12\xfe\xb3\xea\x3f\x1e\x97\x4f\xe7\xc5\x65\xb3\x6a\xab\x55\x7b\x76\xfd\x6d\x5d\x15\xea\xa6\x43\xe7\x45\x5b\x3d\xb7\x23\x99\x3f\x51\x37\xf7\xf3\xcd\xb6\x6a\xcf\x3f\x5d\xff\x7c\x16\x8a\x51\x16\xd4\x2e\xdb\xba\xba\x78\x79\x81\x6b\xf9\xb2\xdb\x4d\x47\xdd\x48\x47\xad\x97\xab\x2f\xea\x7e\x53\xdd\x76\xca\x6d\xc7\xa3\xd1\x6d\xb3\x6a\xb7\x70\xd7\x34\x77\x75\x35\x5f\x2f\xb7\x70\xd3\x3c\x8c\x6e\xb6\xdb\x7f\xbc\x9d\x3f\x2c\xeb\x6f\xe7\x7f\x54\x75\x7d\x5b\x57\x55\x3b\x36\x5a\x17\x6a\x53\xd5\xe7\xc5\xb6\xfd\x56\x57\xdb\xfb\xaa\x6a\x0b\xd5\x7e\x5b\x57\x59\xaf\x9b\xed\x56\xcc\x1a\x75\x76\x4d\x3f\x37\x8b\x6f\x2a\xb1\x9e\x17\x0f\xf3\xcd\xdd\x72\x35\xd6\x13\xb5\x9e\x2f\x16\xcb\xd5\x9d\x7c\xfd\xba\x5c\xb4\xf7\x63\xd4\xfa\x1f\x26\x32\x6f\xb1\x7c\x7a\xc3\x7e\xe0\x1e\x30\x8b\xca\x67\x9d\x7a\xe3\xbf\xfe\xd3\x66\x39\xaf\xff\x5a\x6e\xe7\xab\xed\xd9\xb6\xda\x2c\x6f\x45\x56\x32\x76\x20\x6f\x30\xf9\xf3\xfc\xe6\xcb\xdd\xa6\x79\x5c\x2d\xc6\x2f\x2f\xcb\x5b\x05\x97\x4d\xdd\x6c\x76\xbb\x97\x97\xc3\xb7\xaa\xde\x56\xbb\xdd\xc7\x48\xd1\xc6\xf0\xf2\x52\xad\x16\xbb\xdd\xe4\xbe\x5a\xde\xdd\xb7\xe3\xa0\xd7\xcf\x93\x75\xd3\x45\xce\x78\x53\xd5\xf3\x76\xf9\x54\xf5\x8b\xbe\xbf\xb0\xd3\x32\x69\xc0\xf2\x3e\x9b\xb2\x46\xf8\xd4\xde\x47\x4a\x2b\x7e\x67\xe6\xeb\xd9\x7b\x76\xd4\xeb\x67\xa5\xe5\xf3\xde\x14\xf9\x4c\xe7\xaf\xb6\x7f\xd9\x6e\xbf\x35\x8f\xd0\xac\xea\xe5\xaa\xfa\xce\x24\xa5\x54\xe7\xaa\x59\x73\xd7\x7c\xfa\x7d\xb6\xdb\x4d\x97\x0f\x77\x6a\xbb\xb9\x39\x2f\x5e\x5e\x0e\xa3\x85\x9a\xd7\x6d\x1a\xfa\x75\xfe\x50\x09\xee\x7c\x76\x5e\x38\x5d\xf4\xca\x2e\x96\xdb\x75\x3d\xff\x36\x56\x9f\xeb\xe6\xe6\xcb\xa4\x18\x5d\xf4\xfe\x9e\x6e\x9f\xee\xd4\x72\x71\x5e\x6c\x9f\xee\x0a\xf5\x54\x6d\xb6\xcb\x66\x75\x5e\x20\x60\xa1\x92\x83\xce\x8b\x14\x82\x43\xa1\xdf\xcd\x34\xd2\x5a\x8f\x92\xa0\xc4\x32\x7e\x96\xd0\xff\x7e\x4a\x0a\xf5\xbb\x2a\x5e\x4c\xf7\x7a\xdd\x15\x17\xd3\xf5\xbc\xbd\x4f\x58\xbe\xe8\x42\x2d\xce\x8b\x2b\x22\xd0\x5a\x2b\x0a\x10\xb4\x56\x33\x95\xb1\x71\x09\x93\x03\x73\x40\x33\xc5\x1a\xc2\x01\x67\x44\xe1\x98\x8a\x79\xb8\x9b\x8b\xfa\x58\x72\x4f\x3d\x5a\xf7\x8a\x4d\xc7\xdc\xfd\x33\x53\x3d\x0e\x89\x8b\xe3\x10\xcd\x94\x39\xa2\x66\xc4\x04\x66\x48\xcd\x4a\x9a\x30\x54\x79\xa6\x2c\x03\x1d\x70\x46\xfb\xb9\x19\x67\xc9\x36\x0c\xd1\x4c\xb9\x23\x6a\x8f\x7a\x9d\x7b\x9c\x7d\x14\x86\xce\x38\x58\xd4\x51\x7b\xd4\xfd\x73\xe5\x09\xbc\x09\x0a\x11\xac\xd1\xea\x52\xb9\x00\x9a\xb5\x42\x06\x63\xb0\x74\x16\x7c\xf4\x0a\x1d\x78\x43\x82\x82\xb6\x8a\x10\x82\x66\xe1\xb5\x10\xd0\x28\xf2\xc0\x91\x4a\xe7\x21\x38\xa3\x28\x82\x37\xbe\xf4\x06\x1c\xb2\x62\x84\x48\x46\x5d\xaa\xa0\x21\x9a\xa0\x98\x21\x3a\x5f\x06\x02\x44\xa7\xd8\x82\x26\x53\x06\x04\xf4\x51\xb1\x08\xb0\x1d\x2f\x91\x55\x46\x83\xb7\xb6\xf4\x0e\x8c\xd1\xca\x20\x10\xdb\xd2\x13\x10\x79\xc5\x31\x39\x22\xe9\x4b\xec\x15\x3b\x08\x81\x45\x07\x63\x9c\x48\xd2\x3e\xe9\xcb\x56\x8b\x1c\x67\xa2\x9a\x29\x67\x80\x65\x03\x08\x8c\x21\xd1\x1e\xbd\x55\x86\x81\xbd\x58\xe3\x35\x44\xcb\xca\x44\xb0\x64\xca\x60\xc0\xb9\xe4\x4b\x44\x5f\x86\x00\x14\x9d\xe8\xd0\xad\x1a\x09\xc8\xa0\x58\x43\x01\x85\xea\x0c\x8a\x1f\x1c\xda\xd2\x7b\xf0\xc6\x28\x32\xc0\x51\x78\xbd\x05\xb2\x46\x11\x83\xb3\x5c\x7a\x86\xe0\xbc\x04\x21\x07\xb3\x47\x1a\x82\x21\xe1\xed\x30\x8a\x24\x93\x24\x31\x89\xf7\x9d\x77\xe2\x33\xa6\x14\x02\x3e\xa2\x78\xc9\x82\x8b\x41\x91\x06\x36\xa1\x0c\x0e\xd0\xa1\x20\x8c\x4e\x34\xd2\xda\xc8\xcc\xc8\xa8\x66\x2a\x44\xa0\x68\x65\xd3\x9d\x31\x2a\x04\x60\xe3\x65\x8f\x65\xc3\x2e\x55\x30\x10\x51\x4b\x0c\x68\x26\xf1\x77\xb4\xa8\x22\x04\xd9\xc6\xa3\xf0\xb8\x12\x39\x12\x3c\x08\x3a\x88\xbe\x07\x4c\xde\x95\x91\x40\x07\x2d\xab\xb2\xa5\x32\x5a\x70\x5d\xba\xb1\x63\x35\x53\xa8\xbb\xc4\x13\xa7\x45\xbd\x87\x46\x83\x36\x76\x40\xcf\xf9\x81\xfa\x55\x05\xc0\x1c\xc0\x3d\xbd\x8f\xe7\x08\xd1\xd1\x80\xce\x0c\x48\xac\x10\x7d\xd2\x8d\x08\x30\x04\x75\xa9\x90\x34\x78\x71\xa0\x03\xf4\x5c\xa2\x6c\x48\x92\x03\x68\xf5\x10\x6a\x72\x89\x3d\x0f\x68\x88\x9a\x4a\x24\x04\xcf\x26\x67\x51\x89\x18\xc1\xd8\x1e\x0a\x3b\x1a\xd0\x18\x0e\x74\x03\x48\x51\xa0\x8b\xb1\x44\x1d\x21\xfa\xa0\x30\x02\x7b\x71\x39\xea\x90\xe8\xc4\x40\x1c\x4a\x31\xd6\x6a\x97\x2b\x57\x82\xec\xb1\x2f\x64\xc2\xee\x52\x98\xef\xe9\x06\x6c\x74\x12\x53\xa4\x6d\x29\xae\xf4\x64\x92\xf4\xcc\xae\x21\x58\x56\x68\x81\xa3\x2d\x63\x80\x18\xd3\x26\x46\xd6\x82\x9c\x46\x41\x62\xde\xa5\x8a\x1e\x7c\x24\xd1\x34\x92\x2f\xfb\x1d\xd5\x62\xde\x1e\x75\xfb\x7d\x85\x92\xb0\x3e\xca\x5c\xe2\xde\xa7\x29\x60\x0d\xd8\xc0\x25\x4a\xa1\x70\xa9\xc6\x68\xeb\x4b\xa4\x00\x14\xbc\xa4\x98\xb1\x92\x62\xc8\x0e\x5c\x44\x65\x35\xb0\x36\x25\x5a\x04\xcb\x4e\x19\x0f\x8e\x05\x3a\xb0\x01\x15\x07\xe0\x14\x97\xe8\x0c\x38\xf2\x62\xa6\x0d\x98\xd8\x3d\x7b\xe5\x81\x31\x94\xaf\x74\xb9\x42\x29\x82\xde\x0a\xb3\x47\x29\x8a\x32\x20\xde\x93\x35\xd3\xb6\x1b\xf0\xda\x48\xbc\x90\xf3\x22\xdd\x6b\xd0\x2e\x88\x32\x68\x5d\x89\xc1\x83\x0f\x28\xf9\x1f\x29\x94\x18\x09\x22\x26\x65\xa2\x4e\xca\x44\xee\x6c\xf3\x12\xe4\x25\x46\x86\xa0\x49\x0a\x8f\x31\x31\xc1\x88\x51\x56\x8f\x5d\xb4\x46\x03\xda\x60\x1f\x20\x18\x22\x04\x1a\x14\x65\x0c\xb6\x0f\x2f\x19\x10\x68\xa3\x96\xda\x2a\xf3\x2f\xbb\x01\x71\x86\x94\x27\x2c\x05\xb2\xd4\x48\x0b\x86\xb9\xc4\x60\x00\x65\x75\x0f\x68\x92\x76\x52\x43\x25\x39\x10\xd0\xd9\x12\xbd\x05\x1d\x83\x94\x3e\xef\x4d\x89\x9e\x92\x58\x51\x16\xa5\xc2\xca\x80\x96\x32\x6a\xc0\x85\x8e\x2e\xb9\xc8\x26\xe5\x71\x0f\x89\xc0\x63\x4a\xcd\x3c\xd0\x2b\xeb\x3c\x58\x6f\x07\xc6\x38\x96\x24\xda\x1b\x73\xbc\x15\x57\x44\x04\x46\x72\x83\x40\xb3\xb8\x9e\x10\x53\xa9\x47\xb1\xc2\x95\x24\xe1\x6e\xa2\x14\x50\x1b\x7d\x29\x54\xeb\xbd\xb8\xde\x5a\xa9\x32\x84\x52\x52\x51\x59\x04\xe3\x42\x49\x2c\x75\x51\x2b\x13\x21\xb0\x2e\xc9\x10\x68\x4c\xc6\x69\x2b\x41\x49\xc6\x81\x61\x27\xbe\x0c\xe8\x4a\x62\x07\x64\x82\x8a\xc0\xe4\xca\x57\xba\x5c\x91\xcd\xb7\x82\x98\xb6\x63\xa6\xfa\x01\x93\xcb\x87\xcd\x55\xa9\x83\x42\xcf\xc7\x78\x4f\xdf\x9f\xe3\xc8\x76\x40\x47\x0f\xda\xcb\x5d\xc4\x00\xd9\x20\x07\x61\x60\x71\x3d\x79\x29\xd8\x5a\xe2\x30\x5a\x57\x92\xb7\xc0\x92\x03\x0e\x9c\x18\x23\xd0\xc6\x04\x53\x94\x26\x7a\x20\x19\xf0\xda\x26\x7a\x90\x93\xc8\x41\xb0\xb1\x24\x2f\x75\x03\x25\x83\x34\x4a\xa1\x27\xef\x93\x1d\xa2\x7d\xf0\x7b\x48\x11\x9c\xe1\x01\x1d\xf3\x0e\xcb\xe1\xaa\x6d\x0f\x85\x6e\x81\x70\x40\xb7\x80\x72\xe4\xbb\x74\xe4\x77\x74\x21\x18\xa9\x1e\x52\xaf\x18\x42\x8c\x3d\xfd\x52\x91\x35\x60\x82\x91\x93\x86\x4c\x28\xc9\x12\x38\xe3\xb2\xb8\x92\x24\x83\x63\xec\xa5\x1f\x9c\xdd\x2f\x97\xa1\xa8\x2b\x77\x33\x0a\x07\xf5\x53\x8d\xef\x07\x8c\x07\x26\x56\x14\x09\xbc\x68\xe3\x41\xbb\xb4\x7c\xb4\x60\xb5\x15\xf7\x44\xd2\x25\x45\xc9\xba\x04\x83\xe6\x92\x35\x75\x17\x00\xd1\x56\xc4\xb1\x76\xc7\xb7\xca\x0c\x6d\x8e\x85\xfd\x40\x77\x59\xa2\x54\x3a\xf7\x70\xa6\x28\x6a\x30\x87\x81\x1e\x4a\xd9\x3b\xa2\xf7\xe6\x85\xee\xd6\x92\xe1\xc1\x9c\x3d\xfd\xc8\xdc\x2b\x46\xca\x72\xf3\x7a\xfd\x40\xaf\x2f\xf2\x50\xfd\x99\x62\xb4\xc3\x2b\x66\x0f\x07\xf3\xbb\x81\xbc\x5e\x3f\x3f\xc3\x83\xfc\x3d\xfd\x68\xfd\x2b\x26\x03\xe1\x48\x1e\x99\xa1\x02\x4c\x0e\x30\x66\x77\x77\x74\xc9\xe8\x01\xdd\x83\x8b\x36\xe5\x4a\x34\x99\x2e\x13\xd1\x83\x0d\x72\x39\x0c\x72\x77\xec\xe9\x97\x8a\x4d\x48\x15\xc8\x58\x30\xd1\x96\x6c\x22\xf8\x1c\xfc\x5a\x97\x2c\xd1\x62\x7c\x2f\x7d\xa6\xb8\xcf\xd5\xbc\x5c\x86\x07\x75\xf3\x40\x6f\x9e\xd5\xe0\x86\xc1\x2f\xf2\x07\xc1\x2f\x30\x07\xbf\xf3\x94\xe9\x39\xf8\xd9\x70\x52\x57\x6e\x59\x99\x7e\xa9\x98\x02\x60\x88\x0a\x19\xac\xb7\xa5\xb8\x23\xc4\x5e\x5c\xc9\x64\xd3\x51\x72\x58\x2e\x7b\xaf\x5f\xee\xd8\xbb\x57\xec\x06\xea\x7b\xe1\xcf\x03\x62\xaf\x5c\xad\x3d\x81\xed\xa0\xef\xe8\x81\x7b\xba\x44\x11\x07\x06\x24\xaf\x8c\xa4\x84\xce\x74\xb2\xbc\xff\xd5\xe2\x4d\x3a\x03\x32\x14\xf9\x79\x37\x33\x3d\x43\xf6\xaf\xe8\xac\xf3\xfc\x2e\x3a\x32\x14\xf9\x1a\xf4\x61\xa0\x87\x14\x81\x8e\xe8\x94\x7f\x5a\xe5\xf9\x19\x1e\xe4\xf7\xf4\x1e\x22\xd0\x11\x3d\xff\x74\x61\x9f\xa3\x77\xaf\x7f\xa0\x23\x7a\x0f\x19\xe8\x98\x4e\x47\xeb\x67\x78\xf0\x6f\x4f\x3f\xf6\xff\x15\x31\xc9\xc5\x43\xa1\x05\x6b\x52\xb1\x31\x0c\x9e\xac\x28\x68\x1c\xa5\x73\x88\xd0\xa7\x33\x97\x4c\x49\xac\xd3\x01\x60\xa4\xfe\xa7\xc2\x8f\x01\x9c\x54\x72\x0f\xd6\xc9\xa1\x17\xc1\xc6\x28\xc7\x16\x06\x39\xf4\xe4\x92\xc3\x2a\x5f\x43\x85\xdd\x81\x46\x2f\xcb\x45\xd4\x25\x91\x05\x46\x97\x2e\x71\x88\xe5\x2b\x65\xae\x50\x74\x91\xeb\x46\x00\xc3\x72\x66\xca\x65\xc9\x06\x23\x3f\x08\x1c\xea\x12\x2d\x01\x31\xc9\xfd\xc1\x87\x58\xa2\x91\x9b\x55\x90\x43\x88\x92\x2d\xc8\xa1\xbb\x3f\x10\x90\x96\xcb\x15\x02\x0b\x7b\x00\x6d\x04\x6a\xb0\xd6\xc9\xce\xda\x98\xa4\x4b\x89\x92\x9f\x3c\xf2\x53\x10\xd3\x5d\xcc\x23\xca\xd5\x9a\x34\x97\xaf\x94\xb9\x22\x8d\xa0\x1d\xc9\x3d\x10\x59\x7e\xba\x90\xd6\xe9\x37\x80\x61\x88\xc1\x94\x02\x51\xee\x89\x4e\x02\x32\xc1\x10\xba\xc0\xa6\xe4\x0a\x2d\x5a\x45\xf9\x31\xa6\x4d\x2c\x49\xef\xab\x9c\x77\xfe\x00\x0d\x04\x17\x13\x7b\x1e\x20\x08\x2c\x8e\x26\xb9\x05\xcb\xd5\xc8\xc9\x91\x79\xac\x8c\xbc\x60\x6c\x9a\x2f\xd5\x79\xb1\x6a\x56\x55\xa1\x6e\x97\x75\x7d\x5e\x7c\xbc\x4d\x7f\x1d\x3c\xdb\x3c\xca\x2b\x4c\xf5\x54\xad\x9a\xc5\xa2\xb8\x98\x8e\xe4\x15\xe3\xd5\xa3\x06\x9e\x1e\x35\x4e\x8f\x1a\xa7\x47\x8d\xd3\xa3\xc6\xe9\x51\xe3\xf4\xa8\x71\x7a\xd4\x38\x3d\x6a\x9c\x1e\x35\x4e\x8f\x1a\xa7\x47\x8d\xd3\xa3\xc6\xe9\x51\xe3\xf4\xa8\x71\x7a\xd4\x38\x3d\x6a\xfc\x49\x1e\x35\x46\x77\x17\x53\x69\x02\xb9\xc8\x3d\x3c\xd3\xd1\xfc\x6d\x87\xcb\x74\xb4\x58\x3e\x5d\x7c\xf8\xc1\xd0\x00\x0e\xbf\xbe\xed\x57\x4a\xdd\x3a\x6a\xfe\xd8\x36\x6a\xdf\xde\xa4\xde\x34\xff\x0c\x27\xa6\xbe\x96\x71\xee\xfb\xd9\xf7\xf1\x90\x88\x49\xb2\xde\x34\xf2\x4c\xd7\xfd\xd4\x1b\xe9\x56\x1a\xab\x8f\x94\xfe\x26\x6a\xd8\x15\xa5\xfa\xb6\x28\x35\xe8\x8b\xea\x38\xb6\xcb\xff\xac\xc6\x0a\x9d\x34\x1a\xa5\x81\xaf\xa9\x9d\x66\xac\x56\xcd\xe6\x61\x5e\x4f\x94\x34\x02\x9d\xdd\xe7\x41\x32\xc2\xd7\x9b\x27\xad\x45\x78\xac\xaa\x9e\x28\xe9\xfd\x3a\x9b\xd7\xcb\xbb\xd5\x58\xd5\xd5\x6d\xfb\x5a\x65\xf9\xbc\xbc\xc0\x3f\x6f\xaa\xaa\x5d\xae\xee\x76\xbb\x63\x83\x46\xeb\xff\x2f\x16\x5e\x57\xcf\xed\x7f\x6b\xdd\x60\xf3\xf7\x4b\xa4\xfd\xce\xbd\x5e\x47\x8b\xdd\x54\xab\xb6\xda\xbc\xb7\xdc\xbe\x91\xeb\xe5\x05\x3e\x6d\xea\xdd\xae\xf8\xf0\x8a\x43\xfe\xcb\x0b\x0d\x7a\xde\xd4\x47\xb3\xf0\x1c\xe6\x93\xcf\xcd\x66\x51\x6d\xce\x36\xf3\xc5\xf2\x71\x3b\xe6\xf5\xf3\xa4\x73\x77\x4e\xac\x49\xdf\x04\xb5\x4c\x9d\x61\x67\x5d\x2b\xd4\x70\x07\xfa\x0d\x18\xf8\xff\xe0\x7e\xf4\xeb\xe7\xc9\xd0\xf9\x9f\x9b\x7a\x31\xa9\xab\xb6\xad\x36\x67\xdb\xf5\xfc\x46\xec\xce\xfb\x91\x7d\xaf\x53\xe2\x4c\x7a\xa7\x20\xad\x9f\xbb\xcd\x19\x38\x24\xfb\x23\x8d\x2c\xaa\x9b\x66\x33\x4f\x5d\x78\x52\x18\x72\x83\xa0\xc8\xf8\xcb\xf2\x61\xdd\x6c\xda\xf9\xea\xdd\x8d\xca\x9b\xf5\xb7\xc7\xb6\x6d\x56\xdd\x96\xbd\xa9\x0b\x83\x34\xef\x3f\xd3\xfb\xcd\xbb\xee\x5c\x44\xf9\x64\x77\x76\x9a\x64\x47\x66\xca\xc3\x72\xd5\x07\x1b\xae\x9f\x7b\x6b\x73\xab\x9e\xec\xfc\xa4\x18\xbd\x5a\xea\x4d\x06\xf8\xf4\xf7\xbf\x70\x3f\xbd\x72\x7f\x76\xf5\x30\xf2\x31\x1e\xb4\xd9\x87\x7d\xef\x7d\x3d\xf4\xfa\x0f\x42\xbe\xf3\xe2\x2f\x55\xbd\xde\xed\xbe\x63\xc3\xff\xa5\xd2\x5f\x9b\xcd\xe2\xec\xf3\xa6\x9a\x7f\x19\xa7\xff\x9f\xcd\xeb\xfa\x7f\x96\x4d\xaf\x77\xc0\x60\xe0\x1b\xf3\x26\xf2\xd4\xe3\x6a\x51\x6d\xc4\xad\x93\xe2\xa2\x9f\xfc\x4e\x38\xad\xff\xb4\x3b\xfc\x7b\x35\xdf\x36\xab\xdd\xee\xad\x0d\x83\x4a\x96\x17\x51\xf6\x87\x0d\xa8\x07\xab\x07\x4b\xff\xa0\xc6\x65\x0d\x7e\x6e\x9a\xb6\xda\xbc\x8a\xaf\xf7\xdd\x7a\x9c\xb6\x03\x98\xbf\xee\xff\x91\xde\xe4\x8b\x0f\xd3\xd1\x7d\xfb\x50\x5f\x7c\xf8\xaf\x01\x00\x20\x8f\xb2\x90\x38\x2e\x00\x00")
 
 func emailwithbuttonHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +111,387 @@ func emailwithbuttonHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "emailwithbutton.html", size: 3116, mode: os.FileMode(436), modTime: time.Unix(1465802255, 0)}
+	info := bindataFileInfo{name: "emailwithbutton.html", size: 11832, mode: os.FileMode(436), modTime: time.Unix(1792377563, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _emailwithbuttonTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x7b\x7b\x2e\x47\x72\x65\x65\x74\x69\x6e\x67\x7d\x7d\x0a\x0a\x7b\x7b\x2e\x54\x65\x78\x74\x7d\x7d\x0a\x0a\x7b\x7b\x2e\x42\x75\x74\x74\x6f\x6e\x54\x65\x78\x74\x7d\x7d\x3a\x20\x7b\x7b\x2e\x55\x72\x6c\x7d\x7d\x0a\x0a\x7b\x7b\x2e\x52\x65\x61\x73\x6f\x6e\x7d\x7d\x0a\x0a\x2d\x2d\x0a\x7b\x7b\x2e\x46\x6f\x6f\x74\x65\x72\x7d\x7d\x0a\x03\x00\x35\x06\x38\x80\x51\x00\x00\x00")
+
+func emailwithbuttonTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_emailwithbuttonTxt,
+		"emailwithbutton.txt",
+	)
+}
+
+func emailwithbuttonTxt() (*asset, error) {
+	bytes, err := emailwithbuttonTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "emailwithbutton.txt", size: 81, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnCommonTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcc\x31\x0a\xc2\x40\x10\x46\xe1\x3e\xa7\x18\xb7\xd7\x03\xd8\x08\x56\x96\x82\xe4\x00\x1b\xf3\x67\x33\x64\x9d\x91\xcd\xc6\x20\xc3\xdc\x5d\x10\x41\xc1\xea\xf1\x9a\xcf\xac\xc7\xc0\x02\x0a\xa9\x00\x95\x25\x05\xf7\x13\x72\x56\x33\x1e\x68\xd7\xce\x28\x12\x6f\x70\x27\xb3\x9f\x33\x83\xf4\xee\x9b\x4f\x9b\xaf\xd3\x2d\xb5\xaa\x8c\xc8\xf7\xe0\x7e\x7c\x0f\x89\x56\x5a\xb5\x4c\x2c\xe9\x40\xe7\x38\x57\x50\x1d\x41\x83\xe6\xac\x2b\x4b\xa2\xcc\x32\x11\x4b\x55\x7a\xea\x52\xa8\x2b\xba\xce\x28\xfb\x7f\xfd\xa1\x7c\x45\x8e\x92\x96\x98\x10\xdc\x21\xdb\xf6\x62\x06\xe9\xdd\x9b\xd7\x00\xf5\x33\x4f\x41\xcd\x00\x00\x00")
+
+func messagesEnCommonTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnCommonTmpl,
+		"messages/en/common.tmpl",
+	)
+}
+
+func messagesEnCommonTmpl() (*asset, error) {
+	bytes, err := messagesEnCommonTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/common.tmpl", size: 205, mode: os.FileMode(420), modTime: time.Unix(1792380124, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnEmailvalidationTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\x31\x6e\xc3\x30\x10\x04\x7b\xbf\xe2\xa0\xda\xd0\x1f\x52\xa4\x70\x95\x26\x08\xe0\x92\x22\x57\x36\x13\xe6\x2e\x20\x8f\x56\x04\x82\x80\xbf\xe1\xef\xf9\x25\x81\x24\x23\x89\xe0\xce\x25\xb9\xb3\x9c\x65\x29\x0e\xbd\x67\x50\x93\x72\xf7\x0e\xab\x4d\xad\x3b\x4d\x7b\xc9\xed\x0b\x87\x29\xc0\xa7\xf1\x81\x4e\x88\xbe\xf7\xd6\xa8\x17\x2e\x05\xec\x6a\xdd\xfc\x75\xd5\x6b\xc0\x63\x4d\x7c\x4f\xca\x57\x59\xb8\x91\x46\xc9\xf1\xd6\x34\xce\x45\xa4\x44\xa5\xb4\xcf\xd3\xc5\xd3\x72\xae\x95\x84\x69\xa5\xda\x92\x0d\xde\x7e\x90\x1e\x41\x5d\x56\x15\xa6\x0e\x41\x86\xf6\x5e\xb8\xc4\x4d\xad\x6f\x8b\x6f\x56\xdd\x63\x11\x26\xcd\xd8\x5e\xf2\xf5\x7c\x89\xa0\x08\x0b\x7f\xf2\x7c\x20\x3d\xfa\x74\x9b\xd8\xc1\x9a\x9c\x30\xad\x9e\x01\xd6\x30\x92\x8d\x30\x0a\x47\x86\x18\xc3\x7a\x28\x19\x6b\x25\xb3\x92\x44\x32\xce\xfd\x42\xab\x0f\xb7\xb4\xeb\x17\xc9\x60\x12\x5f\xcf\x17\x9d\xde\xdf\xd2\x57\x80\x49\x20\x7f\x60\x89\xf8\xb7\xa2\x2d\x05\xec\x6a\xdd\xfc\x0c\x00\x10\xc0\xc2\x1a\xcd\x01\x00\x00")
+
+func messagesEnEmailvalidationTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnEmailvalidationTmpl,
+		"messages/en/emailvalidation.tmpl",
+	)
+}
+
+func messagesEnEmailvalidationTmpl() (*asset, error) {
+	bytes, err := messagesEnEmailvalidationTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/emailvalidation.tmpl", size: 461, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnInvitationacceptedTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\x41\x4a\x03\x41\x10\x85\xe1\xbd\xa7\x78\xcc\x01\xe6\x1e\x59\x65\x27\x64\xd9\xd3\xf3\x92\x54\x18\xab\xa4\xbb\x3a\x51\x9b\x02\xaf\xe1\xf5\x3c\x89\x30\x20\x11\x46\xb2\xff\xea\xaf\xd7\xfb\xcc\xa3\x28\x31\xd4\x36\x5d\x98\x7d\x88\xe8\x7d\xdc\xe9\x55\x9c\x8c\xc0\xc5\x44\x39\xa3\xf7\x71\x5f\x4e\x49\xe5\x23\xb9\x98\x46\xc0\x14\x3b\xaf\x07\x6b\xe3\x5e\x17\x51\xf6\x4e\x9d\x23\x9e\xee\x45\x17\x5f\x38\x44\xac\xb1\xf5\x0c\x29\x67\xbe\x3a\xe7\x7f\x30\xdf\x36\xbf\x7f\x35\xfc\x4c\xc8\xbd\xe2\xb6\xce\xda\x8e\x1a\xb7\xdd\xa9\xb9\x9b\x0e\x11\xcf\xc2\x1b\xec\x0f\xdf\xda\xc2\x54\x57\x7b\xb0\xf6\xfd\xf9\x55\x88\xc2\x4c\xb9\x8a\x9e\xe0\x67\xa9\xe0\x4b\x92\x05\x13\x73\x6a\x95\x78\xb7\x86\x54\x88\xa4\xb0\x9b\xb2\xc0\x8e\x0f\x26\xfd\x0c\x00\x92\xff\x54\xe2\x6a\x01\x00\x00")
+
+func messagesEnInvitationacceptedTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnInvitationacceptedTmpl,
+		"messages/en/invitationaccepted.tmpl",
+	)
+}
+
+func messagesEnInvitationacceptedTmpl() (*asset, error) {
+	bytes, err := messagesEnInvitationacceptedTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/invitationaccepted.tmpl", size: 362, mode: os.FileMode(420), modTime: time.Unix(1792382135, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnInvitationrejectedTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\x41\x4e\x02\x41\x10\x85\xe1\xbd\xa7\x78\x99\x03\xcc\x3d\x58\xb1\x33\x61\xd9\x33\xfd\x80\x22\x63\x55\xd2\x5d\x0d\x6a\xa7\x12\xaf\xe1\xf5\x3c\x89\x71\x16\x62\x82\xb0\xff\xdf\x97\xd7\x7b\xe6\x5e\x94\x18\x6a\x9b\x4e\x9c\x7d\x88\xe8\x7d\xdc\xe8\x59\x9c\x8c\x40\xe6\xbc\x88\x32\xc3\x0d\x27\x13\x45\xef\xe3\xb6\x1c\x92\xca\x7b\x72\x31\x8d\x80\x29\x36\x5e\x77\xd6\xc6\xad\xfe\xb4\xbd\x53\x73\xc4\xd3\xd5\x76\xf1\x85\x43\xc4\xca\xae\xb3\x5f\xf7\x9f\x98\xaf\x0f\x5e\x1c\x09\xb9\x2a\x77\x4f\x8d\xb7\xee\xd4\xdc\x4d\x87\x88\x67\xe1\x05\xf6\x27\xbf\x6d\x0b\x53\x5d\xdb\x9d\xb5\xaf\x8f\xcf\x42\x14\xce\x94\xb3\xe8\x01\x7e\x94\x0a\xbe\x24\x59\x30\x71\x4e\xad\x12\x6f\xd6\x90\x0a\x91\x14\x76\x51\x16\xd8\xfe\xc1\xa5\xef\x01\x00\x68\x16\xe4\x00\x74\x01\x00\x00")
+
+func messagesEnInvitationrejectedTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnInvitationrejectedTmpl,
+		"messages/en/invitationrejected.tmpl",
+	)
+}
+
+func messagesEnInvitationrejectedTmpl() (*asset, error) {
+	bytes, err := messagesEnInvitationrejectedTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/invitationrejected.tmpl", size: 372, mode: os.FileMode(420), modTime: time.Unix(1792382135, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnLogincodeTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8f\xc1\x4a\x43\x41\x0c\x45\xf7\xfd\x8a\x4b\xd7\x65\x3e\xa0\x3b\x71\x2b\x08\xa2\x0b\x97\x43\x27\x6f\x5e\xf0\x35\x81\xc9\x8c\xd0\x86\xfc\xbb\x8c\x0a\x55\x17\x6e\x93\x93\x7b\x72\xdd\x0b\x2d\x2c\x84\xbd\x9d\x6d\x1f\xf1\xac\x38\xa9\x74\x96\x41\x30\xae\xc2\x52\xc1\x82\xdc\xc1\xdd\x2e\x3a\x92\xca\x36\x71\x77\x5e\x90\x1e\x5b\xcd\xc2\xd7\xdc\x59\x25\xa2\x2b\xf2\xe8\xab\x36\xbe\x12\xfa\x4a\xd0\x1f\x6b\xb8\xff\xc1\x0f\x70\x27\x29\x11\x24\x9d\xda\xe7\xc1\x49\xcb\x8c\x4e\xf7\x5a\x28\x62\x8a\xe7\x74\xd1\x76\x86\x36\x0c\x9b\xb1\x6c\xd8\x58\xde\x8e\x93\x7b\x79\x7a\x88\xf8\x4e\xd9\xdd\xaa\xbc\x2b\x9f\x68\x96\x59\xd9\xc0\xf6\xfb\xf5\x84\x57\x1d\x0d\x9b\x56\x96\x2f\x21\xdb\xf1\x26\x4d\xb8\xab\x99\xe5\x80\xcb\xbf\x94\x3b\x49\x89\xd8\x7d\x0c\x00\x4f\x44\xda\x62\x40\x01\x00\x00")
+
+func messagesEnLogincodeTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnLogincodeTmpl,
+		"messages/en/logincode.tmpl",
+	)
+}
+
+func messagesEnLogincodeTmpl() (*asset, error) {
+	bytes, err := messagesEnLogincodeTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/logincode.tmpl", size: 320, mode: os.FileMode(420), modTime: time.Unix(1792379376, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnLoginlinkTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x41\x6e\xdb\x30\x10\x45\xf7\x39\xc5\x87\xd7\x82\xee\x11\xa0\x40\x36\xd9\x64\x49\x51\x5f\xd2\x34\xf4\x30\x25\x87\x55\x5c\x82\x40\xae\x91\xeb\xe5\x24\x85\x2c\x03\xb5\xeb\x4d\xb6\xe4\x9b\x3f\xef\x4f\xad\x23\x27\x51\xe2\x90\xcb\xf0\x93\xde\x0e\xad\x3d\x5a\x7e\x89\xa5\x7f\xd2\xb0\x7d\x84\x38\x8b\xd6\x4a\x1d\x5b\x7b\xf8\x87\x9b\x58\xe0\xb7\x61\xbe\x6f\xc1\xcf\x71\x03\x20\x0a\x8b\xb8\x19\xac\x55\x26\xf4\x4f\x69\x76\x2a\x7f\x9c\x49\xd4\xd6\x30\xc5\x84\x5a\xff\x7b\xbd\x84\x77\xf0\x41\xfc\x2b\x6c\x21\x86\x62\x16\x15\x03\x43\x5c\x7b\x3c\x2f\x44\x10\x7d\x85\x77\x8a\xa8\xe1\x84\x81\x28\x99\x23\xa2\x7a\x76\x98\x52\x3c\xee\x63\x29\xae\x99\x69\xd3\x59\x17\xf1\x0b\x4e\xb1\x20\xf1\x57\x61\x36\x8e\x10\xeb\xe0\x74\x04\xdf\xdf\x24\x31\x6f\x98\xc3\xc4\x15\x47\xd1\x62\xcc\xfd\x7d\xcd\x5d\xe4\xd0\xda\x8f\x73\xcb\x7b\x20\xd1\xe5\x33\xf0\x12\xcb\xd7\xc7\x67\x22\x12\x3d\xe5\xb7\xe8\x0c\x5b\x24\x83\x47\x27\x01\x03\xbd\x2b\x99\x17\x21\x4f\xb5\x70\xba\x32\x73\xdb\x15\x45\xf7\x96\xce\x6e\x2f\xd9\xe3\x71\xda\xb3\x56\x97\xf5\xeb\xe3\xd3\xb6\x98\x0e\x6f\x81\x2e\x13\x32\x6b\x4c\xbc\x5a\xd6\xd7\x4a\x1d\x5b\x7b\xf8\x3b\x00\x32\xc1\x9e\x3a\x0a\x02\x00\x00")
+
+func messagesEnLoginlinkTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnLoginlinkTmpl,
+		"messages/en/loginlink.tmpl",
+	)
+}
+
+func messagesEnLoginlinkTmpl() (*asset, error) {
+	bytes, err := messagesEnLoginlinkTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/loginlink.tmpl", size: 522, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnOrganizationinvitationTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x41\x8a\x1b\x3d\x10\x85\xf7\x73\x8a\xc2\x6b\xd3\x07\xf8\x77\x3f\x21\x0b\x87\x90\x81\x21\x59\x78\xa9\xee\x7e\x6d\xd7\xd0\xae\x32\x52\xc9\x8e\x23\x0a\x7c\x8d\x81\xe4\x72\x3e\x49\xe8\x6e\x3b\x9e\xc4\x9e\x40\x76\x92\x78\xfa\xde\x7b\x52\x95\xd2\xa2\x63\x01\xcd\x52\xae\x9f\xd1\xd8\xcc\x7d\x21\x3b\xb6\x60\xac\x42\xa6\xf4\xac\x2c\x54\x4a\xf5\x18\x57\x41\xf8\xdb\x78\xee\x4e\x2a\xb4\xb0\xb4\xd4\x5c\x3d\x4a\xcf\x82\x52\x20\xad\xfb\xc3\x15\x68\x6c\x3d\x66\xee\x1f\xee\x02\xee\xe8\xf1\x75\x70\x2f\x85\x3b\xaa\xc6\x0c\x88\xc3\xf6\xba\x26\x1e\x57\x2d\x1d\x34\x97\x82\x3e\xc1\x7d\xa9\x99\xd6\x61\x07\xaa\x01\xb9\x08\xce\xf0\x7f\x88\x5f\x4d\xb6\x9f\xd4\xe0\x4e\xa7\xe3\xf7\x52\xce\x9b\xd3\xf1\xc7\x05\xf7\x59\x29\x34\x0d\xb6\x46\xb6\xc6\xe4\x35\xb6\x99\x53\x13\x11\x0c\x14\x64\x10\x68\x16\xa3\x20\x2d\xed\x10\xb9\x3b\x8c\x62\x6c\x02\xf7\x14\xda\x36\x22\xa5\x21\xcf\xfb\xe1\xe0\xff\x69\xef\x5e\xd1\xa2\x1b\x5a\x51\xe8\x23\x42\x7b\x98\x2a\x5d\x71\xf3\xe1\xea\xef\x4c\x4e\x7f\x40\x59\x06\x42\xa4\x6d\xd4\x8e\x7b\x54\xb7\x2f\x5c\x67\x33\x95\x99\xfb\xbb\x73\xdc\x09\x7e\x2b\x8c\x08\x69\x14\x2e\x35\x9f\x8e\x2f\x11\x14\xd1\x80\x77\x2c\xab\xd7\xce\x35\x9a\x90\xd3\x98\x53\xf7\x82\x48\xda\xdd\x79\xea\xcb\xa7\xdd\x46\xfe\xd5\xba\x55\x39\x1d\x5f\x8c\xf6\x41\xec\xf2\x69\x73\xda\xf6\x08\x09\xc4\x2b\xd1\x88\x57\xd7\xef\x34\x4b\x9b\x34\x7b\x63\x16\xfe\x3e\x04\x6c\xe9\xa0\xb9\xd2\x69\x08\xe8\x09\x2b\x4e\x86\x48\x7b\xb6\xf5\x64\xb9\x5d\xab\x80\x24\x6f\x6a\x44\xb2\x37\x26\xe0\xbf\x81\xfe\xe5\xe9\xa3\x7b\x29\x90\xd6\xfd\xe1\xe7\x00\xa9\xb3\x68\xeb\x59\x03\x00\x00")
+
+func messagesEnOrganizationinvitationTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnOrganizationinvitationTmpl,
+		"messages/en/organizationinvitation.tmpl",
+	)
+}
+
+func messagesEnOrganizationinvitationTmpl() (*asset, error) {
+	bytes, err := messagesEnOrganizationinvitationTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/organizationinvitation.tmpl", size: 857, mode: os.FileMode(420), modTime: time.Unix(1792382135, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnPasswordresetTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x8f\x31\x6e\x02\x31\x14\x44\x7b\x4e\x31\xda\x1a\xed\x3d\xa8\x22\x45\x69\x28\xbd\xf6\x00\x4e\x1c\x9b\xf8\x7f\x67\x83\x2c\x4b\x5c\x83\xeb\x71\x92\x68\x77\x85\x12\x44\x9a\xd4\x7f\xe6\xbd\xf9\xb5\x3a\xee\x7c\x24\x3a\x29\xc3\x2b\xad\x76\xad\x6d\x54\xb6\xa9\xf4\x4f\x31\x4c\x87\xa3\x11\x19\x53\x76\xc8\x14\x6a\xad\x8c\xae\xb5\xd5\x4f\x4f\xbd\x06\xfe\xbf\xc5\xaf\x49\xf5\x92\x16\x2c\x4e\xa9\x64\xfc\x8d\x58\xc3\x06\x6f\xdf\xa0\x07\x62\x28\xaa\x29\x62\x60\x48\x63\xff\x48\x5d\xce\x5d\x6b\xcf\x33\xf4\x46\x78\x0c\x66\x1a\x99\x83\xdb\x54\xae\xe7\x4b\x26\x32\x2d\xfd\xa7\x8f\x7b\xe8\xc1\x0b\xf8\x6e\x7c\xc0\x40\x6b\x8a\x70\x5a\x37\x07\xa2\x86\x13\x32\x3f\x0a\x45\xe9\xa0\x77\xeb\x6f\x36\x18\xbd\xff\xa4\xc7\x66\xb7\x50\x47\x23\xf1\x7a\xbe\xcc\x85\x35\x8e\x81\x46\x08\xbf\x8f\x29\xf3\x97\xb6\xaf\x95\xd1\xb5\xb6\xfa\x1e\x00\x97\x84\x0c\xb5\x9d\x01\x00\x00")
+
+func messagesEnPasswordresetTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnPasswordresetTmpl,
+		"messages/en/passwordreset.tmpl",
+	)
+}
+
+func messagesEnPasswordresetTmpl() (*asset, error) {
+	bytes, err := messagesEnPasswordresetTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/passwordreset.tmpl", size: 413, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesEnPhonenumbervalidationTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\x4d\x4a\xc0\x40\x0c\xc5\xf1\x7d\x4f\xf1\xe8\x5a\xe6\x00\xdd\x89\x5b\x57\x52\x0f\xa0\x9d\xd4\x06\xdb\x44\xe6\xa3\x50\x42\xee\x2e\x53\x95\x52\x70\x3b\xf3\x0f\xef\x67\x16\x69\x66\x21\xf4\x79\xcb\xbd\xfb\xa8\xd8\x29\xf1\x7c\xe0\xd0\x9a\xf0\xb5\xa8\x90\xd4\xed\x9d\x12\x54\xc0\x25\x1f\x5a\x83\xca\xda\x4e\x48\x0a\x25\x94\x85\x30\x69\x24\x98\x85\x27\x8d\xe4\x0e\x96\xf3\x75\xd6\xb4\x41\x13\x6a\x26\x94\x85\x33\x56\x96\xcf\xa1\x75\xaf\x2f\xcf\xee\x66\x24\xd1\xbd\xbb\x08\xbb\xf2\x44\x0d\xd1\x62\xce\xf7\xb9\x80\xf1\x6f\xa9\xfc\x83\xc4\xaf\x92\xf3\x70\x49\x02\x1e\x3f\xde\x58\x1e\x7e\xba\x53\x79\xff\x37\x23\x89\xee\xdd\xf7\x00\xa6\x37\xec\xc2\x07\x01\x00\x00")
+
+func messagesEnPhonenumbervalidationTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesEnPhonenumbervalidationTmpl,
+		"messages/en/phonenumbervalidation.tmpl",
+	)
+}
+
+func messagesEnPhonenumbervalidationTmpl() (*asset, error) {
+	bytes, err := messagesEnPhonenumbervalidationTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/en/phonenumbervalidation.tmpl", size: 263, mode: os.FileMode(420), modTime: time.Unix(1792379376, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlCommonTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcc\x31\x0a\x02\x31\x10\x85\xe1\x7e\x4f\x31\xa6\xd7\x03\xd8\xd8\x5a\x88\xd8\x88\x75\xd6\xbc\x8d\x31\xe3\x64\xc9\x66\xd7\x62\x98\xbb\x8b\x20\x28\x58\x3d\xde\x5f\x7c\xaa\x01\x43\x12\x90\x8b\x15\x68\x49\xa2\x33\xdb\x7b\xe6\xa2\x9a\x06\xda\x9c\x27\x54\xf1\x0f\x98\x91\xea\xcf\x53\x85\x04\xb3\xd5\x67\xbb\xaf\xd3\xcf\xad\x15\xb9\x81\x47\x67\x76\x41\xcd\x8d\x02\x28\x4b\x19\x49\x12\xda\x8e\x4e\xec\xf3\x3b\x2d\x85\x23\x24\x80\x38\x49\xa6\x24\x74\x07\xf5\xb5\x3c\x27\xd4\xed\x3f\xbb\x94\x74\x05\x7b\x89\xb3\x8f\x70\x66\xc2\xeb\xe3\x41\x15\x12\xcc\xba\xd7\x00\xea\x76\x8e\xcf\xc6\x00\x00\x00")
+
+func messagesNlCommonTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlCommonTmpl,
+		"messages/nl/common.tmpl",
+	)
+}
+
+func messagesNlCommonTmpl() (*asset, error) {
+	bytes, err := messagesNlCommonTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/common.tmpl", size: 198, mode: os.FileMode(420), modTime: time.Unix(1792380124, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlEmailvalidationTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\x31\x6e\xf3\x30\x0c\x46\xf7\x9c\x82\xc8\xfc\xff\xbe\x43\x87\x0c\x6d\x87\x8e\x45\x47\xda\xfa\xa2\xd0\xb1\xc9\x40\xa2\xdd\xa2\x82\xee\x5e\x28\x40\x90\xa4\xd9\x3a\x12\xe2\xe3\x7b\x2a\x25\x60\x2f\x0a\xda\xe6\xa5\x1f\x31\xf8\xb6\xd6\x67\xcf\x1f\xb6\x74\x6f\x3a\xb5\x07\xfc\x9f\x59\xa6\x15\x49\xf6\x32\xb0\x0b\x4a\x81\x86\x5a\x37\x57\xd4\xc5\x27\xfc\x09\xc4\x57\x13\xbe\x4e\x72\x24\x3b\x51\x00\x1d\xd5\x4e\x74\x10\x24\xd3\x80\x44\x36\xd3\x78\xb9\xc4\x21\x21\x53\x29\xdd\xae\x4d\x4f\xa1\x8d\xb9\xd6\x06\xde\x8b\x1d\xd4\x63\x45\x76\x89\xd0\xee\xd1\xda\x2f\xee\xa6\xdb\x5a\x77\x37\x77\xaf\xc4\x23\x90\xc0\xf9\x0c\xbc\x80\x4c\x7d\x65\x8d\x4e\x01\xdf\x97\x32\xb2\x39\xb0\xb7\x52\xd3\x89\x35\x66\x02\xf4\x57\x14\x0f\x83\x2d\xea\x74\x40\xef\xc4\xac\x11\x33\xf3\xd1\xc9\xf6\xe7\x65\x15\x2c\x9f\x77\x1f\x3d\x2f\xba\x21\x62\x35\xc4\xd0\xd1\x3b\x67\x1a\x65\xa4\x20\x4e\x2a\xf0\x7f\x14\x58\x69\xe6\xd8\xc4\xb7\x35\x8a\x88\x04\xed\x4a\x81\x86\x5a\x37\x3f\x03\x00\x4c\x43\x3e\xa3\xe4\x01\x00\x00")
+
+func messagesNlEmailvalidationTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlEmailvalidationTmpl,
+		"messages/nl/emailvalidation.tmpl",
+	)
+}
+
+func messagesNlEmailvalidationTmpl() (*asset, error) {
+	bytes, err := messagesNlEmailvalidationTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/emailvalidation.tmpl", size: 484, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlInvitationacceptedTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\xb1\x4e\xc3\x40\x0c\xc6\xf1\xbd\x4f\x61\x65\xe7\xde\xa3\x2c\x9d\x18\x18\x1d\xee\xeb\xe1\x34\xf1\xa1\x3b\x27\xa0\x5a\x7e\x77\x44\x11\x02\x29\x15\xfb\xdf\xfe\x7e\xee\x19\x67\x51\xd0\xd0\xd7\x71\xc2\x8b\x0d\x11\xee\xe9\xa8\x9b\x18\x10\x41\xd2\x69\x96\x4c\x05\xef\xb5\x65\x28\x6d\xac\xe4\x9e\x4e\xad\xb0\xca\x95\x4d\xaa\x46\x50\x7d\xa3\xa3\xf5\xe7\xba\xa6\x93\xce\xa2\x70\x87\xe6\x88\xc3\xef\x7b\x13\x9b\x31\x44\x3c\x89\x69\xcd\x52\x44\x0b\x31\xeb\xc6\xdc\xf2\x9d\x1a\x1f\x3b\xc9\x2b\x70\x36\xca\xa0\xf5\xcf\x8b\xba\xdc\x78\x06\xfa\xd7\xf7\xb3\x94\xf6\x53\xe3\x6a\x56\x75\x88\xf8\x3e\xe9\x6c\x02\x1a\x71\x91\xe9\x02\xdd\xe7\x0d\xdc\x6f\xf9\x23\xa8\xaa\x6d\xac\xe5\x0b\x75\x05\xe1\x61\x61\x99\xa9\x2e\x99\x8d\x26\x10\xa4\x40\x99\x1b\x8d\x50\xbb\xef\x4a\xee\xd0\x1c\x71\xf8\x1c\x00\xcc\xf0\x34\xc3\x87\x01\x00\x00")
+
+func messagesNlInvitationacceptedTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlInvitationacceptedTmpl,
+		"messages/nl/invitationaccepted.tmpl",
+	)
+}
+
+func messagesNlInvitationacceptedTmpl() (*asset, error) {
+	bytes, err := messagesNlInvitationacceptedTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/invitationaccepted.tmpl", size: 391, mode: os.FileMode(420), modTime: time.Unix(1792382135, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlInvitationrejectedTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8f\x31\x4e\xc3\x40\x10\x45\xfb\x9c\x62\xe4\x1e\xdf\x23\x34\xa9\x28\x28\xc7\xec\xcf\x32\xce\x7a\x16\xad\xc7\x36\xca\x68\xee\x8e\x12\x24\x40\x72\x44\xff\xf4\xdf\xfb\xee\x09\x67\x51\x50\x37\x2f\xc3\x88\x37\xeb\x22\xdc\xfb\xa3\xae\x62\x40\x04\x6d\x52\x28\x03\x4a\x45\x12\x6d\xb5\x25\x28\xad\xac\xe4\xde\x9f\x5a\x66\x95\x2b\x9b\x54\x8d\xa0\xfa\x41\x47\x9b\x5f\xeb\xd2\x9f\xb4\x88\xc2\x1d\x9a\x22\x0e\xbf\x0a\x13\x2b\xe8\x22\x5e\xc4\xb4\x26\xc9\xa2\x99\x32\x36\x48\x46\x4b\x0f\x70\x7c\xee\x72\xde\x81\xb3\x51\x02\x2d\x7f\x36\xea\x74\xaf\x33\xfc\x1f\xf8\xa3\xea\xf7\xae\x61\x31\xab\xda\x45\x7c\x9f\x9a\xd9\x04\x34\xe0\x22\xe3\x05\xba\xc7\x1b\x78\xbe\xe3\xcf\xa0\xaa\xb6\xb2\xe6\x5b\xd5\x15\x84\xa7\x89\xa5\x50\x9d\x12\x1b\x8d\xa0\xdb\x39\x65\x6e\x34\x40\xed\x71\x58\xef\x0e\x4d\x11\x87\xaf\x01\x00\xa3\x51\x2d\xf4\x8d\x01\x00\x00")
+
+func messagesNlInvitationrejectedTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlInvitationrejectedTmpl,
+		"messages/nl/invitationrejected.tmpl",
+	)
+}
+
+func messagesNlInvitationrejectedTmpl() (*asset, error) {
+	bytes, err := messagesNlInvitationrejectedTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/invitationrejected.tmpl", size: 397, mode: os.FileMode(420), modTime: time.Unix(1792382135, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlLogincodeTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8f\xc1\x6a\xc3\x30\x10\x44\xef\xf9\x8a\xc1\xe7\xe0\x0f\xc8\xb5\x3d\x95\xd2\x40\xa1\x1f\xa0\x46\x63\x77\x13\x49\x5b\x24\xd9\xd0\x88\xfd\xf7\x22\xb7\x10\xda\x43\xae\xcb\x9b\x9d\x79\xad\x79\x4e\x92\x88\xa1\xc4\x32\x98\x1d\x23\x56\x66\xcf\x0c\xe7\x12\x2a\x11\x19\x3c\x13\xf4\x13\x52\xcb\x97\x2e\xa3\xa6\xd0\xf9\xd6\x64\xc2\x78\xcc\xb3\x4b\x72\x75\x55\x34\x99\xad\xaa\x19\x9e\xd0\xed\x5a\x5c\x95\xce\xfd\x83\xf6\x68\x8d\xc9\x9b\xcd\xe4\x84\x33\x7b\xe0\xa4\x7e\x23\x1f\xd4\xd3\x0c\xb2\xf5\x7d\xb0\x62\xd2\x1c\x97\x20\xcc\xd0\x09\x33\xdf\xf3\x22\x97\x9f\xcc\x95\x08\x92\x2e\x87\x1e\x7b\x7b\x7d\x36\xfb\xfd\xba\xbb\x19\xad\x2a\x27\x0e\x66\x8f\x52\x21\xe5\xef\xfe\x11\x4f\xec\x8a\x5d\x6f\x6b\x97\x72\xb8\x2d\x18\xf1\xa2\x73\x74\x2e\x94\x3d\xce\xf7\xb8\xd6\x98\xbc\xd9\xee\x7b\x00\x82\x5d\xeb\x7b\x47\x01\x00\x00")
+
+func messagesNlLogincodeTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlLogincodeTmpl,
+		"messages/nl/logincode.tmpl",
+	)
+}
+
+func messagesNlLogincodeTmpl() (*asset, error) {
+	bytes, err := messagesNlLogincodeTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/logincode.tmpl", size: 327, mode: os.FileMode(420), modTime: time.Unix(1792379376, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlLoginlinkTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x41\x6e\xd4\x40\x10\x45\xf7\x39\xc5\xd7\xac\x83\xef\x80\xc4\x06\x58\x64\x89\x58\x96\xe9\x9f\x9e\xb2\xdb\xd5\x51\xbb\xec\x41\x69\xf5\x81\x72\x8e\x5c\x0c\xb5\x03\x62\x18\xc4\x82\xad\x5d\xff\xff\xd7\xaf\xd6\xc0\x47\x35\xe2\xb4\x6e\xe3\xc4\x6f\x7e\x6a\xed\xa3\xaf\x5f\xf3\x36\x3c\x58\xea\x3f\x44\x6c\x61\x0a\xb4\x5a\x69\xa1\xb5\xbb\xdf\x11\x57\x4f\xfc\xaf\x00\xbf\xf7\x81\xcf\x49\x67\xe4\x27\x04\x62\xb6\xfc\x84\xb3\xb2\x64\x0b\x2c\xc8\x0b\xa6\x63\x12\x4e\xbc\xb5\xf4\xc3\x3f\x06\x6a\xd5\x47\x0c\x0f\x25\x8a\xe9\xb3\xb8\x66\x6b\x0d\x7b\xce\x05\xb5\xde\x7c\xfe\x49\x30\xe0\x03\x91\xd4\x66\xcc\x62\x58\x44\x0a\x5e\x5f\x5e\x5f\x0c\x33\x59\x10\x39\x96\x4d\x67\xc7\x25\x97\x40\xbb\x07\x6d\x66\x82\x5a\xe7\x1b\x4b\xbe\xac\x2c\xb8\x88\x14\xb5\x0e\x77\xe6\xd2\x01\x23\xf7\x22\x12\x03\xce\x1c\xbd\x67\xb0\xb3\xec\x92\x1c\x26\x6f\x0d\xc4\xa2\xb6\x39\x6d\xf8\x5b\xc4\xb8\xb9\x67\x3b\xb5\xf6\xfe\xdf\xb2\x0a\x65\x3d\x6e\x3e\x11\xd9\x7c\x17\x8b\x8e\xc0\x67\x82\xef\x16\xd1\x84\xbc\x04\xf1\x8e\x94\x2d\x89\xc5\x15\xa4\xfd\xb2\x7f\xbc\xf6\xd6\xdc\xc1\x7a\x0d\x3f\xe0\x8b\xac\x98\x74\x42\x50\x87\x29\xfd\x1e\xe1\x50\x14\x7b\xef\xf5\x98\x31\xb2\xd0\x86\x5a\x69\xa1\xb5\xbb\x1f\x03\x00\xed\xd1\xa9\xbe\x39\x02\x00\x00")
+
+func messagesNlLoginlinkTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlLoginlinkTmpl,
+		"messages/nl/loginlink.tmpl",
+	)
+}
+
+func messagesNlLoginlinkTmpl() (*asset, error) {
+	bytes, err := messagesNlLoginlinkTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/loginlink.tmpl", size: 569, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlOrganizationinvitationTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\x41\x6e\x1b\x3d\x0c\x85\xf7\x39\x05\xe1\xb5\xff\x39\xc0\xbf\xcb\xa2\x40\x13\x14\x35\x10\x20\x08\xba\xa4\xad\x67\x59\xc9\x88\x0a\x24\xce\xb4\x8d\x40\x20\x07\x69\x2f\x97\x93\x14\x92\xd3\xda\x49\xdc\x02\xed\x6e\x08\x92\x4f\x1f\x1f\x39\xb5\x3a\x6c\x83\x80\x16\x65\x5a\xdf\x62\xa3\x0b\xb3\xeb\xa0\x92\x5c\xf0\x41\x3c\xa5\x48\x63\x70\xa4\xa0\xcf\x29\x3b\x08\xcd\x2c\x54\xeb\xb0\xca\x9e\x25\x3c\xb0\x86\x24\x66\x94\xee\xe9\x42\xcb\xa7\x34\x0d\x2b\x19\x83\xa0\x56\x88\x33\x3b\x3b\xc8\x6b\xd0\x11\x0b\xb3\x9b\x94\x5d\x97\x3c\x29\x74\xa2\x0f\x5f\x1a\x53\xad\x61\x4b\xc3\x85\xcc\x41\x91\x5b\x78\xf8\xa6\x1d\xb0\x55\xba\x05\x4d\x41\x3d\x3a\xba\xab\x15\x63\x81\xd9\x25\x68\x0d\xd1\x57\xa9\xc6\xf6\xaf\xa3\x0d\x7b\x94\x8f\x49\x61\x46\x4f\x8f\xdf\x6a\x7d\x0e\x9e\x1e\xbf\xff\xd4\x5e\x45\x72\x9d\xe7\x97\x91\x0a\x62\x96\x99\xb9\xb9\xb8\xa4\xc8\x7c\xd7\x90\x01\x21\xde\x6c\xd2\x24\xda\xf2\x04\xa1\x35\x66\x14\x0d\xbe\xa5\x77\x50\xc2\x7f\x91\xc3\xc8\x2e\xa3\x34\xe7\xdf\xb5\xe8\xdc\xb5\xb0\x98\x0d\xf4\x1e\xeb\x56\xc9\xe3\xb1\xd6\x92\xe6\x04\x4f\x2e\xbc\x6c\x77\x2c\xa4\xa9\x93\xb4\x9e\xfb\x9c\xb6\x01\xe3\x8b\x47\x77\xd0\xe1\xed\x16\xd6\x93\x6a\x92\x85\xd9\xf9\x81\x35\xf2\x1d\xe4\x6d\x69\x06\x97\x5e\x7a\x09\x4a\xa2\x33\x8b\x57\x72\x78\xc0\x33\x09\xa5\xe8\x58\x3b\x2c\x82\x87\x30\xe7\xdf\x38\xff\x9a\x7e\xbf\xe7\xa3\x4d\x0e\x74\x13\xc6\x36\x88\x6f\x6a\x6d\x99\xfb\x23\x5d\xf6\x41\x23\x77\x0b\x8f\x9f\x16\x78\x64\xc8\x89\x01\x4b\x2c\x8b\x93\xd7\xf2\x17\x67\x12\xb4\x7c\x4d\xd3\x90\xfa\x1f\x30\xd0\x15\x7c\x28\x9a\x81\xdc\x30\x22\xb4\xaf\x43\x31\x62\x9b\x92\xc8\x14\x23\x32\xa5\x3f\x1f\xca\xff\xcd\x96\xeb\xab\x0f\x66\xb5\x42\x9c\xd9\xd9\x8f\x01\x00\x28\x39\xda\xf1\xb1\x03\x00\x00")
+
+func messagesNlOrganizationinvitationTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlOrganizationinvitationTmpl,
+		"messages/nl/organizationinvitation.tmpl",
+	)
+}
+
+func messagesNlOrganizationinvitationTmpl() (*asset, error) {
+	bytes, err := messagesNlOrganizationinvitationTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/organizationinvitation.tmpl", size: 945, mode: os.FileMode(420), modTime: time.Unix(1792382135, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlPasswordresetTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcf\xbd\x4e\xc3\x30\x14\xc5\xf1\xbd\x4f\x71\xd4\x19\xf2\x1e\xc0\xc0\x58\x31\x3a\xf5\xc1\x71\xea\xdc\x1b\xd9\x37\x0d\xc2\xf2\xbb\xa3\xa0\x4a\xe5\x4b\x08\x89\xd9\xf6\xf9\xff\x5c\xab\xe7\x73\x14\x62\x5f\x96\x7e\xe4\xd1\xf6\xad\xdd\x59\x79\xd2\xa5\x7b\x94\xb4\x1d\xac\xee\x38\xd8\xaa\x9a\x3d\x74\x96\xc8\x65\x45\x94\x62\x4c\x89\x52\x2b\xc5\xb7\xb6\xbb\xae\x58\xb4\xc4\xff\x6e\xf0\x65\x63\x3c\xa4\x78\x82\xce\xf0\xc4\x49\x74\xc6\x10\x99\x55\x3c\x33\x74\xc2\x48\xfc\x21\x01\x23\x2e\x99\xee\x7b\xa7\x5f\xcc\x54\xf6\xad\x1d\xae\x6f\x7f\x61\x65\xba\xf2\x7e\xfd\x9e\x50\xb1\xb3\x93\x60\xf0\x7c\x25\x78\x3b\xb9\x98\xa0\x93\x77\xb6\xc9\x54\x92\x93\x50\x10\x78\xce\xce\x05\x8f\x81\xbd\x5d\xd4\x9f\x9c\x5f\xfe\xf0\x33\x1c\x07\x57\x30\xc6\x11\x3e\x1a\x24\xd2\x6e\xe0\x9d\x60\x72\x61\x6b\x7d\x04\x08\x03\x33\xa5\xab\x95\xe2\x5b\xdb\xbd\x0d\x00\x7c\x4c\x4c\xdd\xdd\x01\x00\x00")
+
+func messagesNlPasswordresetTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlPasswordresetTmpl,
+		"messages/nl/passwordreset.tmpl",
+	)
+}
+
+func messagesNlPasswordresetTmpl() (*asset, error) {
+	bytes, err := messagesNlPasswordresetTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/passwordreset.tmpl", size: 477, mode: os.FileMode(420), modTime: time.Unix(1792377555, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _messagesNlPhonenumbervalidationTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xc1\x4a\x03\x31\x10\xc6\xf1\x7b\x9f\xe2\x63\xcf\x65\x1f\xa0\x57\x7b\x14\x05\xc1\x07\x68\x9b\x2f\xeb\xd8\x64\x46\x36\x49\x41\xc3\xbc\xbb\x44\x85\xb2\xe0\x7d\xfe\xdf\xfc\x7a\x0f\x8c\xa2\xc4\x54\x72\x99\xdc\x9f\x33\xde\x89\xca\xc4\x68\xa6\xda\x72\xe6\x0a\xfb\x80\xd4\xf2\x69\x6d\x36\x4d\xe3\xb8\x12\x67\xde\x58\xaa\x2c\xd4\x3d\x16\x32\x8e\x2c\x10\x17\x0b\x44\xef\xf3\x83\x05\xba\x43\x74\xc4\x6f\xac\x88\xb6\xe6\x96\x64\xac\x45\x2c\x3c\xaf\x4d\xae\xbf\xcd\x17\x91\x44\xaf\x87\x91\xbd\xbe\x3c\xba\xf7\x4e\x0d\xee\xbb\xbb\xed\x66\x72\xe1\xe4\x7e\x94\x0a\x29\x5b\xcc\x8c\xe3\xdf\x5b\xfb\xcf\xbe\xa1\x42\xca\xe1\xae\x9b\xf1\x64\x4b\x3e\x9d\x52\xd9\x8f\xf0\x67\x63\x7b\xd0\x3b\x35\xb8\xef\xbe\x07\x00\x8e\xec\xaf\xe1\x26\x01\x00\x00")
+
+func messagesNlPhonenumbervalidationTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_messagesNlPhonenumbervalidationTmpl,
+		"messages/nl/phonenumbervalidation.tmpl",
+	)
+}
+
+func messagesNlPhonenumbervalidationTmpl() (*asset, error) {
+	bytes, err := messagesNlPhonenumbervalidationTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "messages/nl/phonenumbervalidation.tmpl", size: 294, mode: os.FileMode(420), modTime: time.Unix(1792379376, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -140,7 +548,26 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"emailwithbutton.html": emailwithbuttonHtml,
+	"emailwithbutton.html":                    emailwithbuttonHtml,
+	"emailwithbutton.txt":                     emailwithbuttonTxt,
+	"messages/en/common.tmpl":                 messagesEnCommonTmpl,
+	"messages/en/emailvalidation.tmpl":        messagesEnEmailvalidationTmpl,
+	"messages/en/invitationaccepted.tmpl":     messagesEnInvitationacceptedTmpl,
+	"messages/en/invitationrejected.tmpl":     messagesEnInvitationrejectedTmpl,
+	"messages/en/logincode.tmpl":              messagesEnLogincodeTmpl,
+	"messages/en/loginlink.tmpl":              messagesEnLoginlinkTmpl,
+	"messages/en/organizationinvitation.tmpl": messagesEnOrganizationinvitationTmpl,
+	"messages/en/passwordreset.tmpl":          messagesEnPasswordresetTmpl,
+	"messages/en/phonenumbervalidation.tmpl":  messagesEnPhonenumbervalidationTmpl,
+	"messages/nl/common.tmpl":                 messagesNlCommonTmpl,
+	"messages/nl/emailvalidation.tmpl":        messagesNlEmailvalidationTmpl,
+	"messages/nl/invitationaccepted.tmpl":     messagesNlInvitationacceptedTmpl,
+	"messages/nl/invitationrejected.tmpl":     messagesNlInvitationrejectedTmpl,
+	"messages/nl/logincode.tmpl":              messagesNlLogincodeTmpl,
+	"messages/nl/loginlink.tmpl":              messagesNlLoginlinkTmpl,
+	"messages/nl/organizationinvitation.tmpl": messagesNlOrganizationinvitationTmpl,
+	"messages/nl/passwordreset.tmpl":          messagesNlPasswordresetTmpl,
+	"messages/nl/phonenumbervalidation.tmpl":  messagesNlPhonenumbervalidationTmpl,
 }
 
 // AssetDir returns the file names below a certain
@@ -185,6 +612,31 @@ type bintree struct {
 
 var _bintree = &bintree{nil, map[string]*bintree{
 	"emailwithbutton.html": &bintree{emailwithbuttonHtml, map[string]*bintree{}},
+	"emailwithbutton.txt":  &bintree{emailwithbuttonTxt, map[string]*bintree{}},
+	"messages": &bintree{nil, map[string]*bintree{
+		"en": &bintree{nil, map[string]*bintree{
+			"common.tmpl":                 &bintree{messagesEnCommonTmpl, map[string]*bintree{}},
+			"emailvalidation.tmpl":        &bintree{messagesEnEmailvalidationTmpl, map[string]*bintree{}},
+			"invitationaccepted.tmpl":     &bintree{messagesEnInvitationacceptedTmpl, map[string]*bintree{}},
+			"invitationrejected.tmpl":     &bintree{messagesEnInvitationrejectedTmpl, map[string]*bintree{}},
+			"logincode.tmpl":              &bintree{messagesEnLogincodeTmpl, map[string]*bintree{}},
+			"loginlink.tmpl":              &bintree{messagesEnLoginlinkTmpl, map[string]*bintree{}},
+			"organizationinvitation.tmpl": &bintree{messagesEnOrganizationinvitationTmpl, map[string]*bintree{}},
+			"passwordreset.tmpl":          &bintree{messagesEnPasswordresetTmpl, map[string]*bintree{}},
+			"phonenumbervalidation.tmpl":  &bintree{messagesEnPhonenumbervalidationTmpl, map[string]*bintree{}},
+		}},
+		"nl": &bintree{nil, map[string]*bintree{
+			"common.tmpl":                 &bintree{messagesNlCommonTmpl, map[string]*bintree{}},
+			"emailvalidation.tmpl":        &bintree{messagesNlEmailvalidationTmpl, map[string]*bintree{}},
+			"invitationaccepted.tmpl":     &bintree{messagesNlInvitationacceptedTmpl, map[string]*bintree{}},
+			"invitationrejected.tmpl":     &bintree{messagesNlInvitationrejectedTmpl, map[string]*bintree{}},
+			"logincode.tmpl":              &bintree{messagesNlLogincodeTmpl, map[string]*bintree{}},
+			"loginlink.tmpl":              &bintree{messagesNlLoginlinkTmpl, map[string]*bintree{}},
+			"organizationinvitation.tmpl": &bintree{messagesNlOrganizationinvitationTmpl, map[string]*bintree{}},
+			"passwordreset.tmpl":          &bintree{messagesNlPasswordresetTmpl, map[string]*bintree{}},
+			"phonenumbervalidation.tmpl":  &bintree{messagesNlPhonenumbervalidationTmpl, map[string]*bintree{}},
+		}},
+	}},
 }}
 
 // RestoreAsset restores an asset under the given directory
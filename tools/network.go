@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+)
+
+//ErrInternalAddress is returned when a connection to a loopback, private or link-local address is refused
+var ErrInternalAddress = errors.New("Connecting to internal addresses is not allowed")
+
+//IsInternalIP checks if an address is a loopback, private, link-local or unspecified one.
+// Urls organizations configure are not connected to on these so they can not be used to reach the network of the server.
+func IsInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+//IsInternalHost checks if a host name is localhost or an internal ip address.
+// Other names can still resolve to internal addresses, RefuseInternalAddress refuses those when connecting.
+func IsInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && IsInternalIP(ip)
+}
+
+//RefuseInternalAddress is a net.Dialer Control function that only lets connections to public addresses through
+func RefuseInternalAddress(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || IsInternalIP(ip) {
+		return ErrInternalAddress
+	}
+	return nil
+}
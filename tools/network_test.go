@@ -0,0 +1,25 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInternalHost(t *testing.T) {
+	for _, host := range []string{"", "localhost", "LOCALHOST.", "api.localhost", "127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fd00::1"} {
+		assert.True(t, IsInternalHost(host), host)
+	}
+	for _, host := range []string{"example.com", "93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.False(t, IsInternalHost(host), host)
+	}
+}
+
+func TestRefuseInternalAddress(t *testing.T) {
+	assert.Equal(t, ErrInternalAddress, RefuseInternalAddress("tcp", "127.0.0.1:443", nil))
+	assert.Equal(t, ErrInternalAddress, RefuseInternalAddress("tcp", "10.1.2.3:389", nil))
+	assert.Equal(t, ErrInternalAddress, RefuseInternalAddress("tcp", "169.254.169.254:80", nil))
+	assert.Equal(t, ErrInternalAddress, RefuseInternalAddress("tcp6", "[::1]:443", nil))
+	assert.Equal(t, ErrInternalAddress, RefuseInternalAddress("tcp6", "[fd00::1]:443", nil))
+	assert.NoError(t, RefuseInternalAddress("tcp", "93.184.216.34:443", nil))
+}
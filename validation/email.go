@@ -3,20 +3,19 @@ package validation
 import (
 	"fmt"
 	log "github.com/Sirupsen/logrus"
+	"github.com/itsyouonline/identityserver/communication"
 	"github.com/itsyouonline/identityserver/credentials/password"
 	"github.com/itsyouonline/identityserver/db/validation"
-	"github.com/itsyouonline/identityserver/tools"
+	"github.com/itsyouonline/identityserver/domainverification"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+	"github.com/itsyouonline/identityserver/messages"
 	"net/http"
 	"net/url"
 )
 
-const (
-	emailWithButtonTemplateName = "emailwithbutton.html"
-)
-
 //EmailService is the interface for an email communication channel, should be used by the IYOEmailAddressValidationService
 type EmailService interface {
-	Send(receipients []string, subject string, message string) (err error)
+	SendMessage(message *communication.EmailMessage) (err error)
 }
 
 //IYOEmailAddressValidationService is the itsyou.online implementation of a EmailAddressValidationService
@@ -38,27 +37,18 @@ func (service *IYOEmailAddressValidationService) RequestValidation(request *http
 		return
 	}
 	validationurl := fmt.Sprintf("%s?c=%s&k=%s", confirmationurl, url.QueryEscape(info.Secret), url.QueryEscape(info.Key))
-	templateParameters := struct {
-		Url        string
-		Username   string
-		Title      string
-		Text       string
-		ButtonText string
-		Reason     string
-	}{
-		Url:        validationurl,
-		Username:   username,
-		Title:      "It'sYou.Online email verification",
-		Text:       fmt.Sprintf("To verify your email address %s on ItsYou.Online, click the button below.", email),
-		ButtonText: "Verify email",
-		Reason:     " You’re receiving this email because you recently created a new ItsYou.Online account or added a new email address. If this wasn’t you, please ignore this email.",
-	}
-	message, err := tools.RenderTemplate(emailWithButtonTemplateName, templateParameters)
+	message, err := messages.NewContext(request, username, "").RenderEmail(messages.EmailValidation, &messages.Data{
+		Username:     username,
+		EmailAddress: email,
+		URL:          validationurl,
+	})
 	if err != nil {
 		return
 	}
-
-	go service.EmailService.Send([]string{email}, "ItsYou.Online email verification", message)
+	message.Recipients = []string{email}
+	if err = service.EmailService.SendMessage(message); err != nil {
+		return
+	}
 	key = info.Key
 	return
 }
@@ -75,30 +65,39 @@ func (service *IYOEmailAddressValidationService) RequestPasswordReset(request *h
 	}
 
 	passwordreseturl := fmt.Sprintf("https://%s/login#/resetpassword/%s", request.Host, url.QueryEscape(token.Token))
-	templateParameters := struct {
-		Url        string
-		Username   string
-		Title      string
-		Text       string
-		ButtonText string
-		Reason     string
-	}{
-		Url:        passwordreseturl,
-		Username:   username,
-		Title:      "It's You Online password reset",
-		Text:       "To reset your ItsYou.Online password, click the button below.",
-		ButtonText: "Reset password",
-		Reason:     "You’re receiving this email because you recently requested to reset your password at ItsYou.Online. If this wasn’t you, please ignore this email.",
-	}
-	message, err := tools.RenderTemplate(emailWithButtonTemplateName, templateParameters)
+	message, err := messages.NewContext(request, username, "").RenderEmail(messages.PasswordReset, &messages.Data{
+		Username: username,
+		URL:      passwordreseturl,
+	})
 	if err != nil {
 		return
 	}
-	go service.EmailService.Send(emails, "ItsYou.Online password reset", message)
+	message.Recipients = emails
+	if err = service.EmailService.SendMessage(message); err != nil {
+		return
+	}
 	key = token.Token
 	return
 }
 
+//SendLoginLink sends an email with a link that logs the user in without a password.
+// If the login is for an organization, its globalid is passed to use its branding.
+func (service *IYOEmailAddressValidationService) SendLoginLink(request *http.Request, username string, email string, loginurl string, globalid string) (err error) {
+	message, err := messages.NewContext(request, username, globalid).RenderEmail(messages.LoginLink, &messages.Data{
+		Username:     username,
+		EmailAddress: email,
+		URL:          loginurl,
+	})
+	if err != nil {
+		return
+	}
+	message.Recipients = []string{email}
+	if err = service.EmailService.SendMessage(message); err != nil {
+		return
+	}
+	return
+}
+
 //ExpireValidation removes a pending validation
 func (service *IYOEmailAddressValidationService) ExpireValidation(request *http.Request, key string) (err error) {
 	if key == "" {
@@ -152,6 +151,16 @@ func (service *IYOEmailAddressValidationService) ConfirmValidation(request *http
 	if err != nil {
 		return
 	}
+	//Organizations may have invited the person by this email address before it was validated
+	err = invitations.NewInvitationManager(request).AttachEmailInvitations(info.EmailAddress, info.Username)
+	if err != nil {
+		return
+	}
+	//Organizations that verified the domain of the email address may invite or add the user automatically,
+	// a failure here does not undo the validation
+	if joinErr := domainverification.JoinVerifiedDomains(request, info.Username, info.EmailAddress); joinErr != nil {
+		log.Error("Error applying the domain membership rules for ", info.EmailAddress, ": ", joinErr)
+	}
 	err = valMngr.UpdateEmailAddressValidationInformation(key, true)
 	if err != nil {
 		return
@@ -9,4 +9,8 @@ var (
 	ErrInvalidCode = errors.New("Invalid code")
 	//ErrInvalidOrExpiredKey denotes that the key is not found, it can be invalid or expired
 	ErrInvalidOrExpiredKey = errors.New("Invalid key")
+	//ErrCannotReceiveSMS denotes that the phonenumber is a landline or another kind of number that can not receive text messages
+	ErrCannotReceiveSMS = errors.New("The phone number can not receive text messages")
+	//ErrTooManyCalls denotes that the code was already read out in the maximum number of calls
+	ErrTooManyCalls = errors.New("Too many calls")
 )
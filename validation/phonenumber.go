@@ -7,6 +7,8 @@ import (
 
 	"github.com/itsyouonline/identityserver/db/user"
 	"github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/identityservice/invitations"
+	"github.com/itsyouonline/identityserver/messages"
 )
 
 //SMSService is the interface an sms communication channel should have to be used by the IYOPhonenumberValidationService
@@ -14,13 +16,26 @@ type SMSService interface {
 	Send(phonenumber string, message string) (err error)
 }
 
+//VoiceService is the interface a voice communication channel should have to be used by the IYOPhonenumberValidationService
+type VoiceService interface {
+	Call(phonenumber string, message string, language string) (err error)
+}
+
+//MaxCallsPerCode is how many times the code of a validation or login can be read out in a call
+const MaxCallsPerCode = 3
+
 //IYOPhonenumberValidationService is the itsyou.online implementation of a PhonenumberValidationService
 type IYOPhonenumberValidationService struct {
-	SMSService SMSService
+	SMSService   SMSService
+	VoiceService VoiceService
 }
 
-//RequestValidation validates the phonenumber by sending an SMS
+//RequestValidation validates the phonenumber by sending an SMS, ErrCannotReceiveSMS is returned for landlines
 func (service *IYOPhonenumberValidationService) RequestValidation(request *http.Request, username string, phonenumber user.Phonenumber, confirmationurl string) (key string, err error) {
+	if !phonenumber.CanReceiveSMS() {
+		err = ErrCannotReceiveSMS
+		return
+	}
 	valMngr := validation.NewManager(request)
 	info, err := valMngr.NewPhonenumberValidationInformation(username, phonenumber)
 	if err != nil {
@@ -30,13 +45,52 @@ func (service *IYOPhonenumberValidationService) RequestValidation(request *http.
 	if err != nil {
 		return
 	}
-	smsmessage := fmt.Sprintf("To verify your phonenumber on itsyou.online enter the code %s in the form or use this link: %s?c=%s&k=%s", info.SMSCode, confirmationurl, info.SMSCode, url.QueryEscape(info.Key))
+	smsmessage, err := messages.NewContext(request, username, "").RenderSMS(messages.PhonenumberValidation, &messages.Data{
+		Username: username,
+		Code:     info.SMSCode,
+		URL:      fmt.Sprintf("%s?c=%s&k=%s", confirmationurl, info.SMSCode, url.QueryEscape(info.Key)),
+	})
+	if err != nil {
+		return
+	}
 
-	go service.SMSService.Send(phonenumber.Phonenumber, smsmessage)
+	if err = service.SMSService.Send(phonenumber.Phonenumber, smsmessage); err != nil {
+		return
+	}
 	key = info.Key
 	return
 }
 
+//CallValidationCode calls the phonenumber of a pending validation of a user and reads out the code that was sent by sms,
+// for users that did not receive the sms
+func (service *IYOPhonenumberValidationService) CallValidationCode(request *http.Request, username string, key string) (err error) {
+	if key == "" {
+		err = ErrInvalidOrExpiredKey
+		return
+	}
+	info, err := validation.NewManager(request).CountPhonenumberValidationCall(key)
+	if err != nil {
+		return
+	}
+	if info == nil || info.Confirmed || info.Username != username {
+		err = ErrInvalidOrExpiredKey
+		return
+	}
+	if info.Calls > MaxCallsPerCode {
+		err = ErrTooManyCalls
+		return
+	}
+	message, language, err := messages.NewContext(request, info.Username, "").RenderVoice(messages.PhonenumberValidation, &messages.Data{
+		Username: info.Username,
+		Code:     info.SMSCode,
+	})
+	if err != nil {
+		return
+	}
+	err = service.VoiceService.Call(info.Phonenumber, message, language)
+	return
+}
+
 //ExpireValidation removes a pending validation
 func (service *IYOPhonenumberValidationService) ExpireValidation(request *http.Request, key string) (err error) {
 	if key == "" {
@@ -90,6 +144,11 @@ func (service *IYOPhonenumberValidationService) ConfirmValidation(request *http.
 	if err != nil {
 		return
 	}
+	//Organizations may have invited the person by this phone number before it was validated
+	err = invitations.NewInvitationManager(request).AttachPhonenumberInvitations(info.Phonenumber, info.Username)
+	if err != nil {
+		return
+	}
 	err = valMngr.UpdatePhonenumberValidationInformation(key, true)
 	if err != nil {
 		return
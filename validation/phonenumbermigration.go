@@ -0,0 +1,73 @@
+package validation
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"gopkg.in/mgo.v2"
+
+	"github.com/itsyouonline/identityserver/db"
+	"github.com/itsyouonline/identityserver/db/user"
+	"github.com/itsyouonline/identityserver/db/validation"
+	"github.com/itsyouonline/identityserver/globalconfig"
+	"github.com/itsyouonline/identityserver/phonenumbers"
+)
+
+//phonenumberMigrationKey is the globalconfig key that marks the stored phonenumbers as normalized
+const phonenumberMigrationKey = "migrations.phonenumbers.e164"
+
+//NormalizeStoredPhonenumbers converts the phonenumbers of the users and the validated phonenumbers to E.164 format.
+// It only runs once, numbers that are not valid are left untouched.
+func NormalizeStoredPhonenumbers() {
+	r, done := db.NewBackgroundRequest()
+	defer done()
+	if db.GetDBSession(r) == nil {
+		log.Warn("Phonenumber normalization skipped, no database connection")
+		return
+	}
+	configMgr := globalconfig.NewManager()
+	defer configMgr.Close()
+	migrated, err := configMgr.Exists(phonenumberMigrationKey)
+	if err != nil || migrated {
+		return
+	}
+
+	users, validated := 0, 0
+	userMgr := user.NewManager(r)
+	err = userMgr.ForEachWithPhonenumbers(func(u *user.User) error {
+		for _, phonenumber := range u.Phonenumbers {
+			normalized, normalizeErr := phonenumbers.Normalize(phonenumber.Phonenumber, "")
+			if normalizeErr != nil || normalized == phonenumber.Phonenumber {
+				continue
+			}
+			if err := userMgr.ReplacePhonenumber(u.Username, phonenumber.Label, phonenumber.Phonenumber, normalized); err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+			users++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to normalize the phonenumbers of the users: ", err)
+		return
+	}
+	valMgr := validation.NewManager(r)
+	err = valMgr.ForEachValidatedPhonenumber(func(v *validation.ValidatedPhonenumber) error {
+		normalized, normalizeErr := phonenumbers.Normalize(v.Phonenumber, "")
+		if normalizeErr != nil || normalized == v.Phonenumber {
+			return nil
+		}
+		if err := valMgr.ReplaceValidatedPhonenumber(v, normalized); err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+		validated++
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to normalize the validated phonenumbers: ", err)
+		return
+	}
+	if err = configMgr.Save(&globalconfig.GlobalConfig{Key: phonenumberMigrationKey, Value: "done"}); err != nil {
+		log.Error("Failed to mark the phonenumbers as normalized: ", err)
+		return
+	}
+	log.Infof("Normalized %d phonenumbers of users and %d validated phonenumbers", users, validated)
+}